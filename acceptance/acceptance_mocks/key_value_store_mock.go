@@ -0,0 +1,73 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_mocks
+
+import (
+	alias1 "github.com/mokiat/gostub/acceptance"
+	mock "github.com/mokiat/gostub/mock"
+)
+
+type MockKeyValueStore struct {
+	ctrl     *mock.Controller
+	recorder *MockKeyValueStoreMockRecorder
+}
+type MockKeyValueStoreMockRecorder struct {
+	mock *MockKeyValueStore
+}
+
+func NewMockKeyValueStore(ctrl *mock.Controller) *MockKeyValueStore {
+	m := &MockKeyValueStore{ctrl: ctrl}
+	m.recorder = &MockKeyValueStoreMockRecorder{mock: m}
+	return m
+}
+func (m *MockKeyValueStore) EXPECT() *MockKeyValueStoreMockRecorder {
+	return m.recorder
+}
+
+var _ alias1.KeyValueStore = new(MockKeyValueStore)
+
+func (m *MockKeyValueStore) Get(arg1 string) (string, error) {
+	results := m.ctrl.Call(m, "Get", arg1)
+	result1, _ := results[0].(string)
+	result2, _ := results[1].(error)
+	return result1, result2
+}
+func (mr *MockKeyValueStoreMockRecorder) Get(arg1 interface{}) *mock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Get", arg1)
+}
+func (m *MockKeyValueStore) Put(arg1 string, arg2 []byte) error {
+	results := m.ctrl.Call(m, "Put", arg1, arg2)
+	result1, _ := results[0].(error)
+	return result1
+}
+func (mr *MockKeyValueStoreMockRecorder) Put(arg1 interface{}, arg2 interface{}) *mock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Put", arg1, arg2)
+}
+func (m *MockKeyValueStore) Tag(arg1 string, arg2 ...string) {
+	args := []interface{}{arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	m.ctrl.Call(m, "Tag", args...)
+}
+func (mr *MockKeyValueStoreMockRecorder) Tag(arg1 interface{}, arg2 ...interface{}) *mock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Tag", append([]interface{}{arg1}, arg2...)...)
+}
+func (m *MockKeyValueStore) Keys(arg1 ...string) []string {
+	args := []interface{}{}
+	for _, arg := range arg1 {
+		args = append(args, arg)
+	}
+	results := m.ctrl.Call(m, "Keys", args...)
+	result1, _ := results[0].([]string)
+	return result1
+}
+func (mr *MockKeyValueStoreMockRecorder) Keys(arg1 ...interface{}) *mock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Keys", arg1...)
+}
+func (m *MockKeyValueStore) Close() {
+	m.ctrl.Call(m, "Close")
+}
+func (mr *MockKeyValueStoreMockRecorder) Close() *mock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Close")
+}
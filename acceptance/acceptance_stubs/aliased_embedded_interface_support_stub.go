@@ -10,37 +10,43 @@ import (
 )
 
 type AliasedEmbeddedInterfaceSupportStub struct {
-	StubGUID       int
-	RunStub        func(arg1 alias2.Address) (result1 error)
-	runMutex       sync.RWMutex
-	runArgsForCall []struct {
-		arg1 alias2.Address
-	}
-	runReturns struct {
-		result1 error
-	}
-	MethodStub        func(arg1 int) (result1 int)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 int
-	}
-	methodReturns struct {
-		result1 int
-	}
+	StubGUID            int
+	RunStub             func(arg1 alias2.Address) (result1 error)
+	runMutex            sync.RWMutex
+	runArgsForCall      []AliasedEmbeddedInterfaceSupportStubRunArgs
+	runReturns          AliasedEmbeddedInterfaceSupportStubRunResults
+	runReturnsOnCall    map[int]AliasedEmbeddedInterfaceSupportStubRunResults
+	MethodStub          func(arg1 int) (result1 int)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []AliasedEmbeddedInterfaceSupportStubMethodArgs
+	methodReturns       AliasedEmbeddedInterfaceSupportStubMethodResults
+	methodReturnsOnCall map[int]AliasedEmbeddedInterfaceSupportStubMethodResults
 }
 
 var _ alias1.AliasedEmbeddedInterfaceSupport = new(AliasedEmbeddedInterfaceSupportStub)
 
+type AliasedEmbeddedInterfaceSupportStubRunArgs struct {
+	Arg1 alias2.Address
+}
+type AliasedEmbeddedInterfaceSupportStubRunResults struct {
+	Result1 error
+}
+
 func (stub *AliasedEmbeddedInterfaceSupportStub) Run(arg1 alias2.Address) error {
 	stub.runMutex.Lock()
-	defer stub.runMutex.Unlock()
-	stub.runArgsForCall = append(stub.runArgsForCall, struct {
-		arg1 alias2.Address
-	}{arg1})
-	if stub.RunStub != nil {
-		return stub.RunStub(arg1)
+	stub.runArgsForCall = append(stub.runArgsForCall, AliasedEmbeddedInterfaceSupportStubRunArgs{arg1})
+	callIndex := len(stub.runArgsForCall) - 1
+	stubFunc := stub.RunStub
+	returns := stub.runReturns
+	returnsOnCall, hasReturnsOnCall := stub.runReturnsOnCall[callIndex]
+	stub.runMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.runReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *AliasedEmbeddedInterfaceSupportStub) RunCallCount() int {
@@ -48,28 +54,54 @@ func (stub *AliasedEmbeddedInterfaceSupportStub) RunCallCount() int {
 	defer stub.runMutex.RUnlock()
 	return len(stub.runArgsForCall)
 }
+func (stub *AliasedEmbeddedInterfaceSupportStub) RunCalls() []AliasedEmbeddedInterfaceSupportStubRunArgs {
+	stub.runMutex.RLock()
+	defer stub.runMutex.RUnlock()
+	calls := make([]AliasedEmbeddedInterfaceSupportStubRunArgs, len(stub.runArgsForCall))
+	copy(calls, stub.runArgsForCall)
+	return calls
+}
 func (stub *AliasedEmbeddedInterfaceSupportStub) RunArgsForCall(index int) alias2.Address {
 	stub.runMutex.RLock()
 	defer stub.runMutex.RUnlock()
-	return stub.runArgsForCall[index].arg1
+	return stub.runArgsForCall[index].Arg1
 }
 func (stub *AliasedEmbeddedInterfaceSupportStub) RunReturns(result1 error) {
 	stub.runMutex.Lock()
 	defer stub.runMutex.Unlock()
-	stub.runReturns = struct {
-		result1 error
-	}{result1}
+	stub.runReturns = AliasedEmbeddedInterfaceSupportStubRunResults{result1}
 }
+func (stub *AliasedEmbeddedInterfaceSupportStub) RunReturnsOnCall(index int, result1 error) {
+	stub.runMutex.Lock()
+	defer stub.runMutex.Unlock()
+	if stub.runReturnsOnCall == nil {
+		stub.runReturnsOnCall = make(map[int]AliasedEmbeddedInterfaceSupportStubRunResults)
+	}
+	stub.runReturnsOnCall[index] = AliasedEmbeddedInterfaceSupportStubRunResults{result1}
+}
+
+type AliasedEmbeddedInterfaceSupportStubMethodArgs struct {
+	Arg1 int
+}
+type AliasedEmbeddedInterfaceSupportStubMethodResults struct {
+	Result1 int
+}
+
 func (stub *AliasedEmbeddedInterfaceSupportStub) Method(arg1 int) int {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 int
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, AliasedEmbeddedInterfaceSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *AliasedEmbeddedInterfaceSupportStub) MethodCallCount() int {
@@ -77,15 +109,28 @@ func (stub *AliasedEmbeddedInterfaceSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *AliasedEmbeddedInterfaceSupportStub) MethodCalls() []AliasedEmbeddedInterfaceSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]AliasedEmbeddedInterfaceSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *AliasedEmbeddedInterfaceSupportStub) MethodArgsForCall(index int) int {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *AliasedEmbeddedInterfaceSupportStub) MethodReturns(result1 int) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 int
-	}{result1}
+	stub.methodReturns = AliasedEmbeddedInterfaceSupportStubMethodResults{result1}
+}
+func (stub *AliasedEmbeddedInterfaceSupportStub) MethodReturnsOnCall(index int, result1 int) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]AliasedEmbeddedInterfaceSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = AliasedEmbeddedInterfaceSupportStubMethodResults{result1}
 }
@@ -10,29 +10,38 @@ import (
 )
 
 type AliasedRefSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 alias2.User) (result1 alias2.User)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 alias2.User
-	}
-	methodReturns struct {
-		result1 alias2.User
-	}
+	StubGUID            int
+	MethodStub          func(arg1 alias2.User) (result1 alias2.User)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []AliasedRefSupportStubMethodArgs
+	methodReturns       AliasedRefSupportStubMethodResults
+	methodReturnsOnCall map[int]AliasedRefSupportStubMethodResults
 }
 
 var _ alias1.AliasedRefSupport = new(AliasedRefSupportStub)
 
+type AliasedRefSupportStubMethodArgs struct {
+	Arg1 alias2.User
+}
+type AliasedRefSupportStubMethodResults struct {
+	Result1 alias2.User
+}
+
 func (stub *AliasedRefSupportStub) Method(arg1 alias2.User) alias2.User {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 alias2.User
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, AliasedRefSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *AliasedRefSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *AliasedRefSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *AliasedRefSupportStub) MethodCalls() []AliasedRefSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]AliasedRefSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *AliasedRefSupportStub) MethodArgsForCall(index int) alias2.User {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *AliasedRefSupportStub) MethodReturns(result1 alias2.User) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 alias2.User
-	}{result1}
+	stub.methodReturns = AliasedRefSupportStubMethodResults{result1}
+}
+func (stub *AliasedRefSupportStub) MethodReturnsOnCall(index int, result1 alias2.User) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]AliasedRefSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = AliasedRefSupportStubMethodResults{result1}
 }
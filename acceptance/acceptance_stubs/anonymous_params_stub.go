@@ -12,23 +12,23 @@ type AnonymousParamsStub struct {
 	StubGUID            int
 	RegisterStub        func(arg1 string, arg2 int)
 	registerMutex       sync.RWMutex
-	registerArgsForCall []struct {
-		arg1 string
-		arg2 int
-	}
+	registerArgsForCall []AnonymousParamsStubRegisterArgs
 }
 
 var _ alias1.AnonymousParams = new(AnonymousParamsStub)
 
+type AnonymousParamsStubRegisterArgs struct {
+	Arg1 string
+	Arg2 int
+}
+
 func (stub *AnonymousParamsStub) Register(arg1 string, arg2 int) {
 	stub.registerMutex.Lock()
-	defer stub.registerMutex.Unlock()
-	stub.registerArgsForCall = append(stub.registerArgsForCall, struct {
-		arg1 string
-		arg2 int
-	}{arg1, arg2})
-	if stub.RegisterStub != nil {
-		stub.RegisterStub(arg1, arg2)
+	stub.registerArgsForCall = append(stub.registerArgsForCall, AnonymousParamsStubRegisterArgs{arg1, arg2})
+	stubFunc := stub.RegisterStub
+	stub.registerMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2)
 	}
 }
 func (stub *AnonymousParamsStub) RegisterCallCount() int {
@@ -36,8 +36,15 @@ func (stub *AnonymousParamsStub) RegisterCallCount() int {
 	defer stub.registerMutex.RUnlock()
 	return len(stub.registerArgsForCall)
 }
+func (stub *AnonymousParamsStub) RegisterCalls() []AnonymousParamsStubRegisterArgs {
+	stub.registerMutex.RLock()
+	defer stub.registerMutex.RUnlock()
+	calls := make([]AnonymousParamsStubRegisterArgs, len(stub.registerArgsForCall))
+	copy(calls, stub.registerArgsForCall)
+	return calls
+}
 func (stub *AnonymousParamsStub) RegisterArgsForCall(index int) (string, int) {
 	stub.registerMutex.RLock()
 	defer stub.registerMutex.RUnlock()
-	return stub.registerArgsForCall[index].arg1, stub.registerArgsForCall[index].arg2
+	return stub.registerArgsForCall[index].Arg1, stub.registerArgsForCall[index].Arg2
 }
@@ -9,28 +9,38 @@ import (
 )
 
 type AnonymousResultsStub struct {
-	StubGUID              int
-	ActiveUserStub        func() (result1 int, result2 string)
-	activeUserMutex       sync.RWMutex
-	activeUserArgsForCall []struct {
-	}
-	activeUserReturns struct {
-		result1 int
-		result2 string
-	}
+	StubGUID                int
+	ActiveUserStub          func() (result1 int, result2 string)
+	activeUserMutex         sync.RWMutex
+	activeUserArgsForCall   []AnonymousResultsStubActiveUserArgs
+	activeUserReturns       AnonymousResultsStubActiveUserResults
+	activeUserReturnsOnCall map[int]AnonymousResultsStubActiveUserResults
 }
 
 var _ alias1.AnonymousResults = new(AnonymousResultsStub)
 
+type AnonymousResultsStubActiveUserArgs struct {
+}
+type AnonymousResultsStubActiveUserResults struct {
+	Result1 int
+	Result2 string
+}
+
 func (stub *AnonymousResultsStub) ActiveUser() (int, string) {
 	stub.activeUserMutex.Lock()
-	defer stub.activeUserMutex.Unlock()
-	stub.activeUserArgsForCall = append(stub.activeUserArgsForCall, struct {
-	}{})
-	if stub.ActiveUserStub != nil {
-		return stub.ActiveUserStub()
+	stub.activeUserArgsForCall = append(stub.activeUserArgsForCall, AnonymousResultsStubActiveUserArgs{})
+	callIndex := len(stub.activeUserArgsForCall) - 1
+	stubFunc := stub.ActiveUserStub
+	returns := stub.activeUserReturns
+	returnsOnCall, hasReturnsOnCall := stub.activeUserReturnsOnCall[callIndex]
+	stub.activeUserMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
 	} else {
-		return stub.activeUserReturns.result1, stub.activeUserReturns.result2
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
 	}
 }
 func (stub *AnonymousResultsStub) ActiveUserCallCount() int {
@@ -38,11 +48,23 @@ func (stub *AnonymousResultsStub) ActiveUserCallCount() int {
 	defer stub.activeUserMutex.RUnlock()
 	return len(stub.activeUserArgsForCall)
 }
+func (stub *AnonymousResultsStub) ActiveUserCalls() []AnonymousResultsStubActiveUserArgs {
+	stub.activeUserMutex.RLock()
+	defer stub.activeUserMutex.RUnlock()
+	calls := make([]AnonymousResultsStubActiveUserArgs, len(stub.activeUserArgsForCall))
+	copy(calls, stub.activeUserArgsForCall)
+	return calls
+}
 func (stub *AnonymousResultsStub) ActiveUserReturns(result1 int, result2 string) {
 	stub.activeUserMutex.Lock()
 	defer stub.activeUserMutex.Unlock()
-	stub.activeUserReturns = struct {
-		result1 int
-		result2 string
-	}{result1, result2}
+	stub.activeUserReturns = AnonymousResultsStubActiveUserResults{result1, result2}
+}
+func (stub *AnonymousResultsStub) ActiveUserReturnsOnCall(index int, result1 int, result2 string) {
+	stub.activeUserMutex.Lock()
+	defer stub.activeUserMutex.Unlock()
+	if stub.activeUserReturnsOnCall == nil {
+		stub.activeUserReturnsOnCall = make(map[int]AnonymousResultsStubActiveUserResults)
+	}
+	stub.activeUserReturnsOnCall[index] = AnonymousResultsStubActiveUserResults{result1, result2}
 }
@@ -10,29 +10,38 @@ import (
 )
 
 type ArraySupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 [3]alias2.Address) (result1 [3]alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 [3]alias2.Address
-	}
-	methodReturns struct {
-		result1 [3]alias2.Address
-	}
+	StubGUID            int
+	MethodStub          func(arg1 [3]alias2.Address) (result1 [3]alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []ArraySupportStubMethodArgs
+	methodReturns       ArraySupportStubMethodResults
+	methodReturnsOnCall map[int]ArraySupportStubMethodResults
 }
 
 var _ alias1.ArraySupport = new(ArraySupportStub)
 
+type ArraySupportStubMethodArgs struct {
+	Arg1 [3]alias2.Address
+}
+type ArraySupportStubMethodResults struct {
+	Result1 [3]alias2.Address
+}
+
 func (stub *ArraySupportStub) Method(arg1 [3]alias2.Address) [3]alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 [3]alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, ArraySupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *ArraySupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *ArraySupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *ArraySupportStub) MethodCalls() []ArraySupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]ArraySupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *ArraySupportStub) MethodArgsForCall(index int) [3]alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *ArraySupportStub) MethodReturns(result1 [3]alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 [3]alias2.Address
-	}{result1}
+	stub.methodReturns = ArraySupportStubMethodResults{result1}
+}
+func (stub *ArraySupportStub) MethodReturnsOnCall(index int, result1 [3]alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]ArraySupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = ArraySupportStubMethodResults{result1}
 }
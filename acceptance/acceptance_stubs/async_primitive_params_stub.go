@@ -0,0 +1,119 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	sync "sync"
+	time "time"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type AsyncPrimitiveParamsStub struct {
+	StubGUID        int
+	SaveStub        func(arg1 int, arg2 string, arg3 float32)
+	saveMutex       sync.RWMutex
+	saveArgsForCall []AsyncPrimitiveParamsStubSaveArgs
+	saveCallSignal  chan struct{}
+	saveGates       []*AsyncPrimitiveParamsStubGate
+	saveDelay       time.Duration
+}
+type AsyncPrimitiveParamsStubGate struct {
+	once    sync.Once
+	release chan struct{}
+}
+
+func (gate *AsyncPrimitiveParamsStubGate) Release() {
+	gate.once.Do(func() {
+		close(gate.release)
+	})
+}
+
+var _ alias1.PrimitiveParams = new(AsyncPrimitiveParamsStub)
+
+type AsyncPrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
+func (stub *AsyncPrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
+	stub.saveMutex.Lock()
+	stub.saveArgsForCall = append(stub.saveArgsForCall, AsyncPrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	callSignal := stub.saveCallSignal
+	stub.saveCallSignal = nil
+	var gate *AsyncPrimitiveParamsStubGate
+	if len(stub.saveGates) > 0 {
+		gate = stub.saveGates[0]
+		stub.saveGates = stub.saveGates[1:]
+	}
+	delay := stub.saveDelay
+	stub.saveMutex.Unlock()
+	if callSignal != nil {
+		close(callSignal)
+	}
+	if gate != nil {
+		<-gate.release
+	}
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	stub.saveMutex.Lock()
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
+	}
+}
+func (stub *AsyncPrimitiveParamsStub) SaveCallCount() int {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return len(stub.saveArgsForCall)
+}
+func (stub *AsyncPrimitiveParamsStub) SaveCalls() []AsyncPrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]AsyncPrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *AsyncPrimitiveParamsStub) WaitForSaveCalls(count int, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		stub.saveMutex.Lock()
+		if len(stub.saveArgsForCall) >= count {
+			stub.saveMutex.Unlock()
+			return nil
+		}
+		if stub.saveCallSignal == nil {
+			stub.saveCallSignal = make(chan struct{})
+		}
+		callSignal := stub.saveCallSignal
+		stub.saveMutex.Unlock()
+		select {
+		case <-callSignal:
+		case <-deadline:
+			return fmt.Errorf("timed out after %v waiting for %d calls to AsyncPrimitiveParamsStub.Save, got %d", timeout, count, stub.SaveCallCount())
+		}
+	}
+}
+func (stub *AsyncPrimitiveParamsStub) HoldSave(count int) *AsyncPrimitiveParamsStubGate {
+	stub.saveMutex.Lock()
+	defer stub.saveMutex.Unlock()
+	gate := &AsyncPrimitiveParamsStubGate{release: make(chan struct{})}
+	for i := 0; i < count; i++ {
+		stub.saveGates = append(stub.saveGates, gate)
+	}
+	return gate
+}
+func (stub *AsyncPrimitiveParamsStub) SaveDelays(d time.Duration) {
+	stub.saveMutex.Lock()
+	defer stub.saveMutex.Unlock()
+	stub.saveDelay = d
+}
+func (stub *AsyncPrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
+}
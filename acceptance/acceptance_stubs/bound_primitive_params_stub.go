@@ -0,0 +1,157 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type BoundPrimitiveParamsStub struct {
+	StubGUID             int
+	mutex                sync.RWMutex
+	expectations         []*BoundPrimitiveParamsStubExpectation
+	verifiesExpectations bool
+	SaveStub             func(arg1 int, arg2 string, arg3 float32)
+	saveMutex            sync.RWMutex
+	saveArgsForCall      []BoundPrimitiveParamsStubSaveArgs
+}
+type BoundPrimitiveParamsStubOption func(stub *BoundPrimitiveParamsStub)
+
+func NewBoundPrimitiveParamsStub(opts ...BoundPrimitiveParamsStubOption) *BoundPrimitiveParamsStub {
+	stub := new(BoundPrimitiveParamsStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+type BoundPrimitiveParamsStubExpectation struct {
+	method      string
+	min         int
+	max         int
+	callCount   func() int
+	callHistory func() [][]interface{}
+}
+
+func (expectation *BoundPrimitiveParamsStubExpectation) Times(count int) *BoundPrimitiveParamsStubExpectation {
+	expectation.min, expectation.max = count, count
+	return expectation
+}
+func (expectation *BoundPrimitiveParamsStubExpectation) AtLeast(count int) *BoundPrimitiveParamsStubExpectation {
+	expectation.min, expectation.max = count, -1
+	return expectation
+}
+func (expectation *BoundPrimitiveParamsStubExpectation) Never() *BoundPrimitiveParamsStubExpectation {
+	expectation.min, expectation.max = 0, 0
+	return expectation
+}
+func (expectation *BoundPrimitiveParamsStubExpectation) verify(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	count := expectation.callCount()
+	if count >= expectation.min && (expectation.max < 0 || count <= expectation.max) {
+		return
+	}
+	expected := fmt.Sprintf("at least %d", expectation.min)
+	if expectation.max >= 0 {
+		expected = fmt.Sprintf("exactly %d", expectation.max)
+	}
+	history := ""
+	for i, args := range expectation.callHistory() {
+		formattedArgs := make([]string, len(args))
+		for j, arg := range args {
+			formattedArgs[j] = fmt.Sprintf("%#v", arg)
+		}
+		history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, expectation.method, strings.Join(formattedArgs, ", "))
+	}
+	reporter.Helper()
+	reporter.Errorf("expected %s calls to BoundPrimitiveParamsStub.%s, got %d%s", expected, expectation.method, count, history)
+}
+func (stub *BoundPrimitiveParamsStub) verifyExpectations(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	reporter.Helper()
+	stub.mutex.RLock()
+	expectations := stub.expectations
+	stub.mutex.RUnlock()
+	for _, expectation := range expectations {
+		expectation.verify(reporter)
+	}
+}
+func NewBoundPrimitiveParamsStubT(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}, opts ...BoundPrimitiveParamsStubOption) *BoundPrimitiveParamsStub {
+	stub := NewBoundPrimitiveParamsStub(opts...)
+	stub.verifiesExpectations = true
+	t.Cleanup(func() {
+		stub.verifyExpectations(t)
+	})
+	return stub
+}
+
+var _ alias1.PrimitiveParams = new(BoundPrimitiveParamsStub)
+
+type BoundPrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
+func (stub *BoundPrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
+	stub.saveMutex.Lock()
+	stub.saveArgsForCall = append(stub.saveArgsForCall, BoundPrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
+	}
+}
+func (stub *BoundPrimitiveParamsStub) SaveCallCount() int {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return len(stub.saveArgsForCall)
+}
+func (stub *BoundPrimitiveParamsStub) SaveCalls() []BoundPrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]BoundPrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *BoundPrimitiveParamsStub) saveCallHistory() [][]interface{} {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	history := make([][]interface{}, len(stub.saveArgsForCall))
+	for i := range stub.saveArgsForCall {
+		history[i] = []interface{}{stub.saveArgsForCall[i].Arg1, stub.saveArgsForCall[i].Arg2, stub.saveArgsForCall[i].Arg3}
+	}
+	return history
+}
+func (stub *BoundPrimitiveParamsStub) ExpectSave() *BoundPrimitiveParamsStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to BoundPrimitiveParamsStub.Save: the stub was not created with NewBoundPrimitiveParamsStubT")
+	}
+	expectation := &BoundPrimitiveParamsStubExpectation{method: "Save", min: 1, max: -1, callCount: stub.SaveCallCount, callHistory: stub.saveCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func (stub *BoundPrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
+}
+func BoundPrimitiveParamsStubWithSaveStub(fn func(arg1 int, arg2 string, arg3 float32)) BoundPrimitiveParamsStubOption {
+	return func(stub *BoundPrimitiveParamsStub) {
+		stub.SaveStub = fn
+	}
+}
@@ -0,0 +1,182 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type BoundPrimitiveResultsStub struct {
+	StubGUID             int
+	mutex                sync.RWMutex
+	expectations         []*BoundPrimitiveResultsStubExpectation
+	verifiesExpectations bool
+	UserStub             func() (result1 string, result2 int, result3 float32)
+	userMutex            sync.RWMutex
+	userArgsForCall      []BoundPrimitiveResultsStubUserArgs
+	userReturns          BoundPrimitiveResultsStubUserResults
+	userReturnsOnCall    map[int]BoundPrimitiveResultsStubUserResults
+}
+type BoundPrimitiveResultsStubOption func(stub *BoundPrimitiveResultsStub)
+
+func NewBoundPrimitiveResultsStub(opts ...BoundPrimitiveResultsStubOption) *BoundPrimitiveResultsStub {
+	stub := new(BoundPrimitiveResultsStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+type BoundPrimitiveResultsStubExpectation struct {
+	method      string
+	min         int
+	max         int
+	callCount   func() int
+	callHistory func() [][]interface{}
+}
+
+func (expectation *BoundPrimitiveResultsStubExpectation) Times(count int) *BoundPrimitiveResultsStubExpectation {
+	expectation.min, expectation.max = count, count
+	return expectation
+}
+func (expectation *BoundPrimitiveResultsStubExpectation) AtLeast(count int) *BoundPrimitiveResultsStubExpectation {
+	expectation.min, expectation.max = count, -1
+	return expectation
+}
+func (expectation *BoundPrimitiveResultsStubExpectation) Never() *BoundPrimitiveResultsStubExpectation {
+	expectation.min, expectation.max = 0, 0
+	return expectation
+}
+func (expectation *BoundPrimitiveResultsStubExpectation) verify(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	count := expectation.callCount()
+	if count >= expectation.min && (expectation.max < 0 || count <= expectation.max) {
+		return
+	}
+	expected := fmt.Sprintf("at least %d", expectation.min)
+	if expectation.max >= 0 {
+		expected = fmt.Sprintf("exactly %d", expectation.max)
+	}
+	history := ""
+	for i, args := range expectation.callHistory() {
+		formattedArgs := make([]string, len(args))
+		for j, arg := range args {
+			formattedArgs[j] = fmt.Sprintf("%#v", arg)
+		}
+		history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, expectation.method, strings.Join(formattedArgs, ", "))
+	}
+	reporter.Helper()
+	reporter.Errorf("expected %s calls to BoundPrimitiveResultsStub.%s, got %d%s", expected, expectation.method, count, history)
+}
+func (stub *BoundPrimitiveResultsStub) verifyExpectations(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	reporter.Helper()
+	stub.mutex.RLock()
+	expectations := stub.expectations
+	stub.mutex.RUnlock()
+	for _, expectation := range expectations {
+		expectation.verify(reporter)
+	}
+}
+func NewBoundPrimitiveResultsStubT(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}, opts ...BoundPrimitiveResultsStubOption) *BoundPrimitiveResultsStub {
+	stub := NewBoundPrimitiveResultsStub(opts...)
+	stub.verifiesExpectations = true
+	t.Cleanup(func() {
+		stub.verifyExpectations(t)
+	})
+	return stub
+}
+
+var _ alias1.PrimitiveResults = new(BoundPrimitiveResultsStub)
+
+type BoundPrimitiveResultsStubUserArgs struct {
+}
+type BoundPrimitiveResultsStubUserResults struct {
+	Result1 string
+	Result2 int
+	Result3 float32
+}
+
+func (stub *BoundPrimitiveResultsStub) User() (string, int, float32) {
+	stub.userMutex.Lock()
+	stub.userArgsForCall = append(stub.userArgsForCall, BoundPrimitiveResultsStubUserArgs{})
+	callIndex := len(stub.userArgsForCall) - 1
+	stubFunc := stub.UserStub
+	returns := stub.userReturns
+	returnsOnCall, hasReturnsOnCall := stub.userReturnsOnCall[callIndex]
+	stub.userMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2, returnsOnCall.Result3
+		}
+		return returns.Result1, returns.Result2, returns.Result3
+	}
+}
+func (stub *BoundPrimitiveResultsStub) UserCallCount() int {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	return len(stub.userArgsForCall)
+}
+func (stub *BoundPrimitiveResultsStub) UserCalls() []BoundPrimitiveResultsStubUserArgs {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	calls := make([]BoundPrimitiveResultsStubUserArgs, len(stub.userArgsForCall))
+	copy(calls, stub.userArgsForCall)
+	return calls
+}
+func (stub *BoundPrimitiveResultsStub) userCallHistory() [][]interface{} {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	history := make([][]interface{}, len(stub.userArgsForCall))
+	for i := range stub.userArgsForCall {
+		history[i] = []interface{}{}
+	}
+	return history
+}
+func (stub *BoundPrimitiveResultsStub) ExpectUser() *BoundPrimitiveResultsStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to BoundPrimitiveResultsStub.User: the stub was not created with NewBoundPrimitiveResultsStubT")
+	}
+	expectation := &BoundPrimitiveResultsStubExpectation{method: "User", min: 1, max: -1, callCount: stub.UserCallCount, callHistory: stub.userCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func (stub *BoundPrimitiveResultsStub) UserReturns(result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	stub.userReturns = BoundPrimitiveResultsStubUserResults{result1, result2, result3}
+}
+func (stub *BoundPrimitiveResultsStub) UserReturnsOnCall(index int, result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	if stub.userReturnsOnCall == nil {
+		stub.userReturnsOnCall = make(map[int]BoundPrimitiveResultsStubUserResults)
+	}
+	stub.userReturnsOnCall[index] = BoundPrimitiveResultsStubUserResults{result1, result2, result3}
+}
+func BoundPrimitiveResultsStubWithUserStub(fn func() (result1 string, result2 int, result3 float32)) BoundPrimitiveResultsStubOption {
+	return func(stub *BoundPrimitiveResultsStub) {
+		stub.UserStub = fn
+	}
+}
+func BoundPrimitiveResultsStubWithUserReturns(result1 string, result2 int, result3 float32) BoundPrimitiveResultsStubOption {
+	return func(stub *BoundPrimitiveResultsStub) {
+		stub.UserReturns(result1, result2, result3)
+	}
+}
@@ -0,0 +1,208 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type CallbackParamsStub struct {
+	StubGUID                int
+	SubscribeStub           func(arg1 string, arg2 func(alias1.Message)) (result1 error)
+	subscribeMutex          sync.RWMutex
+	subscribeArgsForCall    []CallbackParamsStubSubscribeArgs
+	subscribeReturns        CallbackParamsStubSubscribeResults
+	subscribeReturnsOnCall  map[int]CallbackParamsStubSubscribeResults
+	subscribeHandlerInvokes []func(func(arg1 alias1.Message))
+	WalkStub                func(arg1 string, arg2 func(path string, depth int) bool)
+	walkMutex               sync.RWMutex
+	walkArgsForCall         []CallbackParamsStubWalkArgs
+	walkVisitInvokes        []func(func(arg1 string, arg2 int) bool)
+	EmitStub                func(arg1 func(string, ...int))
+	emitMutex               sync.RWMutex
+	emitArgsForCall         []CallbackParamsStubEmitArgs
+	emitListenerInvokes     []func(func(arg1 string, arg2 ...int))
+}
+
+var _ alias1.CallbackParams = new(CallbackParamsStub)
+
+type CallbackParamsStubSubscribeArgs struct {
+	Arg1 string
+	Arg2 func(alias1.Message)
+}
+type CallbackParamsStubSubscribeResults struct {
+	Result1 error
+}
+
+func (stub *CallbackParamsStub) Subscribe(arg1 string, arg2 func(alias1.Message)) error {
+	stub.subscribeMutex.Lock()
+	stub.subscribeArgsForCall = append(stub.subscribeArgsForCall, CallbackParamsStubSubscribeArgs{arg1, arg2})
+	callIndex := len(stub.subscribeArgsForCall) - 1
+	arg2Invokes := stub.subscribeHandlerInvokes
+	stub.subscribeMutex.Unlock()
+	if arg2 != nil {
+		for _, invoke := range arg2Invokes {
+			invoke(arg2)
+		}
+	}
+	stub.subscribeMutex.Lock()
+	stubFunc := stub.SubscribeStub
+	returns := stub.subscribeReturns
+	returnsOnCall, hasReturnsOnCall := stub.subscribeReturnsOnCall[callIndex]
+	stub.subscribeMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *CallbackParamsStub) SubscribeCallCount() int {
+	stub.subscribeMutex.RLock()
+	defer stub.subscribeMutex.RUnlock()
+	return len(stub.subscribeArgsForCall)
+}
+func (stub *CallbackParamsStub) SubscribeCalls() []CallbackParamsStubSubscribeArgs {
+	stub.subscribeMutex.RLock()
+	defer stub.subscribeMutex.RUnlock()
+	calls := make([]CallbackParamsStubSubscribeArgs, len(stub.subscribeArgsForCall))
+	copy(calls, stub.subscribeArgsForCall)
+	return calls
+}
+func (stub *CallbackParamsStub) SubscribeArgsForCall(index int) (string, func(alias1.Message)) {
+	stub.subscribeMutex.RLock()
+	defer stub.subscribeMutex.RUnlock()
+	return stub.subscribeArgsForCall[index].Arg1, stub.subscribeArgsForCall[index].Arg2
+}
+func (stub *CallbackParamsStub) SubscribeInvokesHandler(arg1 alias1.Message) {
+	stub.subscribeMutex.Lock()
+	defer stub.subscribeMutex.Unlock()
+	stub.subscribeHandlerInvokes = append(stub.subscribeHandlerInvokes, func(callback func(arg1 alias1.Message)) {
+		callback(arg1)
+	})
+}
+func (stub *CallbackParamsStub) SubscribeHandlerForCall(index int) func(alias1.Message) {
+	stub.subscribeMutex.RLock()
+	defer stub.subscribeMutex.RUnlock()
+	return stub.subscribeArgsForCall[index].Arg2
+}
+func (stub *CallbackParamsStub) SubscribeReturns(result1 error) {
+	stub.subscribeMutex.Lock()
+	defer stub.subscribeMutex.Unlock()
+	stub.subscribeReturns = CallbackParamsStubSubscribeResults{result1}
+}
+func (stub *CallbackParamsStub) SubscribeReturnsOnCall(index int, result1 error) {
+	stub.subscribeMutex.Lock()
+	defer stub.subscribeMutex.Unlock()
+	if stub.subscribeReturnsOnCall == nil {
+		stub.subscribeReturnsOnCall = make(map[int]CallbackParamsStubSubscribeResults)
+	}
+	stub.subscribeReturnsOnCall[index] = CallbackParamsStubSubscribeResults{result1}
+}
+
+type CallbackParamsStubWalkArgs struct {
+	Arg1 string
+	Arg2 func(path string, depth int) bool
+}
+
+func (stub *CallbackParamsStub) Walk(arg1 string, arg2 func(path string, depth int) bool) {
+	stub.walkMutex.Lock()
+	stub.walkArgsForCall = append(stub.walkArgsForCall, CallbackParamsStubWalkArgs{arg1, arg2})
+	arg2Invokes := stub.walkVisitInvokes
+	stub.walkMutex.Unlock()
+	if arg2 != nil {
+		for _, invoke := range arg2Invokes {
+			invoke(arg2)
+		}
+	}
+	stub.walkMutex.Lock()
+	stubFunc := stub.WalkStub
+	stub.walkMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2)
+	}
+}
+func (stub *CallbackParamsStub) WalkCallCount() int {
+	stub.walkMutex.RLock()
+	defer stub.walkMutex.RUnlock()
+	return len(stub.walkArgsForCall)
+}
+func (stub *CallbackParamsStub) WalkCalls() []CallbackParamsStubWalkArgs {
+	stub.walkMutex.RLock()
+	defer stub.walkMutex.RUnlock()
+	calls := make([]CallbackParamsStubWalkArgs, len(stub.walkArgsForCall))
+	copy(calls, stub.walkArgsForCall)
+	return calls
+}
+func (stub *CallbackParamsStub) WalkArgsForCall(index int) (string, func(path string, depth int) bool) {
+	stub.walkMutex.RLock()
+	defer stub.walkMutex.RUnlock()
+	return stub.walkArgsForCall[index].Arg1, stub.walkArgsForCall[index].Arg2
+}
+func (stub *CallbackParamsStub) WalkInvokesVisit(arg1 string, arg2 int) {
+	stub.walkMutex.Lock()
+	defer stub.walkMutex.Unlock()
+	stub.walkVisitInvokes = append(stub.walkVisitInvokes, func(callback func(arg1 string, arg2 int) bool) {
+		callback(arg1, arg2)
+	})
+}
+func (stub *CallbackParamsStub) WalkVisitForCall(index int) func(path string, depth int) bool {
+	stub.walkMutex.RLock()
+	defer stub.walkMutex.RUnlock()
+	return stub.walkArgsForCall[index].Arg2
+}
+
+type CallbackParamsStubEmitArgs struct {
+	Arg1 func(string, ...int)
+}
+
+func (stub *CallbackParamsStub) Emit(arg1 func(string, ...int)) {
+	stub.emitMutex.Lock()
+	stub.emitArgsForCall = append(stub.emitArgsForCall, CallbackParamsStubEmitArgs{arg1})
+	arg1Invokes := stub.emitListenerInvokes
+	stub.emitMutex.Unlock()
+	if arg1 != nil {
+		for _, invoke := range arg1Invokes {
+			invoke(arg1)
+		}
+	}
+	stub.emitMutex.Lock()
+	stubFunc := stub.EmitStub
+	stub.emitMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1)
+	}
+}
+func (stub *CallbackParamsStub) EmitCallCount() int {
+	stub.emitMutex.RLock()
+	defer stub.emitMutex.RUnlock()
+	return len(stub.emitArgsForCall)
+}
+func (stub *CallbackParamsStub) EmitCalls() []CallbackParamsStubEmitArgs {
+	stub.emitMutex.RLock()
+	defer stub.emitMutex.RUnlock()
+	calls := make([]CallbackParamsStubEmitArgs, len(stub.emitArgsForCall))
+	copy(calls, stub.emitArgsForCall)
+	return calls
+}
+func (stub *CallbackParamsStub) EmitArgsForCall(index int) func(string, ...int) {
+	stub.emitMutex.RLock()
+	defer stub.emitMutex.RUnlock()
+	return stub.emitArgsForCall[index].Arg1
+}
+func (stub *CallbackParamsStub) EmitInvokesListener(arg1 string, arg2 ...int) {
+	stub.emitMutex.Lock()
+	defer stub.emitMutex.Unlock()
+	stub.emitListenerInvokes = append(stub.emitListenerInvokes, func(callback func(arg1 string, arg2 ...int)) {
+		callback(arg1, arg2...)
+	})
+}
+func (stub *CallbackParamsStub) EmitListenerForCall(index int) func(string, ...int) {
+	stub.emitMutex.RLock()
+	defer stub.emitMutex.RUnlock()
+	return stub.emitArgsForCall[index].Arg1
+}
@@ -10,29 +10,38 @@ import (
 )
 
 type ChannelSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 chan alias2.Address) (result1 chan alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 chan alias2.Address
-	}
-	methodReturns struct {
-		result1 chan alias2.Address
-	}
+	StubGUID            int
+	MethodStub          func(arg1 chan alias2.Address) (result1 chan alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []ChannelSupportStubMethodArgs
+	methodReturns       ChannelSupportStubMethodResults
+	methodReturnsOnCall map[int]ChannelSupportStubMethodResults
 }
 
 var _ alias1.ChannelSupport = new(ChannelSupportStub)
 
+type ChannelSupportStubMethodArgs struct {
+	Arg1 chan alias2.Address
+}
+type ChannelSupportStubMethodResults struct {
+	Result1 chan alias2.Address
+}
+
 func (stub *ChannelSupportStub) Method(arg1 chan alias2.Address) chan alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 chan alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, ChannelSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *ChannelSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *ChannelSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *ChannelSupportStub) MethodCalls() []ChannelSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]ChannelSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *ChannelSupportStub) MethodArgsForCall(index int) chan alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *ChannelSupportStub) MethodReturns(result1 chan alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 chan alias2.Address
-	}{result1}
+	stub.methodReturns = ChannelSupportStubMethodResults{result1}
+}
+func (stub *ChannelSupportStub) MethodReturnsOnCall(index int, result1 chan alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]ChannelSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = ChannelSupportStubMethodResults{result1}
 }
@@ -0,0 +1,174 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	reflect "reflect"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type ConditionalReturnsStub struct {
+	StubGUID       int
+	mutex          sync.RWMutex
+	strict         bool
+	strictReporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+	LookupStub              func(arg1 string, arg2 int) (result1 string, result2 error)
+	lookupMutex             sync.RWMutex
+	lookupArgsForCall       []ConditionalReturnsStubLookupArgs
+	lookupReturns           ConditionalReturnsStubLookupResults
+	lookupReturnsOnCall     map[int]ConditionalReturnsStubLookupResults
+	lookupReturnsConfigured bool
+	lookupRules             []*ConditionalReturnsStubLookupRule
+}
+
+func (stub *ConditionalReturnsStub) SetStrict(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.strict, stub.strictReporter = true, reporter
+}
+func (stub *ConditionalReturnsStub) reportUnconfiguredCall(method string, args ...interface{}) {
+	stub.mutex.RLock()
+	strict, reporter := stub.strict, stub.strictReporter
+	stub.mutex.RUnlock()
+	if !strict {
+		return
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	message := fmt.Sprintf("unconfigured call to ConditionalReturnsStub.%s(%s)", method, strings.Join(formattedArgs, ", "))
+	if reporter == nil {
+		panic(message)
+	}
+	reporter.Helper()
+	reporter.Errorf("%s", message)
+}
+
+type ConditionalReturnsStubOption func(stub *ConditionalReturnsStub)
+
+func NewConditionalReturnsStub(opts ...ConditionalReturnsStubOption) *ConditionalReturnsStub {
+	stub := new(ConditionalReturnsStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+var _ alias1.ConditionalReturns = new(ConditionalReturnsStub)
+
+type ConditionalReturnsStubLookupArgs struct {
+	Arg1 string
+	Arg2 int
+}
+type ConditionalReturnsStubLookupResults struct {
+	Result1 string
+	Result2 error
+}
+
+func (stub *ConditionalReturnsStub) Lookup(arg1 string, arg2 int) (string, error) {
+	stub.lookupMutex.Lock()
+	stub.lookupArgsForCall = append(stub.lookupArgsForCall, ConditionalReturnsStubLookupArgs{arg1, arg2})
+	callIndex := len(stub.lookupArgsForCall) - 1
+	stubFunc := stub.LookupStub
+	returns := stub.lookupReturns
+	configured := stub.lookupReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.lookupReturnsOnCall[callIndex]
+	rules := make([]ConditionalReturnsStubLookupRule, len(stub.lookupRules))
+	for i, rule := range stub.lookupRules {
+		rules[i] = *rule
+	}
+	stub.lookupMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		for _, rule := range rules {
+			if rule.matcher(arg1, arg2) {
+				return rule.returns.Result1, rule.returns.Result2
+			}
+		}
+		if !configured {
+			stub.reportUnconfiguredCall("Lookup", arg1, arg2)
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *ConditionalReturnsStub) LookupCallCount() int {
+	stub.lookupMutex.RLock()
+	defer stub.lookupMutex.RUnlock()
+	return len(stub.lookupArgsForCall)
+}
+func (stub *ConditionalReturnsStub) LookupCalls() []ConditionalReturnsStubLookupArgs {
+	stub.lookupMutex.RLock()
+	defer stub.lookupMutex.RUnlock()
+	calls := make([]ConditionalReturnsStubLookupArgs, len(stub.lookupArgsForCall))
+	copy(calls, stub.lookupArgsForCall)
+	return calls
+}
+func (stub *ConditionalReturnsStub) LookupArgsForCall(index int) (string, int) {
+	stub.lookupMutex.RLock()
+	defer stub.lookupMutex.RUnlock()
+	return stub.lookupArgsForCall[index].Arg1, stub.lookupArgsForCall[index].Arg2
+}
+func (stub *ConditionalReturnsStub) LookupReturns(result1 string, result2 error) {
+	stub.lookupMutex.Lock()
+	defer stub.lookupMutex.Unlock()
+	stub.lookupReturns = ConditionalReturnsStubLookupResults{result1, result2}
+	stub.lookupReturnsConfigured = true
+}
+func (stub *ConditionalReturnsStub) LookupReturnsOnCall(index int, result1 string, result2 error) {
+	stub.lookupMutex.Lock()
+	defer stub.lookupMutex.Unlock()
+	if stub.lookupReturnsOnCall == nil {
+		stub.lookupReturnsOnCall = make(map[int]ConditionalReturnsStubLookupResults)
+	}
+	stub.lookupReturnsOnCall[index] = ConditionalReturnsStubLookupResults{result1, result2}
+}
+func ConditionalReturnsStubWithLookupStub(fn func(arg1 string, arg2 int) (result1 string, result2 error)) ConditionalReturnsStubOption {
+	return func(stub *ConditionalReturnsStub) {
+		stub.LookupStub = fn
+	}
+}
+func ConditionalReturnsStubWithLookupReturns(result1 string, result2 error) ConditionalReturnsStubOption {
+	return func(stub *ConditionalReturnsStub) {
+		stub.LookupReturns(result1, result2)
+	}
+}
+func (stub *ConditionalReturnsStub) LookupWhen(matcher func(arg1 string, arg2 int) bool) *ConditionalReturnsStubLookupRule {
+	stub.lookupMutex.Lock()
+	defer stub.lookupMutex.Unlock()
+	rule := &ConditionalReturnsStubLookupRule{mutex: &stub.lookupMutex, matcher: matcher}
+	stub.lookupRules = append(stub.lookupRules, rule)
+	return rule
+}
+func (stub *ConditionalReturnsStub) LookupCalledWith(arg1 string, arg2 int) *ConditionalReturnsStubLookupRule {
+	expected := []interface{}{arg1, arg2}
+	return stub.LookupWhen(func(arg1 string, arg2 int) bool {
+		return reflect.DeepEqual([]interface{}{arg1, arg2}, expected)
+	})
+}
+
+type ConditionalReturnsStubLookupRule struct {
+	mutex   *sync.RWMutex
+	matcher func(arg1 string, arg2 int) bool
+	returns ConditionalReturnsStubLookupResults
+}
+
+func (rule *ConditionalReturnsStubLookupRule) Returns(result1 string, result2 error) {
+	rule.mutex.Lock()
+	defer rule.mutex.Unlock()
+	rule.returns = ConditionalReturnsStubLookupResults{result1, result2}
+}
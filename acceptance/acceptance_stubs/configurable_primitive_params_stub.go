@@ -0,0 +1,65 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type ConfigurablePrimitiveParamsStub struct {
+	StubGUID        int
+	SaveStub        func(arg1 int, arg2 string, arg3 float32)
+	saveMutex       sync.RWMutex
+	saveArgsForCall []ConfigurablePrimitiveParamsStubSaveArgs
+}
+type ConfigurablePrimitiveParamsStubOption func(stub *ConfigurablePrimitiveParamsStub)
+
+func NewConfigurablePrimitiveParamsStub(opts ...ConfigurablePrimitiveParamsStubOption) *ConfigurablePrimitiveParamsStub {
+	stub := new(ConfigurablePrimitiveParamsStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+var _ alias1.PrimitiveParams = new(ConfigurablePrimitiveParamsStub)
+
+type ConfigurablePrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
+func (stub *ConfigurablePrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
+	stub.saveMutex.Lock()
+	stub.saveArgsForCall = append(stub.saveArgsForCall, ConfigurablePrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
+	}
+}
+func (stub *ConfigurablePrimitiveParamsStub) SaveCallCount() int {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return len(stub.saveArgsForCall)
+}
+func (stub *ConfigurablePrimitiveParamsStub) SaveCalls() []ConfigurablePrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]ConfigurablePrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *ConfigurablePrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
+}
+func ConfigurablePrimitiveParamsStubWithSaveStub(fn func(arg1 int, arg2 string, arg3 float32)) ConfigurablePrimitiveParamsStubOption {
+	return func(stub *ConfigurablePrimitiveParamsStub) {
+		stub.SaveStub = fn
+	}
+}
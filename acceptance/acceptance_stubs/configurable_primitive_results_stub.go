@@ -0,0 +1,90 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type ConfigurablePrimitiveResultsStub struct {
+	StubGUID          int
+	UserStub          func() (result1 string, result2 int, result3 float32)
+	userMutex         sync.RWMutex
+	userArgsForCall   []ConfigurablePrimitiveResultsStubUserArgs
+	userReturns       ConfigurablePrimitiveResultsStubUserResults
+	userReturnsOnCall map[int]ConfigurablePrimitiveResultsStubUserResults
+}
+type ConfigurablePrimitiveResultsStubOption func(stub *ConfigurablePrimitiveResultsStub)
+
+func NewConfigurablePrimitiveResultsStub(opts ...ConfigurablePrimitiveResultsStubOption) *ConfigurablePrimitiveResultsStub {
+	stub := new(ConfigurablePrimitiveResultsStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+var _ alias1.PrimitiveResults = new(ConfigurablePrimitiveResultsStub)
+
+type ConfigurablePrimitiveResultsStubUserArgs struct {
+}
+type ConfigurablePrimitiveResultsStubUserResults struct {
+	Result1 string
+	Result2 int
+	Result3 float32
+}
+
+func (stub *ConfigurablePrimitiveResultsStub) User() (string, int, float32) {
+	stub.userMutex.Lock()
+	stub.userArgsForCall = append(stub.userArgsForCall, ConfigurablePrimitiveResultsStubUserArgs{})
+	callIndex := len(stub.userArgsForCall) - 1
+	stubFunc := stub.UserStub
+	returns := stub.userReturns
+	returnsOnCall, hasReturnsOnCall := stub.userReturnsOnCall[callIndex]
+	stub.userMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2, returnsOnCall.Result3
+		}
+		return returns.Result1, returns.Result2, returns.Result3
+	}
+}
+func (stub *ConfigurablePrimitiveResultsStub) UserCallCount() int {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	return len(stub.userArgsForCall)
+}
+func (stub *ConfigurablePrimitiveResultsStub) UserCalls() []ConfigurablePrimitiveResultsStubUserArgs {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	calls := make([]ConfigurablePrimitiveResultsStubUserArgs, len(stub.userArgsForCall))
+	copy(calls, stub.userArgsForCall)
+	return calls
+}
+func (stub *ConfigurablePrimitiveResultsStub) UserReturns(result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	stub.userReturns = ConfigurablePrimitiveResultsStubUserResults{result1, result2, result3}
+}
+func (stub *ConfigurablePrimitiveResultsStub) UserReturnsOnCall(index int, result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	if stub.userReturnsOnCall == nil {
+		stub.userReturnsOnCall = make(map[int]ConfigurablePrimitiveResultsStubUserResults)
+	}
+	stub.userReturnsOnCall[index] = ConfigurablePrimitiveResultsStubUserResults{result1, result2, result3}
+}
+func ConfigurablePrimitiveResultsStubWithUserStub(fn func() (result1 string, result2 int, result3 float32)) ConfigurablePrimitiveResultsStubOption {
+	return func(stub *ConfigurablePrimitiveResultsStub) {
+		stub.UserStub = fn
+	}
+}
+func ConfigurablePrimitiveResultsStubWithUserReturns(result1 string, result2 int, result3 float32) ConfigurablePrimitiveResultsStubOption {
+	return func(stub *ConfigurablePrimitiveResultsStub) {
+		stub.UserReturns(result1, result2, result3)
+	}
+}
@@ -0,0 +1,174 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	alias2 "context"
+	fmt "fmt"
+	sync "sync"
+	time "time"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type ContextParamsStub struct {
+	StubGUID                    int
+	FetchStub                   func(arg1 alias2.Context, arg2 int) (result1 string, result2 error)
+	fetchMutex                  sync.RWMutex
+	fetchArgsForCall            []ContextParamsStubFetchArgs
+	fetchCallSignal             chan struct{}
+	fetchGates                  []*ContextParamsStubGate
+	fetchDelay                  time.Duration
+	fetchBlocksUntilContextDone bool
+	fetchReturns                ContextParamsStubFetchResults
+	fetchReturnsOnCall          map[int]ContextParamsStubFetchResults
+}
+type ContextParamsStubGate struct {
+	once    sync.Once
+	release chan struct{}
+}
+
+func (gate *ContextParamsStubGate) Release() {
+	gate.once.Do(func() {
+		close(gate.release)
+	})
+}
+
+var _ alias1.ContextParams = new(ContextParamsStub)
+
+type ContextParamsStubFetchArgs struct {
+	Arg1 alias2.Context
+	Arg2 int
+}
+type ContextParamsStubFetchResults struct {
+	Result1 string
+	Result2 error
+}
+
+func (stub *ContextParamsStub) Fetch(arg1 alias2.Context, arg2 int) (string, error) {
+	stub.fetchMutex.Lock()
+	stub.fetchArgsForCall = append(stub.fetchArgsForCall, ContextParamsStubFetchArgs{arg1, arg2})
+	callIndex := len(stub.fetchArgsForCall) - 1
+	callSignal := stub.fetchCallSignal
+	stub.fetchCallSignal = nil
+	var gate *ContextParamsStubGate
+	if len(stub.fetchGates) > 0 {
+		gate = stub.fetchGates[0]
+		stub.fetchGates = stub.fetchGates[1:]
+	}
+	delay := stub.fetchDelay
+	blocks := stub.fetchBlocksUntilContextDone
+	stub.fetchMutex.Unlock()
+	if callSignal != nil {
+		close(callSignal)
+	}
+	var done <-chan struct {
+	}
+	if arg1 != nil {
+		done = arg1.Done()
+	}
+	if gate != nil {
+		select {
+		case <-gate.release:
+		case <-done:
+			var results ContextParamsStubFetchResults
+			results.Result2 = arg1.Err()
+			return results.Result1, results.Result2
+		}
+	}
+	if delay > 0 || blocks {
+		var delayed <-chan time.Time
+		if !blocks {
+			delayed = time.After(delay)
+		}
+		select {
+		case <-delayed:
+		case <-done:
+			var results ContextParamsStubFetchResults
+			results.Result2 = arg1.Err()
+			return results.Result1, results.Result2
+		}
+	}
+	stub.fetchMutex.Lock()
+	stubFunc := stub.FetchStub
+	returns := stub.fetchReturns
+	returnsOnCall, hasReturnsOnCall := stub.fetchReturnsOnCall[callIndex]
+	stub.fetchMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *ContextParamsStub) FetchCallCount() int {
+	stub.fetchMutex.RLock()
+	defer stub.fetchMutex.RUnlock()
+	return len(stub.fetchArgsForCall)
+}
+func (stub *ContextParamsStub) FetchCalls() []ContextParamsStubFetchArgs {
+	stub.fetchMutex.RLock()
+	defer stub.fetchMutex.RUnlock()
+	calls := make([]ContextParamsStubFetchArgs, len(stub.fetchArgsForCall))
+	copy(calls, stub.fetchArgsForCall)
+	return calls
+}
+func (stub *ContextParamsStub) WaitForFetchCalls(count int, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		stub.fetchMutex.Lock()
+		if len(stub.fetchArgsForCall) >= count {
+			stub.fetchMutex.Unlock()
+			return nil
+		}
+		if stub.fetchCallSignal == nil {
+			stub.fetchCallSignal = make(chan struct{})
+		}
+		callSignal := stub.fetchCallSignal
+		stub.fetchMutex.Unlock()
+		select {
+		case <-callSignal:
+		case <-deadline:
+			return fmt.Errorf("timed out after %v waiting for %d calls to ContextParamsStub.Fetch, got %d", timeout, count, stub.FetchCallCount())
+		}
+	}
+}
+func (stub *ContextParamsStub) HoldFetch(count int) *ContextParamsStubGate {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	gate := &ContextParamsStubGate{release: make(chan struct{})}
+	for i := 0; i < count; i++ {
+		stub.fetchGates = append(stub.fetchGates, gate)
+	}
+	return gate
+}
+func (stub *ContextParamsStub) FetchDelays(d time.Duration) {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	stub.fetchDelay = d
+}
+func (stub *ContextParamsStub) FetchBlocksUntilContextDone() {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	stub.fetchBlocksUntilContextDone = true
+}
+func (stub *ContextParamsStub) FetchArgsForCall(index int) (alias2.Context, int) {
+	stub.fetchMutex.RLock()
+	defer stub.fetchMutex.RUnlock()
+	return stub.fetchArgsForCall[index].Arg1, stub.fetchArgsForCall[index].Arg2
+}
+func (stub *ContextParamsStub) FetchReturns(result1 string, result2 error) {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	stub.fetchReturns = ContextParamsStubFetchResults{result1, result2}
+}
+func (stub *ContextParamsStub) FetchReturnsOnCall(index int, result1 string, result2 error) {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	if stub.fetchReturnsOnCall == nil {
+		stub.fetchReturnsOnCall = make(map[int]ContextParamsStubFetchResults)
+	}
+	stub.fetchReturnsOnCall[index] = ContextParamsStubFetchResults{result1, result2}
+}
@@ -0,0 +1,208 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type DeepClientStub struct {
+	StubGUID                int
+	UsersStub               func() (result1 alias1.DeepUserService)
+	usersMutex              sync.RWMutex
+	usersArgsForCall        []DeepClientStubUsersArgs
+	usersReturns            DeepClientStubUsersResults
+	usersReturnsOnCall      map[int]DeepClientStubUsersResults
+	usersReturnsConfigured  bool
+	mutex                   sync.RWMutex
+	usersChildStub          *DeepUserServiceStub
+	OrdersStub              func() (result1 alias1.DeepOrderService)
+	ordersMutex             sync.RWMutex
+	ordersArgsForCall       []DeepClientStubOrdersArgs
+	ordersReturns           DeepClientStubOrdersResults
+	ordersReturnsOnCall     map[int]DeepClientStubOrdersResults
+	ordersReturnsConfigured bool
+	ordersChildStub         *DeepOrderServiceStub
+	NameStub                func() (result1 string)
+	nameMutex               sync.RWMutex
+	nameArgsForCall         []DeepClientStubNameArgs
+	nameReturns             DeepClientStubNameResults
+	nameReturnsOnCall       map[int]DeepClientStubNameResults
+}
+
+var _ alias1.DeepClient = new(DeepClientStub)
+
+type DeepClientStubUsersArgs struct {
+}
+type DeepClientStubUsersResults struct {
+	Result1 alias1.DeepUserService
+}
+
+func (stub *DeepClientStub) UsersStubValue() *DeepUserServiceStub {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if stub.usersChildStub == nil {
+		stub.usersChildStub = new(DeepUserServiceStub)
+	}
+	return stub.usersChildStub
+}
+func (stub *DeepClientStub) Users() alias1.DeepUserService {
+	stub.usersMutex.Lock()
+	stub.usersArgsForCall = append(stub.usersArgsForCall, DeepClientStubUsersArgs{})
+	callIndex := len(stub.usersArgsForCall) - 1
+	stubFunc := stub.UsersStub
+	returns := stub.usersReturns
+	configured := stub.usersReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.usersReturnsOnCall[callIndex]
+	stub.usersMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			return stub.UsersStubValue()
+		}
+		return returns.Result1
+	}
+}
+func (stub *DeepClientStub) UsersCallCount() int {
+	stub.usersMutex.RLock()
+	defer stub.usersMutex.RUnlock()
+	return len(stub.usersArgsForCall)
+}
+func (stub *DeepClientStub) UsersCalls() []DeepClientStubUsersArgs {
+	stub.usersMutex.RLock()
+	defer stub.usersMutex.RUnlock()
+	calls := make([]DeepClientStubUsersArgs, len(stub.usersArgsForCall))
+	copy(calls, stub.usersArgsForCall)
+	return calls
+}
+func (stub *DeepClientStub) UsersReturns(result1 alias1.DeepUserService) {
+	stub.usersMutex.Lock()
+	defer stub.usersMutex.Unlock()
+	stub.usersReturns = DeepClientStubUsersResults{result1}
+	stub.usersReturnsConfigured = true
+}
+func (stub *DeepClientStub) UsersReturnsOnCall(index int, result1 alias1.DeepUserService) {
+	stub.usersMutex.Lock()
+	defer stub.usersMutex.Unlock()
+	if stub.usersReturnsOnCall == nil {
+		stub.usersReturnsOnCall = make(map[int]DeepClientStubUsersResults)
+	}
+	stub.usersReturnsOnCall[index] = DeepClientStubUsersResults{result1}
+}
+
+type DeepClientStubOrdersArgs struct {
+}
+type DeepClientStubOrdersResults struct {
+	Result1 alias1.DeepOrderService
+}
+
+func (stub *DeepClientStub) OrdersStubValue() *DeepOrderServiceStub {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if stub.ordersChildStub == nil {
+		stub.ordersChildStub = new(DeepOrderServiceStub)
+	}
+	return stub.ordersChildStub
+}
+func (stub *DeepClientStub) Orders() alias1.DeepOrderService {
+	stub.ordersMutex.Lock()
+	stub.ordersArgsForCall = append(stub.ordersArgsForCall, DeepClientStubOrdersArgs{})
+	callIndex := len(stub.ordersArgsForCall) - 1
+	stubFunc := stub.OrdersStub
+	returns := stub.ordersReturns
+	configured := stub.ordersReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.ordersReturnsOnCall[callIndex]
+	stub.ordersMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			return stub.OrdersStubValue()
+		}
+		return returns.Result1
+	}
+}
+func (stub *DeepClientStub) OrdersCallCount() int {
+	stub.ordersMutex.RLock()
+	defer stub.ordersMutex.RUnlock()
+	return len(stub.ordersArgsForCall)
+}
+func (stub *DeepClientStub) OrdersCalls() []DeepClientStubOrdersArgs {
+	stub.ordersMutex.RLock()
+	defer stub.ordersMutex.RUnlock()
+	calls := make([]DeepClientStubOrdersArgs, len(stub.ordersArgsForCall))
+	copy(calls, stub.ordersArgsForCall)
+	return calls
+}
+func (stub *DeepClientStub) OrdersReturns(result1 alias1.DeepOrderService) {
+	stub.ordersMutex.Lock()
+	defer stub.ordersMutex.Unlock()
+	stub.ordersReturns = DeepClientStubOrdersResults{result1}
+	stub.ordersReturnsConfigured = true
+}
+func (stub *DeepClientStub) OrdersReturnsOnCall(index int, result1 alias1.DeepOrderService) {
+	stub.ordersMutex.Lock()
+	defer stub.ordersMutex.Unlock()
+	if stub.ordersReturnsOnCall == nil {
+		stub.ordersReturnsOnCall = make(map[int]DeepClientStubOrdersResults)
+	}
+	stub.ordersReturnsOnCall[index] = DeepClientStubOrdersResults{result1}
+}
+
+type DeepClientStubNameArgs struct {
+}
+type DeepClientStubNameResults struct {
+	Result1 string
+}
+
+func (stub *DeepClientStub) Name() string {
+	stub.nameMutex.Lock()
+	stub.nameArgsForCall = append(stub.nameArgsForCall, DeepClientStubNameArgs{})
+	callIndex := len(stub.nameArgsForCall) - 1
+	stubFunc := stub.NameStub
+	returns := stub.nameReturns
+	returnsOnCall, hasReturnsOnCall := stub.nameReturnsOnCall[callIndex]
+	stub.nameMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *DeepClientStub) NameCallCount() int {
+	stub.nameMutex.RLock()
+	defer stub.nameMutex.RUnlock()
+	return len(stub.nameArgsForCall)
+}
+func (stub *DeepClientStub) NameCalls() []DeepClientStubNameArgs {
+	stub.nameMutex.RLock()
+	defer stub.nameMutex.RUnlock()
+	calls := make([]DeepClientStubNameArgs, len(stub.nameArgsForCall))
+	copy(calls, stub.nameArgsForCall)
+	return calls
+}
+func (stub *DeepClientStub) NameReturns(result1 string) {
+	stub.nameMutex.Lock()
+	defer stub.nameMutex.Unlock()
+	stub.nameReturns = DeepClientStubNameResults{result1}
+}
+func (stub *DeepClientStub) NameReturnsOnCall(index int, result1 string) {
+	stub.nameMutex.Lock()
+	defer stub.nameMutex.Unlock()
+	if stub.nameReturnsOnCall == nil {
+		stub.nameReturnsOnCall = make(map[int]DeepClientStubNameResults)
+	}
+	stub.nameReturnsOnCall[index] = DeepClientStubNameResults{result1}
+}
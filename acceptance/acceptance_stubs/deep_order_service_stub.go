@@ -0,0 +1,139 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type DeepOrderServiceStub struct {
+	StubGUID               int
+	CountStub              func() (result1 int)
+	countMutex             sync.RWMutex
+	countArgsForCall       []DeepOrderServiceStubCountArgs
+	countReturns           DeepOrderServiceStubCountResults
+	countReturnsOnCall     map[int]DeepOrderServiceStubCountResults
+	UsersStub              func() (result1 alias1.DeepUserService)
+	usersMutex             sync.RWMutex
+	usersArgsForCall       []DeepOrderServiceStubUsersArgs
+	usersReturns           DeepOrderServiceStubUsersResults
+	usersReturnsOnCall     map[int]DeepOrderServiceStubUsersResults
+	usersReturnsConfigured bool
+	mutex                  sync.RWMutex
+	usersChildStub         *DeepUserServiceStub
+}
+
+var _ alias1.DeepOrderService = new(DeepOrderServiceStub)
+
+type DeepOrderServiceStubCountArgs struct {
+}
+type DeepOrderServiceStubCountResults struct {
+	Result1 int
+}
+
+func (stub *DeepOrderServiceStub) Count() int {
+	stub.countMutex.Lock()
+	stub.countArgsForCall = append(stub.countArgsForCall, DeepOrderServiceStubCountArgs{})
+	callIndex := len(stub.countArgsForCall) - 1
+	stubFunc := stub.CountStub
+	returns := stub.countReturns
+	returnsOnCall, hasReturnsOnCall := stub.countReturnsOnCall[callIndex]
+	stub.countMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *DeepOrderServiceStub) CountCallCount() int {
+	stub.countMutex.RLock()
+	defer stub.countMutex.RUnlock()
+	return len(stub.countArgsForCall)
+}
+func (stub *DeepOrderServiceStub) CountCalls() []DeepOrderServiceStubCountArgs {
+	stub.countMutex.RLock()
+	defer stub.countMutex.RUnlock()
+	calls := make([]DeepOrderServiceStubCountArgs, len(stub.countArgsForCall))
+	copy(calls, stub.countArgsForCall)
+	return calls
+}
+func (stub *DeepOrderServiceStub) CountReturns(result1 int) {
+	stub.countMutex.Lock()
+	defer stub.countMutex.Unlock()
+	stub.countReturns = DeepOrderServiceStubCountResults{result1}
+}
+func (stub *DeepOrderServiceStub) CountReturnsOnCall(index int, result1 int) {
+	stub.countMutex.Lock()
+	defer stub.countMutex.Unlock()
+	if stub.countReturnsOnCall == nil {
+		stub.countReturnsOnCall = make(map[int]DeepOrderServiceStubCountResults)
+	}
+	stub.countReturnsOnCall[index] = DeepOrderServiceStubCountResults{result1}
+}
+
+type DeepOrderServiceStubUsersArgs struct {
+}
+type DeepOrderServiceStubUsersResults struct {
+	Result1 alias1.DeepUserService
+}
+
+func (stub *DeepOrderServiceStub) UsersStubValue() *DeepUserServiceStub {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if stub.usersChildStub == nil {
+		stub.usersChildStub = new(DeepUserServiceStub)
+	}
+	return stub.usersChildStub
+}
+func (stub *DeepOrderServiceStub) Users() alias1.DeepUserService {
+	stub.usersMutex.Lock()
+	stub.usersArgsForCall = append(stub.usersArgsForCall, DeepOrderServiceStubUsersArgs{})
+	callIndex := len(stub.usersArgsForCall) - 1
+	stubFunc := stub.UsersStub
+	returns := stub.usersReturns
+	configured := stub.usersReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.usersReturnsOnCall[callIndex]
+	stub.usersMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			return stub.UsersStubValue()
+		}
+		return returns.Result1
+	}
+}
+func (stub *DeepOrderServiceStub) UsersCallCount() int {
+	stub.usersMutex.RLock()
+	defer stub.usersMutex.RUnlock()
+	return len(stub.usersArgsForCall)
+}
+func (stub *DeepOrderServiceStub) UsersCalls() []DeepOrderServiceStubUsersArgs {
+	stub.usersMutex.RLock()
+	defer stub.usersMutex.RUnlock()
+	calls := make([]DeepOrderServiceStubUsersArgs, len(stub.usersArgsForCall))
+	copy(calls, stub.usersArgsForCall)
+	return calls
+}
+func (stub *DeepOrderServiceStub) UsersReturns(result1 alias1.DeepUserService) {
+	stub.usersMutex.Lock()
+	defer stub.usersMutex.Unlock()
+	stub.usersReturns = DeepOrderServiceStubUsersResults{result1}
+	stub.usersReturnsConfigured = true
+}
+func (stub *DeepOrderServiceStub) UsersReturnsOnCall(index int, result1 alias1.DeepUserService) {
+	stub.usersMutex.Lock()
+	defer stub.usersMutex.Unlock()
+	if stub.usersReturnsOnCall == nil {
+		stub.usersReturnsOnCall = make(map[int]DeepOrderServiceStubUsersResults)
+	}
+	stub.usersReturnsOnCall[index] = DeepOrderServiceStubUsersResults{result1}
+}
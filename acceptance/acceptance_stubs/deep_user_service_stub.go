@@ -0,0 +1,146 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type DeepUserServiceStub struct {
+	StubGUID                int
+	GetStub                 func(arg1 int) (result1 string, result2 error)
+	getMutex                sync.RWMutex
+	getArgsForCall          []DeepUserServiceStubGetArgs
+	getReturns              DeepUserServiceStubGetResults
+	getReturnsOnCall        map[int]DeepUserServiceStubGetResults
+	ClientStub              func() (result1 alias1.DeepClient)
+	clientMutex             sync.RWMutex
+	clientArgsForCall       []DeepUserServiceStubClientArgs
+	clientReturns           DeepUserServiceStubClientResults
+	clientReturnsOnCall     map[int]DeepUserServiceStubClientResults
+	clientReturnsConfigured bool
+	mutex                   sync.RWMutex
+	clientChildStub         *DeepClientStub
+}
+
+var _ alias1.DeepUserService = new(DeepUserServiceStub)
+
+type DeepUserServiceStubGetArgs struct {
+	Arg1 int
+}
+type DeepUserServiceStubGetResults struct {
+	Result1 string
+	Result2 error
+}
+
+func (stub *DeepUserServiceStub) Get(arg1 int) (string, error) {
+	stub.getMutex.Lock()
+	stub.getArgsForCall = append(stub.getArgsForCall, DeepUserServiceStubGetArgs{arg1})
+	callIndex := len(stub.getArgsForCall) - 1
+	stubFunc := stub.GetStub
+	returns := stub.getReturns
+	returnsOnCall, hasReturnsOnCall := stub.getReturnsOnCall[callIndex]
+	stub.getMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *DeepUserServiceStub) GetCallCount() int {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	return len(stub.getArgsForCall)
+}
+func (stub *DeepUserServiceStub) GetCalls() []DeepUserServiceStubGetArgs {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	calls := make([]DeepUserServiceStubGetArgs, len(stub.getArgsForCall))
+	copy(calls, stub.getArgsForCall)
+	return calls
+}
+func (stub *DeepUserServiceStub) GetArgsForCall(index int) int {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	return stub.getArgsForCall[index].Arg1
+}
+func (stub *DeepUserServiceStub) GetReturns(result1 string, result2 error) {
+	stub.getMutex.Lock()
+	defer stub.getMutex.Unlock()
+	stub.getReturns = DeepUserServiceStubGetResults{result1, result2}
+}
+func (stub *DeepUserServiceStub) GetReturnsOnCall(index int, result1 string, result2 error) {
+	stub.getMutex.Lock()
+	defer stub.getMutex.Unlock()
+	if stub.getReturnsOnCall == nil {
+		stub.getReturnsOnCall = make(map[int]DeepUserServiceStubGetResults)
+	}
+	stub.getReturnsOnCall[index] = DeepUserServiceStubGetResults{result1, result2}
+}
+
+type DeepUserServiceStubClientArgs struct {
+}
+type DeepUserServiceStubClientResults struct {
+	Result1 alias1.DeepClient
+}
+
+func (stub *DeepUserServiceStub) ClientStubValue() *DeepClientStub {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if stub.clientChildStub == nil {
+		stub.clientChildStub = new(DeepClientStub)
+	}
+	return stub.clientChildStub
+}
+func (stub *DeepUserServiceStub) Client() alias1.DeepClient {
+	stub.clientMutex.Lock()
+	stub.clientArgsForCall = append(stub.clientArgsForCall, DeepUserServiceStubClientArgs{})
+	callIndex := len(stub.clientArgsForCall) - 1
+	stubFunc := stub.ClientStub
+	returns := stub.clientReturns
+	configured := stub.clientReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.clientReturnsOnCall[callIndex]
+	stub.clientMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			return stub.ClientStubValue()
+		}
+		return returns.Result1
+	}
+}
+func (stub *DeepUserServiceStub) ClientCallCount() int {
+	stub.clientMutex.RLock()
+	defer stub.clientMutex.RUnlock()
+	return len(stub.clientArgsForCall)
+}
+func (stub *DeepUserServiceStub) ClientCalls() []DeepUserServiceStubClientArgs {
+	stub.clientMutex.RLock()
+	defer stub.clientMutex.RUnlock()
+	calls := make([]DeepUserServiceStubClientArgs, len(stub.clientArgsForCall))
+	copy(calls, stub.clientArgsForCall)
+	return calls
+}
+func (stub *DeepUserServiceStub) ClientReturns(result1 alias1.DeepClient) {
+	stub.clientMutex.Lock()
+	defer stub.clientMutex.Unlock()
+	stub.clientReturns = DeepUserServiceStubClientResults{result1}
+	stub.clientReturnsConfigured = true
+}
+func (stub *DeepUserServiceStub) ClientReturnsOnCall(index int, result1 alias1.DeepClient) {
+	stub.clientMutex.Lock()
+	defer stub.clientMutex.Unlock()
+	if stub.clientReturnsOnCall == nil {
+		stub.clientReturnsOnCall = make(map[int]DeepUserServiceStubClientResults)
+	}
+	stub.clientReturnsOnCall[index] = DeepUserServiceStubClientResults{result1}
+}
@@ -13,25 +13,24 @@ type EllipsisSupportStub struct {
 	StubGUID          int
 	MethodStub        func(arg1 string, arg2 int, arg3 ...alias2.Address)
 	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 string
-		arg2 int
-		arg3 []alias2.Address
-	}
+	methodArgsForCall []EllipsisSupportStubMethodArgs
 }
 
 var _ alias1.EllipsisSupport = new(EllipsisSupportStub)
 
+type EllipsisSupportStubMethodArgs struct {
+	Arg1 string
+	Arg2 int
+	Arg3 []alias2.Address
+}
+
 func (stub *EllipsisSupportStub) Method(arg1 string, arg2 int, arg3 ...alias2.Address) {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 string
-		arg2 int
-		arg3 []alias2.Address
-	}{arg1, arg2, arg3})
-	if stub.MethodStub != nil {
-		stub.MethodStub(arg1, arg2, arg3...)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, EllipsisSupportStubMethodArgs{arg1, arg2, arg3})
+	stubFunc := stub.MethodStub
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3...)
 	}
 }
 func (stub *EllipsisSupportStub) MethodCallCount() int {
@@ -39,8 +38,15 @@ func (stub *EllipsisSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *EllipsisSupportStub) MethodCalls() []EllipsisSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]EllipsisSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *EllipsisSupportStub) MethodArgsForCall(index int) (string, int, []alias2.Address) {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1, stub.methodArgsForCall[index].arg2, stub.methodArgsForCall[index].arg3
+	return stub.methodArgsForCall[index].Arg1, stub.methodArgsForCall[index].Arg2, stub.methodArgsForCall[index].Arg3
 }
@@ -10,37 +10,43 @@ import (
 )
 
 type EmbeddedEmbeddedInterfaceSupportStub struct {
-	StubGUID       int
-	RunStub        func(arg1 alias2.Address) (result1 error)
-	runMutex       sync.RWMutex
-	runArgsForCall []struct {
-		arg1 alias2.Address
-	}
-	runReturns struct {
-		result1 error
-	}
-	MethodStub        func(arg1 int) (result1 int)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 int
-	}
-	methodReturns struct {
-		result1 int
-	}
+	StubGUID            int
+	RunStub             func(arg1 alias2.Address) (result1 error)
+	runMutex            sync.RWMutex
+	runArgsForCall      []EmbeddedEmbeddedInterfaceSupportStubRunArgs
+	runReturns          EmbeddedEmbeddedInterfaceSupportStubRunResults
+	runReturnsOnCall    map[int]EmbeddedEmbeddedInterfaceSupportStubRunResults
+	MethodStub          func(arg1 int) (result1 int)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []EmbeddedEmbeddedInterfaceSupportStubMethodArgs
+	methodReturns       EmbeddedEmbeddedInterfaceSupportStubMethodResults
+	methodReturnsOnCall map[int]EmbeddedEmbeddedInterfaceSupportStubMethodResults
 }
 
 var _ alias1.EmbeddedEmbeddedInterfaceSupport = new(EmbeddedEmbeddedInterfaceSupportStub)
 
+type EmbeddedEmbeddedInterfaceSupportStubRunArgs struct {
+	Arg1 alias2.Address
+}
+type EmbeddedEmbeddedInterfaceSupportStubRunResults struct {
+	Result1 error
+}
+
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) Run(arg1 alias2.Address) error {
 	stub.runMutex.Lock()
-	defer stub.runMutex.Unlock()
-	stub.runArgsForCall = append(stub.runArgsForCall, struct {
-		arg1 alias2.Address
-	}{arg1})
-	if stub.RunStub != nil {
-		return stub.RunStub(arg1)
+	stub.runArgsForCall = append(stub.runArgsForCall, EmbeddedEmbeddedInterfaceSupportStubRunArgs{arg1})
+	callIndex := len(stub.runArgsForCall) - 1
+	stubFunc := stub.RunStub
+	returns := stub.runReturns
+	returnsOnCall, hasReturnsOnCall := stub.runReturnsOnCall[callIndex]
+	stub.runMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.runReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) RunCallCount() int {
@@ -48,28 +54,54 @@ func (stub *EmbeddedEmbeddedInterfaceSupportStub) RunCallCount() int {
 	defer stub.runMutex.RUnlock()
 	return len(stub.runArgsForCall)
 }
+func (stub *EmbeddedEmbeddedInterfaceSupportStub) RunCalls() []EmbeddedEmbeddedInterfaceSupportStubRunArgs {
+	stub.runMutex.RLock()
+	defer stub.runMutex.RUnlock()
+	calls := make([]EmbeddedEmbeddedInterfaceSupportStubRunArgs, len(stub.runArgsForCall))
+	copy(calls, stub.runArgsForCall)
+	return calls
+}
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) RunArgsForCall(index int) alias2.Address {
 	stub.runMutex.RLock()
 	defer stub.runMutex.RUnlock()
-	return stub.runArgsForCall[index].arg1
+	return stub.runArgsForCall[index].Arg1
 }
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) RunReturns(result1 error) {
 	stub.runMutex.Lock()
 	defer stub.runMutex.Unlock()
-	stub.runReturns = struct {
-		result1 error
-	}{result1}
+	stub.runReturns = EmbeddedEmbeddedInterfaceSupportStubRunResults{result1}
 }
+func (stub *EmbeddedEmbeddedInterfaceSupportStub) RunReturnsOnCall(index int, result1 error) {
+	stub.runMutex.Lock()
+	defer stub.runMutex.Unlock()
+	if stub.runReturnsOnCall == nil {
+		stub.runReturnsOnCall = make(map[int]EmbeddedEmbeddedInterfaceSupportStubRunResults)
+	}
+	stub.runReturnsOnCall[index] = EmbeddedEmbeddedInterfaceSupportStubRunResults{result1}
+}
+
+type EmbeddedEmbeddedInterfaceSupportStubMethodArgs struct {
+	Arg1 int
+}
+type EmbeddedEmbeddedInterfaceSupportStubMethodResults struct {
+	Result1 int
+}
+
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) Method(arg1 int) int {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 int
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, EmbeddedEmbeddedInterfaceSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) MethodCallCount() int {
@@ -77,15 +109,28 @@ func (stub *EmbeddedEmbeddedInterfaceSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *EmbeddedEmbeddedInterfaceSupportStub) MethodCalls() []EmbeddedEmbeddedInterfaceSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]EmbeddedEmbeddedInterfaceSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) MethodArgsForCall(index int) int {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *EmbeddedEmbeddedInterfaceSupportStub) MethodReturns(result1 int) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 int
-	}{result1}
+	stub.methodReturns = EmbeddedEmbeddedInterfaceSupportStubMethodResults{result1}
+}
+func (stub *EmbeddedEmbeddedInterfaceSupportStub) MethodReturnsOnCall(index int, result1 int) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]EmbeddedEmbeddedInterfaceSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = EmbeddedEmbeddedInterfaceSupportStubMethodResults{result1}
 }
@@ -10,29 +10,38 @@ import (
 )
 
 type EmbeddedRefSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 alias2.Resource) (result1 alias2.Resource)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 alias2.Resource
-	}
-	methodReturns struct {
-		result1 alias2.Resource
-	}
+	StubGUID            int
+	MethodStub          func(arg1 alias2.Resource) (result1 alias2.Resource)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []EmbeddedRefSupportStubMethodArgs
+	methodReturns       EmbeddedRefSupportStubMethodResults
+	methodReturnsOnCall map[int]EmbeddedRefSupportStubMethodResults
 }
 
 var _ alias1.EmbeddedRefSupport = new(EmbeddedRefSupportStub)
 
+type EmbeddedRefSupportStubMethodArgs struct {
+	Arg1 alias2.Resource
+}
+type EmbeddedRefSupportStubMethodResults struct {
+	Result1 alias2.Resource
+}
+
 func (stub *EmbeddedRefSupportStub) Method(arg1 alias2.Resource) alias2.Resource {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 alias2.Resource
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, EmbeddedRefSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *EmbeddedRefSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *EmbeddedRefSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *EmbeddedRefSupportStub) MethodCalls() []EmbeddedRefSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]EmbeddedRefSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *EmbeddedRefSupportStub) MethodArgsForCall(index int) alias2.Resource {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *EmbeddedRefSupportStub) MethodReturns(result1 alias2.Resource) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 alias2.Resource
-	}{result1}
+	stub.methodReturns = EmbeddedRefSupportStubMethodResults{result1}
+}
+func (stub *EmbeddedRefSupportStub) MethodReturnsOnCall(index int, result1 alias2.Resource) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]EmbeddedRefSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = EmbeddedRefSupportStubMethodResults{result1}
 }
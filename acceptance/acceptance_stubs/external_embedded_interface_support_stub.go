@@ -11,37 +11,43 @@ import (
 )
 
 type ExternalEmbeddedInterfaceSupportStub struct {
-	StubGUID       int
-	RunStub        func(arg1 alias2.Address) (result1 error)
-	runMutex       sync.RWMutex
-	runArgsForCall []struct {
-		arg1 alias2.Address
-	}
-	runReturns struct {
-		result1 error
-	}
-	MethodStub        func(arg1 alias3.Runner) (result1 alias3.Runner)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 alias3.Runner
-	}
-	methodReturns struct {
-		result1 alias3.Runner
-	}
+	StubGUID            int
+	RunStub             func(arg1 alias2.Address) (result1 error)
+	runMutex            sync.RWMutex
+	runArgsForCall      []ExternalEmbeddedInterfaceSupportStubRunArgs
+	runReturns          ExternalEmbeddedInterfaceSupportStubRunResults
+	runReturnsOnCall    map[int]ExternalEmbeddedInterfaceSupportStubRunResults
+	MethodStub          func(arg1 alias3.Runner) (result1 alias3.Runner)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []ExternalEmbeddedInterfaceSupportStubMethodArgs
+	methodReturns       ExternalEmbeddedInterfaceSupportStubMethodResults
+	methodReturnsOnCall map[int]ExternalEmbeddedInterfaceSupportStubMethodResults
 }
 
 var _ alias1.ExternalEmbeddedInterfaceSupport = new(ExternalEmbeddedInterfaceSupportStub)
 
+type ExternalEmbeddedInterfaceSupportStubRunArgs struct {
+	Arg1 alias2.Address
+}
+type ExternalEmbeddedInterfaceSupportStubRunResults struct {
+	Result1 error
+}
+
 func (stub *ExternalEmbeddedInterfaceSupportStub) Run(arg1 alias2.Address) error {
 	stub.runMutex.Lock()
-	defer stub.runMutex.Unlock()
-	stub.runArgsForCall = append(stub.runArgsForCall, struct {
-		arg1 alias2.Address
-	}{arg1})
-	if stub.RunStub != nil {
-		return stub.RunStub(arg1)
+	stub.runArgsForCall = append(stub.runArgsForCall, ExternalEmbeddedInterfaceSupportStubRunArgs{arg1})
+	callIndex := len(stub.runArgsForCall) - 1
+	stubFunc := stub.RunStub
+	returns := stub.runReturns
+	returnsOnCall, hasReturnsOnCall := stub.runReturnsOnCall[callIndex]
+	stub.runMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.runReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *ExternalEmbeddedInterfaceSupportStub) RunCallCount() int {
@@ -49,28 +55,54 @@ func (stub *ExternalEmbeddedInterfaceSupportStub) RunCallCount() int {
 	defer stub.runMutex.RUnlock()
 	return len(stub.runArgsForCall)
 }
+func (stub *ExternalEmbeddedInterfaceSupportStub) RunCalls() []ExternalEmbeddedInterfaceSupportStubRunArgs {
+	stub.runMutex.RLock()
+	defer stub.runMutex.RUnlock()
+	calls := make([]ExternalEmbeddedInterfaceSupportStubRunArgs, len(stub.runArgsForCall))
+	copy(calls, stub.runArgsForCall)
+	return calls
+}
 func (stub *ExternalEmbeddedInterfaceSupportStub) RunArgsForCall(index int) alias2.Address {
 	stub.runMutex.RLock()
 	defer stub.runMutex.RUnlock()
-	return stub.runArgsForCall[index].arg1
+	return stub.runArgsForCall[index].Arg1
 }
 func (stub *ExternalEmbeddedInterfaceSupportStub) RunReturns(result1 error) {
 	stub.runMutex.Lock()
 	defer stub.runMutex.Unlock()
-	stub.runReturns = struct {
-		result1 error
-	}{result1}
+	stub.runReturns = ExternalEmbeddedInterfaceSupportStubRunResults{result1}
 }
+func (stub *ExternalEmbeddedInterfaceSupportStub) RunReturnsOnCall(index int, result1 error) {
+	stub.runMutex.Lock()
+	defer stub.runMutex.Unlock()
+	if stub.runReturnsOnCall == nil {
+		stub.runReturnsOnCall = make(map[int]ExternalEmbeddedInterfaceSupportStubRunResults)
+	}
+	stub.runReturnsOnCall[index] = ExternalEmbeddedInterfaceSupportStubRunResults{result1}
+}
+
+type ExternalEmbeddedInterfaceSupportStubMethodArgs struct {
+	Arg1 alias3.Runner
+}
+type ExternalEmbeddedInterfaceSupportStubMethodResults struct {
+	Result1 alias3.Runner
+}
+
 func (stub *ExternalEmbeddedInterfaceSupportStub) Method(arg1 alias3.Runner) alias3.Runner {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 alias3.Runner
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, ExternalEmbeddedInterfaceSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *ExternalEmbeddedInterfaceSupportStub) MethodCallCount() int {
@@ -78,15 +110,28 @@ func (stub *ExternalEmbeddedInterfaceSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *ExternalEmbeddedInterfaceSupportStub) MethodCalls() []ExternalEmbeddedInterfaceSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]ExternalEmbeddedInterfaceSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *ExternalEmbeddedInterfaceSupportStub) MethodArgsForCall(index int) alias3.Runner {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *ExternalEmbeddedInterfaceSupportStub) MethodReturns(result1 alias3.Runner) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 alias3.Runner
-	}{result1}
+	stub.methodReturns = ExternalEmbeddedInterfaceSupportStubMethodResults{result1}
+}
+func (stub *ExternalEmbeddedInterfaceSupportStub) MethodReturnsOnCall(index int, result1 alias3.Runner) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]ExternalEmbeddedInterfaceSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = ExternalEmbeddedInterfaceSupportStubMethodResults{result1}
 }
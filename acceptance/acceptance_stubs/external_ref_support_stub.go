@@ -10,29 +10,38 @@ import (
 )
 
 type ExternalRefSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 alias2.Address) (result1 alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 alias2.Address
-	}
-	methodReturns struct {
-		result1 alias2.Address
-	}
+	StubGUID            int
+	MethodStub          func(arg1 alias2.Address) (result1 alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []ExternalRefSupportStubMethodArgs
+	methodReturns       ExternalRefSupportStubMethodResults
+	methodReturnsOnCall map[int]ExternalRefSupportStubMethodResults
 }
 
 var _ alias1.ExternalRefSupport = new(ExternalRefSupportStub)
 
+type ExternalRefSupportStubMethodArgs struct {
+	Arg1 alias2.Address
+}
+type ExternalRefSupportStubMethodResults struct {
+	Result1 alias2.Address
+}
+
 func (stub *ExternalRefSupportStub) Method(arg1 alias2.Address) alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, ExternalRefSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *ExternalRefSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *ExternalRefSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *ExternalRefSupportStub) MethodCalls() []ExternalRefSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]ExternalRefSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *ExternalRefSupportStub) MethodArgsForCall(index int) alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *ExternalRefSupportStub) MethodReturns(result1 alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 alias2.Address
-	}{result1}
+	stub.methodReturns = ExternalRefSupportStubMethodResults{result1}
+}
+func (stub *ExternalRefSupportStub) MethodReturnsOnCall(index int, result1 alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]ExternalRefSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = ExternalRefSupportStubMethodResults{result1}
 }
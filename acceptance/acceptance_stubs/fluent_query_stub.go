@@ -0,0 +1,243 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type FluentQueryStub struct {
+	StubGUID       int
+	mutex          sync.RWMutex
+	strict         bool
+	strictReporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+	WhereStub              func(arg1 string) (result1 alias1.FluentFilter)
+	whereMutex             sync.RWMutex
+	whereArgsForCall       []FluentQueryStubWhereArgs
+	whereReturns           FluentQueryStubWhereResults
+	whereReturnsOnCall     map[int]FluentQueryStubWhereResults
+	whereReturnsConfigured bool
+	LimitStub              func(arg1 int) (result1 alias1.FluentQuery)
+	limitMutex             sync.RWMutex
+	limitArgsForCall       []FluentQueryStubLimitArgs
+	limitReturns           FluentQueryStubLimitResults
+	limitReturnsOnCall     map[int]FluentQueryStubLimitResults
+	limitReturnsConfigured bool
+	RunStub                func() (result1 []string, result2 error)
+	runMutex               sync.RWMutex
+	runArgsForCall         []FluentQueryStubRunArgs
+	runReturns             FluentQueryStubRunResults
+	runReturnsOnCall       map[int]FluentQueryStubRunResults
+	runReturnsConfigured   bool
+}
+
+func (stub *FluentQueryStub) SetStrict(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.strict, stub.strictReporter = true, reporter
+}
+func (stub *FluentQueryStub) reportUnconfiguredCall(method string, args ...interface{}) {
+	stub.mutex.RLock()
+	strict, reporter := stub.strict, stub.strictReporter
+	stub.mutex.RUnlock()
+	if !strict {
+		return
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	message := fmt.Sprintf("unconfigured call to FluentQueryStub.%s(%s)", method, strings.Join(formattedArgs, ", "))
+	if reporter == nil {
+		panic(message)
+	}
+	reporter.Helper()
+	reporter.Errorf("%s", message)
+}
+
+var _ alias1.FluentQuery = new(FluentQueryStub)
+
+type FluentQueryStubWhereArgs struct {
+	Arg1 string
+}
+type FluentQueryStubWhereResults struct {
+	Result1 alias1.FluentFilter
+}
+
+func (stub *FluentQueryStub) Where(arg1 string) alias1.FluentFilter {
+	stub.whereMutex.Lock()
+	stub.whereArgsForCall = append(stub.whereArgsForCall, FluentQueryStubWhereArgs{arg1})
+	callIndex := len(stub.whereArgsForCall) - 1
+	stubFunc := stub.WhereStub
+	returns := stub.whereReturns
+	configured := stub.whereReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.whereReturnsOnCall[callIndex]
+	stub.whereMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			return stub
+		}
+		return returns.Result1
+	}
+}
+func (stub *FluentQueryStub) WhereCallCount() int {
+	stub.whereMutex.RLock()
+	defer stub.whereMutex.RUnlock()
+	return len(stub.whereArgsForCall)
+}
+func (stub *FluentQueryStub) WhereCalls() []FluentQueryStubWhereArgs {
+	stub.whereMutex.RLock()
+	defer stub.whereMutex.RUnlock()
+	calls := make([]FluentQueryStubWhereArgs, len(stub.whereArgsForCall))
+	copy(calls, stub.whereArgsForCall)
+	return calls
+}
+func (stub *FluentQueryStub) WhereArgsForCall(index int) string {
+	stub.whereMutex.RLock()
+	defer stub.whereMutex.RUnlock()
+	return stub.whereArgsForCall[index].Arg1
+}
+func (stub *FluentQueryStub) WhereReturns(result1 alias1.FluentFilter) {
+	stub.whereMutex.Lock()
+	defer stub.whereMutex.Unlock()
+	stub.whereReturns = FluentQueryStubWhereResults{result1}
+	stub.whereReturnsConfigured = true
+}
+func (stub *FluentQueryStub) WhereReturnsOnCall(index int, result1 alias1.FluentFilter) {
+	stub.whereMutex.Lock()
+	defer stub.whereMutex.Unlock()
+	if stub.whereReturnsOnCall == nil {
+		stub.whereReturnsOnCall = make(map[int]FluentQueryStubWhereResults)
+	}
+	stub.whereReturnsOnCall[index] = FluentQueryStubWhereResults{result1}
+}
+
+type FluentQueryStubLimitArgs struct {
+	Arg1 int
+}
+type FluentQueryStubLimitResults struct {
+	Result1 alias1.FluentQuery
+}
+
+func (stub *FluentQueryStub) Limit(arg1 int) alias1.FluentQuery {
+	stub.limitMutex.Lock()
+	stub.limitArgsForCall = append(stub.limitArgsForCall, FluentQueryStubLimitArgs{arg1})
+	callIndex := len(stub.limitArgsForCall) - 1
+	stubFunc := stub.LimitStub
+	returns := stub.limitReturns
+	configured := stub.limitReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.limitReturnsOnCall[callIndex]
+	stub.limitMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			return stub
+		}
+		return returns.Result1
+	}
+}
+func (stub *FluentQueryStub) LimitCallCount() int {
+	stub.limitMutex.RLock()
+	defer stub.limitMutex.RUnlock()
+	return len(stub.limitArgsForCall)
+}
+func (stub *FluentQueryStub) LimitCalls() []FluentQueryStubLimitArgs {
+	stub.limitMutex.RLock()
+	defer stub.limitMutex.RUnlock()
+	calls := make([]FluentQueryStubLimitArgs, len(stub.limitArgsForCall))
+	copy(calls, stub.limitArgsForCall)
+	return calls
+}
+func (stub *FluentQueryStub) LimitArgsForCall(index int) int {
+	stub.limitMutex.RLock()
+	defer stub.limitMutex.RUnlock()
+	return stub.limitArgsForCall[index].Arg1
+}
+func (stub *FluentQueryStub) LimitReturns(result1 alias1.FluentQuery) {
+	stub.limitMutex.Lock()
+	defer stub.limitMutex.Unlock()
+	stub.limitReturns = FluentQueryStubLimitResults{result1}
+	stub.limitReturnsConfigured = true
+}
+func (stub *FluentQueryStub) LimitReturnsOnCall(index int, result1 alias1.FluentQuery) {
+	stub.limitMutex.Lock()
+	defer stub.limitMutex.Unlock()
+	if stub.limitReturnsOnCall == nil {
+		stub.limitReturnsOnCall = make(map[int]FluentQueryStubLimitResults)
+	}
+	stub.limitReturnsOnCall[index] = FluentQueryStubLimitResults{result1}
+}
+
+type FluentQueryStubRunArgs struct {
+}
+type FluentQueryStubRunResults struct {
+	Result1 []string
+	Result2 error
+}
+
+func (stub *FluentQueryStub) Run() ([]string, error) {
+	stub.runMutex.Lock()
+	stub.runArgsForCall = append(stub.runArgsForCall, FluentQueryStubRunArgs{})
+	callIndex := len(stub.runArgsForCall) - 1
+	stubFunc := stub.RunStub
+	returns := stub.runReturns
+	configured := stub.runReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.runReturnsOnCall[callIndex]
+	stub.runMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		if !configured {
+			stub.reportUnconfiguredCall("Run")
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *FluentQueryStub) RunCallCount() int {
+	stub.runMutex.RLock()
+	defer stub.runMutex.RUnlock()
+	return len(stub.runArgsForCall)
+}
+func (stub *FluentQueryStub) RunCalls() []FluentQueryStubRunArgs {
+	stub.runMutex.RLock()
+	defer stub.runMutex.RUnlock()
+	calls := make([]FluentQueryStubRunArgs, len(stub.runArgsForCall))
+	copy(calls, stub.runArgsForCall)
+	return calls
+}
+func (stub *FluentQueryStub) RunReturns(result1 []string, result2 error) {
+	stub.runMutex.Lock()
+	defer stub.runMutex.Unlock()
+	stub.runReturns = FluentQueryStubRunResults{result1, result2}
+	stub.runReturnsConfigured = true
+}
+func (stub *FluentQueryStub) RunReturnsOnCall(index int, result1 []string, result2 error) {
+	stub.runMutex.Lock()
+	defer stub.runMutex.Unlock()
+	if stub.runReturnsOnCall == nil {
+		stub.runReturnsOnCall = make(map[int]FluentQueryStubRunResults)
+	}
+	stub.runReturnsOnCall[index] = FluentQueryStubRunResults{result1, result2}
+}
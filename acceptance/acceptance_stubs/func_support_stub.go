@@ -3,6 +3,10 @@
 package acceptance_stubs
 
 import (
+	json "encoding/json"
+	fmt "fmt"
+	io "io"
+	sort "sort"
 	sync "sync"
 
 	alias1 "github.com/mokiat/gostub/acceptance"
@@ -10,29 +14,100 @@ import (
 )
 
 type FuncSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 func(alias2.Address) alias2.Address) (result1 func(alias2.Address) alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 func(alias2.Address) alias2.Address
+	StubGUID            int
+	MethodStub          func(arg1 func(alias2.Address) alias2.Address) (result1 func(alias2.Address) alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []FuncSupportStubMethodArgs
+	methodReturns       FuncSupportStubMethodResults
+	methodReturnsOnCall map[int]FuncSupportStubMethodResults
+	methodArg1Invokes   []func(func(arg1 alias2.Address) alias2.Address)
+}
+
+func (stub *FuncSupportStub) LoadScenario(r io.Reader) error {
+	var scenario map[string]struct {
+		Returns []json.RawMessage
+		OnCall  map[int][]json.RawMessage
+	}
+	err := json.NewDecoder(r).Decode(&scenario)
+	if err != nil {
+		return fmt.Errorf("cannot decode scenario for FuncSupportStub: %v", err)
+	}
+	methods := make([]string, 0, len(scenario))
+	for key := range scenario {
+		methods = append(methods, key)
 	}
-	methodReturns struct {
-		result1 func(alias2.Address) alias2.Address
+	sort.Strings(methods)
+	var configure []func()
+	for _, method := range methods {
+		methodScenario := scenario[method]
+		switch method {
+		case "Method":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeMethodScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.MethodReturns(results.Result1)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeMethodScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.MethodReturnsOnCall(index, results.Result1)
+				})
+			}
+		default:
+			return fmt.Errorf("cannot configure FuncSupportStub.%s from scenario: unknown method", method)
+		}
 	}
+	for _, apply := range configure {
+		apply()
+	}
+	return nil
 }
 
 var _ alias1.FuncSupport = new(FuncSupportStub)
 
+type FuncSupportStubMethodArgs struct {
+	Arg1 func(alias2.Address) alias2.Address
+}
+type FuncSupportStubMethodResults struct {
+	Result1 func(alias2.Address) alias2.Address
+}
+
 func (stub *FuncSupportStub) Method(arg1 func(alias2.Address) alias2.Address) func(alias2.Address) alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 func(alias2.Address) alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, FuncSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	arg1Invokes := stub.methodArg1Invokes
+	stub.methodMutex.Unlock()
+	if arg1 != nil {
+		for _, invoke := range arg1Invokes {
+			invoke(arg1)
+		}
+	}
+	stub.methodMutex.Lock()
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *FuncSupportStub) MethodCallCount() int {
@@ -40,15 +115,44 @@ func (stub *FuncSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *FuncSupportStub) MethodCalls() []FuncSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]FuncSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *FuncSupportStub) MethodArgsForCall(index int) func(alias2.Address) alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
+}
+func (stub *FuncSupportStub) MethodInvokesArg1(arg1 alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	stub.methodArg1Invokes = append(stub.methodArg1Invokes, func(callback func(arg1 alias2.Address) alias2.Address) {
+		callback(arg1)
+	})
+}
+func (stub *FuncSupportStub) MethodArg1ForCall(index int) func(alias2.Address) alias2.Address {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *FuncSupportStub) MethodReturns(result1 func(alias2.Address) alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 func(alias2.Address) alias2.Address
-	}{result1}
+	stub.methodReturns = FuncSupportStubMethodResults{result1}
+}
+func (stub *FuncSupportStub) MethodReturnsOnCall(index int, result1 func(alias2.Address) alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]FuncSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = FuncSupportStubMethodResults{result1}
+}
+func (stub *FuncSupportStub) decodeMethodScenario(values []json.RawMessage) (FuncSupportStubMethodResults, error) {
+	var results FuncSupportStubMethodResults
+	return results, fmt.Errorf("cannot configure FuncSupportStub.Method from scenario: result 1 is a function and cannot be decoded from JSON")
 }
@@ -0,0 +1,156 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	bytes "bytes"
+	json "encoding/json"
+	fmt "fmt"
+	ioutil "io/ioutil"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type GoldenServiceStubGoldenCall struct {
+	Method  string
+	Args    json.RawMessage
+	Results json.RawMessage
+}
+type GoldenServiceStubGoldenRecorder struct {
+	target alias1.GoldenService
+	mutex  sync.Mutex
+	calls  []GoldenServiceStubGoldenCall
+	err    error
+}
+
+var _ alias1.GoldenService = new(GoldenServiceStubGoldenRecorder)
+
+func NewGoldenServiceStubGoldenRecorder(target alias1.GoldenService) *GoldenServiceStubGoldenRecorder {
+	return &GoldenServiceStubGoldenRecorder{target: target}
+}
+func (recorder *GoldenServiceStubGoldenRecorder) record(method string, args interface{}, results interface{}) {
+	argsData, err := json.Marshal(args)
+	resultsData, resultsErr := json.Marshal(results)
+	if err == nil {
+		err = resultsErr
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if err != nil {
+		if recorder.err == nil {
+			recorder.err = fmt.Errorf("cannot record call to GoldenServiceStub.%s: %v", method, err)
+		}
+		return
+	}
+	recorder.calls = append(recorder.calls, GoldenServiceStubGoldenCall{Method: method, Args: argsData, Results: resultsData})
+}
+func (recorder *GoldenServiceStubGoldenRecorder) SaveGolden(path string) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.err != nil {
+		return recorder.err
+	}
+	data, err := json.MarshalIndent(recorder.calls, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+type GoldenServiceStubGoldenReplay struct {
+	mutex    sync.Mutex
+	calls    map[string][]GoldenServiceStubGoldenCall
+	reporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+}
+
+func (replay *GoldenServiceStubGoldenReplay) next(method string, args interface{}, results interface{}) {
+	replay.mutex.Lock()
+	defer replay.mutex.Unlock()
+	if len(replay.calls[method]) == 0 {
+		replay.fail("no more recorded calls to GoldenServiceStub.%s", method)
+		return
+	}
+	call := replay.calls[method][0]
+	replay.calls[method] = replay.calls[method][1:]
+	argsData, err := json.Marshal(args)
+	if err != nil {
+		replay.fail("cannot replay call to GoldenServiceStub.%s: %v", method, err)
+		return
+	}
+	recordedArgs := new(bytes.Buffer)
+	err = json.Compact(recordedArgs, call.Args)
+	if err != nil {
+		replay.fail("cannot replay call to GoldenServiceStub.%s: %v", method, err)
+		return
+	}
+	if !bytes.Equal(argsData, recordedArgs.Bytes()) {
+		replay.fail("unexpected arguments in call to GoldenServiceStub.%s: got %s, recorded %s", method, argsData, recordedArgs)
+		return
+	}
+	if results == nil {
+		return
+	}
+	err = json.Unmarshal(call.Results, results)
+	if err != nil {
+		replay.fail("cannot replay call to GoldenServiceStub.%s: %v", method, err)
+		return
+	}
+}
+func (replay *GoldenServiceStubGoldenReplay) fail(format string, args ...interface{}) {
+	if replay.reporter == nil {
+		panic(fmt.Sprintf(format, args...))
+	}
+	replay.reporter.Helper()
+	replay.reporter.Errorf(format, args...)
+}
+func NewGoldenServiceStubFromGolden(path string, reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) (*GoldenServiceStub, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var calls []GoldenServiceStubGoldenCall
+	err = json.Unmarshal(data, &calls)
+	if err != nil {
+		return nil, err
+	}
+	replay := &GoldenServiceStubGoldenReplay{calls: make(map[string][]GoldenServiceStubGoldenCall), reporter: reporter}
+	for _, call := range calls {
+		replay.calls[call.Method] = append(replay.calls[call.Method], call)
+	}
+	stub := new(GoldenServiceStub)
+	stub.LookupStub = func(arg1 int) (alias1.Customer, error) {
+		results := new(GoldenServiceStubLookupResults)
+		replay.next("Lookup", &GoldenServiceStubLookupArgs{arg1}, results)
+		return results.Result1, results.Result2
+	}
+	stub.SearchStub = func(arg1 string, arg2 ...string) []string {
+		results := new(GoldenServiceStubSearchResults)
+		replay.next("Search", &GoldenServiceStubSearchArgs{arg1, arg2}, results)
+		return results.Result1
+	}
+	stub.NotifyStub = func(arg1 string) {
+		replay.next("Notify", &GoldenServiceStubNotifyArgs{arg1}, nil)
+	}
+	return stub, nil
+}
+func (recorder *GoldenServiceStubGoldenRecorder) Lookup(arg1 int) (alias1.Customer, error) {
+	result1, result2 := recorder.target.Lookup(arg1)
+	recorder.record("Lookup", &GoldenServiceStubLookupArgs{arg1}, &GoldenServiceStubLookupResults{result1, result2})
+	return result1, result2
+}
+func (recorder *GoldenServiceStubGoldenRecorder) Search(arg1 string, arg2 ...string) []string {
+	result1 := recorder.target.Search(arg1, arg2...)
+	recorder.record("Search", &GoldenServiceStubSearchArgs{arg1, arg2}, &GoldenServiceStubSearchResults{result1})
+	return result1
+}
+func (recorder *GoldenServiceStubGoldenRecorder) Notify(arg1 string) {
+	recorder.target.Notify(arg1)
+	recorder.record("Notify", &GoldenServiceStubNotifyArgs{arg1}, nil)
+}
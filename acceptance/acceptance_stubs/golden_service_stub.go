@@ -0,0 +1,384 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	reflect "reflect"
+	sort "sort"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type GoldenServiceStub struct {
+	StubGUID            int
+	LookupStub          func(arg1 int) (result1 alias1.Customer, result2 error)
+	lookupMutex         sync.RWMutex
+	lookupArgsForCall   []GoldenServiceStubLookupArgs
+	lookupReturns       GoldenServiceStubLookupResults
+	lookupReturnsOnCall map[int]GoldenServiceStubLookupResults
+	lookupRules         []*GoldenServiceStubLookupRule
+	SearchStub          func(arg1 string, arg2 ...string) (result1 []string)
+	searchMutex         sync.RWMutex
+	searchArgsForCall   []GoldenServiceStubSearchArgs
+	searchReturns       GoldenServiceStubSearchResults
+	searchReturnsOnCall map[int]GoldenServiceStubSearchResults
+	searchRules         []*GoldenServiceStubSearchRule
+	NotifyStub          func(arg1 string)
+	notifyMutex         sync.RWMutex
+	notifyArgsForCall   []GoldenServiceStubNotifyArgs
+}
+
+func (stub *GoldenServiceStub) LoadScenario(r io.Reader) error {
+	var scenario map[string]struct {
+		Returns []json.RawMessage
+		OnCall  map[int][]json.RawMessage
+	}
+	err := json.NewDecoder(r).Decode(&scenario)
+	if err != nil {
+		return fmt.Errorf("cannot decode scenario for GoldenServiceStub: %v", err)
+	}
+	methods := make([]string, 0, len(scenario))
+	for key := range scenario {
+		methods = append(methods, key)
+	}
+	sort.Strings(methods)
+	var configure []func()
+	for _, method := range methods {
+		methodScenario := scenario[method]
+		switch method {
+		case "Lookup":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeLookupScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.LookupReturns(results.Result1, results.Result2)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeLookupScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.LookupReturnsOnCall(index, results.Result1, results.Result2)
+				})
+			}
+		case "Search":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeSearchScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.SearchReturns(results.Result1)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeSearchScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.SearchReturnsOnCall(index, results.Result1)
+				})
+			}
+		case "Notify":
+			return fmt.Errorf("cannot configure GoldenServiceStub.%s from scenario: method has no results", "Notify")
+		default:
+			return fmt.Errorf("cannot configure GoldenServiceStub.%s from scenario: unknown method", method)
+		}
+	}
+	for _, apply := range configure {
+		apply()
+	}
+	return nil
+}
+
+var _ alias1.GoldenService = new(GoldenServiceStub)
+
+type GoldenServiceStubLookupArgs struct {
+	Arg1 int
+}
+type GoldenServiceStubLookupResults struct {
+	Result1 alias1.Customer
+	Result2 error
+}
+
+func (value *GoldenServiceStubLookupResults) MarshalJSON() ([]byte, error) {
+	var aux struct {
+		Result1 alias1.Customer
+		Result2 *string
+	}
+	aux.Result1 = value.Result1
+	if value.Result2 != nil {
+		message := value.Result2.Error()
+		aux.Result2 = &message
+	}
+	return json.Marshal(aux)
+}
+func (value *GoldenServiceStubLookupResults) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Result1 alias1.Customer
+		Result2 *string
+	}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	value.Result1 = aux.Result1
+	if aux.Result2 != nil {
+		value.Result2 = errors.New(*aux.Result2)
+	}
+	return nil
+}
+func (stub *GoldenServiceStub) Lookup(arg1 int) (alias1.Customer, error) {
+	stub.lookupMutex.Lock()
+	stub.lookupArgsForCall = append(stub.lookupArgsForCall, GoldenServiceStubLookupArgs{arg1})
+	callIndex := len(stub.lookupArgsForCall) - 1
+	stubFunc := stub.LookupStub
+	returns := stub.lookupReturns
+	returnsOnCall, hasReturnsOnCall := stub.lookupReturnsOnCall[callIndex]
+	rules := make([]GoldenServiceStubLookupRule, len(stub.lookupRules))
+	for i, rule := range stub.lookupRules {
+		rules[i] = *rule
+	}
+	stub.lookupMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		for _, rule := range rules {
+			if rule.matcher(arg1) {
+				return rule.returns.Result1, rule.returns.Result2
+			}
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *GoldenServiceStub) LookupCallCount() int {
+	stub.lookupMutex.RLock()
+	defer stub.lookupMutex.RUnlock()
+	return len(stub.lookupArgsForCall)
+}
+func (stub *GoldenServiceStub) LookupCalls() []GoldenServiceStubLookupArgs {
+	stub.lookupMutex.RLock()
+	defer stub.lookupMutex.RUnlock()
+	calls := make([]GoldenServiceStubLookupArgs, len(stub.lookupArgsForCall))
+	copy(calls, stub.lookupArgsForCall)
+	return calls
+}
+func (stub *GoldenServiceStub) LookupArgsForCall(index int) int {
+	stub.lookupMutex.RLock()
+	defer stub.lookupMutex.RUnlock()
+	return stub.lookupArgsForCall[index].Arg1
+}
+func (stub *GoldenServiceStub) LookupReturns(result1 alias1.Customer, result2 error) {
+	stub.lookupMutex.Lock()
+	defer stub.lookupMutex.Unlock()
+	stub.lookupReturns = GoldenServiceStubLookupResults{result1, result2}
+}
+func (stub *GoldenServiceStub) LookupReturnsOnCall(index int, result1 alias1.Customer, result2 error) {
+	stub.lookupMutex.Lock()
+	defer stub.lookupMutex.Unlock()
+	if stub.lookupReturnsOnCall == nil {
+		stub.lookupReturnsOnCall = make(map[int]GoldenServiceStubLookupResults)
+	}
+	stub.lookupReturnsOnCall[index] = GoldenServiceStubLookupResults{result1, result2}
+}
+func (stub *GoldenServiceStub) decodeLookupScenario(values []json.RawMessage) (GoldenServiceStubLookupResults, error) {
+	var results GoldenServiceStubLookupResults
+	if len(values) != 2 {
+		return results, fmt.Errorf("cannot configure GoldenServiceStub.Lookup from scenario: expected 2 results, got %d", len(values))
+	}
+	err := json.Unmarshal(values[0], &results.Result1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure GoldenServiceStub.Lookup from scenario: cannot decode result 1: %v", err)
+	}
+	var message2 *string
+	err = json.Unmarshal(values[1], &message2)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure GoldenServiceStub.Lookup from scenario: cannot decode result 2: %v", err)
+	}
+	if message2 != nil {
+		results.Result2 = errors.New(*message2)
+	}
+	return results, nil
+}
+func (stub *GoldenServiceStub) LookupWhen(matcher func(arg1 int) bool) *GoldenServiceStubLookupRule {
+	stub.lookupMutex.Lock()
+	defer stub.lookupMutex.Unlock()
+	rule := &GoldenServiceStubLookupRule{mutex: &stub.lookupMutex, matcher: matcher}
+	stub.lookupRules = append(stub.lookupRules, rule)
+	return rule
+}
+func (stub *GoldenServiceStub) LookupCalledWith(arg1 int) *GoldenServiceStubLookupRule {
+	expected := []interface{}{arg1}
+	return stub.LookupWhen(func(arg1 int) bool {
+		return reflect.DeepEqual([]interface{}{arg1}, expected)
+	})
+}
+
+type GoldenServiceStubLookupRule struct {
+	mutex   *sync.RWMutex
+	matcher func(arg1 int) bool
+	returns GoldenServiceStubLookupResults
+}
+
+func (rule *GoldenServiceStubLookupRule) Returns(result1 alias1.Customer, result2 error) {
+	rule.mutex.Lock()
+	defer rule.mutex.Unlock()
+	rule.returns = GoldenServiceStubLookupResults{result1, result2}
+}
+
+type GoldenServiceStubSearchArgs struct {
+	Arg1 string
+	Arg2 []string
+}
+type GoldenServiceStubSearchResults struct {
+	Result1 []string
+}
+
+func (stub *GoldenServiceStub) Search(arg1 string, arg2 ...string) []string {
+	stub.searchMutex.Lock()
+	stub.searchArgsForCall = append(stub.searchArgsForCall, GoldenServiceStubSearchArgs{arg1, arg2})
+	callIndex := len(stub.searchArgsForCall) - 1
+	stubFunc := stub.SearchStub
+	returns := stub.searchReturns
+	returnsOnCall, hasReturnsOnCall := stub.searchReturnsOnCall[callIndex]
+	rules := make([]GoldenServiceStubSearchRule, len(stub.searchRules))
+	for i, rule := range stub.searchRules {
+		rules[i] = *rule
+	}
+	stub.searchMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2...)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		for _, rule := range rules {
+			if rule.matcher(arg1, arg2...) {
+				return rule.returns.Result1
+			}
+		}
+		return returns.Result1
+	}
+}
+func (stub *GoldenServiceStub) SearchCallCount() int {
+	stub.searchMutex.RLock()
+	defer stub.searchMutex.RUnlock()
+	return len(stub.searchArgsForCall)
+}
+func (stub *GoldenServiceStub) SearchCalls() []GoldenServiceStubSearchArgs {
+	stub.searchMutex.RLock()
+	defer stub.searchMutex.RUnlock()
+	calls := make([]GoldenServiceStubSearchArgs, len(stub.searchArgsForCall))
+	copy(calls, stub.searchArgsForCall)
+	return calls
+}
+func (stub *GoldenServiceStub) SearchArgsForCall(index int) (string, []string) {
+	stub.searchMutex.RLock()
+	defer stub.searchMutex.RUnlock()
+	return stub.searchArgsForCall[index].Arg1, stub.searchArgsForCall[index].Arg2
+}
+func (stub *GoldenServiceStub) SearchReturns(result1 []string) {
+	stub.searchMutex.Lock()
+	defer stub.searchMutex.Unlock()
+	stub.searchReturns = GoldenServiceStubSearchResults{result1}
+}
+func (stub *GoldenServiceStub) SearchReturnsOnCall(index int, result1 []string) {
+	stub.searchMutex.Lock()
+	defer stub.searchMutex.Unlock()
+	if stub.searchReturnsOnCall == nil {
+		stub.searchReturnsOnCall = make(map[int]GoldenServiceStubSearchResults)
+	}
+	stub.searchReturnsOnCall[index] = GoldenServiceStubSearchResults{result1}
+}
+func (stub *GoldenServiceStub) decodeSearchScenario(values []json.RawMessage) (GoldenServiceStubSearchResults, error) {
+	var results GoldenServiceStubSearchResults
+	if len(values) != 1 {
+		return results, fmt.Errorf("cannot configure GoldenServiceStub.Search from scenario: expected 1 results, got %d", len(values))
+	}
+	err := json.Unmarshal(values[0], &results.Result1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure GoldenServiceStub.Search from scenario: cannot decode result 1: %v", err)
+	}
+	return results, nil
+}
+func (stub *GoldenServiceStub) SearchWhen(matcher func(arg1 string, arg2 ...string) bool) *GoldenServiceStubSearchRule {
+	stub.searchMutex.Lock()
+	defer stub.searchMutex.Unlock()
+	rule := &GoldenServiceStubSearchRule{mutex: &stub.searchMutex, matcher: matcher}
+	stub.searchRules = append(stub.searchRules, rule)
+	return rule
+}
+func (stub *GoldenServiceStub) SearchCalledWith(arg1 string, arg2 ...string) *GoldenServiceStubSearchRule {
+	expected := []interface{}{arg1, arg2}
+	return stub.SearchWhen(func(arg1 string, arg2 ...string) bool {
+		return reflect.DeepEqual([]interface{}{arg1, arg2}, expected)
+	})
+}
+
+type GoldenServiceStubSearchRule struct {
+	mutex   *sync.RWMutex
+	matcher func(arg1 string, arg2 ...string) bool
+	returns GoldenServiceStubSearchResults
+}
+
+func (rule *GoldenServiceStubSearchRule) Returns(result1 []string) {
+	rule.mutex.Lock()
+	defer rule.mutex.Unlock()
+	rule.returns = GoldenServiceStubSearchResults{result1}
+}
+
+type GoldenServiceStubNotifyArgs struct {
+	Arg1 string
+}
+
+func (stub *GoldenServiceStub) Notify(arg1 string) {
+	stub.notifyMutex.Lock()
+	stub.notifyArgsForCall = append(stub.notifyArgsForCall, GoldenServiceStubNotifyArgs{arg1})
+	stubFunc := stub.NotifyStub
+	stub.notifyMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1)
+	}
+}
+func (stub *GoldenServiceStub) NotifyCallCount() int {
+	stub.notifyMutex.RLock()
+	defer stub.notifyMutex.RUnlock()
+	return len(stub.notifyArgsForCall)
+}
+func (stub *GoldenServiceStub) NotifyCalls() []GoldenServiceStubNotifyArgs {
+	stub.notifyMutex.RLock()
+	defer stub.notifyMutex.RUnlock()
+	calls := make([]GoldenServiceStubNotifyArgs, len(stub.notifyArgsForCall))
+	copy(calls, stub.notifyArgsForCall)
+	return calls
+}
+func (stub *GoldenServiceStub) NotifyArgsForCall(index int) string {
+	stub.notifyMutex.RLock()
+	defer stub.notifyMutex.RUnlock()
+	return stub.notifyArgsForCall[index].Arg1
+}
@@ -18,23 +18,27 @@ type InterfaceSupportStub struct {
 		alias2.Runner
 		ProcessAddress(alias2.Address) alias2.Address
 	})
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 interface {
-			alias2.Runner
-			ResolveAddress(alias2.Address) alias2.Address
-		}
-	}
-	methodReturns struct {
-		result1 interface {
-			alias2.Runner
-			ProcessAddress(alias2.Address) alias2.Address
-		}
-	}
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []InterfaceSupportStubMethodArgs
+	methodReturns       InterfaceSupportStubMethodResults
+	methodReturnsOnCall map[int]InterfaceSupportStubMethodResults
 }
 
 var _ alias1.InterfaceSupport = new(InterfaceSupportStub)
 
+type InterfaceSupportStubMethodArgs struct {
+	Arg1 interface {
+		alias2.Runner
+		ResolveAddress(alias2.Address) alias2.Address
+	}
+}
+type InterfaceSupportStubMethodResults struct {
+	Result1 interface {
+		alias2.Runner
+		ProcessAddress(alias2.Address) alias2.Address
+	}
+}
+
 func (stub *InterfaceSupportStub) Method(arg1 interface {
 	alias2.Runner
 	ResolveAddress(alias2.Address) alias2.Address
@@ -43,17 +47,19 @@ func (stub *InterfaceSupportStub) Method(arg1 interface {
 	ProcessAddress(alias2.Address) alias2.Address
 } {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 interface {
-			alias2.Runner
-			ResolveAddress(alias2.Address) alias2.Address
-		}
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, InterfaceSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *InterfaceSupportStub) MethodCallCount() int {
@@ -61,13 +67,20 @@ func (stub *InterfaceSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *InterfaceSupportStub) MethodCalls() []InterfaceSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]InterfaceSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *InterfaceSupportStub) MethodArgsForCall(index int) interface {
 	alias2.Runner
 	ResolveAddress(alias2.Address) alias2.Address
 } {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *InterfaceSupportStub) MethodReturns(result1 interface {
 	alias2.Runner
@@ -75,10 +88,16 @@ func (stub *InterfaceSupportStub) MethodReturns(result1 interface {
 }) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 interface {
-			alias2.Runner
-			ProcessAddress(alias2.Address) alias2.Address
-		}
-	}{result1}
+	stub.methodReturns = InterfaceSupportStubMethodResults{result1}
+}
+func (stub *InterfaceSupportStub) MethodReturnsOnCall(index int, result1 interface {
+	alias2.Runner
+	ProcessAddress(alias2.Address) alias2.Address
+}) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]InterfaceSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = InterfaceSupportStubMethodResults{result1}
 }
@@ -9,39 +9,44 @@ import (
 )
 
 type LocalEmbeddedInterfaceSupportStub struct {
-	StubGUID            int
-	ScheduleStub        func(arg1 string, arg2 alias1.Customer) (result1 int)
-	scheduleMutex       sync.RWMutex
-	scheduleArgsForCall []struct {
-		arg1 string
-		arg2 alias1.Customer
-	}
-	scheduleReturns struct {
-		result1 int
-	}
-	MethodStub        func(arg1 int) (result1 int)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 int
-	}
-	methodReturns struct {
-		result1 int
-	}
+	StubGUID              int
+	ScheduleStub          func(arg1 string, arg2 alias1.Customer) (result1 int)
+	scheduleMutex         sync.RWMutex
+	scheduleArgsForCall   []LocalEmbeddedInterfaceSupportStubScheduleArgs
+	scheduleReturns       LocalEmbeddedInterfaceSupportStubScheduleResults
+	scheduleReturnsOnCall map[int]LocalEmbeddedInterfaceSupportStubScheduleResults
+	MethodStub            func(arg1 int) (result1 int)
+	methodMutex           sync.RWMutex
+	methodArgsForCall     []LocalEmbeddedInterfaceSupportStubMethodArgs
+	methodReturns         LocalEmbeddedInterfaceSupportStubMethodResults
+	methodReturnsOnCall   map[int]LocalEmbeddedInterfaceSupportStubMethodResults
 }
 
 var _ alias1.LocalEmbeddedInterfaceSupport = new(LocalEmbeddedInterfaceSupportStub)
 
+type LocalEmbeddedInterfaceSupportStubScheduleArgs struct {
+	Arg1 string
+	Arg2 alias1.Customer
+}
+type LocalEmbeddedInterfaceSupportStubScheduleResults struct {
+	Result1 int
+}
+
 func (stub *LocalEmbeddedInterfaceSupportStub) Schedule(arg1 string, arg2 alias1.Customer) int {
 	stub.scheduleMutex.Lock()
-	defer stub.scheduleMutex.Unlock()
-	stub.scheduleArgsForCall = append(stub.scheduleArgsForCall, struct {
-		arg1 string
-		arg2 alias1.Customer
-	}{arg1, arg2})
-	if stub.ScheduleStub != nil {
-		return stub.ScheduleStub(arg1, arg2)
+	stub.scheduleArgsForCall = append(stub.scheduleArgsForCall, LocalEmbeddedInterfaceSupportStubScheduleArgs{arg1, arg2})
+	callIndex := len(stub.scheduleArgsForCall) - 1
+	stubFunc := stub.ScheduleStub
+	returns := stub.scheduleReturns
+	returnsOnCall, hasReturnsOnCall := stub.scheduleReturnsOnCall[callIndex]
+	stub.scheduleMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2)
 	} else {
-		return stub.scheduleReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *LocalEmbeddedInterfaceSupportStub) ScheduleCallCount() int {
@@ -49,28 +54,54 @@ func (stub *LocalEmbeddedInterfaceSupportStub) ScheduleCallCount() int {
 	defer stub.scheduleMutex.RUnlock()
 	return len(stub.scheduleArgsForCall)
 }
+func (stub *LocalEmbeddedInterfaceSupportStub) ScheduleCalls() []LocalEmbeddedInterfaceSupportStubScheduleArgs {
+	stub.scheduleMutex.RLock()
+	defer stub.scheduleMutex.RUnlock()
+	calls := make([]LocalEmbeddedInterfaceSupportStubScheduleArgs, len(stub.scheduleArgsForCall))
+	copy(calls, stub.scheduleArgsForCall)
+	return calls
+}
 func (stub *LocalEmbeddedInterfaceSupportStub) ScheduleArgsForCall(index int) (string, alias1.Customer) {
 	stub.scheduleMutex.RLock()
 	defer stub.scheduleMutex.RUnlock()
-	return stub.scheduleArgsForCall[index].arg1, stub.scheduleArgsForCall[index].arg2
+	return stub.scheduleArgsForCall[index].Arg1, stub.scheduleArgsForCall[index].Arg2
 }
 func (stub *LocalEmbeddedInterfaceSupportStub) ScheduleReturns(result1 int) {
 	stub.scheduleMutex.Lock()
 	defer stub.scheduleMutex.Unlock()
-	stub.scheduleReturns = struct {
-		result1 int
-	}{result1}
+	stub.scheduleReturns = LocalEmbeddedInterfaceSupportStubScheduleResults{result1}
 }
+func (stub *LocalEmbeddedInterfaceSupportStub) ScheduleReturnsOnCall(index int, result1 int) {
+	stub.scheduleMutex.Lock()
+	defer stub.scheduleMutex.Unlock()
+	if stub.scheduleReturnsOnCall == nil {
+		stub.scheduleReturnsOnCall = make(map[int]LocalEmbeddedInterfaceSupportStubScheduleResults)
+	}
+	stub.scheduleReturnsOnCall[index] = LocalEmbeddedInterfaceSupportStubScheduleResults{result1}
+}
+
+type LocalEmbeddedInterfaceSupportStubMethodArgs struct {
+	Arg1 int
+}
+type LocalEmbeddedInterfaceSupportStubMethodResults struct {
+	Result1 int
+}
+
 func (stub *LocalEmbeddedInterfaceSupportStub) Method(arg1 int) int {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 int
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, LocalEmbeddedInterfaceSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *LocalEmbeddedInterfaceSupportStub) MethodCallCount() int {
@@ -78,15 +109,28 @@ func (stub *LocalEmbeddedInterfaceSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *LocalEmbeddedInterfaceSupportStub) MethodCalls() []LocalEmbeddedInterfaceSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]LocalEmbeddedInterfaceSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *LocalEmbeddedInterfaceSupportStub) MethodArgsForCall(index int) int {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *LocalEmbeddedInterfaceSupportStub) MethodReturns(result1 int) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 int
-	}{result1}
+	stub.methodReturns = LocalEmbeddedInterfaceSupportStubMethodResults{result1}
+}
+func (stub *LocalEmbeddedInterfaceSupportStub) MethodReturnsOnCall(index int, result1 int) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]LocalEmbeddedInterfaceSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = LocalEmbeddedInterfaceSupportStubMethodResults{result1}
 }
@@ -9,29 +9,38 @@ import (
 )
 
 type LocalRefSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 alias1.Customer) (result1 alias1.Customer)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 alias1.Customer
-	}
-	methodReturns struct {
-		result1 alias1.Customer
-	}
+	StubGUID            int
+	MethodStub          func(arg1 alias1.Customer) (result1 alias1.Customer)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []LocalRefSupportStubMethodArgs
+	methodReturns       LocalRefSupportStubMethodResults
+	methodReturnsOnCall map[int]LocalRefSupportStubMethodResults
 }
 
 var _ alias1.LocalRefSupport = new(LocalRefSupportStub)
 
+type LocalRefSupportStubMethodArgs struct {
+	Arg1 alias1.Customer
+}
+type LocalRefSupportStubMethodResults struct {
+	Result1 alias1.Customer
+}
+
 func (stub *LocalRefSupportStub) Method(arg1 alias1.Customer) alias1.Customer {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 alias1.Customer
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, LocalRefSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *LocalRefSupportStub) MethodCallCount() int {
@@ -39,15 +48,28 @@ func (stub *LocalRefSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *LocalRefSupportStub) MethodCalls() []LocalRefSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]LocalRefSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *LocalRefSupportStub) MethodArgsForCall(index int) alias1.Customer {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *LocalRefSupportStub) MethodReturns(result1 alias1.Customer) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 alias1.Customer
-	}{result1}
+	stub.methodReturns = LocalRefSupportStubMethodResults{result1}
+}
+func (stub *LocalRefSupportStub) MethodReturnsOnCall(index int, result1 alias1.Customer) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]LocalRefSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = LocalRefSupportStubMethodResults{result1}
 }
@@ -10,29 +10,38 @@ import (
 )
 
 type MapSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 map[alias2.Address]alias2.Address) (result1 map[alias2.Address]alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 map[alias2.Address]alias2.Address
-	}
-	methodReturns struct {
-		result1 map[alias2.Address]alias2.Address
-	}
+	StubGUID            int
+	MethodStub          func(arg1 map[alias2.Address]alias2.Address) (result1 map[alias2.Address]alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []MapSupportStubMethodArgs
+	methodReturns       MapSupportStubMethodResults
+	methodReturnsOnCall map[int]MapSupportStubMethodResults
 }
 
 var _ alias1.MapSupport = new(MapSupportStub)
 
+type MapSupportStubMethodArgs struct {
+	Arg1 map[alias2.Address]alias2.Address
+}
+type MapSupportStubMethodResults struct {
+	Result1 map[alias2.Address]alias2.Address
+}
+
 func (stub *MapSupportStub) Method(arg1 map[alias2.Address]alias2.Address) map[alias2.Address]alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 map[alias2.Address]alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, MapSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *MapSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *MapSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *MapSupportStub) MethodCalls() []MapSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]MapSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *MapSupportStub) MethodArgsForCall(index int) map[alias2.Address]alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *MapSupportStub) MethodReturns(result1 map[alias2.Address]alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 map[alias2.Address]alias2.Address
-	}{result1}
+	stub.methodReturns = MapSupportStubMethodResults{result1}
+}
+func (stub *MapSupportStub) MethodReturnsOnCall(index int, result1 map[alias2.Address]alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]MapSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = MapSupportStubMethodResults{result1}
 }
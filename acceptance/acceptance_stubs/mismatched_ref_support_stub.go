@@ -10,29 +10,38 @@ import (
 )
 
 type MismatchedRefSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 alias2.Job) (result1 alias2.Job)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 alias2.Job
-	}
-	methodReturns struct {
-		result1 alias2.Job
-	}
+	StubGUID            int
+	MethodStub          func(arg1 alias2.Job) (result1 alias2.Job)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []MismatchedRefSupportStubMethodArgs
+	methodReturns       MismatchedRefSupportStubMethodResults
+	methodReturnsOnCall map[int]MismatchedRefSupportStubMethodResults
 }
 
 var _ alias1.MismatchedRefSupport = new(MismatchedRefSupportStub)
 
+type MismatchedRefSupportStubMethodArgs struct {
+	Arg1 alias2.Job
+}
+type MismatchedRefSupportStubMethodResults struct {
+	Result1 alias2.Job
+}
+
 func (stub *MismatchedRefSupportStub) Method(arg1 alias2.Job) alias2.Job {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 alias2.Job
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, MismatchedRefSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *MismatchedRefSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *MismatchedRefSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *MismatchedRefSupportStub) MethodCalls() []MismatchedRefSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]MismatchedRefSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *MismatchedRefSupportStub) MethodArgsForCall(index int) alias2.Job {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *MismatchedRefSupportStub) MethodReturns(result1 alias2.Job) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 alias2.Job
-	}{result1}
+	stub.methodReturns = MismatchedRefSupportStubMethodResults{result1}
+}
+func (stub *MismatchedRefSupportStub) MethodReturnsOnCall(index int, result1 alias2.Job) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]MismatchedRefSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = MismatchedRefSupportStubMethodResults{result1}
 }
@@ -9,22 +9,44 @@ import (
 )
 
 type NoParamsNoResultsStub struct {
-	StubGUID       int
+	StubGUID int
+	mutex    sync.RWMutex
+	recorder interface {
+		Record(stub string, method string, args []interface{})
+	}
 	RunStub        func()
 	runMutex       sync.RWMutex
-	runArgsForCall []struct {
-	}
+	runArgsForCall []NoParamsNoResultsStubRunArgs
+}
+
+func (stub *NoParamsNoResultsStub) AttachRecorder(recorder interface {
+	Record(stub string, method string, args []interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.recorder = recorder
 }
 
 var _ alias1.NoParamsNoResults = new(NoParamsNoResultsStub)
 
+type NoParamsNoResultsStubRunArgs struct {
+}
+
 func (stub *NoParamsNoResultsStub) Run() {
 	stub.runMutex.Lock()
-	defer stub.runMutex.Unlock()
-	stub.runArgsForCall = append(stub.runArgsForCall, struct {
-	}{})
-	if stub.RunStub != nil {
-		stub.RunStub()
+	stub.runArgsForCall = append(stub.runArgsForCall, NoParamsNoResultsStubRunArgs{})
+	stub.mutex.Lock()
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	stub.runMutex.Unlock()
+	if recorder != nil {
+		recorder.Record("NoParamsNoResultsStub", "Run", []interface{}{})
+	}
+	stub.runMutex.Lock()
+	stubFunc := stub.RunStub
+	stub.runMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc()
 	}
 }
 func (stub *NoParamsNoResultsStub) RunCallCount() int {
@@ -32,3 +54,10 @@ func (stub *NoParamsNoResultsStub) RunCallCount() int {
 	defer stub.runMutex.RUnlock()
 	return len(stub.runArgsForCall)
 }
+func (stub *NoParamsNoResultsStub) RunCalls() []NoParamsNoResultsStubRunArgs {
+	stub.runMutex.RLock()
+	defer stub.runMutex.RUnlock()
+	calls := make([]NoParamsNoResultsStubRunArgs, len(stub.runArgsForCall))
+	copy(calls, stub.runArgsForCall)
+	return calls
+}
@@ -0,0 +1,380 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	reflect "reflect"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type OutParamsStub struct {
+	StubGUID              int
+	LoadStub              func(arg1 string, arg2 *alias1.Customer) (result1 error)
+	loadMutex             sync.RWMutex
+	loadArgsForCall       []OutParamsStubLoadArgs
+	loadReturns           OutParamsStubLoadResults
+	loadReturnsOnCall     map[int]OutParamsStubLoadResults
+	loadIntoSets          func(*alias1.Customer)
+	loadIntoSetsOnCall    map[int]func(*alias1.Customer)
+	DecodeStub            func(arg1 interface{}) (result1 error)
+	decodeMutex           sync.RWMutex
+	decodeArgsForCall     []OutParamsStubDecodeArgs
+	decodeReturns         OutParamsStubDecodeResults
+	decodeReturnsOnCall   map[int]OutParamsStubDecodeResults
+	decodeVSets           func(interface{})
+	decodeVSetsOnCall     map[int]func(interface{})
+	ScanStub              func(arg1 ...interface{}) (result1 error)
+	scanMutex             sync.RWMutex
+	scanArgsForCall       []OutParamsStubScanArgs
+	scanReturns           OutParamsStubScanResults
+	scanReturnsOnCall     map[int]OutParamsStubScanResults
+	scanDestSets          func([]interface{})
+	scanDestSetsOnCall    map[int]func([]interface{})
+	FillStub              func(arg1 ...*int)
+	fillMutex             sync.RWMutex
+	fillArgsForCall       []OutParamsStubFillArgs
+	fillTargetsSets       func([]*int)
+	fillTargetsSetsOnCall map[int]func([]*int)
+}
+
+var _ alias1.OutParams = new(OutParamsStub)
+
+type OutParamsStubLoadArgs struct {
+	Arg1 string
+	Arg2 *alias1.Customer
+}
+type OutParamsStubLoadResults struct {
+	Result1 error
+}
+
+func (stub *OutParamsStub) Load(arg1 string, arg2 *alias1.Customer) error {
+	stub.loadMutex.Lock()
+	stub.loadArgsForCall = append(stub.loadArgsForCall, OutParamsStubLoadArgs{arg1, arg2})
+	callIndex := len(stub.loadArgsForCall) - 1
+	stubFunc := stub.LoadStub
+	returns := stub.loadReturns
+	returnsOnCall, hasReturnsOnCall := stub.loadReturnsOnCall[callIndex]
+	arg2Sets := stub.loadIntoSets
+	if sets, ok := stub.loadIntoSetsOnCall[callIndex]; ok {
+		arg2Sets = sets
+	}
+	stub.loadMutex.Unlock()
+	if arg2Sets != nil {
+		arg2Sets(arg2)
+	}
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *OutParamsStub) LoadCallCount() int {
+	stub.loadMutex.RLock()
+	defer stub.loadMutex.RUnlock()
+	return len(stub.loadArgsForCall)
+}
+func (stub *OutParamsStub) LoadCalls() []OutParamsStubLoadArgs {
+	stub.loadMutex.RLock()
+	defer stub.loadMutex.RUnlock()
+	calls := make([]OutParamsStubLoadArgs, len(stub.loadArgsForCall))
+	copy(calls, stub.loadArgsForCall)
+	return calls
+}
+func (stub *OutParamsStub) LoadArgsForCall(index int) (string, *alias1.Customer) {
+	stub.loadMutex.RLock()
+	defer stub.loadMutex.RUnlock()
+	return stub.loadArgsForCall[index].Arg1, stub.loadArgsForCall[index].Arg2
+}
+func (stub *OutParamsStub) LoadSetsInto(value alias1.Customer) {
+	stub.loadMutex.Lock()
+	defer stub.loadMutex.Unlock()
+	stub.loadIntoSets = func(arg2 *alias1.Customer) {
+		if arg2 == nil {
+			panic("cannot set argument 2 of OutParamsStub.Load: not a non-nil pointer")
+		}
+		*arg2 = value
+	}
+}
+func (stub *OutParamsStub) LoadSetsIntoOnCall(index int, value alias1.Customer) {
+	stub.loadMutex.Lock()
+	defer stub.loadMutex.Unlock()
+	if stub.loadIntoSetsOnCall == nil {
+		stub.loadIntoSetsOnCall = make(map[int]func(arg2 *alias1.Customer))
+	}
+	stub.loadIntoSetsOnCall[index] = func(arg2 *alias1.Customer) {
+		if arg2 == nil {
+			panic("cannot set argument 2 of OutParamsStub.Load: not a non-nil pointer")
+		}
+		*arg2 = value
+	}
+}
+func (stub *OutParamsStub) LoadReturns(result1 error) {
+	stub.loadMutex.Lock()
+	defer stub.loadMutex.Unlock()
+	stub.loadReturns = OutParamsStubLoadResults{result1}
+}
+func (stub *OutParamsStub) LoadReturnsOnCall(index int, result1 error) {
+	stub.loadMutex.Lock()
+	defer stub.loadMutex.Unlock()
+	if stub.loadReturnsOnCall == nil {
+		stub.loadReturnsOnCall = make(map[int]OutParamsStubLoadResults)
+	}
+	stub.loadReturnsOnCall[index] = OutParamsStubLoadResults{result1}
+}
+
+type OutParamsStubDecodeArgs struct {
+	Arg1 interface{}
+}
+type OutParamsStubDecodeResults struct {
+	Result1 error
+}
+
+func (stub *OutParamsStub) setArg(method string, position int, arg interface{}, value interface{}) {
+	target := reflect.ValueOf(arg)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		panic(fmt.Sprintf("cannot set argument %d of OutParamsStub.%s: not a non-nil pointer", position, method))
+	}
+	if value == nil {
+		target.Elem().Set(reflect.Zero(target.Elem().Type()))
+	} else {
+		target.Elem().Set(reflect.ValueOf(value))
+	}
+}
+func (stub *OutParamsStub) Decode(arg1 interface{}) error {
+	stub.decodeMutex.Lock()
+	stub.decodeArgsForCall = append(stub.decodeArgsForCall, OutParamsStubDecodeArgs{arg1})
+	callIndex := len(stub.decodeArgsForCall) - 1
+	stubFunc := stub.DecodeStub
+	returns := stub.decodeReturns
+	returnsOnCall, hasReturnsOnCall := stub.decodeReturnsOnCall[callIndex]
+	arg1Sets := stub.decodeVSets
+	if sets, ok := stub.decodeVSetsOnCall[callIndex]; ok {
+		arg1Sets = sets
+	}
+	stub.decodeMutex.Unlock()
+	if arg1Sets != nil {
+		arg1Sets(arg1)
+	}
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *OutParamsStub) DecodeCallCount() int {
+	stub.decodeMutex.RLock()
+	defer stub.decodeMutex.RUnlock()
+	return len(stub.decodeArgsForCall)
+}
+func (stub *OutParamsStub) DecodeCalls() []OutParamsStubDecodeArgs {
+	stub.decodeMutex.RLock()
+	defer stub.decodeMutex.RUnlock()
+	calls := make([]OutParamsStubDecodeArgs, len(stub.decodeArgsForCall))
+	copy(calls, stub.decodeArgsForCall)
+	return calls
+}
+func (stub *OutParamsStub) DecodeArgsForCall(index int) interface{} {
+	stub.decodeMutex.RLock()
+	defer stub.decodeMutex.RUnlock()
+	return stub.decodeArgsForCall[index].Arg1
+}
+func (stub *OutParamsStub) DecodeSetsV(value interface{}) {
+	stub.decodeMutex.Lock()
+	defer stub.decodeMutex.Unlock()
+	stub.decodeVSets = func(arg1 interface{}) {
+		stub.setArg("Decode", 1, arg1, value)
+	}
+}
+func (stub *OutParamsStub) DecodeSetsVOnCall(index int, value interface{}) {
+	stub.decodeMutex.Lock()
+	defer stub.decodeMutex.Unlock()
+	if stub.decodeVSetsOnCall == nil {
+		stub.decodeVSetsOnCall = make(map[int]func(arg1 interface{}))
+	}
+	stub.decodeVSetsOnCall[index] = func(arg1 interface{}) {
+		stub.setArg("Decode", 1, arg1, value)
+	}
+}
+func (stub *OutParamsStub) DecodeReturns(result1 error) {
+	stub.decodeMutex.Lock()
+	defer stub.decodeMutex.Unlock()
+	stub.decodeReturns = OutParamsStubDecodeResults{result1}
+}
+func (stub *OutParamsStub) DecodeReturnsOnCall(index int, result1 error) {
+	stub.decodeMutex.Lock()
+	defer stub.decodeMutex.Unlock()
+	if stub.decodeReturnsOnCall == nil {
+		stub.decodeReturnsOnCall = make(map[int]OutParamsStubDecodeResults)
+	}
+	stub.decodeReturnsOnCall[index] = OutParamsStubDecodeResults{result1}
+}
+
+type OutParamsStubScanArgs struct {
+	Arg1 []interface{}
+}
+type OutParamsStubScanResults struct {
+	Result1 error
+}
+
+func (stub *OutParamsStub) Scan(arg1 ...interface{}) error {
+	stub.scanMutex.Lock()
+	stub.scanArgsForCall = append(stub.scanArgsForCall, OutParamsStubScanArgs{arg1})
+	callIndex := len(stub.scanArgsForCall) - 1
+	stubFunc := stub.ScanStub
+	returns := stub.scanReturns
+	returnsOnCall, hasReturnsOnCall := stub.scanReturnsOnCall[callIndex]
+	arg1Sets := stub.scanDestSets
+	if sets, ok := stub.scanDestSetsOnCall[callIndex]; ok {
+		arg1Sets = sets
+	}
+	stub.scanMutex.Unlock()
+	if arg1Sets != nil {
+		arg1Sets(arg1)
+	}
+	if stubFunc != nil {
+		return stubFunc(arg1...)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *OutParamsStub) ScanCallCount() int {
+	stub.scanMutex.RLock()
+	defer stub.scanMutex.RUnlock()
+	return len(stub.scanArgsForCall)
+}
+func (stub *OutParamsStub) ScanCalls() []OutParamsStubScanArgs {
+	stub.scanMutex.RLock()
+	defer stub.scanMutex.RUnlock()
+	calls := make([]OutParamsStubScanArgs, len(stub.scanArgsForCall))
+	copy(calls, stub.scanArgsForCall)
+	return calls
+}
+func (stub *OutParamsStub) ScanArgsForCall(index int) []interface{} {
+	stub.scanMutex.RLock()
+	defer stub.scanMutex.RUnlock()
+	return stub.scanArgsForCall[index].Arg1
+}
+func (stub *OutParamsStub) ScanSetsDest(values ...interface{}) {
+	stub.scanMutex.Lock()
+	defer stub.scanMutex.Unlock()
+	stub.scanDestSets = func(arg1 []interface{}) {
+		if len(values) > len(arg1) {
+			panic("cannot set argument 1 of OutParamsStub.Scan: out of range")
+		}
+		for i, value := range values {
+			stub.setArg("Scan", 1, arg1[i], value)
+		}
+	}
+}
+func (stub *OutParamsStub) ScanSetsDestOnCall(index int, values ...interface{}) {
+	stub.scanMutex.Lock()
+	defer stub.scanMutex.Unlock()
+	if stub.scanDestSetsOnCall == nil {
+		stub.scanDestSetsOnCall = make(map[int]func(arg1 []interface{}))
+	}
+	stub.scanDestSetsOnCall[index] = func(arg1 []interface{}) {
+		if len(values) > len(arg1) {
+			panic("cannot set argument 1 of OutParamsStub.Scan: out of range")
+		}
+		for i, value := range values {
+			stub.setArg("Scan", 1, arg1[i], value)
+		}
+	}
+}
+func (stub *OutParamsStub) ScanReturns(result1 error) {
+	stub.scanMutex.Lock()
+	defer stub.scanMutex.Unlock()
+	stub.scanReturns = OutParamsStubScanResults{result1}
+}
+func (stub *OutParamsStub) ScanReturnsOnCall(index int, result1 error) {
+	stub.scanMutex.Lock()
+	defer stub.scanMutex.Unlock()
+	if stub.scanReturnsOnCall == nil {
+		stub.scanReturnsOnCall = make(map[int]OutParamsStubScanResults)
+	}
+	stub.scanReturnsOnCall[index] = OutParamsStubScanResults{result1}
+}
+
+type OutParamsStubFillArgs struct {
+	Arg1 []*int
+}
+
+func (stub *OutParamsStub) Fill(arg1 ...*int) {
+	stub.fillMutex.Lock()
+	stub.fillArgsForCall = append(stub.fillArgsForCall, OutParamsStubFillArgs{arg1})
+	callIndex := len(stub.fillArgsForCall) - 1
+	stubFunc := stub.FillStub
+	arg1Sets := stub.fillTargetsSets
+	if sets, ok := stub.fillTargetsSetsOnCall[callIndex]; ok {
+		arg1Sets = sets
+	}
+	stub.fillMutex.Unlock()
+	if arg1Sets != nil {
+		arg1Sets(arg1)
+	}
+	if stubFunc != nil {
+		stubFunc(arg1...)
+	}
+}
+func (stub *OutParamsStub) FillCallCount() int {
+	stub.fillMutex.RLock()
+	defer stub.fillMutex.RUnlock()
+	return len(stub.fillArgsForCall)
+}
+func (stub *OutParamsStub) FillCalls() []OutParamsStubFillArgs {
+	stub.fillMutex.RLock()
+	defer stub.fillMutex.RUnlock()
+	calls := make([]OutParamsStubFillArgs, len(stub.fillArgsForCall))
+	copy(calls, stub.fillArgsForCall)
+	return calls
+}
+func (stub *OutParamsStub) FillArgsForCall(index int) []*int {
+	stub.fillMutex.RLock()
+	defer stub.fillMutex.RUnlock()
+	return stub.fillArgsForCall[index].Arg1
+}
+func (stub *OutParamsStub) FillSetsTargets(values ...int) {
+	stub.fillMutex.Lock()
+	defer stub.fillMutex.Unlock()
+	stub.fillTargetsSets = func(arg1 []*int) {
+		if len(values) > len(arg1) {
+			panic("cannot set argument 1 of OutParamsStub.Fill: out of range")
+		}
+		for i, value := range values {
+			if arg1[i] == nil {
+				panic("cannot set argument 1 of OutParamsStub.Fill: not a non-nil pointer")
+			}
+			*arg1[i] = value
+		}
+	}
+}
+func (stub *OutParamsStub) FillSetsTargetsOnCall(index int, values ...int) {
+	stub.fillMutex.Lock()
+	defer stub.fillMutex.Unlock()
+	if stub.fillTargetsSetsOnCall == nil {
+		stub.fillTargetsSetsOnCall = make(map[int]func(arg1 []*int))
+	}
+	stub.fillTargetsSetsOnCall[index] = func(arg1 []*int) {
+		if len(values) > len(arg1) {
+			panic("cannot set argument 1 of OutParamsStub.Fill: out of range")
+		}
+		for i, value := range values {
+			if arg1[i] == nil {
+				panic("cannot set argument 1 of OutParamsStub.Fill: not a non-nil pointer")
+			}
+			*arg1[i] = value
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type PanickingPrimitiveParamsStub struct {
+	StubGUID        int
+	mutex           sync.RWMutex
+	invocations     []PanickingPrimitiveParamsStubInvocation
+	SaveStub        func(arg1 int, arg2 string, arg3 float32)
+	saveMutex       sync.RWMutex
+	saveArgsForCall []PanickingPrimitiveParamsStubSaveArgs
+	savePanic       interface{}
+	savePanicOnCall map[int]interface{}
+}
+type PanickingPrimitiveParamsStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+	Panicked bool
+}
+
+func (stub *PanickingPrimitiveParamsStub) Invocations() []PanickingPrimitiveParamsStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]PanickingPrimitiveParamsStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+
+var _ alias1.PrimitiveParams = new(PanickingPrimitiveParamsStub)
+
+type PanickingPrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
+func (stub *PanickingPrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
+	stub.saveMutex.Lock()
+	stub.saveArgsForCall = append(stub.saveArgsForCall, PanickingPrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	callIndex := len(stub.saveArgsForCall) - 1
+	panicValue := stub.savePanic
+	if value, ok := stub.savePanicOnCall[callIndex]; ok {
+		panicValue = value
+	}
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, PanickingPrimitiveParamsStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Save", Args: []interface{}{arg1, arg2, arg3}, Panicked: panicValue != nil})
+	stub.mutex.Unlock()
+	stub.saveMutex.Unlock()
+	if panicValue != nil {
+		panic(panicValue)
+	}
+	stub.saveMutex.Lock()
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
+	}
+}
+func (stub *PanickingPrimitiveParamsStub) SaveCallCount() int {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return len(stub.saveArgsForCall)
+}
+func (stub *PanickingPrimitiveParamsStub) SaveCalls() []PanickingPrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]PanickingPrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *PanickingPrimitiveParamsStub) SavePanics(value interface{}) {
+	stub.saveMutex.Lock()
+	defer stub.saveMutex.Unlock()
+	stub.savePanic = value
+}
+func (stub *PanickingPrimitiveParamsStub) SavePanicsOnCall(index int, value interface{}) {
+	stub.saveMutex.Lock()
+	defer stub.saveMutex.Unlock()
+	if stub.savePanicOnCall == nil {
+		stub.savePanicOnCall = make(map[int]interface{})
+	}
+	stub.savePanicOnCall[index] = value
+}
+func (stub *PanickingPrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
+}
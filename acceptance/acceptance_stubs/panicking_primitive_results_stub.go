@@ -0,0 +1,114 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type PanickingPrimitiveResultsStub struct {
+	StubGUID          int
+	mutex             sync.RWMutex
+	invocations       []PanickingPrimitiveResultsStubInvocation
+	UserStub          func() (result1 string, result2 int, result3 float32)
+	userMutex         sync.RWMutex
+	userArgsForCall   []PanickingPrimitiveResultsStubUserArgs
+	userPanic         interface{}
+	userPanicOnCall   map[int]interface{}
+	userReturns       PanickingPrimitiveResultsStubUserResults
+	userReturnsOnCall map[int]PanickingPrimitiveResultsStubUserResults
+}
+type PanickingPrimitiveResultsStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+	Panicked bool
+}
+
+func (stub *PanickingPrimitiveResultsStub) Invocations() []PanickingPrimitiveResultsStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]PanickingPrimitiveResultsStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+
+var _ alias1.PrimitiveResults = new(PanickingPrimitiveResultsStub)
+
+type PanickingPrimitiveResultsStubUserArgs struct {
+}
+type PanickingPrimitiveResultsStubUserResults struct {
+	Result1 string
+	Result2 int
+	Result3 float32
+}
+
+func (stub *PanickingPrimitiveResultsStub) User() (string, int, float32) {
+	stub.userMutex.Lock()
+	stub.userArgsForCall = append(stub.userArgsForCall, PanickingPrimitiveResultsStubUserArgs{})
+	callIndex := len(stub.userArgsForCall) - 1
+	panicValue := stub.userPanic
+	if value, ok := stub.userPanicOnCall[callIndex]; ok {
+		panicValue = value
+	}
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, PanickingPrimitiveResultsStubInvocation{Sequence: len(stub.invocations) + 1, Method: "User", Args: []interface{}{}, Panicked: panicValue != nil})
+	stub.mutex.Unlock()
+	stub.userMutex.Unlock()
+	if panicValue != nil {
+		panic(panicValue)
+	}
+	stub.userMutex.Lock()
+	stubFunc := stub.UserStub
+	returns := stub.userReturns
+	returnsOnCall, hasReturnsOnCall := stub.userReturnsOnCall[callIndex]
+	stub.userMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2, returnsOnCall.Result3
+		}
+		return returns.Result1, returns.Result2, returns.Result3
+	}
+}
+func (stub *PanickingPrimitiveResultsStub) UserCallCount() int {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	return len(stub.userArgsForCall)
+}
+func (stub *PanickingPrimitiveResultsStub) UserCalls() []PanickingPrimitiveResultsStubUserArgs {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	calls := make([]PanickingPrimitiveResultsStubUserArgs, len(stub.userArgsForCall))
+	copy(calls, stub.userArgsForCall)
+	return calls
+}
+func (stub *PanickingPrimitiveResultsStub) UserPanics(value interface{}) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	stub.userPanic = value
+}
+func (stub *PanickingPrimitiveResultsStub) UserPanicsOnCall(index int, value interface{}) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	if stub.userPanicOnCall == nil {
+		stub.userPanicOnCall = make(map[int]interface{})
+	}
+	stub.userPanicOnCall[index] = value
+}
+func (stub *PanickingPrimitiveResultsStub) UserReturns(result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	stub.userReturns = PanickingPrimitiveResultsStubUserResults{result1, result2, result3}
+}
+func (stub *PanickingPrimitiveResultsStub) UserReturnsOnCall(index int, result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	if stub.userReturnsOnCall == nil {
+		stub.userReturnsOnCall = make(map[int]PanickingPrimitiveResultsStubUserResults)
+	}
+	stub.userReturnsOnCall[index] = PanickingPrimitiveResultsStubUserResults{result1, result2, result3}
+}
@@ -0,0 +1,195 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	reflect "reflect"
+	strings "strings"
+
+	types "github.com/onsi/gomega/types"
+)
+
+type PartialClientStubCallMatcher struct {
+	method      string
+	callHistory func(*PartialClientStub) [][]interface{}
+	args        []interface{}
+	argMatchers []types.GomegaMatcher
+	checksArgs  []bool
+	times       int
+}
+
+var _ types.GomegaMatcher = new(PartialClientStubCallMatcher)
+
+func (matcher *PartialClientStubCallMatcher) expectArg(index int, value interface{}) {
+	matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = value, nil, true
+}
+func (matcher *PartialClientStubCallMatcher) expectArgThat(index int, argMatcher types.GomegaMatcher) {
+	matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = argMatcher, argMatcher, true
+}
+func (matcher *PartialClientStubCallMatcher) ignoreArg(index int) {
+	matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = nil, nil, false
+}
+func (matcher *PartialClientStubCallMatcher) Match(actual interface{}) (bool, error) {
+	stub, ok := actual.(*PartialClientStub)
+	if !ok {
+		return false, fmt.Errorf("expected a *PartialClientStub, got %T", actual)
+	}
+	count := 0
+	for _, args := range matcher.callHistory(stub) {
+		matched, err := matcher.matchArgs(args)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			count++
+		}
+	}
+	if matcher.times < 0 {
+		return count > 0, nil
+	}
+	return count == matcher.times, nil
+}
+func (matcher *PartialClientStubCallMatcher) matchArgs(args []interface{}) (bool, error) {
+	if len(args) != len(matcher.args) {
+		return false, fmt.Errorf("expected %d arguments in calls to %s, got %d", len(matcher.args), matcher.method, len(args))
+	}
+	for i, expected := range matcher.args {
+		if !matcher.checksArgs[i] {
+			continue
+		}
+		matched := reflect.DeepEqual(args[i], expected)
+		if argMatcher := matcher.argMatchers[i]; argMatcher != nil {
+			var err error
+			matched, err = argMatcher.Match(args[i])
+			if err != nil {
+				return false, err
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+func (matcher *PartialClientStubCallMatcher) FailureMessage(actual interface{}) string {
+	return matcher.message(actual, "to have received")
+}
+func (matcher *PartialClientStubCallMatcher) NegatedFailureMessage(actual interface{}) string {
+	return matcher.message(actual, "not to have received")
+}
+func (matcher *PartialClientStubCallMatcher) message(actual interface{}, expectation string) string {
+	description := matcher.method
+	if expectedArgs := matcher.formatExpectedArgs(); expectedArgs != "" {
+		description += fmt.Sprintf("(%s)", expectedArgs)
+	}
+	if matcher.times >= 0 {
+		description += fmt.Sprintf(" exactly %d times", matcher.times)
+	}
+	history := ""
+	if stub, ok := actual.(*PartialClientStub); ok {
+		for i, args := range matcher.callHistory(stub) {
+			history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, matcher.method, matcher.formatArgs(args))
+		}
+	}
+	if history == "" {
+		history = "\n\t<none>"
+	}
+	return fmt.Sprintf("Expected PartialClientStub %s %s\nRecorded calls:%s", expectation, description, history)
+}
+func (matcher *PartialClientStubCallMatcher) formatArgs(args []interface{}) string {
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	return strings.Join(formattedArgs, ", ")
+}
+func (matcher *PartialClientStubCallMatcher) formatExpectedArgs() string {
+	checked := false
+	formattedArgs := make([]string, len(matcher.args))
+	for i, arg := range matcher.args {
+		formattedArgs[i] = "<any>"
+		if matcher.checksArgs[i] {
+			formattedArgs[i], checked = fmt.Sprintf("%#v", arg), true
+		}
+	}
+	if !checked {
+		return ""
+	}
+	return strings.Join(formattedArgs, ", ")
+}
+
+type PartialClientStubGetCallMatcher struct {
+	PartialClientStubCallMatcher
+}
+
+func (matcher *PartialClientStubGetCallMatcher) With(arg1 string) *PartialClientStubGetCallMatcher {
+	matcher.expectArg(0, arg1)
+	return matcher
+}
+func (matcher *PartialClientStubGetCallMatcher) WithKey(arg1 string) *PartialClientStubGetCallMatcher {
+	matcher.expectArg(0, arg1)
+	return matcher
+}
+func (matcher *PartialClientStubGetCallMatcher) WithKeyThat(argMatcher types.GomegaMatcher) *PartialClientStubGetCallMatcher {
+	matcher.expectArgThat(0, argMatcher)
+	return matcher
+}
+func (matcher *PartialClientStubGetCallMatcher) WithAnyKey() *PartialClientStubGetCallMatcher {
+	matcher.ignoreArg(0)
+	return matcher
+}
+func (matcher *PartialClientStubGetCallMatcher) Times(count int) *PartialClientStubGetCallMatcher {
+	matcher.times = count
+	return matcher
+}
+func PartialClientStubHaveReceivedGet() *PartialClientStubGetCallMatcher {
+	return &PartialClientStubGetCallMatcher{PartialClientStubCallMatcher{method: "Get", callHistory: (*PartialClientStub).getCallHistory, args: make([]interface{}, 1), argMatchers: make([]types.GomegaMatcher, 1), checksArgs: make([]bool, 1), times: -1}}
+}
+func PartialClientStubHaveReceivedGetTimes(count int) *PartialClientStubGetCallMatcher {
+	return PartialClientStubHaveReceivedGet().Times(count)
+}
+
+type PartialClientStubPutCallMatcher struct {
+	PartialClientStubCallMatcher
+}
+
+func (matcher *PartialClientStubPutCallMatcher) With(arg1 string, arg2 string) *PartialClientStubPutCallMatcher {
+	matcher.expectArg(0, arg1)
+	matcher.expectArg(1, arg2)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) WithKey(arg1 string) *PartialClientStubPutCallMatcher {
+	matcher.expectArg(0, arg1)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) WithKeyThat(argMatcher types.GomegaMatcher) *PartialClientStubPutCallMatcher {
+	matcher.expectArgThat(0, argMatcher)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) WithAnyKey() *PartialClientStubPutCallMatcher {
+	matcher.ignoreArg(0)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) WithValue(arg2 string) *PartialClientStubPutCallMatcher {
+	matcher.expectArg(1, arg2)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) WithValueThat(argMatcher types.GomegaMatcher) *PartialClientStubPutCallMatcher {
+	matcher.expectArgThat(1, argMatcher)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) WithAnyValue() *PartialClientStubPutCallMatcher {
+	matcher.ignoreArg(1)
+	return matcher
+}
+func (matcher *PartialClientStubPutCallMatcher) Times(count int) *PartialClientStubPutCallMatcher {
+	matcher.times = count
+	return matcher
+}
+func PartialClientStubHaveReceivedPut() *PartialClientStubPutCallMatcher {
+	return &PartialClientStubPutCallMatcher{PartialClientStubCallMatcher{method: "Put", callHistory: (*PartialClientStub).putCallHistory, args: make([]interface{}, 2), argMatchers: make([]types.GomegaMatcher, 2), checksArgs: make([]bool, 2), times: -1}}
+}
+func PartialClientStubHaveReceivedPutTimes(count int) *PartialClientStubPutCallMatcher {
+	return PartialClientStubHaveReceivedPut().Times(count)
+}
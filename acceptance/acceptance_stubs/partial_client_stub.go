@@ -0,0 +1,185 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type PartialClientStub struct {
+	StubGUID         int
+	mutex            sync.RWMutex
+	invocations      []PartialClientStubInvocation
+	GetStub          func(arg1 string) (result1 string, result2 error)
+	getMutex         sync.RWMutex
+	getArgsForCall   []PartialClientStubGetArgs
+	getReturns       PartialClientStubGetResults
+	getReturnsOnCall map[int]PartialClientStubGetResults
+	PutStub          func(arg1 string, arg2 string) (result1 error)
+	putMutex         sync.RWMutex
+	putArgsForCall   []PartialClientStubPutArgs
+	putReturns       PartialClientStubPutResults
+	putReturnsOnCall map[int]PartialClientStubPutResults
+}
+type PartialClientStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+}
+
+func (stub *PartialClientStub) Invocations() []PartialClientStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]PartialClientStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+
+var _ alias1.PartialClient = new(PartialClientStub)
+
+type PartialClientStubGetArgs struct {
+	Arg1 string
+}
+type PartialClientStubGetResults struct {
+	Result1 string
+	Result2 error
+}
+
+func (stub *PartialClientStub) Get(arg1 string) (string, error) {
+	stub.getMutex.Lock()
+	stub.getArgsForCall = append(stub.getArgsForCall, PartialClientStubGetArgs{arg1})
+	callIndex := len(stub.getArgsForCall) - 1
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, PartialClientStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Get", Args: []interface{}{arg1}})
+	stub.mutex.Unlock()
+	stubFunc := stub.GetStub
+	returns := stub.getReturns
+	returnsOnCall, hasReturnsOnCall := stub.getReturnsOnCall[callIndex]
+	stub.getMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *PartialClientStub) GetCallCount() int {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	return len(stub.getArgsForCall)
+}
+func (stub *PartialClientStub) GetCalls() []PartialClientStubGetArgs {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	calls := make([]PartialClientStubGetArgs, len(stub.getArgsForCall))
+	copy(calls, stub.getArgsForCall)
+	return calls
+}
+func (stub *PartialClientStub) getCallHistory() [][]interface{} {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	history := make([][]interface{}, len(stub.getArgsForCall))
+	for i := range stub.getArgsForCall {
+		history[i] = []interface{}{stub.getArgsForCall[i].Arg1}
+	}
+	return history
+}
+func (stub *PartialClientStub) GetArgsForCall(index int) string {
+	stub.getMutex.RLock()
+	defer stub.getMutex.RUnlock()
+	return stub.getArgsForCall[index].Arg1
+}
+func (stub *PartialClientStub) GetReturns(result1 string, result2 error) {
+	stub.getMutex.Lock()
+	defer stub.getMutex.Unlock()
+	stub.getReturns = PartialClientStubGetResults{result1, result2}
+}
+func (stub *PartialClientStub) GetReturnsOnCall(index int, result1 string, result2 error) {
+	stub.getMutex.Lock()
+	defer stub.getMutex.Unlock()
+	if stub.getReturnsOnCall == nil {
+		stub.getReturnsOnCall = make(map[int]PartialClientStubGetResults)
+	}
+	stub.getReturnsOnCall[index] = PartialClientStubGetResults{result1, result2}
+}
+
+type PartialClientStubPutArgs struct {
+	Arg1 string
+	Arg2 string
+}
+type PartialClientStubPutResults struct {
+	Result1 error
+}
+
+func (stub *PartialClientStub) Put(arg1 string, arg2 string) error {
+	stub.putMutex.Lock()
+	stub.putArgsForCall = append(stub.putArgsForCall, PartialClientStubPutArgs{arg1, arg2})
+	callIndex := len(stub.putArgsForCall) - 1
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, PartialClientStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Put", Args: []interface{}{arg1, arg2}})
+	stub.mutex.Unlock()
+	stubFunc := stub.PutStub
+	returns := stub.putReturns
+	returnsOnCall, hasReturnsOnCall := stub.putReturnsOnCall[callIndex]
+	stub.putMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *PartialClientStub) PutCallCount() int {
+	stub.putMutex.RLock()
+	defer stub.putMutex.RUnlock()
+	return len(stub.putArgsForCall)
+}
+func (stub *PartialClientStub) PutCalls() []PartialClientStubPutArgs {
+	stub.putMutex.RLock()
+	defer stub.putMutex.RUnlock()
+	calls := make([]PartialClientStubPutArgs, len(stub.putArgsForCall))
+	copy(calls, stub.putArgsForCall)
+	return calls
+}
+func (stub *PartialClientStub) putCallHistory() [][]interface{} {
+	stub.putMutex.RLock()
+	defer stub.putMutex.RUnlock()
+	history := make([][]interface{}, len(stub.putArgsForCall))
+	for i := range stub.putArgsForCall {
+		history[i] = []interface{}{stub.putArgsForCall[i].Arg1, stub.putArgsForCall[i].Arg2}
+	}
+	return history
+}
+func (stub *PartialClientStub) PutArgsForCall(index int) (string, string) {
+	stub.putMutex.RLock()
+	defer stub.putMutex.RUnlock()
+	return stub.putArgsForCall[index].Arg1, stub.putArgsForCall[index].Arg2
+}
+func (stub *PartialClientStub) PutReturns(result1 error) {
+	stub.putMutex.Lock()
+	defer stub.putMutex.Unlock()
+	stub.putReturns = PartialClientStubPutResults{result1}
+}
+func (stub *PartialClientStub) PutReturnsOnCall(index int, result1 error) {
+	stub.putMutex.Lock()
+	defer stub.putMutex.Unlock()
+	if stub.putReturnsOnCall == nil {
+		stub.putReturnsOnCall = make(map[int]PartialClientStubPutResults)
+	}
+	stub.putReturnsOnCall[index] = PartialClientStubPutResults{result1}
+}
+func (stub *PartialClientStub) Delete(string) error {
+	panic("unexpected call to Delete")
+}
+func (stub *PartialClientStub) List(string) ([]string, error) {
+	panic("unexpected call to List")
+}
+func (stub *PartialClientStub) Close() {
+	panic("unexpected call to Close")
+}
@@ -10,29 +10,38 @@ import (
 )
 
 type PointerSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 *alias2.Address) (result1 *alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 *alias2.Address
-	}
-	methodReturns struct {
-		result1 *alias2.Address
-	}
+	StubGUID            int
+	MethodStub          func(arg1 *alias2.Address) (result1 *alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []PointerSupportStubMethodArgs
+	methodReturns       PointerSupportStubMethodResults
+	methodReturnsOnCall map[int]PointerSupportStubMethodResults
 }
 
 var _ alias1.PointerSupport = new(PointerSupportStub)
 
+type PointerSupportStubMethodArgs struct {
+	Arg1 *alias2.Address
+}
+type PointerSupportStubMethodResults struct {
+	Result1 *alias2.Address
+}
+
 func (stub *PointerSupportStub) Method(arg1 *alias2.Address) *alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 *alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, PointerSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *PointerSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *PointerSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *PointerSupportStub) MethodCalls() []PointerSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]PointerSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *PointerSupportStub) MethodArgsForCall(index int) *alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *PointerSupportStub) MethodReturns(result1 *alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 *alias2.Address
-	}{result1}
+	stub.methodReturns = PointerSupportStubMethodResults{result1}
+}
+func (stub *PointerSupportStub) MethodReturnsOnCall(index int, result1 *alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]PointerSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = PointerSupportStubMethodResults{result1}
 }
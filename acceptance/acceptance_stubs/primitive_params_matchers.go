@@ -0,0 +1,177 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	reflect "reflect"
+	strings "strings"
+
+	types "github.com/onsi/gomega/types"
+)
+
+type PrimitiveParamsStubCallMatcher struct {
+	method      string
+	callHistory func(*PrimitiveParamsStub) [][]interface{}
+	args        []interface{}
+	argMatchers []types.GomegaMatcher
+	checksArgs  []bool
+	times       int
+}
+
+var _ types.GomegaMatcher = new(PrimitiveParamsStubCallMatcher)
+
+func (matcher *PrimitiveParamsStubCallMatcher) expectArg(index int, value interface{}) {
+	matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = value, nil, true
+}
+func (matcher *PrimitiveParamsStubCallMatcher) expectArgThat(index int, argMatcher types.GomegaMatcher) {
+	matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = argMatcher, argMatcher, true
+}
+func (matcher *PrimitiveParamsStubCallMatcher) ignoreArg(index int) {
+	matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = nil, nil, false
+}
+func (matcher *PrimitiveParamsStubCallMatcher) Match(actual interface{}) (bool, error) {
+	stub, ok := actual.(*PrimitiveParamsStub)
+	if !ok {
+		return false, fmt.Errorf("expected a *PrimitiveParamsStub, got %T", actual)
+	}
+	count := 0
+	for _, args := range matcher.callHistory(stub) {
+		matched, err := matcher.matchArgs(args)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			count++
+		}
+	}
+	if matcher.times < 0 {
+		return count > 0, nil
+	}
+	return count == matcher.times, nil
+}
+func (matcher *PrimitiveParamsStubCallMatcher) matchArgs(args []interface{}) (bool, error) {
+	if len(args) != len(matcher.args) {
+		return false, fmt.Errorf("expected %d arguments in calls to %s, got %d", len(matcher.args), matcher.method, len(args))
+	}
+	for i, expected := range matcher.args {
+		if !matcher.checksArgs[i] {
+			continue
+		}
+		matched := reflect.DeepEqual(args[i], expected)
+		if argMatcher := matcher.argMatchers[i]; argMatcher != nil {
+			var err error
+			matched, err = argMatcher.Match(args[i])
+			if err != nil {
+				return false, err
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+func (matcher *PrimitiveParamsStubCallMatcher) FailureMessage(actual interface{}) string {
+	return matcher.message(actual, "to have received")
+}
+func (matcher *PrimitiveParamsStubCallMatcher) NegatedFailureMessage(actual interface{}) string {
+	return matcher.message(actual, "not to have received")
+}
+func (matcher *PrimitiveParamsStubCallMatcher) message(actual interface{}, expectation string) string {
+	description := matcher.method
+	if expectedArgs := matcher.formatExpectedArgs(); expectedArgs != "" {
+		description += fmt.Sprintf("(%s)", expectedArgs)
+	}
+	if matcher.times >= 0 {
+		description += fmt.Sprintf(" exactly %d times", matcher.times)
+	}
+	history := ""
+	if stub, ok := actual.(*PrimitiveParamsStub); ok {
+		for i, args := range matcher.callHistory(stub) {
+			history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, matcher.method, matcher.formatArgs(args))
+		}
+	}
+	if history == "" {
+		history = "\n\t<none>"
+	}
+	return fmt.Sprintf("Expected PrimitiveParamsStub %s %s\nRecorded calls:%s", expectation, description, history)
+}
+func (matcher *PrimitiveParamsStubCallMatcher) formatArgs(args []interface{}) string {
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	return strings.Join(formattedArgs, ", ")
+}
+func (matcher *PrimitiveParamsStubCallMatcher) formatExpectedArgs() string {
+	checked := false
+	formattedArgs := make([]string, len(matcher.args))
+	for i, arg := range matcher.args {
+		formattedArgs[i] = "<any>"
+		if matcher.checksArgs[i] {
+			formattedArgs[i], checked = fmt.Sprintf("%#v", arg), true
+		}
+	}
+	if !checked {
+		return ""
+	}
+	return strings.Join(formattedArgs, ", ")
+}
+
+type PrimitiveParamsStubSaveCallMatcher struct {
+	PrimitiveParamsStubCallMatcher
+}
+
+func (matcher *PrimitiveParamsStubSaveCallMatcher) With(arg1 int, arg2 string, arg3 float32) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArg(0, arg1)
+	matcher.expectArg(1, arg2)
+	matcher.expectArg(2, arg3)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithCount(arg1 int) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArg(0, arg1)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithCountThat(argMatcher types.GomegaMatcher) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArgThat(0, argMatcher)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithAnyCount() *PrimitiveParamsStubSaveCallMatcher {
+	matcher.ignoreArg(0)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithLocation(arg2 string) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArg(1, arg2)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithLocationThat(argMatcher types.GomegaMatcher) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArgThat(1, argMatcher)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithAnyLocation() *PrimitiveParamsStubSaveCallMatcher {
+	matcher.ignoreArg(1)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithTimeout(arg3 float32) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArg(2, arg3)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithTimeoutThat(argMatcher types.GomegaMatcher) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.expectArgThat(2, argMatcher)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) WithAnyTimeout() *PrimitiveParamsStubSaveCallMatcher {
+	matcher.ignoreArg(2)
+	return matcher
+}
+func (matcher *PrimitiveParamsStubSaveCallMatcher) Times(count int) *PrimitiveParamsStubSaveCallMatcher {
+	matcher.times = count
+	return matcher
+}
+func PrimitiveParamsStubHaveReceivedSave() *PrimitiveParamsStubSaveCallMatcher {
+	return &PrimitiveParamsStubSaveCallMatcher{PrimitiveParamsStubCallMatcher{method: "Save", callHistory: (*PrimitiveParamsStub).saveCallHistory, args: make([]interface{}, 3), argMatchers: make([]types.GomegaMatcher, 3), checksArgs: make([]bool, 3), times: -1}}
+}
+func PrimitiveParamsStubHaveReceivedSaveTimes(count int) *PrimitiveParamsStubSaveCallMatcher {
+	return PrimitiveParamsStubHaveReceivedSave().Times(count)
+}
@@ -12,25 +12,24 @@ type PrimitiveParamsStub struct {
 	StubGUID        int
 	SaveStub        func(arg1 int, arg2 string, arg3 float32)
 	saveMutex       sync.RWMutex
-	saveArgsForCall []struct {
-		arg1 int
-		arg2 string
-		arg3 float32
-	}
+	saveArgsForCall []PrimitiveParamsStubSaveArgs
 }
 
 var _ alias1.PrimitiveParams = new(PrimitiveParamsStub)
 
+type PrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
 func (stub *PrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
 	stub.saveMutex.Lock()
-	defer stub.saveMutex.Unlock()
-	stub.saveArgsForCall = append(stub.saveArgsForCall, struct {
-		arg1 int
-		arg2 string
-		arg3 float32
-	}{arg1, arg2, arg3})
-	if stub.SaveStub != nil {
-		stub.SaveStub(arg1, arg2, arg3)
+	stub.saveArgsForCall = append(stub.saveArgsForCall, PrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
 	}
 }
 func (stub *PrimitiveParamsStub) SaveCallCount() int {
@@ -38,8 +37,24 @@ func (stub *PrimitiveParamsStub) SaveCallCount() int {
 	defer stub.saveMutex.RUnlock()
 	return len(stub.saveArgsForCall)
 }
+func (stub *PrimitiveParamsStub) SaveCalls() []PrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]PrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *PrimitiveParamsStub) saveCallHistory() [][]interface{} {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	history := make([][]interface{}, len(stub.saveArgsForCall))
+	for i := range stub.saveArgsForCall {
+		history[i] = []interface{}{stub.saveArgsForCall[i].Arg1, stub.saveArgsForCall[i].Arg2, stub.saveArgsForCall[i].Arg3}
+	}
+	return history
+}
 func (stub *PrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
 	stub.saveMutex.RLock()
 	defer stub.saveMutex.RUnlock()
-	return stub.saveArgsForCall[index].arg1, stub.saveArgsForCall[index].arg2, stub.saveArgsForCall[index].arg3
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
 }
@@ -9,29 +9,39 @@ import (
 )
 
 type PrimitiveResultsStub struct {
-	StubGUID        int
-	UserStub        func() (result1 string, result2 int, result3 float32)
-	userMutex       sync.RWMutex
-	userArgsForCall []struct {
-	}
-	userReturns struct {
-		result1 string
-		result2 int
-		result3 float32
-	}
+	StubGUID          int
+	UserStub          func() (result1 string, result2 int, result3 float32)
+	userMutex         sync.RWMutex
+	userArgsForCall   []PrimitiveResultsStubUserArgs
+	userReturns       PrimitiveResultsStubUserResults
+	userReturnsOnCall map[int]PrimitiveResultsStubUserResults
 }
 
 var _ alias1.PrimitiveResults = new(PrimitiveResultsStub)
 
+type PrimitiveResultsStubUserArgs struct {
+}
+type PrimitiveResultsStubUserResults struct {
+	Result1 string
+	Result2 int
+	Result3 float32
+}
+
 func (stub *PrimitiveResultsStub) User() (string, int, float32) {
 	stub.userMutex.Lock()
-	defer stub.userMutex.Unlock()
-	stub.userArgsForCall = append(stub.userArgsForCall, struct {
-	}{})
-	if stub.UserStub != nil {
-		return stub.UserStub()
+	stub.userArgsForCall = append(stub.userArgsForCall, PrimitiveResultsStubUserArgs{})
+	callIndex := len(stub.userArgsForCall) - 1
+	stubFunc := stub.UserStub
+	returns := stub.userReturns
+	returnsOnCall, hasReturnsOnCall := stub.userReturnsOnCall[callIndex]
+	stub.userMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
 	} else {
-		return stub.userReturns.result1, stub.userReturns.result2, stub.userReturns.result3
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2, returnsOnCall.Result3
+		}
+		return returns.Result1, returns.Result2, returns.Result3
 	}
 }
 func (stub *PrimitiveResultsStub) UserCallCount() int {
@@ -39,12 +49,23 @@ func (stub *PrimitiveResultsStub) UserCallCount() int {
 	defer stub.userMutex.RUnlock()
 	return len(stub.userArgsForCall)
 }
+func (stub *PrimitiveResultsStub) UserCalls() []PrimitiveResultsStubUserArgs {
+	stub.userMutex.RLock()
+	defer stub.userMutex.RUnlock()
+	calls := make([]PrimitiveResultsStubUserArgs, len(stub.userArgsForCall))
+	copy(calls, stub.userArgsForCall)
+	return calls
+}
 func (stub *PrimitiveResultsStub) UserReturns(result1 string, result2 int, result3 float32) {
 	stub.userMutex.Lock()
 	defer stub.userMutex.Unlock()
-	stub.userReturns = struct {
-		result1 string
-		result2 int
-		result3 float32
-	}{result1, result2, result3}
+	stub.userReturns = PrimitiveResultsStubUserResults{result1, result2, result3}
+}
+func (stub *PrimitiveResultsStub) UserReturnsOnCall(index int, result1 string, result2 int, result3 float32) {
+	stub.userMutex.Lock()
+	defer stub.userMutex.Unlock()
+	if stub.userReturnsOnCall == nil {
+		stub.userReturnsOnCall = make(map[int]PrimitiveResultsStubUserResults)
+	}
+	stub.userReturnsOnCall[index] = PrimitiveResultsStubUserResults{result1, result2, result3}
 }
@@ -0,0 +1,390 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	errors "errors"
+	rand "math/rand"
+	strconv "strconv"
+	sync "sync"
+	time "time"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type RandomResultsStub struct {
+	StubGUID              int
+	CustomerStub          func(arg1 int) (result1 alias1.Customer, result2 error)
+	customerMutex         sync.RWMutex
+	customerArgsForCall   []RandomResultsStubCustomerArgs
+	customerReturns       RandomResultsStubCustomerResults
+	customerReturnsOnCall map[int]RandomResultsStubCustomerResults
+	CatalogStub           func() (result1 map[string][]alias1.Product, result2 []*alias1.Customer)
+	catalogMutex          sync.RWMutex
+	catalogArgsForCall    []RandomResultsStubCatalogArgs
+	catalogReturns        RandomResultsStubCatalogResults
+	catalogReturnsOnCall  map[int]RandomResultsStubCatalogResults
+	MeasureStub           func() (result1 float64, result2 bool, result3 alias1.Status, result4 [2]rune)
+	measureMutex          sync.RWMutex
+	measureArgsForCall    []RandomResultsStubMeasureArgs
+	measureReturns        RandomResultsStubMeasureResults
+	measureReturnsOnCall  map[int]RandomResultsStubMeasureResults
+	NodeStub              func() (result1 *alias1.Node)
+	nodeMutex             sync.RWMutex
+	nodeArgsForCall       []RandomResultsStubNodeArgs
+	nodeReturns           RandomResultsStubNodeResults
+	nodeReturnsOnCall     map[int]RandomResultsStubNodeResults
+	CallbackStub          func() (result1 func(), result2 chan int, result3 interface{})
+	callbackMutex         sync.RWMutex
+	callbackArgsForCall   []RandomResultsStubCallbackArgs
+	callbackReturns       RandomResultsStubCallbackResults
+	callbackReturnsOnCall map[int]RandomResultsStubCallbackResults
+}
+
+var _ alias1.RandomResults = new(RandomResultsStub)
+
+func (stub *RandomResultsStub) RandomizeResults(seed int64, reporter interface {
+	Helper()
+	Logf(format string, args ...interface{})
+}) int64 {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if reporter != nil {
+		reporter.Helper()
+		reporter.Logf("results of RandomResultsStub are randomized with seed %d", seed)
+	}
+	r := rand.New(rand.NewSource(seed))
+	stub.CustomerReturnsRandom(r)
+	stub.CatalogReturnsRandom(r)
+	stub.MeasureReturnsRandom(r)
+	stub.NodeReturnsRandom(r)
+	stub.CallbackReturnsRandom(r)
+	return seed
+}
+
+type RandomResultsStubCustomerArgs struct {
+	Arg1 int
+}
+type RandomResultsStubCustomerResults struct {
+	Result1 alias1.Customer
+	Result2 error
+}
+
+func (stub *RandomResultsStub) Customer(arg1 int) (alias1.Customer, error) {
+	stub.customerMutex.Lock()
+	stub.customerArgsForCall = append(stub.customerArgsForCall, RandomResultsStubCustomerArgs{arg1})
+	callIndex := len(stub.customerArgsForCall) - 1
+	stubFunc := stub.CustomerStub
+	returns := stub.customerReturns
+	returnsOnCall, hasReturnsOnCall := stub.customerReturnsOnCall[callIndex]
+	stub.customerMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *RandomResultsStub) CustomerCallCount() int {
+	stub.customerMutex.RLock()
+	defer stub.customerMutex.RUnlock()
+	return len(stub.customerArgsForCall)
+}
+func (stub *RandomResultsStub) CustomerCalls() []RandomResultsStubCustomerArgs {
+	stub.customerMutex.RLock()
+	defer stub.customerMutex.RUnlock()
+	calls := make([]RandomResultsStubCustomerArgs, len(stub.customerArgsForCall))
+	copy(calls, stub.customerArgsForCall)
+	return calls
+}
+func (stub *RandomResultsStub) CustomerArgsForCall(index int) int {
+	stub.customerMutex.RLock()
+	defer stub.customerMutex.RUnlock()
+	return stub.customerArgsForCall[index].Arg1
+}
+func (stub *RandomResultsStub) CustomerReturns(result1 alias1.Customer, result2 error) {
+	stub.customerMutex.Lock()
+	defer stub.customerMutex.Unlock()
+	stub.customerReturns = RandomResultsStubCustomerResults{result1, result2}
+}
+func (stub *RandomResultsStub) CustomerReturnsOnCall(index int, result1 alias1.Customer, result2 error) {
+	stub.customerMutex.Lock()
+	defer stub.customerMutex.Unlock()
+	if stub.customerReturnsOnCall == nil {
+		stub.customerReturnsOnCall = make(map[int]RandomResultsStubCustomerResults)
+	}
+	stub.customerReturnsOnCall[index] = RandomResultsStubCustomerResults{result1, result2}
+}
+func (stub *RandomResultsStub) CustomerReturnsRandom(r *rand.Rand) {
+	stub.CustomerReturns(alias1.Customer{Name: strconv.FormatUint(r.Uint64(), 36), Address: strconv.FormatUint(r.Uint64(), 36)}, func() error {
+		if r.Intn(2) == 0 {
+			return nil
+		}
+		return errors.New("random error " + strconv.FormatUint(r.Uint64(), 36))
+	}())
+}
+
+type RandomResultsStubCatalogArgs struct {
+}
+type RandomResultsStubCatalogResults struct {
+	Result1 map[string][]alias1.Product
+	Result2 []*alias1.Customer
+}
+
+func (stub *RandomResultsStub) Catalog() (map[string][]alias1.Product, []*alias1.Customer) {
+	stub.catalogMutex.Lock()
+	stub.catalogArgsForCall = append(stub.catalogArgsForCall, RandomResultsStubCatalogArgs{})
+	callIndex := len(stub.catalogArgsForCall) - 1
+	stubFunc := stub.CatalogStub
+	returns := stub.catalogReturns
+	returnsOnCall, hasReturnsOnCall := stub.catalogReturnsOnCall[callIndex]
+	stub.catalogMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *RandomResultsStub) CatalogCallCount() int {
+	stub.catalogMutex.RLock()
+	defer stub.catalogMutex.RUnlock()
+	return len(stub.catalogArgsForCall)
+}
+func (stub *RandomResultsStub) CatalogCalls() []RandomResultsStubCatalogArgs {
+	stub.catalogMutex.RLock()
+	defer stub.catalogMutex.RUnlock()
+	calls := make([]RandomResultsStubCatalogArgs, len(stub.catalogArgsForCall))
+	copy(calls, stub.catalogArgsForCall)
+	return calls
+}
+func (stub *RandomResultsStub) CatalogReturns(result1 map[string][]alias1.Product, result2 []*alias1.Customer) {
+	stub.catalogMutex.Lock()
+	defer stub.catalogMutex.Unlock()
+	stub.catalogReturns = RandomResultsStubCatalogResults{result1, result2}
+}
+func (stub *RandomResultsStub) CatalogReturnsOnCall(index int, result1 map[string][]alias1.Product, result2 []*alias1.Customer) {
+	stub.catalogMutex.Lock()
+	defer stub.catalogMutex.Unlock()
+	if stub.catalogReturnsOnCall == nil {
+		stub.catalogReturnsOnCall = make(map[int]RandomResultsStubCatalogResults)
+	}
+	stub.catalogReturnsOnCall[index] = RandomResultsStubCatalogResults{result1, result2}
+}
+func (stub *RandomResultsStub) CatalogReturnsRandom(r *rand.Rand) {
+	stub.CatalogReturns(func() map[string][]alias1.Product {
+		values := make(map[string][]alias1.Product)
+		for i := r.Intn(4); i > 0; i-- {
+			values[strconv.FormatUint(r.Uint64(), 36)] = func() []alias1.Product {
+				values := make([]alias1.Product, r.Intn(4))
+				for i := range values {
+					values[i] = alias1.Product{Name: strconv.FormatUint(r.Uint64(), 36), Tags: func() []string {
+						values := make([]string, r.Intn(4))
+						for i := range values {
+							values[i] = strconv.FormatUint(r.Uint64(), 36)
+						}
+						return values
+					}(), Price: alias1.Price{Amount: int64(r.Uint64()), Currency: strconv.FormatUint(r.Uint64(), 36)}, Customer: func() *alias1.Customer {
+						value := new(alias1.Customer)
+						*value = alias1.Customer{Name: strconv.FormatUint(r.Uint64(), 36), Address: strconv.FormatUint(r.Uint64(), 36)}
+						return value
+					}()}
+				}
+				return values
+			}()
+		}
+		return values
+	}(), func() []*alias1.Customer {
+		values := make([]*alias1.Customer, r.Intn(4))
+		for i := range values {
+			values[i] = func() *alias1.Customer {
+				value := new(alias1.Customer)
+				*value = alias1.Customer{Name: strconv.FormatUint(r.Uint64(), 36), Address: strconv.FormatUint(r.Uint64(), 36)}
+				return value
+			}()
+		}
+		return values
+	}())
+}
+
+type RandomResultsStubMeasureArgs struct {
+}
+type RandomResultsStubMeasureResults struct {
+	Result1 float64
+	Result2 bool
+	Result3 alias1.Status
+	Result4 [2]rune
+}
+
+func (stub *RandomResultsStub) Measure() (float64, bool, alias1.Status, [2]rune) {
+	stub.measureMutex.Lock()
+	stub.measureArgsForCall = append(stub.measureArgsForCall, RandomResultsStubMeasureArgs{})
+	callIndex := len(stub.measureArgsForCall) - 1
+	stubFunc := stub.MeasureStub
+	returns := stub.measureReturns
+	returnsOnCall, hasReturnsOnCall := stub.measureReturnsOnCall[callIndex]
+	stub.measureMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2, returnsOnCall.Result3, returnsOnCall.Result4
+		}
+		return returns.Result1, returns.Result2, returns.Result3, returns.Result4
+	}
+}
+func (stub *RandomResultsStub) MeasureCallCount() int {
+	stub.measureMutex.RLock()
+	defer stub.measureMutex.RUnlock()
+	return len(stub.measureArgsForCall)
+}
+func (stub *RandomResultsStub) MeasureCalls() []RandomResultsStubMeasureArgs {
+	stub.measureMutex.RLock()
+	defer stub.measureMutex.RUnlock()
+	calls := make([]RandomResultsStubMeasureArgs, len(stub.measureArgsForCall))
+	copy(calls, stub.measureArgsForCall)
+	return calls
+}
+func (stub *RandomResultsStub) MeasureReturns(result1 float64, result2 bool, result3 alias1.Status, result4 [2]rune) {
+	stub.measureMutex.Lock()
+	defer stub.measureMutex.Unlock()
+	stub.measureReturns = RandomResultsStubMeasureResults{result1, result2, result3, result4}
+}
+func (stub *RandomResultsStub) MeasureReturnsOnCall(index int, result1 float64, result2 bool, result3 alias1.Status, result4 [2]rune) {
+	stub.measureMutex.Lock()
+	defer stub.measureMutex.Unlock()
+	if stub.measureReturnsOnCall == nil {
+		stub.measureReturnsOnCall = make(map[int]RandomResultsStubMeasureResults)
+	}
+	stub.measureReturnsOnCall[index] = RandomResultsStubMeasureResults{result1, result2, result3, result4}
+}
+func (stub *RandomResultsStub) MeasureReturnsRandom(r *rand.Rand) {
+	stub.MeasureReturns(float64(r.NormFloat64()), r.Intn(2) == 1, alias1.Status(int(r.Uint64())), func() [2]rune {
+		var values [2]rune
+		for i := range values {
+			values[i] = rune(r.Uint64())
+		}
+		return values
+	}())
+}
+
+type RandomResultsStubNodeArgs struct {
+}
+type RandomResultsStubNodeResults struct {
+	Result1 *alias1.Node
+}
+
+func (stub *RandomResultsStub) Node() *alias1.Node {
+	stub.nodeMutex.Lock()
+	stub.nodeArgsForCall = append(stub.nodeArgsForCall, RandomResultsStubNodeArgs{})
+	callIndex := len(stub.nodeArgsForCall) - 1
+	stubFunc := stub.NodeStub
+	returns := stub.nodeReturns
+	returnsOnCall, hasReturnsOnCall := stub.nodeReturnsOnCall[callIndex]
+	stub.nodeMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *RandomResultsStub) NodeCallCount() int {
+	stub.nodeMutex.RLock()
+	defer stub.nodeMutex.RUnlock()
+	return len(stub.nodeArgsForCall)
+}
+func (stub *RandomResultsStub) NodeCalls() []RandomResultsStubNodeArgs {
+	stub.nodeMutex.RLock()
+	defer stub.nodeMutex.RUnlock()
+	calls := make([]RandomResultsStubNodeArgs, len(stub.nodeArgsForCall))
+	copy(calls, stub.nodeArgsForCall)
+	return calls
+}
+func (stub *RandomResultsStub) NodeReturns(result1 *alias1.Node) {
+	stub.nodeMutex.Lock()
+	defer stub.nodeMutex.Unlock()
+	stub.nodeReturns = RandomResultsStubNodeResults{result1}
+}
+func (stub *RandomResultsStub) NodeReturnsOnCall(index int, result1 *alias1.Node) {
+	stub.nodeMutex.Lock()
+	defer stub.nodeMutex.Unlock()
+	if stub.nodeReturnsOnCall == nil {
+		stub.nodeReturnsOnCall = make(map[int]RandomResultsStubNodeResults)
+	}
+	stub.nodeReturnsOnCall[index] = RandomResultsStubNodeResults{result1}
+}
+func (stub *RandomResultsStub) NodeReturnsRandom(r *rand.Rand) {
+	stub.NodeReturns(func() *alias1.Node {
+		value := new(alias1.Node)
+		*value = alias1.Node{Value: strconv.FormatUint(r.Uint64(), 36), Next: nil, Children: func() []alias1.Node {
+			values := make([]alias1.Node, r.Intn(4))
+			for i := range values {
+				values[i] = *new(alias1.Node)
+			}
+			return values
+		}()}
+		return value
+	}())
+}
+
+type RandomResultsStubCallbackArgs struct {
+}
+type RandomResultsStubCallbackResults struct {
+	Result1 func()
+	Result2 chan int
+	Result3 interface{}
+}
+
+func (stub *RandomResultsStub) Callback() (func(), chan int, interface{}) {
+	stub.callbackMutex.Lock()
+	stub.callbackArgsForCall = append(stub.callbackArgsForCall, RandomResultsStubCallbackArgs{})
+	callIndex := len(stub.callbackArgsForCall) - 1
+	stubFunc := stub.CallbackStub
+	returns := stub.callbackReturns
+	returnsOnCall, hasReturnsOnCall := stub.callbackReturnsOnCall[callIndex]
+	stub.callbackMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2, returnsOnCall.Result3
+		}
+		return returns.Result1, returns.Result2, returns.Result3
+	}
+}
+func (stub *RandomResultsStub) CallbackCallCount() int {
+	stub.callbackMutex.RLock()
+	defer stub.callbackMutex.RUnlock()
+	return len(stub.callbackArgsForCall)
+}
+func (stub *RandomResultsStub) CallbackCalls() []RandomResultsStubCallbackArgs {
+	stub.callbackMutex.RLock()
+	defer stub.callbackMutex.RUnlock()
+	calls := make([]RandomResultsStubCallbackArgs, len(stub.callbackArgsForCall))
+	copy(calls, stub.callbackArgsForCall)
+	return calls
+}
+func (stub *RandomResultsStub) CallbackReturns(result1 func(), result2 chan int, result3 interface{}) {
+	stub.callbackMutex.Lock()
+	defer stub.callbackMutex.Unlock()
+	stub.callbackReturns = RandomResultsStubCallbackResults{result1, result2, result3}
+}
+func (stub *RandomResultsStub) CallbackReturnsOnCall(index int, result1 func(), result2 chan int, result3 interface{}) {
+	stub.callbackMutex.Lock()
+	defer stub.callbackMutex.Unlock()
+	if stub.callbackReturnsOnCall == nil {
+		stub.callbackReturnsOnCall = make(map[int]RandomResultsStubCallbackResults)
+	}
+	stub.callbackReturnsOnCall[index] = RandomResultsStubCallbackResults{result1, result2, result3}
+}
+func (stub *RandomResultsStub) CallbackReturnsRandom(r *rand.Rand) {
+	stub.CallbackReturns(nil, nil, nil)
+}
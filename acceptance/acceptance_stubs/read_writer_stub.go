@@ -0,0 +1,558 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	sort "sort"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type ReadWriterStub struct {
+	StubGUID       int
+	mutex          sync.RWMutex
+	invocations    []ReadWriterStubInvocation
+	strict         bool
+	strictReporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+	recorder interface {
+		Record(stub string, method string, args []interface{})
+	}
+	expectations         []*ReadWriterStubExpectation
+	verifiesExpectations bool
+	ReaderStub
+	WriterStub
+	ResetStub              func(arg1 int64) (result1 error)
+	resetMutex             sync.RWMutex
+	resetArgsForCall       []ReadWriterStubResetArgs
+	resetPanic             interface{}
+	resetPanicOnCall       map[int]interface{}
+	resetReturns           ReadWriterStubResetResults
+	resetReturnsOnCall     map[int]ReadWriterStubResetResults
+	resetReturnsConfigured bool
+}
+type ReadWriterStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+	Panicked bool
+}
+
+func (stub *ReadWriterStub) Invocations() []ReadWriterStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]ReadWriterStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+func (stub *ReadWriterStub) SetStrict(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.strict, stub.strictReporter = true, reporter
+	stub.ReaderStub.SetStrict(reporter)
+	stub.WriterStub.SetStrict(reporter)
+}
+func (stub *ReadWriterStub) reportUnconfiguredCall(method string, args ...interface{}) {
+	stub.mutex.RLock()
+	strict, reporter := stub.strict, stub.strictReporter
+	stub.mutex.RUnlock()
+	if !strict {
+		return
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	message := fmt.Sprintf("unconfigured call to ReadWriterStub.%s(%s)", method, strings.Join(formattedArgs, ", "))
+	if reporter == nil {
+		panic(message)
+	}
+	reporter.Helper()
+	reporter.Errorf("%s", message)
+}
+func (stub *ReadWriterStub) AttachRecorder(recorder interface {
+	Record(stub string, method string, args []interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.recorder = recorder
+}
+func (stub *ReadWriterStub) LoadScenario(r io.Reader) error {
+	var scenario map[string]struct {
+		Returns []json.RawMessage
+		OnCall  map[int][]json.RawMessage
+	}
+	err := json.NewDecoder(r).Decode(&scenario)
+	if err != nil {
+		return fmt.Errorf("cannot decode scenario for ReadWriterStub: %v", err)
+	}
+	methods := make([]string, 0, len(scenario))
+	for key := range scenario {
+		methods = append(methods, key)
+	}
+	sort.Strings(methods)
+	var configure []func()
+	for _, method := range methods {
+		methodScenario := scenario[method]
+		switch method {
+		case "Read":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeReadScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.ReadReturns(results.Result1, results.Result2)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeReadScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.ReadReturnsOnCall(index, results.Result1, results.Result2)
+				})
+			}
+		case "Write":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeWriteScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.WriteReturns(results.Result1, results.Result2)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeWriteScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.WriteReturnsOnCall(index, results.Result1, results.Result2)
+				})
+			}
+		case "Flush":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeFlushScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.FlushReturns(results.Result1)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeFlushScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.FlushReturnsOnCall(index, results.Result1)
+				})
+			}
+		case "Reset":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeResetScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.ResetReturns(results.Result1)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeResetScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.ResetReturnsOnCall(index, results.Result1)
+				})
+			}
+		default:
+			return fmt.Errorf("cannot configure ReadWriterStub.%s from scenario: unknown method", method)
+		}
+	}
+	for _, apply := range configure {
+		apply()
+	}
+	return nil
+}
+
+type ReadWriterStubOption func(stub *ReadWriterStub)
+
+func NewReadWriterStub(opts ...ReadWriterStubOption) *ReadWriterStub {
+	stub := new(ReadWriterStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+type ReadWriterStubExpectation struct {
+	method      string
+	min         int
+	max         int
+	callCount   func() int
+	callHistory func() [][]interface{}
+}
+
+func (expectation *ReadWriterStubExpectation) Times(count int) *ReadWriterStubExpectation {
+	expectation.min, expectation.max = count, count
+	return expectation
+}
+func (expectation *ReadWriterStubExpectation) AtLeast(count int) *ReadWriterStubExpectation {
+	expectation.min, expectation.max = count, -1
+	return expectation
+}
+func (expectation *ReadWriterStubExpectation) Never() *ReadWriterStubExpectation {
+	expectation.min, expectation.max = 0, 0
+	return expectation
+}
+func (expectation *ReadWriterStubExpectation) verify(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	count := expectation.callCount()
+	if count >= expectation.min && (expectation.max < 0 || count <= expectation.max) {
+		return
+	}
+	expected := fmt.Sprintf("at least %d", expectation.min)
+	if expectation.max >= 0 {
+		expected = fmt.Sprintf("exactly %d", expectation.max)
+	}
+	history := ""
+	for i, args := range expectation.callHistory() {
+		formattedArgs := make([]string, len(args))
+		for j, arg := range args {
+			formattedArgs[j] = fmt.Sprintf("%#v", arg)
+		}
+		history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, expectation.method, strings.Join(formattedArgs, ", "))
+	}
+	reporter.Helper()
+	reporter.Errorf("expected %s calls to ReadWriterStub.%s, got %d%s", expected, expectation.method, count, history)
+}
+func (stub *ReadWriterStub) verifyExpectations(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	reporter.Helper()
+	stub.mutex.RLock()
+	expectations := stub.expectations
+	stub.mutex.RUnlock()
+	for _, expectation := range expectations {
+		expectation.verify(reporter)
+	}
+	stub.ReaderStub.verifyExpectations(reporter)
+	stub.WriterStub.verifyExpectations(reporter)
+}
+func NewReadWriterStubT(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}, opts ...ReadWriterStubOption) *ReadWriterStub {
+	stub := NewReadWriterStub(opts...)
+	stub.verifiesExpectations = true
+	stub.ReaderStub.verifiesExpectations = true
+	stub.WriterStub.verifiesExpectations = true
+	t.Cleanup(func() {
+		stub.verifyExpectations(t)
+	})
+	return stub
+}
+
+var _ alias1.ReadWriter = new(ReadWriterStub)
+
+func (stub *ReadWriterStub) Read(arg1 []byte) (int, error) {
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, ReadWriterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Read", Args: []interface{}{arg1}})
+	index := len(stub.invocations) - 1
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	if recorder != nil {
+		recorder.Record("ReadWriterStub", "Read", []interface{}{arg1})
+	}
+	returned := false
+	defer func() {
+		if !returned {
+			stub.mutex.Lock()
+			stub.invocations[index].Panicked = true
+			stub.mutex.Unlock()
+		}
+	}()
+	result1, result2 := stub.ReaderStub.Read(arg1)
+	returned = true
+	return result1, result2
+}
+func (stub *ReadWriterStub) ExpectRead() *ReadWriterStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to ReadWriterStub.Read: the stub was not created with NewReadWriterStubT")
+	}
+	expectation := &ReadWriterStubExpectation{method: "Read", min: 1, max: -1, callCount: stub.ReadCallCount, callHistory: stub.readCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func ReadWriterStubWithReadStub(fn func(arg1 []byte) (result1 int, result2 error)) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.ReadStub = fn
+	}
+}
+func ReadWriterStubWithReadReturns(result1 int, result2 error) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.ReadReturns(result1, result2)
+	}
+}
+func (stub *ReadWriterStub) Write(arg1 []byte) (int, error) {
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, ReadWriterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Write", Args: []interface{}{arg1}})
+	index := len(stub.invocations) - 1
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	if recorder != nil {
+		recorder.Record("ReadWriterStub", "Write", []interface{}{arg1})
+	}
+	returned := false
+	defer func() {
+		if !returned {
+			stub.mutex.Lock()
+			stub.invocations[index].Panicked = true
+			stub.mutex.Unlock()
+		}
+	}()
+	result1, result2 := stub.WriterStub.Write(arg1)
+	returned = true
+	return result1, result2
+}
+func (stub *ReadWriterStub) ExpectWrite() *ReadWriterStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to ReadWriterStub.Write: the stub was not created with NewReadWriterStubT")
+	}
+	expectation := &ReadWriterStubExpectation{method: "Write", min: 1, max: -1, callCount: stub.WriteCallCount, callHistory: stub.writeCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func ReadWriterStubWithWriteStub(fn func(arg1 []byte) (result1 int, result2 error)) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.WriteStub = fn
+	}
+}
+func ReadWriterStubWithWriteReturns(result1 int, result2 error) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.WriteReturns(result1, result2)
+	}
+}
+func (stub *ReadWriterStub) Flush() error {
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, ReadWriterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Flush", Args: []interface{}{}})
+	index := len(stub.invocations) - 1
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	if recorder != nil {
+		recorder.Record("ReadWriterStub", "Flush", []interface{}{})
+	}
+	returned := false
+	defer func() {
+		if !returned {
+			stub.mutex.Lock()
+			stub.invocations[index].Panicked = true
+			stub.mutex.Unlock()
+		}
+	}()
+	result1 := stub.WriterStub.Flush()
+	returned = true
+	return result1
+}
+func (stub *ReadWriterStub) ExpectFlush() *ReadWriterStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to ReadWriterStub.Flush: the stub was not created with NewReadWriterStubT")
+	}
+	expectation := &ReadWriterStubExpectation{method: "Flush", min: 1, max: -1, callCount: stub.FlushCallCount, callHistory: stub.flushCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func ReadWriterStubWithFlushStub(fn func() (result1 error)) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.FlushStub = fn
+	}
+}
+func ReadWriterStubWithFlushReturns(result1 error) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.FlushReturns(result1)
+	}
+}
+
+type ReadWriterStubResetArgs struct {
+	Arg1 int64
+}
+type ReadWriterStubResetResults struct {
+	Result1 error
+}
+
+func (stub *ReadWriterStub) Reset(arg1 int64) error {
+	stub.resetMutex.Lock()
+	stub.resetArgsForCall = append(stub.resetArgsForCall, ReadWriterStubResetArgs{arg1})
+	callIndex := len(stub.resetArgsForCall) - 1
+	panicValue := stub.resetPanic
+	if value, ok := stub.resetPanicOnCall[callIndex]; ok {
+		panicValue = value
+	}
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, ReadWriterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Reset", Args: []interface{}{arg1}, Panicked: panicValue != nil})
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	stub.resetMutex.Unlock()
+	if recorder != nil {
+		recorder.Record("ReadWriterStub", "Reset", []interface{}{arg1})
+	}
+	if panicValue != nil {
+		panic(panicValue)
+	}
+	stub.resetMutex.Lock()
+	stubFunc := stub.ResetStub
+	returns := stub.resetReturns
+	configured := stub.resetReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.resetReturnsOnCall[callIndex]
+	stub.resetMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			stub.reportUnconfiguredCall("Reset", arg1)
+		}
+		return returns.Result1
+	}
+}
+func (stub *ReadWriterStub) ResetCallCount() int {
+	stub.resetMutex.RLock()
+	defer stub.resetMutex.RUnlock()
+	return len(stub.resetArgsForCall)
+}
+func (stub *ReadWriterStub) ResetCalls() []ReadWriterStubResetArgs {
+	stub.resetMutex.RLock()
+	defer stub.resetMutex.RUnlock()
+	calls := make([]ReadWriterStubResetArgs, len(stub.resetArgsForCall))
+	copy(calls, stub.resetArgsForCall)
+	return calls
+}
+func (stub *ReadWriterStub) ResetPanics(value interface{}) {
+	stub.resetMutex.Lock()
+	defer stub.resetMutex.Unlock()
+	stub.resetPanic = value
+}
+func (stub *ReadWriterStub) ResetPanicsOnCall(index int, value interface{}) {
+	stub.resetMutex.Lock()
+	defer stub.resetMutex.Unlock()
+	if stub.resetPanicOnCall == nil {
+		stub.resetPanicOnCall = make(map[int]interface{})
+	}
+	stub.resetPanicOnCall[index] = value
+}
+func (stub *ReadWriterStub) resetCallHistory() [][]interface{} {
+	stub.resetMutex.RLock()
+	defer stub.resetMutex.RUnlock()
+	history := make([][]interface{}, len(stub.resetArgsForCall))
+	for i := range stub.resetArgsForCall {
+		history[i] = []interface{}{stub.resetArgsForCall[i].Arg1}
+	}
+	return history
+}
+func (stub *ReadWriterStub) ExpectReset() *ReadWriterStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to ReadWriterStub.Reset: the stub was not created with NewReadWriterStubT")
+	}
+	expectation := &ReadWriterStubExpectation{method: "Reset", min: 1, max: -1, callCount: stub.ResetCallCount, callHistory: stub.resetCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func (stub *ReadWriterStub) ResetArgsForCall(index int) int64 {
+	stub.resetMutex.RLock()
+	defer stub.resetMutex.RUnlock()
+	return stub.resetArgsForCall[index].Arg1
+}
+func (stub *ReadWriterStub) ResetReturns(result1 error) {
+	stub.resetMutex.Lock()
+	defer stub.resetMutex.Unlock()
+	stub.resetReturns = ReadWriterStubResetResults{result1}
+	stub.resetReturnsConfigured = true
+}
+func (stub *ReadWriterStub) ResetReturnsOnCall(index int, result1 error) {
+	stub.resetMutex.Lock()
+	defer stub.resetMutex.Unlock()
+	if stub.resetReturnsOnCall == nil {
+		stub.resetReturnsOnCall = make(map[int]ReadWriterStubResetResults)
+	}
+	stub.resetReturnsOnCall[index] = ReadWriterStubResetResults{result1}
+}
+func (stub *ReadWriterStub) decodeResetScenario(values []json.RawMessage) (ReadWriterStubResetResults, error) {
+	var results ReadWriterStubResetResults
+	if len(values) != 1 {
+		return results, fmt.Errorf("cannot configure ReadWriterStub.Reset from scenario: expected 1 results, got %d", len(values))
+	}
+	var message1 *string
+	err := json.Unmarshal(values[0], &message1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure ReadWriterStub.Reset from scenario: cannot decode result 1: %v", err)
+	}
+	if message1 != nil {
+		results.Result1 = errors.New(*message1)
+	}
+	return results, nil
+}
+func ReadWriterStubWithResetStub(fn func(arg1 int64) (result1 error)) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.ResetStub = fn
+	}
+}
+func ReadWriterStubWithResetReturns(result1 error) ReadWriterStubOption {
+	return func(stub *ReadWriterStub) {
+		stub.ResetReturns(result1)
+	}
+}
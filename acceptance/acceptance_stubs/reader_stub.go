@@ -0,0 +1,356 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	sort "sort"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type ReaderStub struct {
+	StubGUID       int
+	mutex          sync.RWMutex
+	invocations    []ReaderStubInvocation
+	strict         bool
+	strictReporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+	recorder interface {
+		Record(stub string, method string, args []interface{})
+	}
+	expectations          []*ReaderStubExpectation
+	verifiesExpectations  bool
+	ReadStub              func(arg1 []byte) (result1 int, result2 error)
+	readMutex             sync.RWMutex
+	readArgsForCall       []ReaderStubReadArgs
+	readPanic             interface{}
+	readPanicOnCall       map[int]interface{}
+	readReturns           ReaderStubReadResults
+	readReturnsOnCall     map[int]ReaderStubReadResults
+	readReturnsConfigured bool
+}
+type ReaderStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+	Panicked bool
+}
+
+func (stub *ReaderStub) Invocations() []ReaderStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]ReaderStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+func (stub *ReaderStub) SetStrict(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.strict, stub.strictReporter = true, reporter
+}
+func (stub *ReaderStub) reportUnconfiguredCall(method string, args ...interface{}) {
+	stub.mutex.RLock()
+	strict, reporter := stub.strict, stub.strictReporter
+	stub.mutex.RUnlock()
+	if !strict {
+		return
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	message := fmt.Sprintf("unconfigured call to ReaderStub.%s(%s)", method, strings.Join(formattedArgs, ", "))
+	if reporter == nil {
+		panic(message)
+	}
+	reporter.Helper()
+	reporter.Errorf("%s", message)
+}
+func (stub *ReaderStub) AttachRecorder(recorder interface {
+	Record(stub string, method string, args []interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.recorder = recorder
+}
+func (stub *ReaderStub) LoadScenario(r io.Reader) error {
+	var scenario map[string]struct {
+		Returns []json.RawMessage
+		OnCall  map[int][]json.RawMessage
+	}
+	err := json.NewDecoder(r).Decode(&scenario)
+	if err != nil {
+		return fmt.Errorf("cannot decode scenario for ReaderStub: %v", err)
+	}
+	methods := make([]string, 0, len(scenario))
+	for key := range scenario {
+		methods = append(methods, key)
+	}
+	sort.Strings(methods)
+	var configure []func()
+	for _, method := range methods {
+		methodScenario := scenario[method]
+		switch method {
+		case "Read":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeReadScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.ReadReturns(results.Result1, results.Result2)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeReadScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.ReadReturnsOnCall(index, results.Result1, results.Result2)
+				})
+			}
+		default:
+			return fmt.Errorf("cannot configure ReaderStub.%s from scenario: unknown method", method)
+		}
+	}
+	for _, apply := range configure {
+		apply()
+	}
+	return nil
+}
+
+type ReaderStubOption func(stub *ReaderStub)
+
+func NewReaderStub(opts ...ReaderStubOption) *ReaderStub {
+	stub := new(ReaderStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+type ReaderStubExpectation struct {
+	method      string
+	min         int
+	max         int
+	callCount   func() int
+	callHistory func() [][]interface{}
+}
+
+func (expectation *ReaderStubExpectation) Times(count int) *ReaderStubExpectation {
+	expectation.min, expectation.max = count, count
+	return expectation
+}
+func (expectation *ReaderStubExpectation) AtLeast(count int) *ReaderStubExpectation {
+	expectation.min, expectation.max = count, -1
+	return expectation
+}
+func (expectation *ReaderStubExpectation) Never() *ReaderStubExpectation {
+	expectation.min, expectation.max = 0, 0
+	return expectation
+}
+func (expectation *ReaderStubExpectation) verify(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	count := expectation.callCount()
+	if count >= expectation.min && (expectation.max < 0 || count <= expectation.max) {
+		return
+	}
+	expected := fmt.Sprintf("at least %d", expectation.min)
+	if expectation.max >= 0 {
+		expected = fmt.Sprintf("exactly %d", expectation.max)
+	}
+	history := ""
+	for i, args := range expectation.callHistory() {
+		formattedArgs := make([]string, len(args))
+		for j, arg := range args {
+			formattedArgs[j] = fmt.Sprintf("%#v", arg)
+		}
+		history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, expectation.method, strings.Join(formattedArgs, ", "))
+	}
+	reporter.Helper()
+	reporter.Errorf("expected %s calls to ReaderStub.%s, got %d%s", expected, expectation.method, count, history)
+}
+func (stub *ReaderStub) verifyExpectations(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	reporter.Helper()
+	stub.mutex.RLock()
+	expectations := stub.expectations
+	stub.mutex.RUnlock()
+	for _, expectation := range expectations {
+		expectation.verify(reporter)
+	}
+}
+func NewReaderStubT(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}, opts ...ReaderStubOption) *ReaderStub {
+	stub := NewReaderStub(opts...)
+	stub.verifiesExpectations = true
+	t.Cleanup(func() {
+		stub.verifyExpectations(t)
+	})
+	return stub
+}
+
+var _ alias1.Reader = new(ReaderStub)
+
+type ReaderStubReadArgs struct {
+	Arg1 []byte
+}
+type ReaderStubReadResults struct {
+	Result1 int
+	Result2 error
+}
+
+func (stub *ReaderStub) Read(arg1 []byte) (int, error) {
+	stub.readMutex.Lock()
+	stub.readArgsForCall = append(stub.readArgsForCall, ReaderStubReadArgs{arg1})
+	callIndex := len(stub.readArgsForCall) - 1
+	panicValue := stub.readPanic
+	if value, ok := stub.readPanicOnCall[callIndex]; ok {
+		panicValue = value
+	}
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, ReaderStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Read", Args: []interface{}{arg1}, Panicked: panicValue != nil})
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	stub.readMutex.Unlock()
+	if recorder != nil {
+		recorder.Record("ReaderStub", "Read", []interface{}{arg1})
+	}
+	if panicValue != nil {
+		panic(panicValue)
+	}
+	stub.readMutex.Lock()
+	stubFunc := stub.ReadStub
+	returns := stub.readReturns
+	configured := stub.readReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.readReturnsOnCall[callIndex]
+	stub.readMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		if !configured {
+			stub.reportUnconfiguredCall("Read", arg1)
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *ReaderStub) ReadCallCount() int {
+	stub.readMutex.RLock()
+	defer stub.readMutex.RUnlock()
+	return len(stub.readArgsForCall)
+}
+func (stub *ReaderStub) ReadCalls() []ReaderStubReadArgs {
+	stub.readMutex.RLock()
+	defer stub.readMutex.RUnlock()
+	calls := make([]ReaderStubReadArgs, len(stub.readArgsForCall))
+	copy(calls, stub.readArgsForCall)
+	return calls
+}
+func (stub *ReaderStub) ReadPanics(value interface{}) {
+	stub.readMutex.Lock()
+	defer stub.readMutex.Unlock()
+	stub.readPanic = value
+}
+func (stub *ReaderStub) ReadPanicsOnCall(index int, value interface{}) {
+	stub.readMutex.Lock()
+	defer stub.readMutex.Unlock()
+	if stub.readPanicOnCall == nil {
+		stub.readPanicOnCall = make(map[int]interface{})
+	}
+	stub.readPanicOnCall[index] = value
+}
+func (stub *ReaderStub) readCallHistory() [][]interface{} {
+	stub.readMutex.RLock()
+	defer stub.readMutex.RUnlock()
+	history := make([][]interface{}, len(stub.readArgsForCall))
+	for i := range stub.readArgsForCall {
+		history[i] = []interface{}{stub.readArgsForCall[i].Arg1}
+	}
+	return history
+}
+func (stub *ReaderStub) ExpectRead() *ReaderStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to ReaderStub.Read: the stub was not created with NewReaderStubT")
+	}
+	expectation := &ReaderStubExpectation{method: "Read", min: 1, max: -1, callCount: stub.ReadCallCount, callHistory: stub.readCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func (stub *ReaderStub) ReadArgsForCall(index int) []byte {
+	stub.readMutex.RLock()
+	defer stub.readMutex.RUnlock()
+	return stub.readArgsForCall[index].Arg1
+}
+func (stub *ReaderStub) ReadReturns(result1 int, result2 error) {
+	stub.readMutex.Lock()
+	defer stub.readMutex.Unlock()
+	stub.readReturns = ReaderStubReadResults{result1, result2}
+	stub.readReturnsConfigured = true
+}
+func (stub *ReaderStub) ReadReturnsOnCall(index int, result1 int, result2 error) {
+	stub.readMutex.Lock()
+	defer stub.readMutex.Unlock()
+	if stub.readReturnsOnCall == nil {
+		stub.readReturnsOnCall = make(map[int]ReaderStubReadResults)
+	}
+	stub.readReturnsOnCall[index] = ReaderStubReadResults{result1, result2}
+}
+func (stub *ReaderStub) decodeReadScenario(values []json.RawMessage) (ReaderStubReadResults, error) {
+	var results ReaderStubReadResults
+	if len(values) != 2 {
+		return results, fmt.Errorf("cannot configure ReaderStub.Read from scenario: expected 2 results, got %d", len(values))
+	}
+	err := json.Unmarshal(values[0], &results.Result1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure ReaderStub.Read from scenario: cannot decode result 1: %v", err)
+	}
+	var message2 *string
+	err = json.Unmarshal(values[1], &message2)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure ReaderStub.Read from scenario: cannot decode result 2: %v", err)
+	}
+	if message2 != nil {
+		results.Result2 = errors.New(*message2)
+	}
+	return results, nil
+}
+func ReaderStubWithReadStub(fn func(arg1 []byte) (result1 int, result2 error)) ReaderStubOption {
+	return func(stub *ReaderStub) {
+		stub.ReadStub = fn
+	}
+}
+func ReaderStubWithReadReturns(result1 int, result2 error) ReaderStubOption {
+	return func(stub *ReaderStub) {
+		stub.ReadReturns(result1, result2)
+	}
+}
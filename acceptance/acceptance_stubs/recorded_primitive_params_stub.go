@@ -0,0 +1,71 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type RecordedPrimitiveParamsStub struct {
+	StubGUID int
+	mutex    sync.RWMutex
+	recorder interface {
+		Record(stub string, method string, args []interface{})
+	}
+	SaveStub        func(arg1 int, arg2 string, arg3 float32)
+	saveMutex       sync.RWMutex
+	saveArgsForCall []RecordedPrimitiveParamsStubSaveArgs
+}
+
+func (stub *RecordedPrimitiveParamsStub) AttachRecorder(recorder interface {
+	Record(stub string, method string, args []interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.recorder = recorder
+}
+
+var _ alias1.PrimitiveParams = new(RecordedPrimitiveParamsStub)
+
+type RecordedPrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
+func (stub *RecordedPrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
+	stub.saveMutex.Lock()
+	stub.saveArgsForCall = append(stub.saveArgsForCall, RecordedPrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	stub.mutex.Lock()
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	stub.saveMutex.Unlock()
+	if recorder != nil {
+		recorder.Record("RecordedPrimitiveParamsStub", "Save", []interface{}{arg1, arg2, arg3})
+	}
+	stub.saveMutex.Lock()
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
+	}
+}
+func (stub *RecordedPrimitiveParamsStub) SaveCallCount() int {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return len(stub.saveArgsForCall)
+}
+func (stub *RecordedPrimitiveParamsStub) SaveCalls() []RecordedPrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]RecordedPrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *RecordedPrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
+}
@@ -0,0 +1,151 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	bytes "bytes"
+	json "encoding/json"
+	fmt "fmt"
+	ioutil "io/ioutil"
+	http "net/http"
+	strings "strings"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type RemoteServiceStubRemoteHandler struct {
+	stub *RemoteServiceStub
+}
+
+var _ http.Handler = new(RemoteServiceStubRemoteHandler)
+
+func NewRemoteServiceStubRemoteHandler(target *RemoteServiceStub) *RemoteServiceStubRemoteHandler {
+	return &RemoteServiceStubRemoteHandler{stub: target}
+}
+func (handler *RemoteServiceStubRemoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/scenario":
+		if r.Method != "POST" {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := handler.stub.LoadScenario(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	case "/calls/Fetch":
+		handler.respond(w, handler.stub.FetchCalls())
+	case "/calls/Publish":
+		handler.respond(w, handler.stub.PublishCalls())
+	case "/calls/Close":
+		handler.respond(w, handler.stub.CloseCalls())
+	default:
+		http.NotFound(w, r)
+	}
+}
+func (handler *RemoteServiceStubRemoteHandler) respond(w http.ResponseWriter, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+type RemoteServiceStubRemoteClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewRemoteServiceStubRemoteClient(url string, httpClient *http.Client) *RemoteServiceStubRemoteClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &RemoteServiceStubRemoteClient{url: url, httpClient: httpClient}
+}
+func (client *RemoteServiceStubRemoteClient) configure(scenario interface{}) error {
+	data, err := json.Marshal(scenario)
+	if err != nil {
+		return err
+	}
+	response, err := client.httpClient.Post(client.url+"/scenario", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	return client.check(response)
+}
+func (client *RemoteServiceStubRemoteClient) fetch(path string, value interface{}) error {
+	response, err := client.httpClient.Get(client.url + path)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	err = client.check(response)
+	if err != nil {
+		return err
+	}
+	return json.NewDecoder(response.Body).Decode(value)
+}
+func (client *RemoteServiceStubRemoteClient) check(response *http.Response) error {
+	if response.StatusCode == http.StatusOK {
+		return nil
+	}
+	message, _ := ioutil.ReadAll(response.Body)
+	return fmt.Errorf("remote RemoteServiceStub responded with %s: %s", response.Status, strings.TrimSpace(string(message)))
+}
+func (client *RemoteServiceStubRemoteClient) FetchReturns(result1 alias1.Customer, result2 error) error {
+	values := []interface{}{result1, result2}
+	if result2 != nil {
+		values[1] = result2.Error()
+	}
+	return client.configure(map[string]interface{}{"Fetch": map[string]interface{}{"returns": values}})
+}
+func (client *RemoteServiceStubRemoteClient) FetchReturnsOnCall(index int, result1 alias1.Customer, result2 error) error {
+	values := []interface{}{result1, result2}
+	if result2 != nil {
+		values[1] = result2.Error()
+	}
+	return client.configure(map[string]interface{}{"Fetch": map[string]interface{}{"onCall": map[int]interface{}{index: values}}})
+}
+func (client *RemoteServiceStubRemoteClient) FetchCalls() ([]RemoteServiceStubFetchArgs, error) {
+	var calls []RemoteServiceStubFetchArgs
+	err := client.fetch("/calls/Fetch", &calls)
+	return calls, err
+}
+func (client *RemoteServiceStubRemoteClient) FetchCallCount() (int, error) {
+	calls, err := client.FetchCalls()
+	return len(calls), err
+}
+func (client *RemoteServiceStubRemoteClient) PublishReturns(result1 error) error {
+	values := []interface{}{result1}
+	if result1 != nil {
+		values[0] = result1.Error()
+	}
+	return client.configure(map[string]interface{}{"Publish": map[string]interface{}{"returns": values}})
+}
+func (client *RemoteServiceStubRemoteClient) PublishReturnsOnCall(index int, result1 error) error {
+	values := []interface{}{result1}
+	if result1 != nil {
+		values[0] = result1.Error()
+	}
+	return client.configure(map[string]interface{}{"Publish": map[string]interface{}{"onCall": map[int]interface{}{index: values}}})
+}
+func (client *RemoteServiceStubRemoteClient) PublishCalls() ([]RemoteServiceStubPublishArgs, error) {
+	var calls []RemoteServiceStubPublishArgs
+	err := client.fetch("/calls/Publish", &calls)
+	return calls, err
+}
+func (client *RemoteServiceStubRemoteClient) PublishCallCount() (int, error) {
+	calls, err := client.PublishCalls()
+	return len(calls), err
+}
+func (client *RemoteServiceStubRemoteClient) CloseCalls() ([]RemoteServiceStubCloseArgs, error) {
+	var calls []RemoteServiceStubCloseArgs
+	err := client.fetch("/calls/Close", &calls)
+	return calls, err
+}
+func (client *RemoteServiceStubRemoteClient) CloseCallCount() (int, error) {
+	calls, err := client.CloseCalls()
+	return len(calls), err
+}
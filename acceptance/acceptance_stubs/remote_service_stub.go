@@ -0,0 +1,334 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	sort "sort"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type RemoteServiceStub struct {
+	StubGUID             int
+	FetchStub            func(arg1 string) (result1 alias1.Customer, result2 error)
+	fetchMutex           sync.RWMutex
+	fetchArgsForCall     []RemoteServiceStubFetchArgs
+	fetchReturns         RemoteServiceStubFetchResults
+	fetchReturnsOnCall   map[int]RemoteServiceStubFetchResults
+	PublishStub          func(arg1 string, arg2 ...string) (result1 error)
+	publishMutex         sync.RWMutex
+	publishArgsForCall   []RemoteServiceStubPublishArgs
+	publishReturns       RemoteServiceStubPublishResults
+	publishReturnsOnCall map[int]RemoteServiceStubPublishResults
+	CloseStub            func()
+	closeMutex           sync.RWMutex
+	closeArgsForCall     []RemoteServiceStubCloseArgs
+}
+
+func (stub *RemoteServiceStub) LoadScenario(r io.Reader) error {
+	var scenario map[string]struct {
+		Returns []json.RawMessage
+		OnCall  map[int][]json.RawMessage
+	}
+	err := json.NewDecoder(r).Decode(&scenario)
+	if err != nil {
+		return fmt.Errorf("cannot decode scenario for RemoteServiceStub: %v", err)
+	}
+	methods := make([]string, 0, len(scenario))
+	for key := range scenario {
+		methods = append(methods, key)
+	}
+	sort.Strings(methods)
+	var configure []func()
+	for _, method := range methods {
+		methodScenario := scenario[method]
+		switch method {
+		case "Fetch":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeFetchScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.FetchReturns(results.Result1, results.Result2)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeFetchScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.FetchReturnsOnCall(index, results.Result1, results.Result2)
+				})
+			}
+		case "Publish":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodePublishScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.PublishReturns(results.Result1)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodePublishScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.PublishReturnsOnCall(index, results.Result1)
+				})
+			}
+		case "Close":
+			return fmt.Errorf("cannot configure RemoteServiceStub.%s from scenario: method has no results", "Close")
+		default:
+			return fmt.Errorf("cannot configure RemoteServiceStub.%s from scenario: unknown method", method)
+		}
+	}
+	for _, apply := range configure {
+		apply()
+	}
+	return nil
+}
+
+var _ alias1.RemoteService = new(RemoteServiceStub)
+
+type RemoteServiceStubFetchArgs struct {
+	Arg1 string
+}
+type RemoteServiceStubFetchResults struct {
+	Result1 alias1.Customer
+	Result2 error
+}
+
+func (value *RemoteServiceStubFetchResults) MarshalJSON() ([]byte, error) {
+	var aux struct {
+		Result1 alias1.Customer
+		Result2 *string
+	}
+	aux.Result1 = value.Result1
+	if value.Result2 != nil {
+		message := value.Result2.Error()
+		aux.Result2 = &message
+	}
+	return json.Marshal(aux)
+}
+func (value *RemoteServiceStubFetchResults) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Result1 alias1.Customer
+		Result2 *string
+	}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	value.Result1 = aux.Result1
+	if aux.Result2 != nil {
+		value.Result2 = errors.New(*aux.Result2)
+	}
+	return nil
+}
+func (stub *RemoteServiceStub) Fetch(arg1 string) (alias1.Customer, error) {
+	stub.fetchMutex.Lock()
+	stub.fetchArgsForCall = append(stub.fetchArgsForCall, RemoteServiceStubFetchArgs{arg1})
+	callIndex := len(stub.fetchArgsForCall) - 1
+	stubFunc := stub.FetchStub
+	returns := stub.fetchReturns
+	returnsOnCall, hasReturnsOnCall := stub.fetchReturnsOnCall[callIndex]
+	stub.fetchMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *RemoteServiceStub) FetchCallCount() int {
+	stub.fetchMutex.RLock()
+	defer stub.fetchMutex.RUnlock()
+	return len(stub.fetchArgsForCall)
+}
+func (stub *RemoteServiceStub) FetchCalls() []RemoteServiceStubFetchArgs {
+	stub.fetchMutex.RLock()
+	defer stub.fetchMutex.RUnlock()
+	calls := make([]RemoteServiceStubFetchArgs, len(stub.fetchArgsForCall))
+	copy(calls, stub.fetchArgsForCall)
+	return calls
+}
+func (stub *RemoteServiceStub) FetchArgsForCall(index int) string {
+	stub.fetchMutex.RLock()
+	defer stub.fetchMutex.RUnlock()
+	return stub.fetchArgsForCall[index].Arg1
+}
+func (stub *RemoteServiceStub) FetchReturns(result1 alias1.Customer, result2 error) {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	stub.fetchReturns = RemoteServiceStubFetchResults{result1, result2}
+}
+func (stub *RemoteServiceStub) FetchReturnsOnCall(index int, result1 alias1.Customer, result2 error) {
+	stub.fetchMutex.Lock()
+	defer stub.fetchMutex.Unlock()
+	if stub.fetchReturnsOnCall == nil {
+		stub.fetchReturnsOnCall = make(map[int]RemoteServiceStubFetchResults)
+	}
+	stub.fetchReturnsOnCall[index] = RemoteServiceStubFetchResults{result1, result2}
+}
+func (stub *RemoteServiceStub) decodeFetchScenario(values []json.RawMessage) (RemoteServiceStubFetchResults, error) {
+	var results RemoteServiceStubFetchResults
+	if len(values) != 2 {
+		return results, fmt.Errorf("cannot configure RemoteServiceStub.Fetch from scenario: expected 2 results, got %d", len(values))
+	}
+	err := json.Unmarshal(values[0], &results.Result1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure RemoteServiceStub.Fetch from scenario: cannot decode result 1: %v", err)
+	}
+	var message2 *string
+	err = json.Unmarshal(values[1], &message2)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure RemoteServiceStub.Fetch from scenario: cannot decode result 2: %v", err)
+	}
+	if message2 != nil {
+		results.Result2 = errors.New(*message2)
+	}
+	return results, nil
+}
+
+type RemoteServiceStubPublishArgs struct {
+	Arg1 string
+	Arg2 []string
+}
+type RemoteServiceStubPublishResults struct {
+	Result1 error
+}
+
+func (value *RemoteServiceStubPublishResults) MarshalJSON() ([]byte, error) {
+	var aux struct {
+		Result1 *string
+	}
+	if value.Result1 != nil {
+		message := value.Result1.Error()
+		aux.Result1 = &message
+	}
+	return json.Marshal(aux)
+}
+func (value *RemoteServiceStubPublishResults) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Result1 *string
+	}
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+	if aux.Result1 != nil {
+		value.Result1 = errors.New(*aux.Result1)
+	}
+	return nil
+}
+func (stub *RemoteServiceStub) Publish(arg1 string, arg2 ...string) error {
+	stub.publishMutex.Lock()
+	stub.publishArgsForCall = append(stub.publishArgsForCall, RemoteServiceStubPublishArgs{arg1, arg2})
+	callIndex := len(stub.publishArgsForCall) - 1
+	stubFunc := stub.PublishStub
+	returns := stub.publishReturns
+	returnsOnCall, hasReturnsOnCall := stub.publishReturnsOnCall[callIndex]
+	stub.publishMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1, arg2...)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
+	}
+}
+func (stub *RemoteServiceStub) PublishCallCount() int {
+	stub.publishMutex.RLock()
+	defer stub.publishMutex.RUnlock()
+	return len(stub.publishArgsForCall)
+}
+func (stub *RemoteServiceStub) PublishCalls() []RemoteServiceStubPublishArgs {
+	stub.publishMutex.RLock()
+	defer stub.publishMutex.RUnlock()
+	calls := make([]RemoteServiceStubPublishArgs, len(stub.publishArgsForCall))
+	copy(calls, stub.publishArgsForCall)
+	return calls
+}
+func (stub *RemoteServiceStub) PublishArgsForCall(index int) (string, []string) {
+	stub.publishMutex.RLock()
+	defer stub.publishMutex.RUnlock()
+	return stub.publishArgsForCall[index].Arg1, stub.publishArgsForCall[index].Arg2
+}
+func (stub *RemoteServiceStub) PublishReturns(result1 error) {
+	stub.publishMutex.Lock()
+	defer stub.publishMutex.Unlock()
+	stub.publishReturns = RemoteServiceStubPublishResults{result1}
+}
+func (stub *RemoteServiceStub) PublishReturnsOnCall(index int, result1 error) {
+	stub.publishMutex.Lock()
+	defer stub.publishMutex.Unlock()
+	if stub.publishReturnsOnCall == nil {
+		stub.publishReturnsOnCall = make(map[int]RemoteServiceStubPublishResults)
+	}
+	stub.publishReturnsOnCall[index] = RemoteServiceStubPublishResults{result1}
+}
+func (stub *RemoteServiceStub) decodePublishScenario(values []json.RawMessage) (RemoteServiceStubPublishResults, error) {
+	var results RemoteServiceStubPublishResults
+	if len(values) != 1 {
+		return results, fmt.Errorf("cannot configure RemoteServiceStub.Publish from scenario: expected 1 results, got %d", len(values))
+	}
+	var message1 *string
+	err := json.Unmarshal(values[0], &message1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure RemoteServiceStub.Publish from scenario: cannot decode result 1: %v", err)
+	}
+	if message1 != nil {
+		results.Result1 = errors.New(*message1)
+	}
+	return results, nil
+}
+
+type RemoteServiceStubCloseArgs struct {
+}
+
+func (stub *RemoteServiceStub) Close() {
+	stub.closeMutex.Lock()
+	stub.closeArgsForCall = append(stub.closeArgsForCall, RemoteServiceStubCloseArgs{})
+	stubFunc := stub.CloseStub
+	stub.closeMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc()
+	}
+}
+func (stub *RemoteServiceStub) CloseCallCount() int {
+	stub.closeMutex.RLock()
+	defer stub.closeMutex.RUnlock()
+	return len(stub.closeArgsForCall)
+}
+func (stub *RemoteServiceStub) CloseCalls() []RemoteServiceStubCloseArgs {
+	stub.closeMutex.RLock()
+	defer stub.closeMutex.RUnlock()
+	calls := make([]RemoteServiceStubCloseArgs, len(stub.closeArgsForCall))
+	copy(calls, stub.closeArgsForCall)
+	return calls
+}
@@ -12,23 +12,23 @@ type ReusedParamsStub struct {
 	StubGUID          int
 	ConcatStub        func(arg1 string, arg2 string)
 	concatMutex       sync.RWMutex
-	concatArgsForCall []struct {
-		arg1 string
-		arg2 string
-	}
+	concatArgsForCall []ReusedParamsStubConcatArgs
 }
 
 var _ alias1.ReusedParams = new(ReusedParamsStub)
 
+type ReusedParamsStubConcatArgs struct {
+	Arg1 string
+	Arg2 string
+}
+
 func (stub *ReusedParamsStub) Concat(arg1 string, arg2 string) {
 	stub.concatMutex.Lock()
-	defer stub.concatMutex.Unlock()
-	stub.concatArgsForCall = append(stub.concatArgsForCall, struct {
-		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	if stub.ConcatStub != nil {
-		stub.ConcatStub(arg1, arg2)
+	stub.concatArgsForCall = append(stub.concatArgsForCall, ReusedParamsStubConcatArgs{arg1, arg2})
+	stubFunc := stub.ConcatStub
+	stub.concatMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2)
 	}
 }
 func (stub *ReusedParamsStub) ConcatCallCount() int {
@@ -36,8 +36,15 @@ func (stub *ReusedParamsStub) ConcatCallCount() int {
 	defer stub.concatMutex.RUnlock()
 	return len(stub.concatArgsForCall)
 }
+func (stub *ReusedParamsStub) ConcatCalls() []ReusedParamsStubConcatArgs {
+	stub.concatMutex.RLock()
+	defer stub.concatMutex.RUnlock()
+	calls := make([]ReusedParamsStubConcatArgs, len(stub.concatArgsForCall))
+	copy(calls, stub.concatArgsForCall)
+	return calls
+}
 func (stub *ReusedParamsStub) ConcatArgsForCall(index int) (string, string) {
 	stub.concatMutex.RLock()
 	defer stub.concatMutex.RUnlock()
-	return stub.concatArgsForCall[index].arg1, stub.concatArgsForCall[index].arg2
+	return stub.concatArgsForCall[index].Arg1, stub.concatArgsForCall[index].Arg2
 }
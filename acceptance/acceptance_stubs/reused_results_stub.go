@@ -9,28 +9,38 @@ import (
 )
 
 type ReusedResultsStub struct {
-	StubGUID            int
-	FullNameStub        func() (result1 string, result2 string)
-	fullNameMutex       sync.RWMutex
-	fullNameArgsForCall []struct {
-	}
-	fullNameReturns struct {
-		result1 string
-		result2 string
-	}
+	StubGUID              int
+	FullNameStub          func() (result1 string, result2 string)
+	fullNameMutex         sync.RWMutex
+	fullNameArgsForCall   []ReusedResultsStubFullNameArgs
+	fullNameReturns       ReusedResultsStubFullNameResults
+	fullNameReturnsOnCall map[int]ReusedResultsStubFullNameResults
 }
 
 var _ alias1.ReusedResults = new(ReusedResultsStub)
 
+type ReusedResultsStubFullNameArgs struct {
+}
+type ReusedResultsStubFullNameResults struct {
+	Result1 string
+	Result2 string
+}
+
 func (stub *ReusedResultsStub) FullName() (string, string) {
 	stub.fullNameMutex.Lock()
-	defer stub.fullNameMutex.Unlock()
-	stub.fullNameArgsForCall = append(stub.fullNameArgsForCall, struct {
-	}{})
-	if stub.FullNameStub != nil {
-		return stub.FullNameStub()
+	stub.fullNameArgsForCall = append(stub.fullNameArgsForCall, ReusedResultsStubFullNameArgs{})
+	callIndex := len(stub.fullNameArgsForCall) - 1
+	stubFunc := stub.FullNameStub
+	returns := stub.fullNameReturns
+	returnsOnCall, hasReturnsOnCall := stub.fullNameReturnsOnCall[callIndex]
+	stub.fullNameMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
 	} else {
-		return stub.fullNameReturns.result1, stub.fullNameReturns.result2
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		return returns.Result1, returns.Result2
 	}
 }
 func (stub *ReusedResultsStub) FullNameCallCount() int {
@@ -38,11 +48,23 @@ func (stub *ReusedResultsStub) FullNameCallCount() int {
 	defer stub.fullNameMutex.RUnlock()
 	return len(stub.fullNameArgsForCall)
 }
+func (stub *ReusedResultsStub) FullNameCalls() []ReusedResultsStubFullNameArgs {
+	stub.fullNameMutex.RLock()
+	defer stub.fullNameMutex.RUnlock()
+	calls := make([]ReusedResultsStubFullNameArgs, len(stub.fullNameArgsForCall))
+	copy(calls, stub.fullNameArgsForCall)
+	return calls
+}
 func (stub *ReusedResultsStub) FullNameReturns(result1 string, result2 string) {
 	stub.fullNameMutex.Lock()
 	defer stub.fullNameMutex.Unlock()
-	stub.fullNameReturns = struct {
-		result1 string
-		result2 string
-	}{result1, result2}
+	stub.fullNameReturns = ReusedResultsStubFullNameResults{result1, result2}
+}
+func (stub *ReusedResultsStub) FullNameReturnsOnCall(index int, result1 string, result2 string) {
+	stub.fullNameMutex.Lock()
+	defer stub.fullNameMutex.Unlock()
+	if stub.fullNameReturnsOnCall == nil {
+		stub.fullNameReturnsOnCall = make(map[int]ReusedResultsStubFullNameResults)
+	}
+	stub.fullNameReturnsOnCall[index] = ReusedResultsStubFullNameResults{result1, result2}
 }
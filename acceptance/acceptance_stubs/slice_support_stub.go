@@ -10,29 +10,38 @@ import (
 )
 
 type SliceSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 []alias2.Address) (result1 []alias2.Address)
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 []alias2.Address
-	}
-	methodReturns struct {
-		result1 []alias2.Address
-	}
+	StubGUID            int
+	MethodStub          func(arg1 []alias2.Address) (result1 []alias2.Address)
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []SliceSupportStubMethodArgs
+	methodReturns       SliceSupportStubMethodResults
+	methodReturnsOnCall map[int]SliceSupportStubMethodResults
 }
 
 var _ alias1.SliceSupport = new(SliceSupportStub)
 
+type SliceSupportStubMethodArgs struct {
+	Arg1 []alias2.Address
+}
+type SliceSupportStubMethodResults struct {
+	Result1 []alias2.Address
+}
+
 func (stub *SliceSupportStub) Method(arg1 []alias2.Address) []alias2.Address {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 []alias2.Address
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, SliceSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *SliceSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *SliceSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *SliceSupportStub) MethodCalls() []SliceSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]SliceSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *SliceSupportStub) MethodArgsForCall(index int) []alias2.Address {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *SliceSupportStub) MethodReturns(result1 []alias2.Address) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 []alias2.Address
-	}{result1}
+	stub.methodReturns = SliceSupportStubMethodResults{result1}
+}
+func (stub *SliceSupportStub) MethodReturnsOnCall(index int, result1 []alias2.Address) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]SliceSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = SliceSupportStubMethodResults{result1}
 }
@@ -0,0 +1,88 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	fmt "fmt"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type StrictPrimitiveParamsStub struct {
+	StubGUID       int
+	mutex          sync.RWMutex
+	strict         bool
+	strictReporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+	SaveStub        func(arg1 int, arg2 string, arg3 float32)
+	saveMutex       sync.RWMutex
+	saveArgsForCall []StrictPrimitiveParamsStubSaveArgs
+}
+
+func (stub *StrictPrimitiveParamsStub) SetStrict(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.strict, stub.strictReporter = true, reporter
+}
+func (stub *StrictPrimitiveParamsStub) reportUnconfiguredCall(method string, args ...interface{}) {
+	stub.mutex.RLock()
+	strict, reporter := stub.strict, stub.strictReporter
+	stub.mutex.RUnlock()
+	if !strict {
+		return
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	message := fmt.Sprintf("unconfigured call to StrictPrimitiveParamsStub.%s(%s)", method, strings.Join(formattedArgs, ", "))
+	if reporter == nil {
+		panic(message)
+	}
+	reporter.Helper()
+	reporter.Errorf("%s", message)
+}
+
+var _ alias1.PrimitiveParams = new(StrictPrimitiveParamsStub)
+
+type StrictPrimitiveParamsStubSaveArgs struct {
+	Arg1 int
+	Arg2 string
+	Arg3 float32
+}
+
+func (stub *StrictPrimitiveParamsStub) Save(arg1 int, arg2 string, arg3 float32) {
+	stub.saveMutex.Lock()
+	stub.saveArgsForCall = append(stub.saveArgsForCall, StrictPrimitiveParamsStubSaveArgs{arg1, arg2, arg3})
+	stubFunc := stub.SaveStub
+	stub.saveMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2, arg3)
+	} else {
+		stub.reportUnconfiguredCall("Save", arg1, arg2, arg3)
+	}
+}
+func (stub *StrictPrimitiveParamsStub) SaveCallCount() int {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return len(stub.saveArgsForCall)
+}
+func (stub *StrictPrimitiveParamsStub) SaveCalls() []StrictPrimitiveParamsStubSaveArgs {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	calls := make([]StrictPrimitiveParamsStubSaveArgs, len(stub.saveArgsForCall))
+	copy(calls, stub.saveArgsForCall)
+	return calls
+}
+func (stub *StrictPrimitiveParamsStub) SaveArgsForCall(index int) (int, string, float32) {
+	stub.saveMutex.RLock()
+	defer stub.saveMutex.RUnlock()
+	return stub.saveArgsForCall[index].Arg1, stub.saveArgsForCall[index].Arg2, stub.saveArgsForCall[index].Arg3
+}
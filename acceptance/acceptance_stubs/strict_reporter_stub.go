@@ -0,0 +1,99 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type StrictReporterStub struct {
+	StubGUID          int
+	mutex             sync.RWMutex
+	invocations       []StrictReporterStubInvocation
+	HelperStub        func()
+	helperMutex       sync.RWMutex
+	helperArgsForCall []StrictReporterStubHelperArgs
+	ErrorfStub        func(arg1 string, arg2 ...interface{})
+	errorfMutex       sync.RWMutex
+	errorfArgsForCall []StrictReporterStubErrorfArgs
+}
+type StrictReporterStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+}
+
+func (stub *StrictReporterStub) Invocations() []StrictReporterStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]StrictReporterStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+
+var _ alias1.StrictReporter = new(StrictReporterStub)
+
+type StrictReporterStubHelperArgs struct {
+}
+
+func (stub *StrictReporterStub) Helper() {
+	stub.helperMutex.Lock()
+	stub.helperArgsForCall = append(stub.helperArgsForCall, StrictReporterStubHelperArgs{})
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, StrictReporterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Helper", Args: []interface{}{}})
+	stub.mutex.Unlock()
+	stubFunc := stub.HelperStub
+	stub.helperMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc()
+	}
+}
+func (stub *StrictReporterStub) HelperCallCount() int {
+	stub.helperMutex.RLock()
+	defer stub.helperMutex.RUnlock()
+	return len(stub.helperArgsForCall)
+}
+func (stub *StrictReporterStub) HelperCalls() []StrictReporterStubHelperArgs {
+	stub.helperMutex.RLock()
+	defer stub.helperMutex.RUnlock()
+	calls := make([]StrictReporterStubHelperArgs, len(stub.helperArgsForCall))
+	copy(calls, stub.helperArgsForCall)
+	return calls
+}
+
+type StrictReporterStubErrorfArgs struct {
+	Arg1 string
+	Arg2 []interface{}
+}
+
+func (stub *StrictReporterStub) Errorf(arg1 string, arg2 ...interface{}) {
+	stub.errorfMutex.Lock()
+	stub.errorfArgsForCall = append(stub.errorfArgsForCall, StrictReporterStubErrorfArgs{arg1, arg2})
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, StrictReporterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Errorf", Args: []interface{}{arg1, arg2}})
+	stub.mutex.Unlock()
+	stubFunc := stub.ErrorfStub
+	stub.errorfMutex.Unlock()
+	if stubFunc != nil {
+		stubFunc(arg1, arg2...)
+	}
+}
+func (stub *StrictReporterStub) ErrorfCallCount() int {
+	stub.errorfMutex.RLock()
+	defer stub.errorfMutex.RUnlock()
+	return len(stub.errorfArgsForCall)
+}
+func (stub *StrictReporterStub) ErrorfCalls() []StrictReporterStubErrorfArgs {
+	stub.errorfMutex.RLock()
+	defer stub.errorfMutex.RUnlock()
+	calls := make([]StrictReporterStubErrorfArgs, len(stub.errorfArgsForCall))
+	copy(calls, stub.errorfArgsForCall)
+	return calls
+}
+func (stub *StrictReporterStub) ErrorfArgsForCall(index int) (string, []interface{}) {
+	stub.errorfMutex.RLock()
+	defer stub.errorfMutex.RUnlock()
+	return stub.errorfArgsForCall[index].Arg1, stub.errorfArgsForCall[index].Arg2
+}
@@ -10,29 +10,38 @@ import (
 )
 
 type StructSupportStub struct {
-	StubGUID          int
-	MethodStub        func(arg1 struct{ Input alias2.Address }) (result1 struct{ Output alias2.Address })
-	methodMutex       sync.RWMutex
-	methodArgsForCall []struct {
-		arg1 struct{ Input alias2.Address }
-	}
-	methodReturns struct {
-		result1 struct{ Output alias2.Address }
-	}
+	StubGUID            int
+	MethodStub          func(arg1 struct{ Input alias2.Address }) (result1 struct{ Output alias2.Address })
+	methodMutex         sync.RWMutex
+	methodArgsForCall   []StructSupportStubMethodArgs
+	methodReturns       StructSupportStubMethodResults
+	methodReturnsOnCall map[int]StructSupportStubMethodResults
 }
 
 var _ alias1.StructSupport = new(StructSupportStub)
 
+type StructSupportStubMethodArgs struct {
+	Arg1 struct{ Input alias2.Address }
+}
+type StructSupportStubMethodResults struct {
+	Result1 struct{ Output alias2.Address }
+}
+
 func (stub *StructSupportStub) Method(arg1 struct{ Input alias2.Address }) struct{ Output alias2.Address } {
 	stub.methodMutex.Lock()
-	defer stub.methodMutex.Unlock()
-	stub.methodArgsForCall = append(stub.methodArgsForCall, struct {
-		arg1 struct{ Input alias2.Address }
-	}{arg1})
-	if stub.MethodStub != nil {
-		return stub.MethodStub(arg1)
+	stub.methodArgsForCall = append(stub.methodArgsForCall, StructSupportStubMethodArgs{arg1})
+	callIndex := len(stub.methodArgsForCall) - 1
+	stubFunc := stub.MethodStub
+	returns := stub.methodReturns
+	returnsOnCall, hasReturnsOnCall := stub.methodReturnsOnCall[callIndex]
+	stub.methodMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
 	} else {
-		return stub.methodReturns.result1
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		return returns.Result1
 	}
 }
 func (stub *StructSupportStub) MethodCallCount() int {
@@ -40,15 +49,28 @@ func (stub *StructSupportStub) MethodCallCount() int {
 	defer stub.methodMutex.RUnlock()
 	return len(stub.methodArgsForCall)
 }
+func (stub *StructSupportStub) MethodCalls() []StructSupportStubMethodArgs {
+	stub.methodMutex.RLock()
+	defer stub.methodMutex.RUnlock()
+	calls := make([]StructSupportStubMethodArgs, len(stub.methodArgsForCall))
+	copy(calls, stub.methodArgsForCall)
+	return calls
+}
 func (stub *StructSupportStub) MethodArgsForCall(index int) struct{ Input alias2.Address } {
 	stub.methodMutex.RLock()
 	defer stub.methodMutex.RUnlock()
-	return stub.methodArgsForCall[index].arg1
+	return stub.methodArgsForCall[index].Arg1
 }
 func (stub *StructSupportStub) MethodReturns(result1 struct{ Output alias2.Address }) {
 	stub.methodMutex.Lock()
 	defer stub.methodMutex.Unlock()
-	stub.methodReturns = struct {
-		result1 struct{ Output alias2.Address }
-	}{result1}
+	stub.methodReturns = StructSupportStubMethodResults{result1}
+}
+func (stub *StructSupportStub) MethodReturnsOnCall(index int, result1 struct{ Output alias2.Address }) {
+	stub.methodMutex.Lock()
+	defer stub.methodMutex.Unlock()
+	if stub.methodReturnsOnCall == nil {
+		stub.methodReturnsOnCall = make(map[int]StructSupportStubMethodResults)
+	}
+	stub.methodReturnsOnCall[index] = StructSupportStubMethodResults{result1}
 }
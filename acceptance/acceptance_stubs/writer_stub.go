@@ -0,0 +1,516 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptance_stubs
+
+import (
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	sort "sort"
+	strings "strings"
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type WriterStub struct {
+	StubGUID       int
+	mutex          sync.RWMutex
+	invocations    []WriterStubInvocation
+	strict         bool
+	strictReporter interface {
+		Helper()
+		Errorf(format string, args ...interface{})
+	}
+	recorder interface {
+		Record(stub string, method string, args []interface{})
+	}
+	expectations           []*WriterStubExpectation
+	verifiesExpectations   bool
+	WriteStub              func(arg1 []byte) (result1 int, result2 error)
+	writeMutex             sync.RWMutex
+	writeArgsForCall       []WriterStubWriteArgs
+	writePanic             interface{}
+	writePanicOnCall       map[int]interface{}
+	writeReturns           WriterStubWriteResults
+	writeReturnsOnCall     map[int]WriterStubWriteResults
+	writeReturnsConfigured bool
+	FlushStub              func() (result1 error)
+	flushMutex             sync.RWMutex
+	flushArgsForCall       []WriterStubFlushArgs
+	flushPanic             interface{}
+	flushPanicOnCall       map[int]interface{}
+	flushReturns           WriterStubFlushResults
+	flushReturnsOnCall     map[int]WriterStubFlushResults
+	flushReturnsConfigured bool
+}
+type WriterStubInvocation struct {
+	Sequence int
+	Method   string
+	Args     []interface{}
+	Panicked bool
+}
+
+func (stub *WriterStub) Invocations() []WriterStubInvocation {
+	stub.mutex.RLock()
+	defer stub.mutex.RUnlock()
+	calls := make([]WriterStubInvocation, len(stub.invocations))
+	copy(calls, stub.invocations)
+	return calls
+}
+func (stub *WriterStub) SetStrict(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.strict, stub.strictReporter = true, reporter
+}
+func (stub *WriterStub) reportUnconfiguredCall(method string, args ...interface{}) {
+	stub.mutex.RLock()
+	strict, reporter := stub.strict, stub.strictReporter
+	stub.mutex.RUnlock()
+	if !strict {
+		return
+	}
+	formattedArgs := make([]string, len(args))
+	for i, arg := range args {
+		formattedArgs[i] = fmt.Sprintf("%#v", arg)
+	}
+	message := fmt.Sprintf("unconfigured call to WriterStub.%s(%s)", method, strings.Join(formattedArgs, ", "))
+	if reporter == nil {
+		panic(message)
+	}
+	reporter.Helper()
+	reporter.Errorf("%s", message)
+}
+func (stub *WriterStub) AttachRecorder(recorder interface {
+	Record(stub string, method string, args []interface{})
+}) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.recorder = recorder
+}
+func (stub *WriterStub) LoadScenario(r io.Reader) error {
+	var scenario map[string]struct {
+		Returns []json.RawMessage
+		OnCall  map[int][]json.RawMessage
+	}
+	err := json.NewDecoder(r).Decode(&scenario)
+	if err != nil {
+		return fmt.Errorf("cannot decode scenario for WriterStub: %v", err)
+	}
+	methods := make([]string, 0, len(scenario))
+	for key := range scenario {
+		methods = append(methods, key)
+	}
+	sort.Strings(methods)
+	var configure []func()
+	for _, method := range methods {
+		methodScenario := scenario[method]
+		switch method {
+		case "Write":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeWriteScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.WriteReturns(results.Result1, results.Result2)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeWriteScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.WriteReturnsOnCall(index, results.Result1, results.Result2)
+				})
+			}
+		case "Flush":
+			if methodScenario.Returns != nil {
+				results, err := stub.decodeFlushScenario(methodScenario.Returns)
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.FlushReturns(results.Result1)
+				})
+			}
+			indices := make([]int, 0, len(methodScenario.OnCall))
+			for key := range methodScenario.OnCall {
+				indices = append(indices, key)
+			}
+			sort.Ints(indices)
+			for _, index := range indices {
+				index := index
+				results, err := stub.decodeFlushScenario(methodScenario.OnCall[index])
+				if err != nil {
+					return err
+				}
+				configure = append(configure, func() {
+					stub.FlushReturnsOnCall(index, results.Result1)
+				})
+			}
+		default:
+			return fmt.Errorf("cannot configure WriterStub.%s from scenario: unknown method", method)
+		}
+	}
+	for _, apply := range configure {
+		apply()
+	}
+	return nil
+}
+
+type WriterStubOption func(stub *WriterStub)
+
+func NewWriterStub(opts ...WriterStubOption) *WriterStub {
+	stub := new(WriterStub)
+	for _, opt := range opts {
+		opt(stub)
+	}
+	return stub
+}
+
+type WriterStubExpectation struct {
+	method      string
+	min         int
+	max         int
+	callCount   func() int
+	callHistory func() [][]interface{}
+}
+
+func (expectation *WriterStubExpectation) Times(count int) *WriterStubExpectation {
+	expectation.min, expectation.max = count, count
+	return expectation
+}
+func (expectation *WriterStubExpectation) AtLeast(count int) *WriterStubExpectation {
+	expectation.min, expectation.max = count, -1
+	return expectation
+}
+func (expectation *WriterStubExpectation) Never() *WriterStubExpectation {
+	expectation.min, expectation.max = 0, 0
+	return expectation
+}
+func (expectation *WriterStubExpectation) verify(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	count := expectation.callCount()
+	if count >= expectation.min && (expectation.max < 0 || count <= expectation.max) {
+		return
+	}
+	expected := fmt.Sprintf("at least %d", expectation.min)
+	if expectation.max >= 0 {
+		expected = fmt.Sprintf("exactly %d", expectation.max)
+	}
+	history := ""
+	for i, args := range expectation.callHistory() {
+		formattedArgs := make([]string, len(args))
+		for j, arg := range args {
+			formattedArgs[j] = fmt.Sprintf("%#v", arg)
+		}
+		history += fmt.Sprintf("\n\t#%d: %s(%s)", i+1, expectation.method, strings.Join(formattedArgs, ", "))
+	}
+	reporter.Helper()
+	reporter.Errorf("expected %s calls to WriterStub.%s, got %d%s", expected, expectation.method, count, history)
+}
+func (stub *WriterStub) verifyExpectations(reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) {
+	reporter.Helper()
+	stub.mutex.RLock()
+	expectations := stub.expectations
+	stub.mutex.RUnlock()
+	for _, expectation := range expectations {
+		expectation.verify(reporter)
+	}
+}
+func NewWriterStubT(t interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}, opts ...WriterStubOption) *WriterStub {
+	stub := NewWriterStub(opts...)
+	stub.verifiesExpectations = true
+	t.Cleanup(func() {
+		stub.verifyExpectations(t)
+	})
+	return stub
+}
+
+var _ alias1.Writer = new(WriterStub)
+
+type WriterStubWriteArgs struct {
+	Arg1 []byte
+}
+type WriterStubWriteResults struct {
+	Result1 int
+	Result2 error
+}
+
+func (stub *WriterStub) Write(arg1 []byte) (int, error) {
+	stub.writeMutex.Lock()
+	stub.writeArgsForCall = append(stub.writeArgsForCall, WriterStubWriteArgs{arg1})
+	callIndex := len(stub.writeArgsForCall) - 1
+	panicValue := stub.writePanic
+	if value, ok := stub.writePanicOnCall[callIndex]; ok {
+		panicValue = value
+	}
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, WriterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Write", Args: []interface{}{arg1}, Panicked: panicValue != nil})
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	stub.writeMutex.Unlock()
+	if recorder != nil {
+		recorder.Record("WriterStub", "Write", []interface{}{arg1})
+	}
+	if panicValue != nil {
+		panic(panicValue)
+	}
+	stub.writeMutex.Lock()
+	stubFunc := stub.WriteStub
+	returns := stub.writeReturns
+	configured := stub.writeReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.writeReturnsOnCall[callIndex]
+	stub.writeMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc(arg1)
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1, returnsOnCall.Result2
+		}
+		if !configured {
+			stub.reportUnconfiguredCall("Write", arg1)
+		}
+		return returns.Result1, returns.Result2
+	}
+}
+func (stub *WriterStub) WriteCallCount() int {
+	stub.writeMutex.RLock()
+	defer stub.writeMutex.RUnlock()
+	return len(stub.writeArgsForCall)
+}
+func (stub *WriterStub) WriteCalls() []WriterStubWriteArgs {
+	stub.writeMutex.RLock()
+	defer stub.writeMutex.RUnlock()
+	calls := make([]WriterStubWriteArgs, len(stub.writeArgsForCall))
+	copy(calls, stub.writeArgsForCall)
+	return calls
+}
+func (stub *WriterStub) WritePanics(value interface{}) {
+	stub.writeMutex.Lock()
+	defer stub.writeMutex.Unlock()
+	stub.writePanic = value
+}
+func (stub *WriterStub) WritePanicsOnCall(index int, value interface{}) {
+	stub.writeMutex.Lock()
+	defer stub.writeMutex.Unlock()
+	if stub.writePanicOnCall == nil {
+		stub.writePanicOnCall = make(map[int]interface{})
+	}
+	stub.writePanicOnCall[index] = value
+}
+func (stub *WriterStub) writeCallHistory() [][]interface{} {
+	stub.writeMutex.RLock()
+	defer stub.writeMutex.RUnlock()
+	history := make([][]interface{}, len(stub.writeArgsForCall))
+	for i := range stub.writeArgsForCall {
+		history[i] = []interface{}{stub.writeArgsForCall[i].Arg1}
+	}
+	return history
+}
+func (stub *WriterStub) ExpectWrite() *WriterStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to WriterStub.Write: the stub was not created with NewWriterStubT")
+	}
+	expectation := &WriterStubExpectation{method: "Write", min: 1, max: -1, callCount: stub.WriteCallCount, callHistory: stub.writeCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func (stub *WriterStub) WriteArgsForCall(index int) []byte {
+	stub.writeMutex.RLock()
+	defer stub.writeMutex.RUnlock()
+	return stub.writeArgsForCall[index].Arg1
+}
+func (stub *WriterStub) WriteReturns(result1 int, result2 error) {
+	stub.writeMutex.Lock()
+	defer stub.writeMutex.Unlock()
+	stub.writeReturns = WriterStubWriteResults{result1, result2}
+	stub.writeReturnsConfigured = true
+}
+func (stub *WriterStub) WriteReturnsOnCall(index int, result1 int, result2 error) {
+	stub.writeMutex.Lock()
+	defer stub.writeMutex.Unlock()
+	if stub.writeReturnsOnCall == nil {
+		stub.writeReturnsOnCall = make(map[int]WriterStubWriteResults)
+	}
+	stub.writeReturnsOnCall[index] = WriterStubWriteResults{result1, result2}
+}
+func (stub *WriterStub) decodeWriteScenario(values []json.RawMessage) (WriterStubWriteResults, error) {
+	var results WriterStubWriteResults
+	if len(values) != 2 {
+		return results, fmt.Errorf("cannot configure WriterStub.Write from scenario: expected 2 results, got %d", len(values))
+	}
+	err := json.Unmarshal(values[0], &results.Result1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure WriterStub.Write from scenario: cannot decode result 1: %v", err)
+	}
+	var message2 *string
+	err = json.Unmarshal(values[1], &message2)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure WriterStub.Write from scenario: cannot decode result 2: %v", err)
+	}
+	if message2 != nil {
+		results.Result2 = errors.New(*message2)
+	}
+	return results, nil
+}
+func WriterStubWithWriteStub(fn func(arg1 []byte) (result1 int, result2 error)) WriterStubOption {
+	return func(stub *WriterStub) {
+		stub.WriteStub = fn
+	}
+}
+func WriterStubWithWriteReturns(result1 int, result2 error) WriterStubOption {
+	return func(stub *WriterStub) {
+		stub.WriteReturns(result1, result2)
+	}
+}
+
+type WriterStubFlushArgs struct {
+}
+type WriterStubFlushResults struct {
+	Result1 error
+}
+
+func (stub *WriterStub) Flush() error {
+	stub.flushMutex.Lock()
+	stub.flushArgsForCall = append(stub.flushArgsForCall, WriterStubFlushArgs{})
+	callIndex := len(stub.flushArgsForCall) - 1
+	panicValue := stub.flushPanic
+	if value, ok := stub.flushPanicOnCall[callIndex]; ok {
+		panicValue = value
+	}
+	stub.mutex.Lock()
+	stub.invocations = append(stub.invocations, WriterStubInvocation{Sequence: len(stub.invocations) + 1, Method: "Flush", Args: []interface{}{}, Panicked: panicValue != nil})
+	recorder := stub.recorder
+	stub.mutex.Unlock()
+	stub.flushMutex.Unlock()
+	if recorder != nil {
+		recorder.Record("WriterStub", "Flush", []interface{}{})
+	}
+	if panicValue != nil {
+		panic(panicValue)
+	}
+	stub.flushMutex.Lock()
+	stubFunc := stub.FlushStub
+	returns := stub.flushReturns
+	configured := stub.flushReturnsConfigured
+	returnsOnCall, hasReturnsOnCall := stub.flushReturnsOnCall[callIndex]
+	stub.flushMutex.Unlock()
+	if stubFunc != nil {
+		return stubFunc()
+	} else {
+		if hasReturnsOnCall {
+			return returnsOnCall.Result1
+		}
+		if !configured {
+			stub.reportUnconfiguredCall("Flush")
+		}
+		return returns.Result1
+	}
+}
+func (stub *WriterStub) FlushCallCount() int {
+	stub.flushMutex.RLock()
+	defer stub.flushMutex.RUnlock()
+	return len(stub.flushArgsForCall)
+}
+func (stub *WriterStub) FlushCalls() []WriterStubFlushArgs {
+	stub.flushMutex.RLock()
+	defer stub.flushMutex.RUnlock()
+	calls := make([]WriterStubFlushArgs, len(stub.flushArgsForCall))
+	copy(calls, stub.flushArgsForCall)
+	return calls
+}
+func (stub *WriterStub) FlushPanics(value interface{}) {
+	stub.flushMutex.Lock()
+	defer stub.flushMutex.Unlock()
+	stub.flushPanic = value
+}
+func (stub *WriterStub) FlushPanicsOnCall(index int, value interface{}) {
+	stub.flushMutex.Lock()
+	defer stub.flushMutex.Unlock()
+	if stub.flushPanicOnCall == nil {
+		stub.flushPanicOnCall = make(map[int]interface{})
+	}
+	stub.flushPanicOnCall[index] = value
+}
+func (stub *WriterStub) flushCallHistory() [][]interface{} {
+	stub.flushMutex.RLock()
+	defer stub.flushMutex.RUnlock()
+	history := make([][]interface{}, len(stub.flushArgsForCall))
+	for i := range stub.flushArgsForCall {
+		history[i] = []interface{}{}
+	}
+	return history
+}
+func (stub *WriterStub) ExpectFlush() *WriterStubExpectation {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if !stub.verifiesExpectations {
+		panic("cannot expect calls to WriterStub.Flush: the stub was not created with NewWriterStubT")
+	}
+	expectation := &WriterStubExpectation{method: "Flush", min: 1, max: -1, callCount: stub.FlushCallCount, callHistory: stub.flushCallHistory}
+	stub.expectations = append(stub.expectations, expectation)
+	return expectation
+}
+func (stub *WriterStub) FlushReturns(result1 error) {
+	stub.flushMutex.Lock()
+	defer stub.flushMutex.Unlock()
+	stub.flushReturns = WriterStubFlushResults{result1}
+	stub.flushReturnsConfigured = true
+}
+func (stub *WriterStub) FlushReturnsOnCall(index int, result1 error) {
+	stub.flushMutex.Lock()
+	defer stub.flushMutex.Unlock()
+	if stub.flushReturnsOnCall == nil {
+		stub.flushReturnsOnCall = make(map[int]WriterStubFlushResults)
+	}
+	stub.flushReturnsOnCall[index] = WriterStubFlushResults{result1}
+}
+func (stub *WriterStub) decodeFlushScenario(values []json.RawMessage) (WriterStubFlushResults, error) {
+	var results WriterStubFlushResults
+	if len(values) != 1 {
+		return results, fmt.Errorf("cannot configure WriterStub.Flush from scenario: expected 1 results, got %d", len(values))
+	}
+	var message1 *string
+	err := json.Unmarshal(values[0], &message1)
+	if err != nil {
+		return results, fmt.Errorf("cannot configure WriterStub.Flush from scenario: cannot decode result 1: %v", err)
+	}
+	if message1 != nil {
+		results.Result1 = errors.New(*message1)
+	}
+	return results, nil
+}
+func WriterStubWithFlushStub(fn func() (result1 error)) WriterStubOption {
+	return func(stub *WriterStub) {
+		stub.FlushStub = fn
+	}
+}
+func WriterStubWithFlushReturns(result1 error) WriterStubOption {
+	return func(stub *WriterStub) {
+		stub.FlushReturns(result1)
+	}
+}
@@ -0,0 +1,239 @@
+// Generated by 'github.com/mokiat/gostub'
+
+package acceptancefakes
+
+import (
+	sync "sync"
+
+	alias1 "github.com/mokiat/gostub/acceptance"
+)
+
+type FakeCounterfeiterStore struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+	GetStub          func(string) (string, error)
+	getMutex         sync.RWMutex
+	getArgsForCall   []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 string
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PutStub        func(string, []byte) error
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	putReturns struct {
+		result1 error
+	}
+	putReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TagStub        func(string, ...string)
+	tagMutex       sync.RWMutex
+	tagArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+}
+
+func (fake *FakeCounterfeiterStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+func (fake *FakeCounterfeiterStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ alias1.CounterfeiterStore = new(FakeCounterfeiterStore)
+
+func (fake *FakeCounterfeiterStore) Get(arg1 string) (string, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+func (fake *FakeCounterfeiterStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+func (fake *FakeCounterfeiterStore) GetCalls(stub func(string) (string, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+func (fake *FakeCounterfeiterStore) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+func (fake *FakeCounterfeiterStore) GetReturns(result1 string, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+func (fake *FakeCounterfeiterStore) GetReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+func (fake *FakeCounterfeiterStore) Put(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.putMutex.Lock()
+	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.PutStub
+	fakeReturns := fake.putReturns
+	fake.recordInvocation("Put", []interface{}{arg1, arg2Copy})
+	fake.putMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+func (fake *FakeCounterfeiterStore) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+func (fake *FakeCounterfeiterStore) PutCalls(stub func(string, []byte) error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = stub
+}
+func (fake *FakeCounterfeiterStore) PutArgsForCall(i int) (string, []byte) {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	argsForCall := fake.putArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+func (fake *FakeCounterfeiterStore) PutReturns(result1 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 error
+	}{result1}
+}
+func (fake *FakeCounterfeiterStore) PutReturnsOnCall(i int, result1 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	if fake.putReturnsOnCall == nil {
+		fake.putReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.putReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+func (fake *FakeCounterfeiterStore) Tag(arg1 string, arg2 ...string) {
+	fake.tagMutex.Lock()
+	fake.tagArgsForCall = append(fake.tagArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	stub := fake.TagStub
+	fake.recordInvocation("Tag", []interface{}{arg1, arg2})
+	fake.tagMutex.Unlock()
+	if stub != nil {
+		stub(arg1, arg2...)
+	}
+}
+func (fake *FakeCounterfeiterStore) TagCallCount() int {
+	fake.tagMutex.RLock()
+	defer fake.tagMutex.RUnlock()
+	return len(fake.tagArgsForCall)
+}
+func (fake *FakeCounterfeiterStore) TagCalls(stub func(string, ...string)) {
+	fake.tagMutex.Lock()
+	defer fake.tagMutex.Unlock()
+	fake.TagStub = stub
+}
+func (fake *FakeCounterfeiterStore) TagArgsForCall(i int) (string, []string) {
+	fake.tagMutex.RLock()
+	defer fake.tagMutex.RUnlock()
+	argsForCall := fake.tagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+func (fake *FakeCounterfeiterStore) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	stub := fake.CloseStub
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		stub()
+	}
+}
+func (fake *FakeCounterfeiterStore) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+func (fake *FakeCounterfeiterStore) CloseCalls(stub func()) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
@@ -0,0 +1,14 @@
+package acceptance
+
+//go:generate gostub --invokes CallbackParams
+
+type Message struct {
+	Topic string
+	Body  string
+}
+
+type CallbackParams interface {
+	Subscribe(topic string, handler func(Message)) error
+	Walk(root string, visit func(path string, depth int) bool)
+	Emit(listener func(string, ...int))
+}
@@ -0,0 +1,91 @@
+package acceptance_test
+
+import (
+	"github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+	"github.com/mokiat/gostub/acceptance/external/external_dup"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CallbackParams", func() {
+	var stub *acceptance_stubs.CallbackParamsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.CallbackParamsStub)
+	})
+
+	It("is possible to invoke callbacks during the call", func() {
+		message := acceptance.Message{Topic: "news", Body: "hello"}
+		stub.SubscribeInvokesHandler(message)
+		var received []acceptance.Message
+		stub.Subscribe("news", func(msg acceptance.Message) {
+			received = append(received, msg)
+		})
+		Ω(received).Should(Equal([]acceptance.Message{message}))
+	})
+
+	It("invokes callbacks with all configured arguments in order", func() {
+		stub.WalkInvokesVisit("/a", 1)
+		stub.WalkInvokesVisit("/a/b", 2)
+		var visited []string
+		stub.Walk("/", func(path string, depth int) bool {
+			visited = append(visited, path)
+			return true
+		})
+		Ω(visited).Should(Equal([]string{"/a", "/a/b"}))
+	})
+
+	It("is possible to invoke variadic callbacks", func() {
+		stub.EmitInvokesListener("event", 1, 2)
+		var name string
+		var values []int
+		stub.Emit(func(event string, args ...int) {
+			name = event
+			values = args
+		})
+		Ω(name).Should(Equal("event"))
+		Ω(values).Should(Equal([]int{1, 2}))
+	})
+
+	It("is possible to use the stub from within a callback", func() {
+		stub.SubscribeInvokesHandler(acceptance.Message{})
+		var count int
+		stub.Subscribe("news", func(msg acceptance.Message) {
+			count = stub.SubscribeCallCount()
+		})
+		Ω(count).Should(Equal(1))
+	})
+
+	It("skips nil callbacks", func() {
+		stub.SubscribeInvokesHandler(acceptance.Message{})
+		Ω(func() {
+			stub.Subscribe("news", nil)
+		}).ShouldNot(Panic())
+		Ω(stub.SubscribeCallCount()).Should(Equal(1))
+	})
+
+	It("is possible to get the callback of a specific call", func() {
+		var received acceptance.Message
+		stub.Subscribe("news", func(msg acceptance.Message) {
+			received = msg
+		})
+		handler := stub.SubscribeHandlerForCall(0)
+		handler(acceptance.Message{Body: "late"})
+		Ω(received.Body).Should(Equal("late"))
+	})
+
+	It("names helpers of anonymous callbacks by position", func() {
+		address := external.Address{Value: 1}
+		funcStub := new(acceptance_stubs.FuncSupportStub)
+		funcStub.MethodInvokesArg1(address)
+		var received external.Address
+		funcStub.Method(func(addr external.Address) external.Address {
+			received = addr
+			return addr
+		})
+		Ω(received).Should(Equal(address))
+		Ω(funcStub.MethodArg1ForCall(0)).ShouldNot(BeNil())
+	})
+})
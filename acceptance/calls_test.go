@@ -0,0 +1,59 @@
+package acceptance_test
+
+import (
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Calls", func() {
+	var stub *acceptance_stubs.PrimitiveParamsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.PrimitiveParamsStub)
+	})
+
+	It("returns no calls initially", func() {
+		Ω(stub.SaveCalls()).Should(BeEmpty())
+	})
+
+	It("returns the arguments of all calls", func() {
+		stub.Save(1, "/first", 0.1)
+		stub.Save(2, "/second", 0.2)
+		Ω(stub.SaveCalls()).Should(Equal([]acceptance_stubs.PrimitiveParamsStubSaveArgs{
+			{Arg1: 1, Arg2: "/first", Arg3: 0.1},
+			{Arg1: 2, Arg2: "/second", Arg3: 0.2},
+		}))
+	})
+
+	It("allows comparing calls regardless of order", func() {
+		stub.Save(1, "/first", 0.1)
+		stub.Save(2, "/second", 0.2)
+		Ω(stub.SaveCalls()).Should(ConsistOf(
+			acceptance_stubs.PrimitiveParamsStubSaveArgs{Arg1: 2, Arg2: "/second", Arg3: 0.2},
+			acceptance_stubs.PrimitiveParamsStubSaveArgs{Arg1: 1, Arg2: "/first", Arg3: 0.1},
+		))
+	})
+
+	It("returns a copy of the recorded calls", func() {
+		stub.Save(1, "/first", 0.1)
+		calls := stub.SaveCalls()
+		calls[0].Arg2 = "/modified"
+		_, location, _ := stub.SaveArgsForCall(0)
+		Ω(location).Should(Equal("/first"))
+	})
+
+	It("is available for methods without params", func() {
+		noParamsStub := new(acceptance_stubs.NoParamsNoResultsStub)
+		noParamsStub.Run()
+		Ω(noParamsStub.RunCalls()).Should(HaveLen(1))
+	})
+
+	It("exposes the results of methods", func() {
+		results := acceptance_stubs.ConditionalReturnsStubLookupResults{
+			Result1: "value",
+		}
+		Ω(results.Result2).Should(BeNil())
+	})
+})
@@ -0,0 +1,20 @@
+package acceptance
+
+//go:generate gostub --strict --expect --invocations --recorder --scenario --panics Reader
+//go:generate gostub --strict --expect --invocations --recorder --scenario --panics Writer
+//go:generate gostub --compose --strict --expect --invocations --recorder --scenario --panics ReadWriter
+
+type Reader interface {
+	Read(p []byte) (int, error)
+}
+
+type Writer interface {
+	Write(p []byte) (int, error)
+	Flush() error
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+	Reset(offset int64) error
+}
@@ -0,0 +1,145 @@
+package acceptance_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+	"github.com/mokiat/gostub/recorder"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ComposedReadWriter", func() {
+	var stub *acceptance_stubs.ReadWriterStub
+
+	configureReader := func(readerStub *acceptance_stubs.ReaderStub, count int) {
+		readerStub.ReadReturns(count, nil)
+	}
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.ReadWriterStub)
+	})
+
+	It("is assignable to the original interface", func() {
+		_ = acceptance.ReadWriter(stub)
+	})
+
+	It("embeds the stubs of the embedded interfaces", func() {
+		configureReader(&stub.ReaderStub, 5)
+		stub.WriteReturns(3, nil)
+		stub.FlushReturns(errors.New("disk full"))
+
+		count, err := stub.Read(make([]byte, 10))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(5))
+		count, err = stub.Write([]byte("abc"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(3))
+		Ω(stub.Flush()).Should(MatchError("disk full"))
+
+		Ω(stub.ReaderStub.ReadCallCount()).Should(Equal(1))
+		Ω(stub.WriterStub.WriteArgsForCall(0)).Should(Equal([]byte("abc")))
+		Ω(stub.FlushCallCount()).Should(Equal(1))
+	})
+
+	It("stubs the remaining methods", func() {
+		stub.ResetReturns(errors.New("not seekable"))
+
+		Ω(stub.Reset(10)).Should(MatchError("not seekable"))
+		Ω(stub.ResetArgsForCall(0)).Should(Equal(int64(10)))
+	})
+
+	It("records the calls to all methods in a single log", func() {
+		stub.Read(nil)
+		stub.Reset(1)
+		stub.Write([]byte("abc"))
+
+		Ω(stub.Invocations()).Should(Equal([]acceptance_stubs.ReadWriterStubInvocation{
+			{Sequence: 1, Method: "Read", Args: []interface{}{[]byte(nil)}},
+			{Sequence: 2, Method: "Reset", Args: []interface{}{int64(1)}},
+			{Sequence: 3, Method: "Write", Args: []interface{}{[]byte("abc")}},
+		}))
+		Ω(stub.ReaderStub.Invocations()).Should(HaveLen(1))
+	})
+
+	It("notes the calls of the embedded stubs that panicked", func() {
+		stub.FlushPanics("disk failure")
+
+		Ω(func() { stub.Flush() }).Should(PanicWith("disk failure"))
+		Ω(stub.Invocations()).Should(Equal([]acceptance_stubs.ReadWriterStubInvocation{
+			{Sequence: 1, Method: "Flush", Args: []interface{}{}, Panicked: true},
+		}))
+	})
+
+	It("switches the embedded stubs to strict mode", func() {
+		reporter := new(acceptance_stubs.StrictReporterStub)
+		stub.SetStrict(reporter)
+
+		stub.Read(nil)
+		stub.Reset(1)
+		Ω(reporter.ErrorfCallCount()).Should(Equal(2))
+		format, args := reporter.ErrorfArgsForCall(0)
+		Ω(fmt.Sprintf(format, args...)).Should(ContainSubstring("ReaderStub.Read([]byte(nil))"))
+	})
+
+	It("records the calls to the embedded stubs under its own name", func() {
+		rec := recorder.NewRecorder()
+		stub.AttachRecorder(rec)
+
+		stub.Write([]byte("abc"))
+		stub.Reset(1)
+		Ω(rec.InOrder("ReadWriterStub.Write", "ReadWriterStub.Reset")).Should(Succeed())
+		Ω(rec.Calls()).Should(Equal([]recorder.Call{
+			{Sequence: 1, Stub: "ReadWriterStub", Method: "Write", Args: []interface{}{[]byte("abc")}},
+			{Sequence: 2, Stub: "ReadWriterStub", Method: "Reset", Args: []interface{}{int64(1)}},
+		}))
+	})
+
+	It("configures the embedded stubs from scenarios", func() {
+		err := stub.LoadScenario(strings.NewReader(`{
+			"Read": {"returns": [5, null]},
+			"Flush": {"onCall": {"1": ["disk full"]}},
+			"Reset": {"returns": ["not seekable"]}
+		}`))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		count, err := stub.Read(nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(5))
+		Ω(stub.Flush()).Should(Succeed())
+		Ω(stub.Flush()).Should(MatchError("disk full"))
+		Ω(stub.Reset(1)).Should(MatchError("not seekable"))
+	})
+
+	It("verifies the expectations of the embedded stubs", func() {
+		test := new(fakeTest)
+		stub = acceptance_stubs.NewReadWriterStubT(test)
+		stub.ExpectRead().Times(2)
+		stub.ExpectReset()
+
+		stub.Read(nil)
+		stub.Reset(1)
+		test.finish()
+		Ω(test.errors).Should(HaveLen(1))
+		Ω(test.errors[0]).Should(ContainSubstring("expected exactly 2 calls to ReadWriterStub.Read, got 1"))
+	})
+
+	It("configures the embedded stubs through constructor options", func() {
+		stub = acceptance_stubs.NewReadWriterStub(
+			acceptance_stubs.ReadWriterStubWithReadReturns(5, nil),
+			acceptance_stubs.ReadWriterStubWithFlushStub(func() error {
+				return errors.New("disk full")
+			}),
+		)
+
+		count, err := stub.Read(nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(5))
+		Ω(stub.Flush()).Should(MatchError("disk full"))
+		Ω(stub.ReaderStub.ReadCallCount()).Should(Equal(1))
+	})
+})
@@ -0,0 +1,7 @@
+package acceptance
+
+//go:generate gostub --rules --strict --options ConditionalReturns
+
+type ConditionalReturns interface {
+	Lookup(key string, limit int) (string, error)
+}
@@ -0,0 +1,100 @@
+package acceptance_test
+
+import (
+	"errors"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConditionalReturns", func() {
+	var stub *acceptance_stubs.ConditionalReturnsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.ConditionalReturnsStub)
+		stub.LookupReturns("default", nil)
+	})
+
+	It("is possible to stub results based on a matcher", func() {
+		stub.LookupWhen(func(key string, limit int) bool {
+			return limit > 10
+		}).Returns("large", nil)
+
+		value, err := stub.Lookup("first", 20)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(Equal("large"))
+	})
+
+	It("is possible to stub results based on exact arguments", func() {
+		lookupErr := errors.New("not found")
+		stub.LookupCalledWith("missing", 1).Returns("", lookupErr)
+
+		value, err := stub.Lookup("missing", 1)
+		Ω(err).Should(Equal(lookupErr))
+		Ω(value).Should(BeEmpty())
+	})
+
+	It("evaluates rules in registration order", func() {
+		stub.LookupCalledWith("first", 1).Returns("exact", nil)
+		stub.LookupWhen(func(key string, limit int) bool {
+			return true
+		}).Returns("any", nil)
+
+		value, _ := stub.Lookup("first", 1)
+		Ω(value).Should(Equal("exact"))
+
+		value, _ = stub.Lookup("second", 2)
+		Ω(value).Should(Equal("any"))
+	})
+
+	It("falls back to default results for unmatched calls", func() {
+		stub.LookupCalledWith("first", 1).Returns("exact", nil)
+
+		value, _ := stub.Lookup("first", 2)
+		Ω(value).Should(Equal("default"))
+	})
+
+	It("prefers the stub function over rules", func() {
+		stub.LookupCalledWith("first", 1).Returns("exact", nil)
+		stub.LookupStub = func(key string, limit int) (string, error) {
+			return "stubbed", nil
+		}
+
+		value, _ := stub.Lookup("first", 1)
+		Ω(value).Should(Equal("stubbed"))
+	})
+
+	It("still records calls that match a rule", func() {
+		stub.LookupCalledWith("first", 1).Returns("exact", nil)
+		stub.Lookup("first", 1)
+		Ω(stub.LookupCallCount()).Should(Equal(1))
+	})
+
+	It("allows the stub function to call the stub", func() {
+		stub.LookupStub = func(key string, limit int) (string, error) {
+			if limit == 0 {
+				return key, nil
+			}
+			return stub.Lookup(key, limit-1)
+		}
+
+		value, _ := stub.Lookup("nested", 2)
+		Ω(value).Should(Equal("nested"))
+		Ω(stub.LookupCallCount()).Should(Equal(3))
+	})
+
+	It("allows matchers to configure the stub", func() {
+		stub.LookupWhen(func(key string, limit int) bool {
+			stub.LookupReturns("changed", nil)
+			return false
+		}).Returns("matched", nil)
+
+		value, _ := stub.Lookup("first", 1)
+		Ω(value).Should(Equal("default"))
+
+		value, _ = stub.Lookup("first", 1)
+		Ω(value).Should(Equal("changed"))
+	})
+})
@@ -0,0 +1,53 @@
+package acceptance_test
+
+import (
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Constructor", func() {
+	It("creates a stub without options", func() {
+		stub := acceptance_stubs.NewConditionalReturnsStub()
+		Ω(stub).ShouldNot(BeNil())
+		Ω(stub.LookupCallCount()).Should(Equal(0))
+	})
+
+	It("applies returns options", func() {
+		stub := acceptance_stubs.NewConfigurablePrimitiveResultsStub(
+			acceptance_stubs.ConfigurablePrimitiveResultsStubWithUserReturns("John", 31, 1.83),
+		)
+		name, age, height := stub.User()
+		Ω(name).Should(Equal("John"))
+		Ω(age).Should(Equal(31))
+		Ω(height).Should(Equal(float32(1.83)))
+	})
+
+	It("applies stub options", func() {
+		var savedLocation string
+		stub := acceptance_stubs.NewConfigurablePrimitiveParamsStub(
+			acceptance_stubs.ConfigurablePrimitiveParamsStubWithSaveStub(func(id int, location string, value float32) {
+				savedLocation = location
+			}),
+		)
+		stub.Save(1, "/home", 0.5)
+		Ω(savedLocation).Should(Equal("/home"))
+	})
+
+	It("applies options in order", func() {
+		stub := acceptance_stubs.NewConditionalReturnsStub(
+			acceptance_stubs.ConditionalReturnsStubWithLookupReturns("first", nil),
+			acceptance_stubs.ConditionalReturnsStubWithLookupReturns("second", nil),
+		)
+		value, _ := stub.Lookup("key", 1)
+		Ω(value).Should(Equal("second"))
+	})
+
+	It("produces stubs that are not strict by default", func() {
+		stub := acceptance_stubs.NewConfigurablePrimitiveResultsStub()
+		Ω(func() {
+			stub.User()
+		}).ShouldNot(Panic())
+	})
+})
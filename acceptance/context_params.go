@@ -0,0 +1,9 @@
+package acceptance
+
+import "context"
+
+//go:generate gostub --wait --hold --latency ContextParams
+
+type ContextParams interface {
+	Fetch(ctx context.Context, id int) (string, error)
+}
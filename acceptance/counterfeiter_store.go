@@ -0,0 +1,10 @@
+package acceptance
+
+//go:generate gostub --counterfeiter CounterfeiterStore
+
+type CounterfeiterStore interface {
+	Get(key string) (string, error)
+	Put(key string, value []byte) error
+	Tag(key string, tags ...string)
+	Close()
+}
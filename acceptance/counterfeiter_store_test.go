@@ -0,0 +1,100 @@
+package acceptance_test
+
+import (
+	"errors"
+
+	"github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptancefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CounterfeiterStore", func() {
+	var fake *acceptancefakes.FakeCounterfeiterStore
+
+	BeforeEach(func() {
+		fake = new(acceptancefakes.FakeCounterfeiterStore)
+	})
+
+	It("is assignable to the original interface", func() {
+		_ = acceptance.CounterfeiterStore(fake)
+	})
+
+	It("returns zero values by default", func() {
+		value, err := fake.Get("key")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(BeEmpty())
+	})
+
+	It("is possible to configure results", func() {
+		fake.GetReturns("value", errors.New("failed"))
+
+		value, err := fake.Get("key")
+		Ω(err).Should(MatchError("failed"))
+		Ω(value).Should(Equal("value"))
+	})
+
+	It("is possible to configure results for specific calls", func() {
+		fake.GetReturns("default", nil)
+		fake.GetReturnsOnCall(1, "second", nil)
+
+		first, _ := fake.Get("first")
+		second, _ := fake.Get("second")
+		third, _ := fake.Get("third")
+		Ω(first).Should(Equal("default"))
+		Ω(second).Should(Equal("second"))
+		Ω(third).Should(Equal("default"))
+	})
+
+	It("is possible to replace the method with a stub", func() {
+		fake.PutCalls(func(key string, value []byte) error {
+			return errors.New(key)
+		})
+
+		Ω(fake.Put("key", nil)).Should(MatchError("key"))
+	})
+
+	It("clears the stub when results are configured", func() {
+		fake.PutCalls(func(string, []byte) error {
+			return errors.New("stub")
+		})
+		fake.PutReturns(errors.New("returns"))
+
+		Ω(fake.Put("key", nil)).Should(MatchError("returns"))
+	})
+
+	It("tracks the calls and their arguments", func() {
+		fake.Tag("key", "first", "second")
+		fake.Close()
+
+		Ω(fake.TagCallCount()).Should(Equal(1))
+		key, tags := fake.TagArgsForCall(0)
+		Ω(key).Should(Equal("key"))
+		Ω(tags).Should(Equal([]string{"first", "second"}))
+		Ω(fake.CloseCallCount()).Should(Equal(1))
+	})
+
+	It("copies slice arguments", func() {
+		value := []byte("value")
+		fake.Put("key", value)
+		value[0] = 'V'
+
+		_, arg := fake.PutArgsForCall(0)
+		Ω(arg).Should(Equal([]byte("value")))
+	})
+
+	It("records the invocations", func() {
+		fake.Get("key")
+		fake.Tag("key", "tag")
+
+		invocations := fake.Invocations()
+		Ω(invocations["Get"]).Should(Equal([][]interface{}{
+			{"key"},
+		}))
+		Ω(invocations["Tag"]).Should(Equal([][]interface{}{
+			{"key", []string{"tag"}},
+		}))
+		Ω(invocations).ShouldNot(HaveKey("Put"))
+	})
+})
@@ -0,0 +1,19 @@
+package acceptance
+
+//go:generate gostub -d DeepClient
+
+type DeepClient interface {
+	Users() DeepUserService
+	Orders() DeepOrderService
+	Name() string
+}
+
+type DeepUserService interface {
+	Get(id int) (string, error)
+	Client() DeepClient
+}
+
+type DeepOrderService interface {
+	Count() int
+	Users() DeepUserService
+}
@@ -0,0 +1,51 @@
+package acceptance_test
+
+import (
+	. "github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeepClient", func() {
+	var stub *acceptance_stubs.DeepClientStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.DeepClientStub)
+	})
+
+	It("child stubs are assignable to their interfaces", func() {
+		_, assignable := interface{}(new(acceptance_stubs.DeepUserServiceStub)).(DeepUserService)
+		Ω(assignable).Should(BeTrue())
+		_, assignable = interface{}(new(acceptance_stubs.DeepOrderServiceStub)).(DeepOrderService)
+		Ω(assignable).Should(BeTrue())
+	})
+
+	It("returns child stubs by default", func() {
+		Ω(stub.Users()).Should(BeIdenticalTo(stub.UsersStubValue()))
+		Ω(stub.Orders()).Should(BeIdenticalTo(stub.OrdersStubValue()))
+	})
+
+	It("returns the same child stub on each call", func() {
+		Ω(stub.Users()).Should(BeIdenticalTo(stub.Users()))
+	})
+
+	It("is possible to configure child stubs", func() {
+		stub.UsersStubValue().GetReturns("John", nil)
+		name, err := stub.Users().Get(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(name).Should(Equal("John"))
+	})
+
+	It("is possible to override the result", func() {
+		users := new(acceptance_stubs.DeepUserServiceStub)
+		stub.UsersReturns(users)
+		Ω(stub.Users()).Should(BeIdenticalTo(users))
+	})
+
+	It("generates child stubs transitively", func() {
+		client := stub.Orders().Users().Client()
+		Ω(client).Should(BeIdenticalTo(stub.OrdersStubValue().UsersStubValue().ClientStubValue()))
+	})
+})
@@ -0,0 +1,110 @@
+package acceptance_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeTest struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (t *fakeTest) Helper() {}
+
+func (t *fakeTest) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeTest) Cleanup(cleanup func()) {
+	t.cleanups = append(t.cleanups, cleanup)
+}
+
+func (t *fakeTest) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+var _ = func(t *testing.T) {
+	acceptance_stubs.NewBoundPrimitiveParamsStubT(t)
+}
+
+var _ = Describe("Expectations", func() {
+	var test *fakeTest
+	var stub *acceptance_stubs.BoundPrimitiveParamsStub
+
+	BeforeEach(func() {
+		test = new(fakeTest)
+		stub = acceptance_stubs.NewBoundPrimitiveParamsStubT(test)
+	})
+
+	It("does not report anything without expectations", func() {
+		test.finish()
+		Ω(test.errors).Should(BeEmpty())
+	})
+
+	It("expects at least one call by default", func() {
+		stub.ExpectSave()
+		test.finish()
+		Ω(test.errors).Should(HaveLen(1))
+		Ω(test.errors[0]).Should(ContainSubstring("expected at least 1 calls to BoundPrimitiveParamsStub.Save, got 0"))
+	})
+
+	It("does not report satisfied expectations", func() {
+		stub.ExpectSave().Times(2)
+		stub.Save(1, "/first", 0.1)
+		stub.Save(2, "/second", 0.2)
+		test.finish()
+		Ω(test.errors).Should(BeEmpty())
+	})
+
+	It("reports unsatisfied expectations with the call history", func() {
+		stub.ExpectSave().Times(2)
+		stub.Save(1, "/home", 0.5)
+		test.finish()
+		Ω(test.errors).Should(HaveLen(1))
+		Ω(test.errors[0]).Should(ContainSubstring("expected exactly 2 calls to BoundPrimitiveParamsStub.Save, got 1"))
+		Ω(test.errors[0]).Should(ContainSubstring(`#1: Save(1, "/home", 0.5)`))
+	})
+
+	It("supports lower bounds", func() {
+		stub.ExpectSave().AtLeast(2)
+		stub.Save(1, "/first", 0.1)
+		stub.Save(2, "/second", 0.2)
+		stub.Save(3, "/third", 0.3)
+		test.finish()
+		Ω(test.errors).Should(BeEmpty())
+	})
+
+	It("supports expecting no calls", func() {
+		stub.ExpectSave().Never()
+		stub.Save(1, "/home", 0.5)
+		test.finish()
+		Ω(test.errors).Should(HaveLen(1))
+		Ω(test.errors[0]).Should(ContainSubstring("expected exactly 0 calls to BoundPrimitiveParamsStub.Save, got 1"))
+	})
+
+	It("applies constructor options", func() {
+		resultsStub := acceptance_stubs.NewBoundPrimitiveResultsStubT(test,
+			acceptance_stubs.BoundPrimitiveResultsStubWithUserReturns("John", 31, 1.83),
+		)
+		resultsStub.ExpectUser().Times(1)
+		name, _, _ := resultsStub.User()
+		Ω(name).Should(Equal("John"))
+		test.finish()
+		Ω(test.errors).Should(BeEmpty())
+	})
+
+	It("panics when expecting calls on a stub that is not bound to a test", func() {
+		unbound := new(acceptance_stubs.BoundPrimitiveParamsStub)
+		Ω(func() {
+			unbound.ExpectSave()
+		}).Should(PanicWith("cannot expect calls to BoundPrimitiveParamsStub.Save: the stub was not created with NewBoundPrimitiveParamsStubT"))
+	})
+})
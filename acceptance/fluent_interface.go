@@ -0,0 +1,13 @@
+package acceptance
+
+//go:generate gostub --strict FluentQuery
+
+type FluentFilter interface {
+	Where(condition string) FluentFilter
+}
+
+type FluentQuery interface {
+	FluentFilter
+	Limit(count int) FluentQuery
+	Run() ([]string, error)
+}
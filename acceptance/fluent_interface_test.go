@@ -0,0 +1,47 @@
+package acceptance_test
+
+import (
+	. "github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FluentInterface", func() {
+	var stub *acceptance_stubs.FluentQueryStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.FluentQueryStub)
+	})
+
+	It("returns the stub itself by default", func() {
+		Ω(stub.Limit(10)).Should(BeIdenticalTo(stub))
+	})
+
+	It("returns the stub itself for methods of embedded interfaces", func() {
+		Ω(stub.Where("a = 1")).Should(BeIdenticalTo(stub))
+	})
+
+	It("allows chaining calls", func() {
+		stub.RunReturns([]string{"row"}, nil)
+		var query FluentQuery = stub
+		rows, err := query.Limit(10).Run()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(rows).Should(Equal([]string{"row"}))
+		Ω(stub.LimitCallCount()).Should(Equal(1))
+	})
+
+	It("is possible to override the result", func() {
+		other := new(acceptance_stubs.FluentQueryStub)
+		stub.LimitReturns(other)
+		Ω(stub.Limit(10)).Should(BeIdenticalTo(other))
+	})
+
+	It("does not report the default result in strict mode", func() {
+		reporter := new(acceptance_stubs.StrictReporterStub)
+		stub.SetStrict(reporter)
+		stub.Limit(10)
+		Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+	})
+})
@@ -0,0 +1,114 @@
+package acceptance_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gate", func() {
+	Context("when the method has no context parameter", func() {
+		var stub *acceptance_stubs.AsyncPrimitiveParamsStub
+		var done chan struct{}
+
+		BeforeEach(func() {
+			stub = new(acceptance_stubs.AsyncPrimitiveParamsStub)
+			done = make(chan struct{})
+		})
+
+		It("blocks held calls until the gate is released", func() {
+			gate := stub.HoldSave(1)
+			go func() {
+				defer close(done)
+				stub.Save(1, "/held", 0.1)
+			}()
+
+			Ω(stub.WaitForSaveCalls(1, time.Second)).Should(Succeed())
+			Consistently(done, 50*time.Millisecond).ShouldNot(BeClosed())
+
+			gate.Release()
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("holds only the specified number of calls", func() {
+			gate := stub.HoldSave(1)
+			defer gate.Release()
+			go stub.Save(1, "/held", 0.1)
+			Ω(stub.WaitForSaveCalls(1, time.Second)).Should(Succeed())
+
+			stub.Save(2, "/free", 0.2)
+			Ω(stub.SaveCallCount()).Should(Equal(2))
+		})
+
+		It("does not block other methods while a call is held", func() {
+			gate := stub.HoldSave(1)
+			defer gate.Release()
+			go stub.Save(1, "/held", 0.1)
+			Ω(stub.WaitForSaveCalls(1, time.Second)).Should(Succeed())
+
+			Ω(stub.SaveCallCount()).Should(Equal(1))
+			id, location, _ := stub.SaveArgsForCall(0)
+			Ω(id).Should(Equal(1))
+			Ω(location).Should(Equal("/held"))
+		})
+
+		It("can be released multiple times", func() {
+			gate := stub.HoldSave(2)
+			gate.Release()
+			gate.Release()
+			stub.Save(1, "/first", 0.1)
+			stub.Save(2, "/second", 0.2)
+			Ω(stub.SaveCallCount()).Should(Equal(2))
+		})
+	})
+
+	Context("when the method has a context parameter", func() {
+		var stub *acceptance_stubs.ContextParamsStub
+
+		BeforeEach(func() {
+			stub = new(acceptance_stubs.ContextParamsStub)
+			stub.FetchReturns("value", nil)
+		})
+
+		It("unblocks held calls when the context is cancelled", func() {
+			gate := stub.HoldFetch(1)
+			defer gate.Release()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			results := make(chan string)
+			errs := make(chan error, 1)
+			go func() {
+				value, err := stub.Fetch(ctx, 1)
+				errs <- err
+				results <- value
+			}()
+
+			Ω(stub.WaitForFetchCalls(1, time.Second)).Should(Succeed())
+			Consistently(results, 50*time.Millisecond).ShouldNot(Receive())
+
+			cancel()
+			Eventually(results).Should(Receive(BeEmpty()))
+			Ω(errs).Should(Receive(Equal(context.Canceled)))
+		})
+
+		It("holds calls with a nil context until they are released", func() {
+			gate := stub.HoldFetch(1)
+
+			results := make(chan string)
+			go func() {
+				value, _ := stub.Fetch(nil, 1)
+				results <- value
+			}()
+
+			Ω(stub.WaitForFetchCalls(1, time.Second)).Should(Succeed())
+			Consistently(results, 50*time.Millisecond).ShouldNot(Receive())
+
+			gate.Release()
+			Eventually(results).Should(Receive(Equal("value")))
+		})
+	})
+})
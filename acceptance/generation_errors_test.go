@@ -0,0 +1,50 @@
+package acceptance_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mokiat/gostub/generator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GenerationErrors", func() {
+	var dir string
+	var path string
+
+	generate := func(interfaceName string) error {
+		return generator.Generate(generator.Config{
+			SourcePackageLocation: "github.com/mokiat/gostub/acceptance/testdata/unresolved",
+			SourceInterfaceName:   interfaceName,
+			TargetFilePath:        path,
+			TargetPackageName:     "unresolved_stubs",
+			TargetStructName:      interfaceName + "Stub",
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gostub")
+		Ω(err).ShouldNot(HaveOccurred())
+		path = filepath.Join(dir, "unresolved_stub.go")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("fails when a method cannot be processed", func() {
+		err := generate("UnresolvedParams")
+		Ω(err).Should(MatchError("Could not find 'Missing' type."))
+		Ω(path).ShouldNot(BeAnExistingFile())
+	})
+
+	It("fails when a method of an embedded interface cannot be processed", func() {
+		err := generate("UnresolvedEmbedded")
+		Ω(err).Should(MatchError("Could not find 'Missing' type."))
+		Ω(path).ShouldNot(BeAnExistingFile())
+	})
+})
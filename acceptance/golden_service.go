@@ -0,0 +1,9 @@
+package acceptance
+
+//go:generate gostub -g --rules --scenario GoldenService
+
+type GoldenService interface {
+	Lookup(id int) (Customer, error)
+	Search(query string, tags ...string) []string
+	Notify(message string)
+}
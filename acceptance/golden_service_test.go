@@ -0,0 +1,133 @@
+package acceptance_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoldenService", func() {
+	var target *acceptance_stubs.GoldenServiceStub
+	var recorder *acceptance_stubs.GoldenServiceStubGoldenRecorder
+	var reporter *acceptance_stubs.StrictReporterStub
+	var dir string
+	var path string
+
+	reportedMessage := func(index int) string {
+		format, args := reporter.ErrorfArgsForCall(index)
+		return fmt.Sprintf(format, args...)
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gostub")
+		Ω(err).ShouldNot(HaveOccurred())
+		path = filepath.Join(dir, "golden_service.json")
+
+		target = new(acceptance_stubs.GoldenServiceStub)
+		target.LookupCalledWith(1).Returns(Customer{Name: "John", Address: "Sofia"}, nil)
+		target.LookupCalledWith(2).Returns(Customer{}, errors.New("customer not found"))
+		target.SearchReturns([]string{"first", "second"})
+		recorder = acceptance_stubs.NewGoldenServiceStubGoldenRecorder(target)
+		reporter = new(acceptance_stubs.StrictReporterStub)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("recorder is assignable to the interface", func() {
+		_, assignable := interface{}(recorder).(GoldenService)
+		Ω(assignable).Should(BeTrue())
+	})
+
+	It("recorder delegates calls to the target", func() {
+		customer, err := recorder.Lookup(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer).Should(Equal(Customer{Name: "John", Address: "Sofia"}))
+		Ω(recorder.Search("query", "a", "b")).Should(Equal([]string{"first", "second"}))
+		recorder.Notify("hello")
+
+		Ω(target.LookupCallCount()).Should(Equal(1))
+		query, tags := target.SearchArgsForCall(0)
+		Ω(query).Should(Equal("query"))
+		Ω(tags).Should(Equal([]string{"a", "b"}))
+		Ω(target.NotifyArgsForCall(0)).Should(Equal("hello"))
+	})
+
+	Context("when calls are recorded and saved", func() {
+		BeforeEach(func() {
+			recorder.Lookup(1)
+			recorder.Lookup(2)
+			recorder.Search("query", "a", "b")
+			recorder.Notify("hello")
+			Ω(recorder.SaveGolden(path)).Should(Succeed())
+		})
+
+		It("writes the calls to the golden file", func() {
+			data, err := ioutil.ReadFile(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(data)).Should(ContainSubstring(`"Method": "Lookup"`))
+			Ω(string(data)).Should(ContainSubstring(`"customer not found"`))
+			Ω(string(data)).Should(ContainSubstring(`"Method": "Notify"`))
+		})
+
+		It("replays the recorded results", func() {
+			stub, err := acceptance_stubs.NewGoldenServiceStubFromGolden(path, reporter)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			customer, err := stub.Lookup(1)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(customer).Should(Equal(Customer{Name: "John", Address: "Sofia"}))
+			_, err = stub.Lookup(2)
+			Ω(err).Should(MatchError("customer not found"))
+			Ω(stub.Search("query", "a", "b")).Should(Equal([]string{"first", "second"}))
+			stub.Notify("hello")
+
+			Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+			Ω(stub.LookupCallCount()).Should(Equal(2))
+		})
+
+		It("reports calls with different arguments", func() {
+			stub, err := acceptance_stubs.NewGoldenServiceStubFromGolden(path, reporter)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stub.Notify("goodbye")
+			Ω(reporter.ErrorfCallCount()).Should(Equal(1))
+			Ω(reportedMessage(0)).Should(ContainSubstring("unexpected arguments in call to GoldenServiceStub.Notify"))
+			Ω(reportedMessage(0)).Should(ContainSubstring(`"goodbye"`))
+		})
+
+		It("reports calls that were not recorded", func() {
+			stub, err := acceptance_stubs.NewGoldenServiceStubFromGolden(path, reporter)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stub.Notify("hello")
+			stub.Notify("hello")
+			Ω(reporter.ErrorfCallCount()).Should(Equal(1))
+			Ω(reportedMessage(0)).Should(Equal("no more recorded calls to GoldenServiceStub.Notify"))
+		})
+
+		It("panics on mismatches without a reporter", func() {
+			stub, err := acceptance_stubs.NewGoldenServiceStubFromGolden(path, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(func() {
+				stub.Search("other")
+			}).Should(Panic())
+		})
+	})
+
+	It("returns an error when the golden file is missing", func() {
+		_, err := acceptance_stubs.NewGoldenServiceStubFromGolden(path, reporter)
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,57 @@
+package acceptance_test
+
+import (
+	"sync"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Invocations", func() {
+	var stub *acceptance_stubs.StrictReporterStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.StrictReporterStub)
+	})
+
+	It("returns no invocations initially", func() {
+		Ω(stub.Invocations()).Should(BeEmpty())
+	})
+
+	It("records calls to all methods in order", func() {
+		stub.Helper()
+		stub.Errorf("first: %d", 1)
+		stub.Helper()
+		Ω(stub.Invocations()).Should(Equal([]acceptance_stubs.StrictReporterStubInvocation{
+			{Sequence: 1, Method: "Helper", Args: []interface{}{}},
+			{Sequence: 2, Method: "Errorf", Args: []interface{}{"first: %d", []interface{}{1}}},
+			{Sequence: 3, Method: "Helper", Args: []interface{}{}},
+		}))
+	})
+
+	It("returns a copy of the recorded invocations", func() {
+		stub.Helper()
+		invocations := stub.Invocations()
+		invocations[0].Method = "Modified"
+		Ω(stub.Invocations()[0].Method).Should(Equal("Helper"))
+	})
+
+	It("assigns unique sequence numbers to concurrent calls", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				stub.Helper()
+			}()
+		}
+		wg.Wait()
+		invocations := stub.Invocations()
+		Ω(invocations).Should(HaveLen(10))
+		for i, invocation := range invocations {
+			Ω(invocation.Sequence).Should(Equal(i + 1))
+		}
+	})
+})
@@ -0,0 +1,11 @@
+package acceptance
+
+//go:generate gostub --mock KeyValueStore
+
+type KeyValueStore interface {
+	Get(key string) (string, error)
+	Put(key string, value []byte) error
+	Tag(key string, tags ...string)
+	Keys(prefixes ...string) []string
+	Close()
+}
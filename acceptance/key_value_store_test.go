@@ -0,0 +1,129 @@
+package acceptance_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_mocks"
+	"github.com/mokiat/gostub/mock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type failureReporter struct {
+	failures []string
+}
+
+func (r *failureReporter) Helper() {}
+
+func (r *failureReporter) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *failureReporter) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+var _ = Describe("KeyValueStore", func() {
+	var reporter *failureReporter
+	var ctrl *mock.Controller
+	var store *acceptance_mocks.MockKeyValueStore
+
+	BeforeEach(func() {
+		reporter = new(failureReporter)
+		ctrl = mock.NewController(reporter)
+		store = acceptance_mocks.NewMockKeyValueStore(ctrl)
+	})
+
+	It("is assignable to the original interface", func() {
+		_ = acceptance.KeyValueStore(store)
+	})
+
+	It("returns the results of expected calls", func() {
+		store.EXPECT().Get("key").Return("value", nil)
+		store.EXPECT().Put("key", mock.Any()).Return(errors.New("read only"))
+
+		value, err := store.Get("key")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(Equal("value"))
+		Ω(store.Put("key", []byte("value"))).Should(MatchError("read only"))
+
+		ctrl.Finish()
+		Ω(reporter.failures).Should(BeEmpty())
+	})
+
+	It("returns zero results by default", func() {
+		store.EXPECT().Get(mock.Any())
+		store.EXPECT().Close()
+
+		value, err := store.Get("key")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(value).Should(BeEmpty())
+		store.Close()
+
+		ctrl.Finish()
+		Ω(reporter.failures).Should(BeEmpty())
+	})
+
+	It("matches variadic arguments individually", func() {
+		store.EXPECT().Tag("key", "first", mock.Not("third"))
+		store.EXPECT().Keys().Return([]string{"all"})
+		store.EXPECT().Keys("a", "b").Return([]string{"a", "b"})
+
+		store.Tag("key", "first", "second")
+		Ω(store.Keys()).Should(Equal([]string{"all"}))
+		Ω(store.Keys("a", "b")).Should(Equal([]string{"a", "b"}))
+
+		ctrl.Finish()
+		Ω(reporter.failures).Should(BeEmpty())
+	})
+
+	It("supports the number of expected calls", func() {
+		store.EXPECT().Get("key").Return("value", nil).Times(2)
+		store.EXPECT().Close().AnyTimes()
+
+		store.Get("key")
+		ctrl.Finish()
+		Ω(reporter.failures).Should(HaveLen(1))
+		Ω(reporter.failures[0]).Should(ContainSubstring(`missing call *acceptance_mocks.MockKeyValueStore.Get(is equal to "key")`))
+	})
+
+	It("calls the configured actions", func() {
+		store.EXPECT().Get(mock.Any()).DoAndReturn(func(key string) (string, error) {
+			return "value of " + key, nil
+		})
+
+		value, _ := store.Get("key")
+		Ω(value).Should(Equal("value of key"))
+	})
+
+	It("reports unexpected calls", func() {
+		store.EXPECT().Get("key")
+
+		store.Get("other")
+		Ω(reporter.failures).Should(HaveLen(1))
+		Ω(reporter.failures[0]).Should(ContainSubstring(`unexpected call to *acceptance_mocks.MockKeyValueStore.Get("other")`))
+	})
+
+	It("supports ordered expectations", func() {
+		mock.InOrder(
+			store.EXPECT().Put("key", []byte("value")),
+			store.EXPECT().Close(),
+		)
+
+		store.Close()
+		Ω(reporter.failures).Should(HaveLen(1))
+		Ω(reporter.failures[0]).Should(ContainSubstring("is expected after"))
+	})
+
+	It("works with GinkgoT", func() {
+		ctrl := mock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		store := acceptance_mocks.NewMockKeyValueStore(ctrl)
+		store.EXPECT().Put("key", []byte("value"))
+
+		Ω(store.Put("key", []byte("value"))).Should(Succeed())
+	})
+})
@@ -0,0 +1,129 @@
+package acceptance_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Latency", func() {
+	Context("when the method has no context parameter", func() {
+		var stub *acceptance_stubs.AsyncPrimitiveParamsStub
+
+		BeforeEach(func() {
+			stub = new(acceptance_stubs.AsyncPrimitiveParamsStub)
+		})
+
+		It("does not delay calls by default", func() {
+			start := time.Now()
+			stub.Save(1, "/fast", 0.1)
+			Ω(time.Since(start)).Should(BeNumerically("<", 50*time.Millisecond))
+		})
+
+		It("delays calls by the configured duration", func() {
+			stub.SaveDelays(50 * time.Millisecond)
+
+			start := time.Now()
+			stub.Save(1, "/slow", 0.1)
+			Ω(time.Since(start)).Should(BeNumerically(">=", 50*time.Millisecond))
+		})
+
+		It("does not block other methods while a call is delayed", func() {
+			stub.SaveDelays(time.Second)
+			go stub.Save(1, "/slow", 0.1)
+			Ω(stub.WaitForSaveCalls(1, time.Second)).Should(Succeed())
+
+			Ω(stub.SaveCallCount()).Should(Equal(1))
+			stub.SaveDelays(0)
+			stub.Save(2, "/fast", 0.2)
+			Ω(stub.SaveCallCount()).Should(Equal(2))
+		})
+	})
+
+	Context("when the method has a context parameter", func() {
+		var stub *acceptance_stubs.ContextParamsStub
+		var ctx context.Context
+		var cancel context.CancelFunc
+
+		type result struct {
+			value string
+			err   error
+		}
+
+		fetch := func() <-chan result {
+			results := make(chan result, 1)
+			go func() {
+				value, err := stub.Fetch(ctx, 1)
+				results <- result{value, err}
+			}()
+			return results
+		}
+
+		BeforeEach(func() {
+			stub = new(acceptance_stubs.ContextParamsStub)
+			stub.FetchReturns("value", nil)
+			ctx, cancel = context.WithCancel(context.Background())
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("returns the configured results after the delay", func() {
+			stub.FetchDelays(50 * time.Millisecond)
+
+			start := time.Now()
+			value, err := stub.Fetch(ctx, 1)
+			Ω(time.Since(start)).Should(BeNumerically(">=", 50*time.Millisecond))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(Equal("value"))
+		})
+
+		It("delays calls with a nil context", func() {
+			stub.FetchDelays(50 * time.Millisecond)
+
+			start := time.Now()
+			value, err := stub.Fetch(nil, 1)
+			Ω(time.Since(start)).Should(BeNumerically(">=", 50*time.Millisecond))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(Equal("value"))
+		})
+
+		It("returns the context error when the context is cancelled during the delay", func() {
+			stub.FetchDelays(time.Minute)
+			results := fetch()
+			Ω(stub.WaitForFetchCalls(1, time.Second)).Should(Succeed())
+			Consistently(results, 50*time.Millisecond).ShouldNot(Receive())
+
+			cancel()
+			Eventually(results).Should(Receive(Equal(result{"", context.Canceled})))
+		})
+
+		It("blocks calls until the context is done", func() {
+			stub.FetchBlocksUntilContextDone()
+			cancel()
+			ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+			value, err := stub.Fetch(ctx, 1)
+			Ω(err).Should(Equal(context.DeadlineExceeded))
+			Ω(value).Should(BeEmpty())
+		})
+
+		It("does not hold the mutex of the method while waiting", func() {
+			stub.FetchBlocksUntilContextDone()
+			results := fetch()
+			Ω(stub.WaitForFetchCalls(1, time.Second)).Should(Succeed())
+
+			stub.FetchReturns("other", nil)
+			Ω(stub.FetchCallCount()).Should(Equal(1))
+			Consistently(results, 50*time.Millisecond).ShouldNot(Receive())
+
+			cancel()
+			Eventually(results).Should(Receive(Equal(result{"", context.Canceled})))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package acceptance_test
+
+import (
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matchers", func() {
+	var stub *acceptance_stubs.PrimitiveParamsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.PrimitiveParamsStub)
+	})
+
+	It("matches stubs that received a call", func() {
+		Ω(stub).ShouldNot(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave())
+		stub.Save(10, "/tmp", 0.5)
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave())
+	})
+
+	It("matches calls by arguments", func() {
+		stub.Save(10, "/tmp", 0.5)
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().With(10, "/tmp", 0.5))
+		Ω(stub).ShouldNot(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().With(11, "/tmp", 0.5))
+	})
+
+	It("matches calls by individual arguments", func() {
+		stub.Save(10, "/tmp", 0.5)
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().WithLocation("/tmp"))
+		Ω(stub).ShouldNot(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().WithCount(11))
+	})
+
+	It("supports matchers as arguments", func() {
+		stub.Save(10, "/tmp", 0.5)
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().WithCount(10).WithLocationThat(HavePrefix("/t")))
+		Ω(stub).ShouldNot(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().WithCountThat(BeNumerically(">", 10)))
+	})
+
+	It("matches any value of arguments that are not checked", func() {
+		stub.Save(10, "/tmp", 0.5)
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().With(10, "/var", 0.5).WithAnyLocation())
+		Ω(stub).ShouldNot(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().With(10, "/var", 0.5).WithAnyTimeout())
+	})
+
+	It("matches the number of calls", func() {
+		stub.Save(10, "/tmp", 0.5)
+		stub.Save(10, "/tmp", 0.5)
+		stub.Save(20, "/var", 0.5)
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSaveTimes(3))
+		Ω(stub).Should(acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().WithCount(10).WithLocation("/tmp").Times(2))
+		Ω(stub).ShouldNot(acceptance_stubs.PrimitiveParamsStubHaveReceivedSaveTimes(2))
+	})
+
+	It("prints the recorded calls in failure messages", func() {
+		stub.Save(10, "/tmp", 0.5)
+		matcher := acceptance_stubs.PrimitiveParamsStubHaveReceivedSaveTimes(2)
+		Ω(matcher.Match(stub)).Should(BeFalse())
+		message := matcher.FailureMessage(stub)
+		Ω(message).Should(ContainSubstring("Expected PrimitiveParamsStub to have received Save exactly 2 times"))
+		Ω(message).Should(ContainSubstring(`#1: Save(10, "/tmp", 0.5)`))
+	})
+
+	It("prints the expected arguments in failure messages", func() {
+		matcher := acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().WithCount(10).WithLocation("/tmp")
+		Ω(matcher.Match(stub)).Should(BeFalse())
+		Ω(matcher.FailureMessage(stub)).Should(ContainSubstring(`to have received Save(10, "/tmp", <any>)`))
+	})
+
+	It("returns an error for values that are not stubs", func() {
+		_, err := acceptance_stubs.PrimitiveParamsStubHaveReceivedSave().Match("value")
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -1,6 +1,6 @@
 package acceptance
 
-//go:generate gostub NoParamsNoResults
+//go:generate gostub --recorder NoParamsNoResults
 
 type NoParamsNoResults interface {
 	Run()
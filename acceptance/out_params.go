@@ -0,0 +1,10 @@
+package acceptance
+
+//go:generate gostub --sets OutParams
+
+type OutParams interface {
+	Load(key string, into *Customer) error
+	Decode(v interface{}) error
+	Scan(dest ...interface{}) error
+	Fill(targets ...*int)
+}
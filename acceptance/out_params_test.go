@@ -0,0 +1,100 @@
+package acceptance_test
+
+import (
+	"github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutParams", func() {
+	var stub *acceptance_stubs.OutParamsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.OutParamsStub)
+	})
+
+	It("is possible to assign into typed pointer params", func() {
+		stub.LoadSetsInto(acceptance.Customer{Name: "John"})
+		var customer acceptance.Customer
+		err := stub.Load("john", &customer)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer.Name).Should(Equal("John"))
+	})
+
+	It("is possible to assign into interface params", func() {
+		stub.DecodeSetsV(42)
+		var value int
+		stub.Decode(&value)
+		Ω(value).Should(Equal(42))
+	})
+
+	It("is possible to assign into variadic params", func() {
+		stub.ScanSetsDest("first", 2)
+		var first string
+		var second int
+		stub.Scan(&first, &second)
+		Ω(first).Should(Equal("first"))
+		Ω(second).Should(Equal(2))
+	})
+
+	It("is possible to assign into typed variadic params", func() {
+		stub.FillSetsTargets(1, 2)
+		var first, second int
+		stub.Fill(&first, &second)
+		Ω(first).Should(Equal(1))
+		Ω(second).Should(Equal(2))
+	})
+
+	It("is possible to assign nil values", func() {
+		stub.DecodeSetsV(nil)
+		value := &acceptance.Customer{}
+		stub.Decode(&value)
+		Ω(value).Should(BeNil())
+	})
+
+	It("is possible to assign values for specific calls", func() {
+		stub.DecodeSetsV(1)
+		stub.DecodeSetsVOnCall(1, 2)
+		values := make([]int, 3)
+		for i := range values {
+			stub.Decode(&values[i])
+		}
+		Ω(values).Should(Equal([]int{1, 2, 1}))
+	})
+
+	It("assigns values before the results are produced", func() {
+		stub.DecodeSetsV(42)
+		var observed int
+		stub.DecodeStub = func(v interface{}) error {
+			observed = *v.(*int)
+			return nil
+		}
+		var value int
+		stub.Decode(&value)
+		Ω(observed).Should(Equal(42))
+	})
+
+	It("panics when an interface param is not a pointer", func() {
+		stub.DecodeSetsV(42)
+		Ω(func() {
+			stub.Decode(42)
+		}).Should(PanicWith("cannot set argument 1 of OutParamsStub.Decode: not a non-nil pointer"))
+	})
+
+	It("panics when a pointer param is nil", func() {
+		stub.LoadSetsInto(acceptance.Customer{Name: "John"})
+		Ω(func() {
+			stub.Load("key", nil)
+		}).Should(PanicWith("cannot set argument 2 of OutParamsStub.Load: not a non-nil pointer"))
+	})
+
+	It("panics when there are more values than variadic args", func() {
+		stub.FillSetsTargets(1, 2)
+		var first int
+		Ω(func() {
+			stub.Fill(&first)
+		}).Should(PanicWith("cannot set argument 1 of OutParamsStub.Fill: out of range"))
+	})
+})
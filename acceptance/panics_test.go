@@ -0,0 +1,109 @@
+package acceptance_test
+
+import (
+	"errors"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Panics", func() {
+	var stub *acceptance_stubs.PanickingPrimitiveParamsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.PanickingPrimitiveParamsStub)
+	})
+
+	It("does not panic by default", func() {
+		Ω(func() {
+			stub.Save(1, "/safe", 0.1)
+		}).ShouldNot(Panic())
+	})
+
+	It("panics with the configured value", func() {
+		failure := errors.New("disk is on fire")
+		stub.SavePanics(failure)
+
+		Ω(func() {
+			stub.Save(1, "/unsafe", 0.1)
+		}).Should(PanicWith(failure))
+	})
+
+	It("records calls before panicking", func() {
+		stub.SavePanics("failure")
+		Ω(func() {
+			stub.Save(1, "/unsafe", 0.1)
+		}).Should(Panic())
+
+		Ω(stub.SaveCallCount()).Should(Equal(1))
+		id, location, _ := stub.SaveArgsForCall(0)
+		Ω(id).Should(Equal(1))
+		Ω(location).Should(Equal("/unsafe"))
+	})
+
+	It("can stop panicking", func() {
+		stub.SavePanics("failure")
+		stub.SavePanics(nil)
+
+		Ω(func() {
+			stub.Save(1, "/safe", 0.1)
+		}).ShouldNot(Panic())
+	})
+
+	It("panics only on the configured call", func() {
+		stub.SavePanicsOnCall(1, "second call")
+
+		Ω(func() {
+			stub.Save(1, "/first", 0.1)
+		}).ShouldNot(Panic())
+		Ω(func() {
+			stub.Save(2, "/second", 0.2)
+		}).Should(PanicWith("second call"))
+		Ω(func() {
+			stub.Save(3, "/third", 0.3)
+		}).ShouldNot(Panic())
+	})
+
+	It("prefers the value configured for a specific call", func() {
+		stub.SavePanics("any call")
+		stub.SavePanicsOnCall(0, "first call")
+
+		Ω(func() {
+			stub.Save(1, "/first", 0.1)
+		}).Should(PanicWith("first call"))
+		Ω(func() {
+			stub.Save(2, "/second", 0.2)
+		}).Should(PanicWith("any call"))
+	})
+
+	It("notes which invocations panicked", func() {
+		stub.SavePanicsOnCall(1, "second call")
+		stub.Save(1, "/first", 0.1)
+		Ω(func() {
+			stub.Save(2, "/second", 0.2)
+		}).Should(Panic())
+
+		Ω(stub.Invocations()).Should(Equal([]acceptance_stubs.PanickingPrimitiveParamsStubInvocation{
+			{Sequence: 1, Method: "Save", Args: []interface{}{1, "/first", float32(0.1)}, Panicked: false},
+			{Sequence: 2, Method: "Save", Args: []interface{}{2, "/second", float32(0.2)}, Panicked: true},
+		}))
+	})
+
+	It("remains usable after a panic", func() {
+		resultsStub := new(acceptance_stubs.PanickingPrimitiveResultsStub)
+		resultsStub.UserReturns("John", 31, 1.83)
+		resultsStub.UserPanicsOnCall(0, "failure")
+
+		Ω(func() {
+			resultsStub.User()
+		}).Should(Panic())
+
+		name, age, height := resultsStub.User()
+		Ω(name).Should(Equal("John"))
+		Ω(age).Should(Equal(31))
+		Ω(height).Should(Equal(float32(1.83)))
+		Ω(resultsStub.UserCallCount()).Should(Equal(2))
+	})
+})
@@ -0,0 +1,11 @@
+package acceptance
+
+//go:generate gostub -m --invocations --methods Get,Put PartialClient
+
+type PartialClient interface {
+	Get(key string) (string, error)
+	Put(key, value string) error
+	Delete(key string) error
+	List(prefix string) ([]string, error)
+	Close()
+}
@@ -0,0 +1,67 @@
+package acceptance_test
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PartialClient", func() {
+	var stub *acceptance_stubs.PartialClientStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.PartialClientStub)
+	})
+
+	It("is assignable to the original interface", func() {
+		_ = acceptance.PartialClient(stub)
+	})
+
+	It("stubs the selected methods", func() {
+		stub.GetReturns("value", nil)
+		stub.PutReturns(errors.New("read only"))
+
+		result, err := stub.Get("key")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(result).Should(Equal("value"))
+		Ω(stub.Put("key", "value")).Should(MatchError("read only"))
+
+		Ω(stub.GetCallCount()).Should(Equal(1))
+		key, value := stub.PutArgsForCall(0)
+		Ω(key).Should(Equal("key"))
+		Ω(value).Should(Equal("value"))
+		Ω(stub).Should(acceptance_stubs.PartialClientStubHaveReceivedPut().With("key", "value"))
+	})
+
+	It("panics on calls to the remaining methods", func() {
+		Ω(func() {
+			stub.Delete("key")
+		}).Should(PanicWith("unexpected call to Delete"))
+		Ω(func() {
+			stub.List("prefix")
+		}).Should(PanicWith("unexpected call to List"))
+		Ω(func() {
+			stub.Close()
+		}).Should(PanicWith("unexpected call to Close"))
+	})
+
+	It("does not record calls to the remaining methods", func() {
+		Ω(func() {
+			stub.Delete("key")
+		}).Should(Panic())
+		Ω(stub.Invocations()).Should(BeEmpty())
+	})
+
+	It("does not generate stubbing for the remaining methods", func() {
+		stubType := reflect.TypeOf(stub)
+		for _, name := range []string{"DeleteReturns", "DeleteCallCount", "ListReturns", "CloseCallCount"} {
+			_, found := stubType.MethodByName(name)
+			Ω(found).Should(BeFalse(), name)
+		}
+	})
+})
@@ -1,6 +1,12 @@
 package acceptance
 
-//go:generate gostub PrimitiveParams
+//go:generate gostub -m PrimitiveParams
+//go:generate gostub --strict -n StrictPrimitiveParamsStub -o acceptance_stubs/strict_primitive_params_stub.go PrimitiveParams
+//go:generate gostub --options -n ConfigurablePrimitiveParamsStub -o acceptance_stubs/configurable_primitive_params_stub.go PrimitiveParams
+//go:generate gostub --expect -n BoundPrimitiveParamsStub -o acceptance_stubs/bound_primitive_params_stub.go PrimitiveParams
+//go:generate gostub --wait --hold --latency -n AsyncPrimitiveParamsStub -o acceptance_stubs/async_primitive_params_stub.go PrimitiveParams
+//go:generate gostub --panics -n PanickingPrimitiveParamsStub -o acceptance_stubs/panicking_primitive_params_stub.go PrimitiveParams
+//go:generate gostub --recorder -n RecordedPrimitiveParamsStub -o acceptance_stubs/recorded_primitive_params_stub.go PrimitiveParams
 
 type PrimitiveParams interface {
 	Save(count int, location string, timeout float32)
@@ -1,6 +1,9 @@
 package acceptance
 
 //go:generate gostub PrimitiveResults
+//go:generate gostub --options -n ConfigurablePrimitiveResultsStub -o acceptance_stubs/configurable_primitive_results_stub.go PrimitiveResults
+//go:generate gostub --expect -n BoundPrimitiveResultsStub -o acceptance_stubs/bound_primitive_results_stub.go PrimitiveResults
+//go:generate gostub --panics -n PanickingPrimitiveResultsStub -o acceptance_stubs/panicking_primitive_results_stub.go PrimitiveResults
 
 type PrimitiveResults interface {
 	User() (name string, age int, height float32)
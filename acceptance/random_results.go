@@ -0,0 +1,32 @@
+package acceptance
+
+//go:generate gostub --random RandomResults
+
+type RandomResults interface {
+	Customer(id int) (Customer, error)
+	Catalog() (map[string][]Product, []*Customer)
+	Measure() (float64, bool, Status, [2]rune)
+	Node() *Node
+	Callback() (func(), chan int, interface{})
+}
+
+type Status int
+
+type Product struct {
+	Name     string
+	Tags     []string
+	Price    Price
+	Customer *Customer
+	internal int
+}
+
+type Price struct {
+	Amount   int64
+	Currency string
+}
+
+type Node struct {
+	Value    string
+	Next     *Node
+	Children []Node
+}
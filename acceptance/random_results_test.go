@@ -0,0 +1,130 @@
+package acceptance_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type seedLogger struct {
+	messages []string
+}
+
+func (l *seedLogger) Helper() {}
+
+func (l *seedLogger) Logf(format string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+var _ = Describe("RandomResults", func() {
+	var stub *acceptance_stubs.RandomResultsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.RandomResultsStub)
+	})
+
+	It("is possible to configure random results for a method", func() {
+		stub.CustomerReturnsRandom(rand.New(rand.NewSource(1)))
+
+		customer, _ := stub.Customer(1)
+		Ω(customer.Name).ShouldNot(BeEmpty())
+		Ω(customer.Address).ShouldNot(BeEmpty())
+	})
+
+	It("populates nested types from other packages", func() {
+		for seed := int64(1); seed <= 20; seed++ {
+			stub.CatalogReturnsRandom(rand.New(rand.NewSource(seed)))
+			catalog, customers := stub.Catalog()
+			for _, products := range catalog {
+				for _, product := range products {
+					Ω(product.Name).ShouldNot(BeEmpty())
+					Ω(product.Price.Currency).ShouldNot(BeEmpty())
+					Ω(product.Customer).ShouldNot(BeNil())
+				}
+			}
+			for _, customer := range customers {
+				Ω(customer).ShouldNot(BeNil())
+			}
+		}
+	})
+
+	It("returns both nil and synthetic errors", func() {
+		var errs []error
+		for seed := int64(1); seed <= 20; seed++ {
+			stub.CustomerReturnsRandom(rand.New(rand.NewSource(seed)))
+			_, err := stub.Customer(1)
+			errs = append(errs, err)
+		}
+		Ω(errs).Should(ContainElement(BeNil()))
+		Ω(errs).Should(ContainElement(MatchError(HavePrefix("random error "))))
+	})
+
+	It("expands recursive types only once", func() {
+		stub.NodeReturnsRandom(rand.New(rand.NewSource(1)))
+
+		node := stub.Node()
+		Ω(node).ShouldNot(BeNil())
+		Ω(node.Value).ShouldNot(BeEmpty())
+		Ω(node.Next).Should(BeNil())
+		for _, child := range node.Children {
+			Ω(child.Value).Should(BeEmpty())
+		}
+	})
+
+	It("uses nil for functions, channels and interfaces", func() {
+		stub.CallbackReturnsRandom(rand.New(rand.NewSource(1)))
+
+		callback, channel, value := stub.Callback()
+		Ω(callback).Should(BeNil())
+		Ω(channel).Should(BeNil())
+		Ω(value).Should(BeNil())
+	})
+
+	It("produces the same results for the same seed", func() {
+		otherStub := new(acceptance_stubs.RandomResultsStub)
+		stub.RandomizeResults(42, nil)
+		otherStub.RandomizeResults(42, nil)
+
+		customer, err := stub.Customer(1)
+		otherCustomer, otherErr := otherStub.Customer(1)
+		Ω(customer).Should(Equal(otherCustomer))
+		Ω(fmt.Sprint(err)).Should(Equal(fmt.Sprint(otherErr)))
+		Ω(stub.Node()).Should(Equal(otherStub.Node()))
+	})
+
+	It("produces different results for different seeds", func() {
+		otherStub := new(acceptance_stubs.RandomResultsStub)
+		stub.RandomizeResults(1, nil)
+		otherStub.RandomizeResults(2, nil)
+
+		customer, _ := stub.Customer(1)
+		otherCustomer, _ := otherStub.Customer(1)
+		Ω(customer).ShouldNot(Equal(otherCustomer))
+	})
+
+	It("reports the seed", func() {
+		logger := new(seedLogger)
+		seed := stub.RandomizeResults(42, logger)
+		Ω(seed).Should(Equal(int64(42)))
+		Ω(logger.messages).Should(Equal([]string{
+			"results of RandomResultsStub are randomized with seed 42",
+		}))
+	})
+
+	It("picks and reports a seed when none is specified", func() {
+		logger := new(seedLogger)
+		seed := stub.RandomizeResults(0, logger)
+		Ω(seed).ShouldNot(BeZero())
+		Ω(logger.messages).Should(Equal([]string{
+			fmt.Sprintf("results of RandomResultsStub are randomized with seed %d", seed),
+		}))
+
+		otherStub := new(acceptance_stubs.RandomResultsStub)
+		otherStub.RandomizeResults(seed, nil)
+		Ω(stub.Node()).Should(Equal(otherStub.Node()))
+	})
+})
@@ -0,0 +1,51 @@
+package acceptance_test
+
+import (
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+	"github.com/mokiat/gostub/recorder"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var rec *recorder.Recorder
+	var saveStub *acceptance_stubs.RecordedPrimitiveParamsStub
+	var runStub *acceptance_stubs.NoParamsNoResultsStub
+
+	BeforeEach(func() {
+		rec = recorder.NewRecorder()
+		saveStub = new(acceptance_stubs.RecordedPrimitiveParamsStub)
+		saveStub.AttachRecorder(rec)
+		runStub = new(acceptance_stubs.NoParamsNoResultsStub)
+		runStub.AttachRecorder(rec)
+	})
+
+	It("records calls across stubs in a single sequence", func() {
+		runStub.Run()
+		saveStub.Save(1, "/tmp", 0.5)
+		runStub.Run()
+		Ω(rec.Calls()).Should(Equal([]recorder.Call{
+			{Sequence: 1, Stub: "NoParamsNoResultsStub", Method: "Run", Args: []interface{}{}},
+			{Sequence: 2, Stub: "RecordedPrimitiveParamsStub", Method: "Save", Args: []interface{}{1, "/tmp", float32(0.5)}},
+			{Sequence: 3, Stub: "NoParamsNoResultsStub", Method: "Run", Args: []interface{}{}},
+		}))
+		Ω(rec.InOrder("NoParamsNoResultsStub.Run", "RecordedPrimitiveParamsStub.Save", "NoParamsNoResultsStub.Run")).Should(Succeed())
+		Ω(rec.InOrder("RecordedPrimitiveParamsStub.Save", "RecordedPrimitiveParamsStub.Save")).ShouldNot(Succeed())
+	})
+
+	It("allows listeners to inspect the stubs", func() {
+		var counts []int
+		rec.AddListener(func(call recorder.Call) {
+			counts = append(counts, saveStub.SaveCallCount())
+		})
+		saveStub.Save(1, "/tmp", 0.5)
+		Ω(counts).Should(Equal([]int{1}))
+	})
+
+	It("does not record calls to stubs without a recorder", func() {
+		otherStub := new(acceptance_stubs.NoParamsNoResultsStub)
+		otherStub.Run()
+		Ω(rec.Calls()).Should(BeEmpty())
+	})
+})
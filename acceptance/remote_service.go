@@ -0,0 +1,9 @@
+package acceptance
+
+//go:generate gostub -r RemoteService
+
+type RemoteService interface {
+	Fetch(key string) (Customer, error)
+	Publish(topic string, tags ...string) error
+	Close()
+}
@@ -0,0 +1,139 @@
+package acceptance_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteService", func() {
+	var stub *acceptance_stubs.RemoteServiceStub
+	var server *httptest.Server
+	var client *acceptance_stubs.RemoteServiceStubRemoteClient
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.RemoteServiceStub)
+		server = httptest.NewServer(acceptance_stubs.NewRemoteServiceStubRemoteHandler(stub))
+		client = acceptance_stubs.NewRemoteServiceStubRemoteClient(server.URL, nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("is possible to configure results remotely", func() {
+		err := client.FetchReturns(Customer{Name: "John", Address: "Sofia"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		customer, err := stub.Fetch("john")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer).Should(Equal(Customer{Name: "John", Address: "Sofia"}))
+	})
+
+	It("is possible to configure error results remotely", func() {
+		err := client.PublishReturns(errors.New("topic not found"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(stub.Publish("news")).Should(MatchError("topic not found"))
+	})
+
+	It("is possible to configure results for specific calls remotely", func() {
+		Ω(client.FetchReturns(Customer{Name: "John"}, nil)).Should(Succeed())
+		Ω(client.FetchReturnsOnCall(1, Customer{}, errors.New("customer not found"))).Should(Succeed())
+
+		customer, err := stub.Fetch("john")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer.Name).Should(Equal("John"))
+
+		_, err = stub.Fetch("jane")
+		Ω(err).Should(MatchError("customer not found"))
+	})
+
+	It("is possible to get the calls remotely", func() {
+		stub.Publish("news", "sports", "weather")
+		stub.Publish("alerts")
+
+		calls, err := client.PublishCalls()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(calls).Should(Equal([]acceptance_stubs.RemoteServiceStubPublishArgs{
+			{Arg1: "news", Arg2: []string{"sports", "weather"}},
+			{Arg1: "alerts"},
+		}))
+
+		count, err := client.PublishCallCount()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(2))
+	})
+
+	It("is possible to get the calls of methods without params remotely", func() {
+		stub.Close()
+
+		count, err := client.CloseCallCount()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(1))
+	})
+
+	It("reports scenarios that are rejected by the stub", func() {
+		resp, err := http.Post(server.URL+"/scenario", "application/json", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Ω(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects unknown paths", func() {
+		resp, err := http.Get(server.URL + "/calls/Delete")
+		Ω(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Ω(resp.StatusCode).Should(Equal(http.StatusNotFound))
+	})
+
+	It("reports unsuccessful responses", func() {
+		client = acceptance_stubs.NewRemoteServiceStubRemoteClient(server.URL+"/missing", nil)
+
+		_, err := client.FetchCalls()
+		Ω(err).Should(MatchError(HavePrefix("remote RemoteServiceStub responded with 404 Not Found")))
+	})
+
+	It("is possible to control the stub over a unix socket", func() {
+		dir, err := ioutil.TempDir("", "gostub")
+		Ω(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		socketPath := filepath.Join(dir, "stub.sock")
+
+		listener, err := net.Listen("unix", socketPath)
+		Ω(err).ShouldNot(HaveOccurred())
+		unixServer := &httptest.Server{
+			Listener: listener,
+			Config: &http.Server{
+				Handler: acceptance_stubs.NewRemoteServiceStubRemoteHandler(stub),
+			},
+		}
+		unixServer.Start()
+		defer unixServer.Close()
+
+		client = acceptance_stubs.NewRemoteServiceStubRemoteClient("http://stub", &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		})
+		Ω(client.FetchReturns(Customer{Name: "John"}, nil)).Should(Succeed())
+
+		customer, err := stub.Fetch("john")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer.Name).Should(Equal("John"))
+	})
+})
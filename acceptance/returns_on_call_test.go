@@ -0,0 +1,58 @@
+package acceptance_test
+
+import (
+	"errors"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReturnsOnCall", func() {
+	var stub *acceptance_stubs.ConditionalReturnsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.ConditionalReturnsStub)
+		stub.LookupReturns("default", nil)
+	})
+
+	It("returns the results configured for specific calls", func() {
+		stub.LookupReturnsOnCall(1, "second", errors.New("failed"))
+
+		value, err := stub.Lookup("key", 1)
+		Ω(value).Should(Equal("default"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		value, err = stub.Lookup("key", 1)
+		Ω(value).Should(Equal("second"))
+		Ω(err).Should(MatchError("failed"))
+
+		value, _ = stub.Lookup("key", 1)
+		Ω(value).Should(Equal("default"))
+	})
+
+	It("takes precedence over rules", func() {
+		stub.LookupCalledWith("key", 1).Returns("rule", nil)
+		stub.LookupReturnsOnCall(0, "first", nil)
+
+		value, _ := stub.Lookup("key", 1)
+		Ω(value).Should(Equal("first"))
+		value, _ = stub.Lookup("key", 1)
+		Ω(value).Should(Equal("rule"))
+	})
+
+	It("does not report configured calls in strict mode", func() {
+		reporter := new(acceptance_stubs.StrictReporterStub)
+		unconfigured := new(acceptance_stubs.ConditionalReturnsStub)
+		unconfigured.SetStrict(reporter)
+		unconfigured.LookupReturnsOnCall(0, "first", nil)
+
+		value, _ := unconfigured.Lookup("key", 1)
+		Ω(value).Should(Equal("first"))
+		Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+
+		unconfigured.Lookup("key", 1)
+		Ω(reporter.ErrorfCallCount()).Should(Equal(1))
+	})
+})
@@ -0,0 +1,102 @@
+package acceptance_test
+
+import (
+	"strings"
+
+	. "github.com/mokiat/gostub/acceptance"
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario", func() {
+	var stub *acceptance_stubs.GoldenServiceStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.GoldenServiceStub)
+	})
+
+	It("configures default results", func() {
+		err := stub.LoadScenario(strings.NewReader(`{
+			"Lookup": {"returns": [{"Name": "John", "Address": "Sofia"}, null]},
+			"Search": {"returns": [["first", "second"]]}
+		}`))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		customer, err := stub.Lookup(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer).Should(Equal(Customer{Name: "John", Address: "Sofia"}))
+		Ω(stub.Search("query")).Should(Equal([]string{"first", "second"}))
+	})
+
+	It("configures results for specific calls", func() {
+		err := stub.LoadScenario(strings.NewReader(`{
+			"Lookup": {
+				"returns": [{"Name": "John"}, null],
+				"onCall": {"1": [{}, "customer not found"]}
+			}
+		}`))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		customer, err := stub.Lookup(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(customer.Name).Should(Equal("John"))
+
+		_, err = stub.Lookup(2)
+		Ω(err).Should(MatchError("customer not found"))
+	})
+
+	It("reports malformed scenarios", func() {
+		err := stub.LoadScenario(strings.NewReader(`{"Lookup":`))
+		Ω(err).Should(MatchError(HavePrefix("cannot decode scenario for GoldenServiceStub")))
+	})
+
+	It("reports unknown methods", func() {
+		err := stub.LoadScenario(strings.NewReader(`{"Delete": {"returns": []}}`))
+		Ω(err).Should(MatchError("cannot configure GoldenServiceStub.Delete from scenario: unknown method"))
+	})
+
+	It("reports methods without results", func() {
+		err := stub.LoadScenario(strings.NewReader(`{"Notify": {"returns": []}}`))
+		Ω(err).Should(MatchError("cannot configure GoldenServiceStub.Notify from scenario: method has no results"))
+	})
+
+	It("reports a wrong number of results", func() {
+		err := stub.LoadScenario(strings.NewReader(`{"Lookup": {"returns": [{}]}}`))
+		Ω(err).Should(MatchError("cannot configure GoldenServiceStub.Lookup from scenario: expected 2 results, got 1"))
+	})
+
+	It("reports results of a wrong type", func() {
+		err := stub.LoadScenario(strings.NewReader(`{"Search": {"returns": ["first"]}}`))
+		Ω(err).Should(MatchError(HavePrefix("cannot configure GoldenServiceStub.Search from scenario: cannot decode result 1")))
+	})
+
+	It("reports results of unsupported types", func() {
+		funcStub := new(acceptance_stubs.FuncSupportStub)
+		err := funcStub.LoadScenario(strings.NewReader(`{"Method": {"returns": [null]}}`))
+		Ω(err).Should(MatchError("cannot configure FuncSupportStub.Method from scenario: result 1 is a function and cannot be decoded from JSON"))
+	})
+
+	It("leaves the stub unchanged if any entry is invalid", func() {
+		err := stub.LoadScenario(strings.NewReader(`{
+			"Lookup": {"returns": [{"Name": "John"}, null]},
+			"Search": {"returns": ["first"]}
+		}`))
+		Ω(err).Should(HaveOccurred())
+
+		customer, _ := stub.Lookup(1)
+		Ω(customer).Should(BeZero())
+	})
+
+	It("reports the first invalid entry in sorted order", func() {
+		err := stub.LoadScenario(strings.NewReader(`{
+			"Lookup": {"onCall": {"2": [{}], "1": [{}]}},
+			"Delete": {"returns": []}
+		}`))
+		Ω(err).Should(MatchError("cannot configure GoldenServiceStub.Delete from scenario: unknown method"))
+
+		err = stub.LoadScenario(strings.NewReader(`{"Lookup": {"onCall": {"2": [{}], "1": []}}}`))
+		Ω(err).Should(MatchError("cannot configure GoldenServiceStub.Lookup from scenario: expected 2 results, got 0"))
+	})
+})
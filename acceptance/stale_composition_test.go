@@ -0,0 +1,89 @@
+package acceptance_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mokiat/gostub/generator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StaleComposition", func() {
+	var dir string
+
+	writeReaderStub := func(source string) {
+		err := ioutil.WriteFile(filepath.Join(dir, "reader_stub.go"), []byte("package stale_stubs\n\n"+source), 0644)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	composeReadCloser := func(features generator.Features) error {
+		return generator.Generate(generator.Config{
+			SourcePackageLocation: "github.com/mokiat/gostub/acceptance/testdata/stale",
+			SourceInterfaceName:   "ReadCloser",
+			TargetFilePath:        filepath.Join(dir, "read_closer_stub.go"),
+			TargetPackageName:     "stale_stubs",
+			TargetStructName:      "ReadCloserStub",
+			Compose:               true,
+			Features:              features,
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gostub")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("composes stubs with matching types", func() {
+		writeReaderStub(`import alias1 "time"
+
+type ReaderStub struct{}
+
+func (stub *ReaderStub) Read(arg1 []byte, arg2 alias1.Duration) (int, error) {
+	return 0, nil
+}
+`)
+		Ω(composeReadCloser(generator.Features{})).Should(Succeed())
+	})
+
+	It("fails when a stub has a different parameter type", func() {
+		writeReaderStub(`type ReaderStub struct{}
+
+func (stub *ReaderStub) Read(arg1 []byte, arg2 int64) (int, error) {
+	return 0, nil
+}
+`)
+		Ω(composeReadCloser(generator.Features{})).Should(MatchError("Stub 'ReaderStub' does not match method 'Read' of interface 'Reader'! Regenerate it first."))
+	})
+
+	It("fails when a stub has a different result type", func() {
+		writeReaderStub(`import alias1 "time"
+
+type ReaderStub struct{}
+
+func (stub *ReaderStub) Read(arg1 []byte, arg2 alias1.Duration) (int64, error) {
+	return 0, nil
+}
+`)
+		Ω(composeReadCloser(generator.Features{})).Should(MatchError("Stub 'ReaderStub' does not match method 'Read' of interface 'Reader'! Regenerate it first."))
+	})
+
+	It("fails when a stub lacks a method that the composed stub relies on", func() {
+		writeReaderStub(`import alias1 "time"
+
+type ReaderStub struct{}
+
+func (stub *ReaderStub) Read(arg1 []byte, arg2 alias1.Duration) (int, error) {
+	return 0, nil
+}
+`)
+		Ω(composeReadCloser(generator.Features{Strict: true})).Should(MatchError("Stub 'ReaderStub' lacks method 'SetStrict', which the composed stub relies on! Regenerate it with the same features."))
+	})
+})
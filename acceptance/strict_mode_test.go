@@ -0,0 +1,91 @@
+package acceptance_test
+
+import (
+	"fmt"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StrictMode", func() {
+	var stub *acceptance_stubs.ConditionalReturnsStub
+	var voidStub *acceptance_stubs.StrictPrimitiveParamsStub
+	var reporter *acceptance_stubs.StrictReporterStub
+
+	reportedMessage := func(index int) string {
+		format, args := reporter.ErrorfArgsForCall(index)
+		return fmt.Sprintf(format, args...)
+	}
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.ConditionalReturnsStub)
+		voidStub = new(acceptance_stubs.StrictPrimitiveParamsStub)
+		reporter = new(acceptance_stubs.StrictReporterStub)
+	})
+
+	It("does not report unconfigured calls by default", func() {
+		stub.Lookup("key", 1)
+		Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+	})
+
+	Context("when strict mode is enabled with a reporter", func() {
+		BeforeEach(func() {
+			stub.SetStrict(reporter)
+			voidStub.SetStrict(reporter)
+		})
+
+		It("reports unconfigured calls with method name and arguments", func() {
+			stub.Lookup("key", 1)
+			Ω(reporter.ErrorfCallCount()).Should(Equal(1))
+			Ω(reportedMessage(0)).Should(ContainSubstring(`ConditionalReturnsStub.Lookup("key", 1)`))
+		})
+
+		It("reports unconfigured calls of methods without results", func() {
+			voidStub.Save(1, "/tmp", 0.5)
+			Ω(reporter.ErrorfCallCount()).Should(Equal(1))
+			Ω(reportedMessage(0)).Should(ContainSubstring(`StrictPrimitiveParamsStub.Save(1, "/tmp", 0.5)`))
+		})
+
+		It("does not report calls when results are configured", func() {
+			stub.LookupReturns("value", nil)
+			stub.Lookup("key", 1)
+			Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+		})
+
+		It("does not report calls matched by a rule", func() {
+			stub.LookupCalledWith("key", 1).Returns("value", nil)
+			stub.Lookup("key", 1)
+			Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+		})
+
+		It("does not report calls when the behavior is stubbed", func() {
+			voidStub.SaveStub = func(int, string, float32) {}
+			voidStub.Save(1, "/tmp", 0.5)
+			Ω(reporter.ErrorfCallCount()).Should(Equal(0))
+		})
+	})
+
+	Context("when strict mode is enabled without a reporter", func() {
+		BeforeEach(func() {
+			stub.SetStrict(nil)
+		})
+
+		It("panics on unconfigured calls", func() {
+			Ω(func() {
+				stub.Lookup("key", 1)
+			}).Should(Panic())
+		})
+
+		It("still records the call", func() {
+			func() {
+				defer func() {
+					recover()
+				}()
+				stub.Lookup("key", 1)
+			}()
+			Ω(stub.LookupCallCount()).Should(Equal(1))
+		})
+	})
+})
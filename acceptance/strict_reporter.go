@@ -0,0 +1,8 @@
+package acceptance
+
+//go:generate gostub --invocations StrictReporter
+
+type StrictReporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
@@ -0,0 +1,15 @@
+package stale
+
+import "time"
+
+// The interfaces in this package are composed from stubs that are
+// written by the tests, so that stale stubs can be detected.
+
+type Reader interface {
+	Read(p []byte, timeout time.Duration) (int, error)
+}
+
+type ReadCloser interface {
+	Reader
+	Close() error
+}
@@ -0,0 +1,13 @@
+package unresolved
+
+// The types in this package refer to types that do not exist, so
+// that generation fails. The package is not built, since it is
+// located in a testdata directory.
+
+type UnresolvedParams interface {
+	Save(value Missing) error
+}
+
+type UnresolvedEmbedded interface {
+	UnresolvedParams
+}
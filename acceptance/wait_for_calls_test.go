@@ -0,0 +1,50 @@
+package acceptance_test
+
+import (
+	"time"
+
+	"github.com/mokiat/gostub/acceptance/acceptance_stubs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WaitForCalls", func() {
+	var stub *acceptance_stubs.AsyncPrimitiveParamsStub
+
+	BeforeEach(func() {
+		stub = new(acceptance_stubs.AsyncPrimitiveParamsStub)
+	})
+
+	It("returns immediately when enough calls were made", func() {
+		stub.Save(1, "/first", 0.1)
+		stub.Save(2, "/second", 0.2)
+		Ω(stub.WaitForSaveCalls(2, time.Millisecond)).Should(Succeed())
+	})
+
+	It("waits for calls made from other goroutines", func() {
+		go func() {
+			for i := 0; i < 3; i++ {
+				time.Sleep(10 * time.Millisecond)
+				stub.Save(i, "/async", 0.5)
+			}
+		}()
+		Ω(stub.WaitForSaveCalls(3, time.Second)).Should(Succeed())
+		Ω(stub.SaveCallCount()).Should(Equal(3))
+	})
+
+	It("returns an error when the timeout expires", func() {
+		stub.Save(1, "/first", 0.1)
+		err := stub.WaitForSaveCalls(2, 10*time.Millisecond)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("AsyncPrimitiveParamsStub.Save"))
+		Ω(err.Error()).Should(ContainSubstring("got 1"))
+	})
+
+	It("allows inspecting calls from within the stub function", func() {
+		stub.SaveStub = func(int, string, float32) {
+			Ω(stub.SaveCallCount()).Should(Equal(1))
+		}
+		stub.Save(1, "/first", 0.1)
+	})
+})
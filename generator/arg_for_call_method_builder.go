@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewArgForCallMethodBuilder(methodBuilder *MethodBuilder) *ArgForCallMethodBuilder {
+	return &ArgForCallMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ArgForCallMethodBuilder is responsible for creating a method on the
+// stub structure that returns a single argument that was used during
+// a specific call on the stub method.
+//
+// Example:
+//     func (stub *StubStruct) SubscribeHandlerForCall(index int) func(Message) {
+//         // ...
+//     }
+type ArgForCallMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	argsFieldSelector  *ast.SelectorExpr
+	param              *ast.Field
+}
+
+func (b *ArgForCallMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *ArgForCallMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+// SetParam specifies the parameter of the original method that
+// should be returned. The parameter needs to have been normalized
+// and resolved in advance.
+func (b *ArgForCallMethodBuilder) SetParam(param *ast.Field) {
+	b.param = param
+}
+
+func (b *ArgForCallMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("index", ast.NewIdent("int")),
+			},
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsWithoutEllipsis(util.FieldsAsAnonymous([]*ast.Field{b.param})),
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.SelectorExpr{
+				X: &ast.IndexExpr{
+					X:     b.argsFieldSelector,
+					Index: ast.NewIdent("index"),
+				},
+				Sel: ast.NewIdent(util.ToPublic(b.param.Names[0].String())),
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -7,9 +7,7 @@ import (
 )
 
 func NewMethodArgsFieldBuilder() *MethodArgsFieldBuilder {
-	return &MethodArgsFieldBuilder{
-		params: make([]*ast.Field, 0),
-	}
+	return &MethodArgsFieldBuilder{}
 }
 
 // The MethodArgsFieldBuilder is responsible for creating the field
@@ -26,8 +24,8 @@ func NewMethodArgsFieldBuilder() *MethodArgsFieldBuilder {
 //         // ...
 //     }
 type MethodArgsFieldBuilder struct {
-	fieldName string
-	params    []*ast.Field
+	fieldName    string
+	argsTypeName string
 }
 
 func (b *MethodArgsFieldBuilder) SetFieldName(name string) {
@@ -36,16 +34,14 @@ func (b *MethodArgsFieldBuilder) SetFieldName(name string) {
 
 // SetParams configures the parameters that the original method has.
 // The parameters should have been normalized and resolved beforehand.
-func (b *MethodArgsFieldBuilder) SetParams(params []*ast.Field) {
-	b.params = params
+// SetArgsTypeName configures the name of the type that holds the
+// arguments of a single call.
+func (b *MethodArgsFieldBuilder) SetArgsTypeName(name string) {
+	b.argsTypeName = name
 }
 
 func (b *MethodArgsFieldBuilder) Build() *ast.Field {
 	return util.CreateField(b.fieldName, &ast.ArrayType{
-		Elt: &ast.StructType{
-			Fields: &ast.FieldList{
-				List: util.FieldsWithoutEllipsis(b.params),
-			},
-		},
+		Elt: ast.NewIdent(b.argsTypeName),
 	})
 }
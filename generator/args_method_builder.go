@@ -73,7 +73,7 @@ func (b *ArgsMethodBuilder) Build() ast.Decl {
 				X:     b.argsFieldSelector,
 				Index: ast.NewIdent("index"),
 			},
-			Sel: ast.NewIdent(param.Names[0].String()),
+			Sel: ast.NewIdent(util.ToPublic(param.Names[0].String())),
 		})
 	}
 	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
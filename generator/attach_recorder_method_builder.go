@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewAttachRecorderMethodBuilder(methodBuilder *MethodBuilder) *AttachRecorderMethodBuilder {
+	return &AttachRecorderMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// AttachRecorderMethodBuilder is responsible for creating a method on
+// the stub structure that attaches a recorder, which gets notified
+// of all subsequent calls to the stub.
+//
+// Example:
+//     func (stub *StubStruct) AttachRecorder(recorder interface {
+//         Record(stub string, method string, args []interface{})
+//     }) {
+//         // ...
+//     }
+type AttachRecorderMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	mutexFieldSelector    *ast.SelectorExpr
+	recorderFieldSelector *ast.SelectorExpr
+	recorderType          ast.Expr
+}
+
+func (b *AttachRecorderMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *AttachRecorderMethodBuilder) SetRecorderFieldSelector(selector *ast.SelectorExpr) {
+	b.recorderFieldSelector = selector
+}
+
+// SetRecorderType configures the type of the recorder.
+// The type should have already been resolved.
+func (b *AttachRecorderMethodBuilder) SetRecorderType(recorderType ast.Expr) {
+	b.recorderType = recorderType
+}
+
+func (b *AttachRecorderMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("recorder", b.recorderType),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.recorderFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("recorder"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewBlocksMethodBuilder(methodBuilder *MethodBuilder) *BlocksMethodBuilder {
+	return &BlocksMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// BlocksMethodBuilder is responsible for creating a method on the stub
+// structure that makes calls to the stubbed method block until their
+// context is done.
+//
+// Example:
+//     func (stub *StubStruct) FetchBlocksUntilContextDone() {
+//         // ...
+//     }
+type BlocksMethodBuilder struct {
+	methodBuilder       *MethodBuilder
+	mutexFieldSelector  *ast.SelectorExpr
+	blocksFieldSelector *ast.SelectorExpr
+}
+
+func (b *BlocksMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *BlocksMethodBuilder) SetBlocksFieldSelector(selector *ast.SelectorExpr) {
+	b.blocksFieldSelector = selector
+}
+
+func (b *BlocksMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.blocksFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("true"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
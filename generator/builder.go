@@ -14,6 +14,20 @@ type StatementBuilder interface {
 	Build() ast.Stmt
 }
 
+func FieldToBuilder(field *ast.Field) FieldBuilder {
+	return &fieldBuilder{
+		field: field,
+	}
+}
+
+type fieldBuilder struct {
+	field *ast.Field
+}
+
+func (b *fieldBuilder) Build() *ast.Field {
+	return b.field
+}
+
 func StatementToBuilder(statement ast.Stmt) StatementBuilder {
 	return &statementBuilder{
 		statement: statement,
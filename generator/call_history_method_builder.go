@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewCallHistoryMethodBuilder(methodBuilder *MethodBuilder) *CallHistoryMethodBuilder {
+	return &CallHistoryMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// CallHistoryMethodBuilder is responsible for creating a method on the
+// stub structure that returns the arguments of all calls to the stubbed
+// method, in a form that can be used for reporting.
+//
+// Example:
+//     func (stub *StubStruct) sumCallHistory() [][]interface{} {
+//         // ...
+//     }
+type CallHistoryMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	argsFieldSelector  *ast.SelectorExpr
+	params             []*ast.Field
+}
+
+func (b *CallHistoryMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *CallHistoryMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *CallHistoryMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+func (b *CallHistoryMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	historyType := &ast.ArrayType{
+		Elt: &ast.ArrayType{
+			Elt: util.CreateEmptyInterface(),
+		},
+	}
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: historyType,
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("history"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("make"),
+				Args: []ast.Expr{
+					historyType,
+					&ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							b.argsFieldSelector,
+						},
+					},
+				},
+			},
+		},
+	}))
+
+	args := []ast.Expr{}
+	for _, param := range b.params {
+		args = append(args, &ast.SelectorExpr{
+			X: &ast.IndexExpr{
+				X:     b.argsFieldSelector,
+				Index: ast.NewIdent("i"),
+			},
+			Sel: ast.NewIdent(util.ToPublic(param.Names[0].String())),
+		})
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key: ast.NewIdent("i"),
+		Tok: token.DEFINE,
+		X:   b.argsFieldSelector,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.IndexExpr{
+							X:     ast.NewIdent("history"),
+							Index: ast.NewIdent("i"),
+						},
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CompositeLit{
+							Type: &ast.ArrayType{
+								Elt: util.CreateEmptyInterface(),
+							},
+							Elts: args,
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("history"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
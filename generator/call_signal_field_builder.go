@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMethodCallSignalFieldBuilder() *MethodCallSignalFieldBuilder {
+	return &MethodCallSignalFieldBuilder{}
+}
+
+// The MethodCallSignalFieldBuilder is responsible for creating the
+// field which is internally used to notify goroutines that are waiting
+// for a given method to be called.
+//
+// Example:
+//     type StubStruct struct {
+//         // ...
+//         sumCallSignal chan struct{}
+//         // ...
+//     }
+type MethodCallSignalFieldBuilder struct {
+	fieldName string
+}
+
+func (b *MethodCallSignalFieldBuilder) SetFieldName(name string) {
+	b.fieldName = name
+}
+
+func (b *MethodCallSignalFieldBuilder) Build() *ast.Field {
+	return util.CreateField(b.fieldName, &ast.ChanType{
+		Dir:   ast.SEND | ast.RECV,
+		Value: util.CreateEmptyStruct(),
+	})
+}
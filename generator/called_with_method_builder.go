@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewCalledWithMethodBuilder(methodBuilder *MethodBuilder) *CalledWithMethodBuilder {
+	return &CalledWithMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// CalledWithMethodBuilder is responsible for creating a method on the
+// stub structure that allows you to register results which are returned
+// only when a call is made with exactly the specified arguments.
+//
+// Example:
+//     func (stub *StubStruct) SumCalledWith(a int, b int) *StubStructSumRule {
+//         // ...
+//     }
+type CalledWithMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	whenMethodSelector *ast.SelectorExpr
+	deepEqualSelector  *ast.SelectorExpr
+	ruleTypeName       string
+	params             []*ast.Field
+}
+
+// SetWhenMethodSelector configures the method which is used to
+// register the exact-match rule.
+func (b *CalledWithMethodBuilder) SetWhenMethodSelector(selector *ast.SelectorExpr) {
+	b.whenMethodSelector = selector
+}
+
+// SetDeepEqualSelector configures the function that is used to
+// compare the expected arguments against the actual ones.
+// The selector should have already been resolved.
+func (b *CalledWithMethodBuilder) SetDeepEqualSelector(selector *ast.SelectorExpr) {
+	b.deepEqualSelector = selector
+}
+
+func (b *CalledWithMethodBuilder) SetRuleTypeName(name string) {
+	b.ruleTypeName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *CalledWithMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+func (b *CalledWithMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.ruleTypeName),
+					},
+				},
+			},
+		},
+	})
+
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("expected"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.buildArgsSlice(),
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.whenMethodSelector,
+				Args: []ast.Expr{
+					&ast.FuncLit{
+						Type: &ast.FuncType{
+							Params: &ast.FieldList{
+								List: b.params,
+							},
+							Results: &ast.FieldList{
+								List: []*ast.Field{
+									{
+										Type: ast.NewIdent("bool"),
+									},
+								},
+							},
+						},
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								&ast.ReturnStmt{
+									Results: []ast.Expr{
+										&ast.CallExpr{
+											Fun: b.deepEqualSelector,
+											Args: []ast.Expr{
+												b.buildArgsSlice(),
+												ast.NewIdent("expected"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *CalledWithMethodBuilder) buildArgsSlice() ast.Expr {
+	paramSelectors := []ast.Expr{}
+	for _, param := range b.params {
+		paramSelectors = append(paramSelectors, ast.NewIdent(param.Names[0].String()))
+	}
+	return &ast.CompositeLit{
+		Type: &ast.ArrayType{
+			Elt: util.CreateEmptyInterface(),
+		},
+		Elts: paramSelectors,
+	}
+}
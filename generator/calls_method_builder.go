@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewCallsMethodBuilder(methodBuilder *MethodBuilder) *CallsMethodBuilder {
+	return &CallsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// CallsMethodBuilder is responsible for creating a method on the stub
+// structure that returns a copy of recorded calls, such as the arguments
+// of all calls to the stubbed method.
+//
+// Example:
+//     func (stub *StubStruct) SumCalls() []StubStructSumArgs {
+//         // ...
+//     }
+type CallsMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	callsFieldSelector *ast.SelectorExpr
+	callTypeName       string
+}
+
+func (b *CallsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *CallsMethodBuilder) SetCallsFieldSelector(selector *ast.SelectorExpr) {
+	b.callsFieldSelector = selector
+}
+
+// SetCallTypeName configures the name of the type that describes
+// a single recorded call.
+func (b *CallsMethodBuilder) SetCallTypeName(name string) {
+	b.callTypeName = name
+}
+
+func (b *CallsMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	callsType := &ast.ArrayType{
+		Elt: ast.NewIdent(b.callTypeName),
+	}
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: callsType,
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("calls"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("make"),
+				Args: []ast.Expr{
+					callsType,
+					&ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							b.callsFieldSelector,
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent("copy"),
+			Args: []ast.Expr{
+				ast.NewIdent("calls"),
+				b.callsFieldSelector,
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("calls"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewChainMethodBuilder(methodBuilder *MethodBuilder) *ChainMethodBuilder {
+	return &ChainMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		fields:        make([]ast.Expr, 0),
+		values:        make([]ast.Expr, 0),
+	}
+}
+
+// ChainMethodBuilder is responsible for creating a method that assigns
+// values to fields of its receiver and returns the receiver, so that
+// calls to such methods can be chained.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) Times(count int) *StubStructCallMatcher {
+//         matcher.times = count
+//         return matcher
+//     }
+type ChainMethodBuilder struct {
+	methodBuilder *MethodBuilder
+	receiverName  string
+	receiverType  string
+	params        []*ast.Field
+	fields        []ast.Expr
+	values        []ast.Expr
+}
+
+// SetReceiver specifies the name and type of the receiver that
+// is returned by the method.
+func (b *ChainMethodBuilder) SetReceiver(name, recType string) {
+	b.receiverName = name
+	b.receiverType = recType
+}
+
+func (b *ChainMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// AddAssignment specifies that the value should be assigned to
+// the field when the method is called.
+func (b *ChainMethodBuilder) AddAssignment(field *ast.SelectorExpr, value ast.Expr) {
+	b.fields = append(b.fields, field)
+	b.values = append(b.values, value)
+}
+
+func (b *ChainMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetReceiver(b.receiverName, b.receiverType)
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.receiverType),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: b.fields,
+		Tok: token.ASSIGN,
+		Rhs: b.values,
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(b.receiverName),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
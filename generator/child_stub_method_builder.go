@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewChildStubMethodBuilder(methodBuilder *MethodBuilder) *ChildStubMethodBuilder {
+	return &ChildStubMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ChildStubMethodBuilder is responsible for creating a method on the
+// stub structure that returns the child stub which is used as the
+// default result of a stubbed method. The child stub is created on
+// first use.
+//
+// Example:
+//     func (stub *StubStruct) UsersStubValue() *UserServiceStub {
+//         // ...
+//     }
+type ChildStubMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	childFieldSelector *ast.SelectorExpr
+	childStubName      string
+}
+
+// SetMutexFieldSelector configures the mutex that guards the child
+// field. It should not be the mutex of the stubbed method, since the
+// method is called while that mutex is held.
+func (b *ChildStubMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *ChildStubMethodBuilder) SetChildFieldSelector(selector *ast.SelectorExpr) {
+	b.childFieldSelector = selector
+}
+
+// SetChildStubName specifies the name of the child stub structure.
+func (b *ChildStubMethodBuilder) SetChildStubName(name string) {
+	b.childStubName = name
+}
+
+func (b *ChildStubMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.childStubName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.childFieldSelector,
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						b.childFieldSelector,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("new"),
+							Args: []ast.Expr{
+								ast.NewIdent(b.childStubName),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			b.childFieldSelector,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewConstructorBuilder(methodBuilder *MethodBuilder) *ConstructorBuilder {
+	return &ConstructorBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ConstructorBuilder is responsible for creating a function that
+// creates a new stub and applies the specified options to it.
+//
+// Example:
+//     func NewStubStruct(opts ...StubStructOption) *StubStruct {
+//         // ...
+//     }
+type ConstructorBuilder struct {
+	methodBuilder  *MethodBuilder
+	stubName       string
+	optionTypeName string
+}
+
+func (b *ConstructorBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *ConstructorBuilder) SetOptionTypeName(name string) {
+	b.optionTypeName = name
+}
+
+func (b *ConstructorBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("opts", &ast.Ellipsis{
+					Elt: ast.NewIdent(b.optionTypeName),
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.stubName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent(receiverName),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("new"),
+				Args: []ast.Expr{
+					ast.NewIdent(b.stubName),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("opt"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("opts"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("opt"),
+						Args: []ast.Expr{
+							ast.NewIdent(receiverName),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(receiverName),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
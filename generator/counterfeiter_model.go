@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+const fakeReceiverName string = "fake"
+const fakeInvocationsFieldName string = "invocations"
+const fakeInvocationsMutexFieldName string = "invocationsMutex"
+const fakeRecordInvocationMethodName string = "recordInvocation"
+
+// NewCounterfeiterModel creates a model for a fake whose API, field
+// names and receiver match the ones of fakes that are generated by
+// counterfeiter, so that tests written against such fakes keep working.
+func NewCounterfeiterModel(pkgName, fakeName string) *CounterfeiterModel {
+	structBuilder := NewStructBuilder()
+	structBuilder.SetName(fakeName)
+
+	fileBuilder := NewFileBuilder()
+	fileBuilder.SetPackage(pkgName)
+	fileBuilder.AddDeclarationBuilder(structBuilder)
+
+	model := &CounterfeiterModel{
+		fileBuilder:   fileBuilder,
+		structBuilder: structBuilder,
+		structName:    fakeName,
+	}
+	model.createInvocationsFields()
+	model.createInvocationsMethod()
+	model.createRecordInvocationMethod()
+	return model
+}
+
+type CounterfeiterModel struct {
+	fileBuilder   *FileBuilder
+	structBuilder *StructBuilder
+	structName    string
+}
+
+func (t *CounterfeiterModel) AddStubAssignment(interfaceLocation, interfaceName string) {
+	builder := NewStubToInterfaceStatementBuilder()
+	builder.SetStubName(t.structName)
+	builder.SetInterfaceSelector(t.resolveInterfaceType(interfaceLocation, interfaceName))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) AddImport(pkgName, location string) string {
+	return t.fileBuilder.AddImport(pkgName, location)
+}
+
+// IsResolvedType checks whether the specified type, which should have
+// been resolved against the fake's namespace, refers to the type with
+// the specified name in the specified location.
+func (t *CounterfeiterModel) IsResolvedType(resolvedType ast.Expr, location, name string) bool {
+	return isResolvedType(t.fileBuilder, resolvedType, location, name)
+}
+
+func (t *CounterfeiterModel) AddMethod(config *MethodConfig) error {
+	t.createMethodFields(config)
+	t.createFakeMethod(config)
+	t.createCallCountMethod(config)
+	t.createCallsMethod(config)
+	if config.HasParams() {
+		t.createArgsForCallMethod(config)
+	}
+	if config.HasResults() {
+		t.createReturnsMethod(config)
+		t.createReturnsOnCallMethod(config)
+	}
+	return nil
+}
+
+// AddUnexpectedMethod adds a method that satisfies the interface
+// but panics when called, for methods that were not selected for
+// faking.
+func (t *CounterfeiterModel) AddUnexpectedMethod(config *MethodConfig) {
+	builder := NewUnexpectedMethodBuilder(t.createMethodBuilder(config.MethodName))
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+// AddEmbeddedStub embeds an existing fake into the fake, so that the
+// methods of the interface of the existing fake are promoted from it.
+func (t *CounterfeiterModel) AddEmbeddedStub(fakeName string) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(&ast.Field{
+		Type: ast.NewIdent(fakeName),
+	}))
+}
+
+// AddPromotedMethod does nothing for fakes, since calls to promoted
+// methods are recorded by the embedded fake.
+func (t *CounterfeiterModel) AddPromotedMethod(fakeName string, config *MethodConfig) {
+}
+
+func (t *CounterfeiterModel) createInvocationsFields() {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(fakeInvocationsFieldName, fakeInvocationsType())))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(fakeInvocationsMutexFieldName, t.resolveMutexType())))
+}
+
+func (t *CounterfeiterModel) createInvocationsMethod() {
+	builder := NewFakeInvocationsMethodBuilder(t.createMethodBuilder("Invocations"))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(fakeInvocationsMutexFieldName))
+	builder.SetInvocationsFieldSelector(t.fakeFieldSelector(fakeInvocationsFieldName))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createRecordInvocationMethod() {
+	builder := NewFakeRecordInvocationMethodBuilder(t.createMethodBuilder(fakeRecordInvocationMethodName))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(fakeInvocationsMutexFieldName))
+	builder.SetInvocationsFieldSelector(t.fakeFieldSelector(fakeInvocationsFieldName))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createMethodFields(config *MethodConfig) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.StubFieldName(), &ast.FuncType{
+		Params: &ast.FieldList{
+			List: util.FieldsAsAnonymous(config.MethodParams),
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(config.MethodResults),
+		},
+	})))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.MutexFieldName(), t.resolveMutexType())))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ArgsFieldName(), &ast.ArrayType{
+		Elt: fakeStructType(util.FieldsWithoutEllipsis(config.MethodParams)),
+	})))
+	if config.HasResults() {
+		t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ReturnsFieldName(), fakeStructType(config.MethodResults))))
+		t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ReturnsOnCallFieldName(), &ast.MapType{
+			Key:   ast.NewIdent("int"),
+			Value: fakeStructType(config.MethodResults),
+		})))
+	}
+}
+
+func (t *CounterfeiterModel) createFakeMethod(config *MethodConfig) {
+	builder := NewFakeMethodBuilder(t.createMethodBuilder(config.MethodName))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(config.MutexFieldName()))
+	builder.SetArgsFieldSelector(t.fakeFieldSelector(config.ArgsFieldName()))
+	builder.SetReturnsFieldSelectors(t.fakeFieldSelector(config.ReturnsFieldName()), t.fakeFieldSelector(config.ReturnsOnCallFieldName()))
+	builder.SetStubFieldSelector(t.fakeFieldSelector(config.StubFieldName()))
+	builder.SetRecordMethodSelector(t.fakeFieldSelector(fakeRecordInvocationMethodName))
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createCallCountMethod(config *MethodConfig) {
+	builder := NewCountMethodBuilder(t.createMethodBuilder(config.CallCountMethodName()))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(config.MutexFieldName()))
+	builder.SetArgsFieldSelector(t.fakeFieldSelector(config.ArgsFieldName()))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createCallsMethod(config *MethodConfig) {
+	builder := NewFakeCallsMethodBuilder(t.createMethodBuilder(config.CallsMethodName()))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(config.MutexFieldName()))
+	builder.SetStubFieldSelector(t.fakeFieldSelector(config.StubFieldName()))
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createArgsForCallMethod(config *MethodConfig) {
+	builder := NewFakeArgsForCallMethodBuilder(t.createMethodBuilder(config.ArgsForCallMethodName()))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(config.MutexFieldName()))
+	builder.SetArgsFieldSelector(t.fakeFieldSelector(config.ArgsFieldName()))
+	builder.SetParams(config.MethodParams)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createReturnsMethod(config *MethodConfig) {
+	builder := NewFakeReturnsMethodBuilder(t.createMethodBuilder(config.ReturnsMethodName()))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(config.MutexFieldName()))
+	builder.SetStubFieldSelector(t.fakeFieldSelector(config.StubFieldName()))
+	builder.SetReturnsFieldSelector(t.fakeFieldSelector(config.ReturnsFieldName()))
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createReturnsOnCallMethod(config *MethodConfig) {
+	builder := NewFakeReturnsMethodBuilder(t.createMethodBuilder(config.ReturnsOnCallMethodName()))
+	builder.SetMutexFieldSelector(t.fakeFieldSelector(config.MutexFieldName()))
+	builder.SetStubFieldSelector(t.fakeFieldSelector(config.StubFieldName()))
+	builder.SetReturnsFieldSelector(t.fakeFieldSelector(config.ReturnsOnCallFieldName()))
+	builder.SetResults(config.MethodResults)
+	builder.SetOnCall(true)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *CounterfeiterModel) createMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(fakeReceiverName, t.structName)
+	return builder
+}
+
+func (t *CounterfeiterModel) fakeFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(fakeReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *CounterfeiterModel) resolveInterfaceType(location, name string) *ast.SelectorExpr {
+	alias := t.AddImport("", location)
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *CounterfeiterModel) resolveMutexType() *ast.SelectorExpr {
+	alias := t.AddImport("sync", "sync")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("RWMutex"),
+	}
+}
+
+func (t *CounterfeiterModel) Save(filePath string) error {
+	return saveFile(t.fileBuilder, filePath)
+}
@@ -0,0 +1,297 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewDecodeScenarioMethodBuilder(methodBuilder *MethodBuilder) *DecodeScenarioMethodBuilder {
+	return &DecodeScenarioMethodBuilder{
+		methodBuilder: methodBuilder,
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// DecodeScenarioMethodBuilder is responsible for creating a method on the
+// stub structure that decodes the JSON values of a scenario into results
+// of the stubbed method. Errors are decoded from their messages, where
+// null stands for a nil error. Results of function or channel types
+// cannot be decoded, in which case the method always returns an error.
+//
+// Example:
+//     func (stub *StubStruct) decodeSumScenario(values []json.RawMessage) (StubStructSumResults, error) {
+//         // ...
+//     }
+type DecodeScenarioMethodBuilder struct {
+	methodBuilder     *MethodBuilder
+	rawMessageType    *ast.SelectorExpr
+	unmarshalSelector *ast.SelectorExpr
+	errorfSelector    *ast.SelectorExpr
+	newErrorSelector  *ast.SelectorExpr
+	resultsTypeName   string
+	stubName          string
+	methodName        string
+	results           []*ast.Field
+}
+
+// SetRawMessageType configures the json.RawMessage type.
+// The type should have already been resolved.
+func (b *DecodeScenarioMethodBuilder) SetRawMessageType(rawMessageType *ast.SelectorExpr) {
+	b.rawMessageType = rawMessageType
+}
+
+// SetUnmarshalSelector configures the json.Unmarshal function.
+// The selector should have already been resolved.
+func (b *DecodeScenarioMethodBuilder) SetUnmarshalSelector(selector *ast.SelectorExpr) {
+	b.unmarshalSelector = selector
+}
+
+// SetErrorfSelector configures the function that is used to create
+// errors. The selector should have already been resolved.
+func (b *DecodeScenarioMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+// SetNewErrorSelector configures the errors.New function, which is used
+// to recreate errors from their messages.
+// The selector should have already been resolved.
+func (b *DecodeScenarioMethodBuilder) SetNewErrorSelector(selector *ast.SelectorExpr) {
+	b.newErrorSelector = selector
+}
+
+// SetResultsTypeName configures the name of the type that holds
+// the results of a single call.
+func (b *DecodeScenarioMethodBuilder) SetResultsTypeName(name string) {
+	b.resultsTypeName = name
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in error messages.
+func (b *DecodeScenarioMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in error messages.
+func (b *DecodeScenarioMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *DecodeScenarioMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *DecodeScenarioMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("values", &ast.ArrayType{
+					Elt: b.rawMessageType,
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent(b.resultsTypeName),
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("results"),
+					},
+					Type: ast.NewIdent(b.resultsTypeName),
+				},
+			},
+		},
+	}))
+
+	for i, result := range b.results {
+		if kind, unsupported := b.unsupportedKind(result.Type); unsupported {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildErrorReturn(
+				fmt.Sprintf("result %d is a %s and cannot be decoded from JSON", i+1, kind),
+			)))
+			return b.methodBuilder.Build()
+		}
+	}
+
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X: &ast.CallExpr{
+				Fun: ast.NewIdent("len"),
+				Args: []ast.Expr{
+					ast.NewIdent("values"),
+				},
+			},
+			Op: token.NEQ,
+			Y: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf("%d", len(b.results)),
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				b.buildErrorReturn(fmt.Sprintf("expected %d results, got %%d", len(b.results)), &ast.CallExpr{
+					Fun: ast.NewIdent("len"),
+					Args: []ast.Expr{
+						ast.NewIdent("values"),
+					},
+				}),
+			},
+		},
+	}))
+	for i, result := range util.FieldsAsExported(b.results) {
+		b.addDecodeResultCode(i, result)
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("results"),
+			ast.NewIdent("nil"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+// addDecodeResultCode adds the code that decodes the value at the
+// specified index into the corresponding field of the results.
+func (b *DecodeScenarioMethodBuilder) addDecodeResultCode(index int, result *ast.Field) {
+	resultSelector := &ast.SelectorExpr{
+		X:   ast.NewIdent("results"),
+		Sel: ast.NewIdent(result.Names[0].String()),
+	}
+	target := ast.Expr(resultSelector)
+	messageName := fmt.Sprintf("message%d", index+1)
+	if isErrorType(result.Type) {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{
+							ast.NewIdent(messageName),
+						},
+						Type: &ast.StarExpr{
+							X: ast.NewIdent("string"),
+						},
+					},
+				},
+			},
+		}))
+		target = ast.NewIdent(messageName)
+	}
+	tok := token.ASSIGN
+	if index == 0 {
+		tok = token.DEFINE
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: tok,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.unmarshalSelector,
+				Args: []ast.Expr{
+					&ast.IndexExpr{
+						X: ast.NewIdent("values"),
+						Index: &ast.BasicLit{
+							Kind:  token.INT,
+							Value: fmt.Sprintf("%d", index),
+						},
+					},
+					&ast.UnaryExpr{
+						Op: token.AND,
+						X:  target,
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				b.buildErrorReturn(fmt.Sprintf("cannot decode result %d: %%v", index+1), ast.NewIdent("err")),
+			},
+		},
+	}))
+	if isErrorType(result.Type) {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent(messageName),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							resultSelector,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: b.newErrorSelector,
+								Args: []ast.Expr{
+									&ast.StarExpr{
+										X: ast.NewIdent(messageName),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+	}
+}
+
+// unsupportedKind checks whether results of the specified type can
+// never be decoded from JSON and returns the kind of the type, if so.
+func (b *DecodeScenarioMethodBuilder) unsupportedKind(resultType ast.Expr) (string, bool) {
+	switch resultType.(type) {
+	case *ast.FuncType:
+		return "function", true
+	case *ast.ChanType:
+		return "channel", true
+	default:
+		return "", false
+	}
+}
+
+func (b *DecodeScenarioMethodBuilder) buildErrorReturn(reason string, args ...ast.Expr) ast.Stmt {
+	errorfArgs := []ast.Expr{
+		&ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf("\"cannot configure %s.%s from scenario: %s\"", b.stubName, b.methodName, reason),
+		},
+	}
+	return &ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("results"),
+			&ast.CallExpr{
+				Fun:  b.errorfSelector,
+				Args: append(errorfArgs, args...),
+			},
+		},
+	}
+}
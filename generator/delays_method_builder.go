@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewDelaysMethodBuilder(methodBuilder *MethodBuilder) *DelaysMethodBuilder {
+	return &DelaysMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// DelaysMethodBuilder is responsible for creating a method on the stub
+// structure that configures how long calls to the stubbed method take
+// before they return.
+//
+// Example:
+//     func (stub *StubStruct) SumDelays(d time.Duration) {
+//         // ...
+//     }
+type DelaysMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	delayFieldSelector *ast.SelectorExpr
+	durationType       ast.Expr
+}
+
+func (b *DelaysMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *DelaysMethodBuilder) SetDelayFieldSelector(selector *ast.SelectorExpr) {
+	b.delayFieldSelector = selector
+}
+
+// SetDurationType configures the type of the delay parameter.
+// The type should have already been resolved.
+func (b *DelaysMethodBuilder) SetDurationType(durationType ast.Expr) {
+	b.durationType = durationType
+}
+
+func (b *DelaysMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("d", b.durationType),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.delayFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("d"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
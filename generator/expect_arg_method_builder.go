@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewExpectArgMethodBuilder(methodBuilder *MethodBuilder) *ExpectArgMethodBuilder {
+	return &ExpectArgMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// ExpectArgMethodBuilder is responsible for creating a method that
+// configures what a call matcher expects of the argument at a given
+// index. The expected value, the Gomega matcher for the argument and
+// whether the argument is checked at all are assigned together.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) expectArg(index int, value interface{}) {
+//         matcher.args[index], matcher.argMatchers[index], matcher.checksArgs[index] = value, nil, true
+//     }
+type ExpectArgMethodBuilder struct {
+	methodBuilder            *MethodBuilder
+	argsFieldSelector        *ast.SelectorExpr
+	argMatchersFieldSelector *ast.SelectorExpr
+	checksArgsFieldSelector  *ast.SelectorExpr
+	params                   []*ast.Field
+	value                    ast.Expr
+	argMatcher               ast.Expr
+	checked                  ast.Expr
+}
+
+func (b *ExpectArgMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+func (b *ExpectArgMethodBuilder) SetArgMatchersFieldSelector(selector *ast.SelectorExpr) {
+	b.argMatchersFieldSelector = selector
+}
+
+func (b *ExpectArgMethodBuilder) SetChecksArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.checksArgsFieldSelector = selector
+}
+
+// SetParams configures the parameters of the method, following
+// the index parameter.
+func (b *ExpectArgMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetValues specifies the expressions that are assigned as the
+// expected value, the Gomega matcher and the checked flag of the
+// argument.
+func (b *ExpectArgMethodBuilder) SetValues(value, argMatcher, checked ast.Expr) {
+	b.value = value
+	b.argMatcher = argMatcher
+	b.checked = checked
+}
+
+func (b *ExpectArgMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: append([]*ast.Field{
+				util.CreateField("index", ast.NewIdent("int")),
+			}, b.params...),
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.indexExpr(b.argsFieldSelector),
+			b.indexExpr(b.argMatchersFieldSelector),
+			b.indexExpr(b.checksArgsFieldSelector),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			b.value,
+			b.argMatcher,
+			b.checked,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *ExpectArgMethodBuilder) indexExpr(selector *ast.SelectorExpr) ast.Expr {
+	return &ast.IndexExpr{
+		X:     selector,
+		Index: ast.NewIdent("index"),
+	}
+}
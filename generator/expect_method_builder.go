@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+func NewExpectMethodBuilder(methodBuilder *MethodBuilder) *ExpectMethodBuilder {
+	return &ExpectMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ExpectMethodBuilder is responsible for creating a method on the stub
+// structure that declares an expectation about the number of calls
+// to the stubbed method. By default, at least one call is expected.
+// The method panics if the stub was not created with the test
+// constructor, since the expectation would never be verified.
+//
+// Example:
+//     func (stub *StubStruct) ExpectSum() *StubStructExpectation {
+//         // ...
+//     }
+type ExpectMethodBuilder struct {
+	methodBuilder             *MethodBuilder
+	mutexFieldSelector        *ast.SelectorExpr
+	expectationsFieldSelector *ast.SelectorExpr
+	verifiesSelector          *ast.SelectorExpr
+	testConstructorName       string
+	stubName                  string
+	callCountMethodSelector   *ast.SelectorExpr
+	callHistoryMethodSelector *ast.SelectorExpr
+	expectationTypeName       string
+	methodName                string
+}
+
+func (b *ExpectMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *ExpectMethodBuilder) SetExpectationsFieldSelector(selector *ast.SelectorExpr) {
+	b.expectationsFieldSelector = selector
+}
+
+// SetVerifiesExpectationsFieldSelector configures the field that marks
+// the stub as one whose expectations are verified.
+func (b *ExpectMethodBuilder) SetVerifiesExpectationsFieldSelector(selector *ast.SelectorExpr) {
+	b.verifiesSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, as it should
+// appear in panic messages.
+func (b *ExpectMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// SetTestConstructorName specifies the name of the constructor that
+// creates stubs whose expectations are verified, as it should appear
+// in panic messages.
+func (b *ExpectMethodBuilder) SetTestConstructorName(name string) {
+	b.testConstructorName = name
+}
+
+func (b *ExpectMethodBuilder) SetCallCountMethodSelector(selector *ast.SelectorExpr) {
+	b.callCountMethodSelector = selector
+}
+
+func (b *ExpectMethodBuilder) SetCallHistoryMethodSelector(selector *ast.SelectorExpr) {
+	b.callHistoryMethodSelector = selector
+}
+
+func (b *ExpectMethodBuilder) SetExpectationTypeName(name string) {
+	b.expectationTypeName = name
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in reports.
+func (b *ExpectMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+func (b *ExpectMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.expectationTypeName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.UnaryExpr{
+			Op: token.NOT,
+			X:  b.verifiesSelector,
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("panic"),
+						Args: []ast.Expr{
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: fmt.Sprintf("\"cannot expect calls to %s.%s: the stub was not created with %s\"", b.stubName, b.methodName, b.testConstructorName),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent(expectationReceiverName),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.expectationTypeName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent(expectationMethodFieldName),
+							Value: &ast.BasicLit{
+								Kind:  token.STRING,
+								Value: fmt.Sprintf("\"%s\"", b.methodName),
+							},
+						},
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent(expectationMinFieldName),
+							Value: &ast.BasicLit{
+								Kind:  token.INT,
+								Value: "1",
+							},
+						},
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent(expectationMaxFieldName),
+							Value: &ast.UnaryExpr{
+								Op: token.SUB,
+								X: &ast.BasicLit{
+									Kind:  token.INT,
+									Value: "1",
+								},
+							},
+						},
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(expectationCallCountFieldName),
+							Value: b.callCountMethodSelector,
+						},
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(expectationCallHistoryFieldName),
+							Value: b.callHistoryMethodSelector,
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.expectationsFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.expectationsFieldSelector,
+					ast.NewIdent(expectationReceiverName),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(expectationReceiverName),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
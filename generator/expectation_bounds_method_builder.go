@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewExpectationBoundsMethodBuilder(methodBuilder *MethodBuilder) *ExpectationBoundsMethodBuilder {
+	return &ExpectationBoundsMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// ExpectationBoundsMethodBuilder is responsible for creating a method
+// on the expectation structure that configures the minimum and maximum
+// number of calls that are expected. A negative maximum means that
+// the number of calls is not bounded.
+//
+// Example:
+//     func (expectation *StubStructExpectation) Times(count int) *StubStructExpectation {
+//         expectation.min, expectation.max = count, count
+//         return expectation
+//     }
+type ExpectationBoundsMethodBuilder struct {
+	methodBuilder       *MethodBuilder
+	minFieldSelector    *ast.SelectorExpr
+	maxFieldSelector    *ast.SelectorExpr
+	expectationTypeName string
+	params              []*ast.Field
+	min                 ast.Expr
+	max                 ast.Expr
+}
+
+func (b *ExpectationBoundsMethodBuilder) SetMinFieldSelector(selector *ast.SelectorExpr) {
+	b.minFieldSelector = selector
+}
+
+func (b *ExpectationBoundsMethodBuilder) SetMaxFieldSelector(selector *ast.SelectorExpr) {
+	b.maxFieldSelector = selector
+}
+
+func (b *ExpectationBoundsMethodBuilder) SetExpectationTypeName(name string) {
+	b.expectationTypeName = name
+}
+
+// SetParams specifies the parameters of the method, which can be
+// referred to by the bound expressions.
+func (b *ExpectationBoundsMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetBounds specifies the expressions that are assigned to the
+// minimum and maximum number of expected calls.
+func (b *ExpectationBoundsMethodBuilder) SetBounds(min, max ast.Expr) {
+	b.min = min
+	b.max = max
+}
+
+func (b *ExpectationBoundsMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.expectationTypeName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.minFieldSelector,
+			b.maxFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			b.min,
+			b.max,
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(expectationReceiverName),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,286 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewExpectationVerifyMethodBuilder(methodBuilder *MethodBuilder) *ExpectationVerifyMethodBuilder {
+	return &ExpectationVerifyMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ExpectationVerifyMethodBuilder is responsible for creating a method
+// on the expectation structure that checks whether the number of
+// calls matches the expectation and reports a failure, including
+// the arguments of all recorded calls, if it does not.
+//
+// Example:
+//     func (expectation *StubStructExpectation) verify(reporter interface {
+//         Helper()
+//         Errorf(format string, args ...interface{})
+//     }) {
+//         // ...
+//     }
+type ExpectationVerifyMethodBuilder struct {
+	methodBuilder   *MethodBuilder
+	reporterType    ast.Expr
+	sprintfSelector *ast.SelectorExpr
+	joinSelector    *ast.SelectorExpr
+	stubName        string
+}
+
+// SetReporterType configures the type of the reporter that failures
+// are reported to. The type should have already been resolved.
+func (b *ExpectationVerifyMethodBuilder) SetReporterType(reporterType ast.Expr) {
+	b.reporterType = reporterType
+}
+
+// SetSprintfSelector configures the function that is used to format
+// the failure message. The selector should have already been resolved.
+func (b *ExpectationVerifyMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+// SetJoinSelector configures the function that is used to join the
+// formatted arguments. The selector should have already been resolved.
+func (b *ExpectationVerifyMethodBuilder) SetJoinSelector(selector *ast.SelectorExpr) {
+	b.joinSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in the failure message.
+func (b *ExpectationVerifyMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *ExpectationVerifyMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("reporter", b.reporterType),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("count"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.fieldSelector(expectationCallCountFieldName),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X: &ast.BinaryExpr{
+				X:  ast.NewIdent("count"),
+				Op: token.GEQ,
+				Y:  b.fieldSelector(expectationMinFieldName),
+			},
+			Op: token.LAND,
+			Y: &ast.ParenExpr{
+				X: &ast.BinaryExpr{
+					X: &ast.BinaryExpr{
+						X:  b.fieldSelector(expectationMaxFieldName),
+						Op: token.LSS,
+						Y:  b.intLiteral(0),
+					},
+					Op: token.LOR,
+					Y: &ast.BinaryExpr{
+						X:  ast.NewIdent("count"),
+						Op: token.LEQ,
+						Y:  b.fieldSelector(expectationMaxFieldName),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("expected"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.sprintfCall("at least %d", b.fieldSelector(expectationMinFieldName)),
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.fieldSelector(expectationMaxFieldName),
+			Op: token.GEQ,
+			Y:  b.intLiteral(0),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("expected"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						b.sprintfCall("exactly %d", b.fieldSelector(expectationMaxFieldName)),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("history"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "\"\"",
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("i"),
+		Value: ast.NewIdent("args"),
+		Tok:   token.DEFINE,
+		X: &ast.CallExpr{
+			Fun: b.fieldSelector(expectationCallHistoryFieldName),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("formattedArgs"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("make"),
+							Args: []ast.Expr{
+								&ast.ArrayType{
+									Elt: ast.NewIdent("string"),
+								},
+								&ast.CallExpr{
+									Fun: ast.NewIdent("len"),
+									Args: []ast.Expr{
+										ast.NewIdent("args"),
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.RangeStmt{
+					Key:   ast.NewIdent("j"),
+					Value: ast.NewIdent("arg"),
+					Tok:   token.DEFINE,
+					X:     ast.NewIdent("args"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									&ast.IndexExpr{
+										X:     ast.NewIdent("formattedArgs"),
+										Index: ast.NewIdent("j"),
+									},
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									b.sprintfCall("%#v", ast.NewIdent("arg")),
+								},
+							},
+						},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("history"),
+					},
+					Tok: token.ADD_ASSIGN,
+					Rhs: []ast.Expr{
+						b.sprintfCall("\\n\\t#%d: %s(%s)",
+							&ast.BinaryExpr{
+								X:  ast.NewIdent("i"),
+								Op: token.ADD,
+								Y:  b.intLiteral(1),
+							},
+							b.fieldSelector(expectationMethodFieldName),
+							&ast.CallExpr{
+								Fun: b.joinSelector,
+								Args: []ast.Expr{
+									ast.NewIdent("formattedArgs"),
+									&ast.BasicLit{
+										Kind:  token.STRING,
+										Value: "\", \"",
+									},
+								},
+							},
+						),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("reporter"),
+				Sel: ast.NewIdent("Helper"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("reporter"),
+				Sel: ast.NewIdent("Errorf"),
+			},
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\"expected %%s calls to %s.%%s, got %%d%%s\"", b.stubName),
+				},
+				ast.NewIdent("expected"),
+				b.fieldSelector(expectationMethodFieldName),
+				ast.NewIdent("count"),
+				ast.NewIdent("history"),
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *ExpectationVerifyMethodBuilder) fieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(expectationReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (b *ExpectationVerifyMethodBuilder) intLiteral(value int) *ast.BasicLit {
+	return &ast.BasicLit{
+		Kind:  token.INT,
+		Value: fmt.Sprintf("%d", value),
+	}
+}
+
+func (b *ExpectationVerifyMethodBuilder) sprintfCall(format string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun: b.sprintfSelector,
+		Args: append([]ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("\"%s\"", format),
+			},
+		}, args...),
+	}
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFailureMessageMethodBuilder(methodBuilder *MethodBuilder) *FailureMessageMethodBuilder {
+	return &FailureMessageMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// FailureMessageMethodBuilder is responsible for creating one of the
+// methods that Gomega uses to obtain the failure message of a call
+// matcher.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) FailureMessage(actual interface{}) string {
+//         return matcher.message(actual, "to have received")
+//     }
+type FailureMessageMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	messageMethodSelector *ast.SelectorExpr
+	expectation           string
+}
+
+func (b *FailureMessageMethodBuilder) SetMessageMethodSelector(selector *ast.SelectorExpr) {
+	b.messageMethodSelector = selector
+}
+
+// SetExpectation specifies the text that describes what was
+// expected of the stub.
+func (b *FailureMessageMethodBuilder) SetExpectation(expectation string) {
+	b.expectation = expectation
+}
+
+func (b *FailureMessageMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("actual", util.CreateEmptyInterface()),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("string"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.messageMethodSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("actual"),
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf("\"%s\"", b.expectation),
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFakeArgsForCallMethodBuilder(methodBuilder *MethodBuilder) *FakeArgsForCallMethodBuilder {
+	return &FakeArgsForCallMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// FakeArgsForCallMethodBuilder is responsible for creating a method on
+// the fake structure that returns the arguments of a given call, as
+// counterfeiter fakes do.
+//
+// Example:
+//     func (fake *FakeStruct) SumArgsForCall(i int) (int, int) {
+//         // ...
+//     }
+type FakeArgsForCallMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	argsFieldSelector  *ast.SelectorExpr
+	params             []*ast.Field
+}
+
+func (b *FakeArgsForCallMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *FakeArgsForCallMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *FakeArgsForCallMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+func (b *FakeArgsForCallMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("i", ast.NewIdent("int")),
+			},
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(util.FieldsWithoutEllipsis(b.params)),
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("argsForCall"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.IndexExpr{
+				X:     b.argsFieldSelector,
+				Index: ast.NewIdent("i"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: fakeFieldSelectors(ast.NewIdent("argsForCall"), b.params),
+	}))
+	return b.methodBuilder.Build()
+}
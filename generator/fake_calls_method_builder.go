@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFakeCallsMethodBuilder(methodBuilder *MethodBuilder) *FakeCallsMethodBuilder {
+	return &FakeCallsMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// FakeCallsMethodBuilder is responsible for creating a method on the
+// fake structure that configures the function which implements the
+// faked method, as counterfeiter fakes do.
+//
+// Example:
+//     func (fake *FakeStruct) SumCalls(stub func(int, int) int) {
+//         // ...
+//     }
+type FakeCallsMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	stubFieldSelector  *ast.SelectorExpr
+	params             []*ast.Field
+	results            []*ast.Field
+}
+
+func (b *FakeCallsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *FakeCallsMethodBuilder) SetStubFieldSelector(selector *ast.SelectorExpr) {
+	b.stubFieldSelector = selector
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *FakeCallsMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *FakeCallsMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *FakeCallsMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("stub", &ast.FuncType{
+					Params: &ast.FieldList{
+						List: util.FieldsAsAnonymous(b.params),
+					},
+					Results: &ast.FieldList{
+						List: util.FieldsAsAnonymous(b.results),
+					},
+				}),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.stubFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("stub"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
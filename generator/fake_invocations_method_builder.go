@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFakeInvocationsMethodBuilder(methodBuilder *MethodBuilder) *FakeInvocationsMethodBuilder {
+	return &FakeInvocationsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// FakeInvocationsMethodBuilder is responsible for creating a method on
+// the fake structure that returns a copy of the arguments of all calls,
+// by method name, as counterfeiter fakes do.
+//
+// Example:
+//     func (fake *FakeStruct) Invocations() map[string][][]interface{} {
+//         // ...
+//     }
+type FakeInvocationsMethodBuilder struct {
+	methodBuilder            *MethodBuilder
+	mutexFieldSelector       *ast.SelectorExpr
+	invocationsFieldSelector *ast.SelectorExpr
+}
+
+func (b *FakeInvocationsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *FakeInvocationsMethodBuilder) SetInvocationsFieldSelector(selector *ast.SelectorExpr) {
+	b.invocationsFieldSelector = selector
+}
+
+func (b *FakeInvocationsMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: fakeInvocationsType(),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("copiedInvocations"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CompositeLit{
+				Type: fakeInvocationsType(),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("key"),
+		Value: ast.NewIdent("value"),
+		Tok:   token.DEFINE,
+		X:     b.invocationsFieldSelector,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.IndexExpr{
+							X:     ast.NewIdent("copiedInvocations"),
+							Index: ast.NewIdent("key"),
+						},
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						ast.NewIdent("value"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("copiedInvocations"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+// fakeInvocationsType creates the `map[string][][]interface{}` type
+// that counterfeiter fakes use to hold the arguments of all calls.
+func fakeInvocationsType() *ast.MapType {
+	return &ast.MapType{
+		Key: ast.NewIdent("string"),
+		Value: &ast.ArrayType{
+			Elt: &ast.ArrayType{
+				Elt: util.CreateEmptyInterface(),
+			},
+		},
+	}
+}
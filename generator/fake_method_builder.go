@@ -0,0 +1,331 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFakeMethodBuilder(methodBuilder *MethodBuilder) *FakeMethodBuilder {
+	return &FakeMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// FakeMethodBuilder is responsible for creating a method that implements
+// the original method from the interface in the way that counterfeiter
+// fakes do. Slice arguments are copied before they are recorded.
+//
+// Example:
+//     func (fake *FakeStruct) Sum(arg1 int, arg2 int) int {
+//         // ...
+//     }
+type FakeMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	mutexFieldSelector    *ast.SelectorExpr
+	argsFieldSelector     *ast.SelectorExpr
+	returnsFieldSelector  *ast.SelectorExpr
+	returnsOnCallSelector *ast.SelectorExpr
+	stubFieldSelector     *ast.SelectorExpr
+	recordMethodSelector  *ast.SelectorExpr
+	methodName            string
+	params                []*ast.Field
+	results               []*ast.Field
+}
+
+func (b *FakeMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *FakeMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+// SetReturnsFieldSelectors configures the fields that hold the results
+// for all calls and for specific calls respectively.
+func (b *FakeMethodBuilder) SetReturnsFieldSelectors(returns, returnsOnCall *ast.SelectorExpr) {
+	b.returnsFieldSelector = returns
+	b.returnsOnCallSelector = returnsOnCall
+}
+
+func (b *FakeMethodBuilder) SetStubFieldSelector(selector *ast.SelectorExpr) {
+	b.stubFieldSelector = selector
+}
+
+// SetRecordMethodSelector configures the method that records the
+// call in the invocations of the fake.
+func (b *FakeMethodBuilder) SetRecordMethodSelector(selector *ast.SelectorExpr) {
+	b.recordMethodSelector = selector
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in the invocations.
+func (b *FakeMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *FakeMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *FakeMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *FakeMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(b.results),
+		},
+	})
+
+	recordedArgs := []ast.Expr{}
+	callArgs := []ast.Expr{}
+	hasEllipsis := false
+	for _, param := range b.params {
+		paramName := param.Names[0].String()
+		callArgs = append(callArgs, ast.NewIdent(paramName))
+		if _, ok := param.Type.(*ast.Ellipsis); ok {
+			hasEllipsis = true
+		}
+		if sliceType, ok := param.Type.(*ast.ArrayType); ok && sliceType.Len == nil {
+			for _, stmt := range b.buildCopySliceCode(paramName, sliceType) {
+				b.methodBuilder.AddStatementBuilder(StatementToBuilder(stmt))
+			}
+			recordedArgs = append(recordedArgs, ast.NewIdent(paramName+"Copy"))
+		} else {
+			recordedArgs = append(recordedArgs, ast.NewIdent(paramName))
+		}
+	}
+
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	if len(b.results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("ret"),
+				ast.NewIdent("specificReturn"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.IndexExpr{
+					X: b.returnsOnCallSelector,
+					Index: &ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							b.argsFieldSelector,
+						},
+					},
+				},
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.argsFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.argsFieldSelector,
+					&ast.CompositeLit{
+						Type: fakeStructType(util.FieldsWithoutEllipsis(b.params)),
+						Elts: recordedArgs,
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("stub"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.stubFieldSelector,
+		},
+	}))
+	if len(b.results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("fakeReturns"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.returnsFieldSelector,
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: b.recordMethodSelector,
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\"%s\"", b.methodName),
+				},
+				&ast.CompositeLit{
+					Type: &ast.ArrayType{
+						Elt: util.CreateEmptyInterface(),
+					},
+					Elts: recordedArgs,
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+
+	ellipsisPos := token.NoPos
+	if hasEllipsis {
+		ellipsisPos = 1
+	}
+	callExpr := &ast.CallExpr{
+		Fun:      ast.NewIdent("stub"),
+		Args:     callArgs,
+		Ellipsis: ellipsisPos,
+	}
+	var callStmt ast.Stmt = &ast.ExprStmt{
+		X: callExpr,
+	}
+	if len(b.results) > 0 {
+		callStmt = &ast.ReturnStmt{
+			Results: []ast.Expr{
+				callExpr,
+			},
+		}
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("stub"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				callStmt,
+			},
+		},
+	}))
+	if len(b.results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: ast.NewIdent("specificReturn"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: fakeFieldSelectors(ast.NewIdent("ret"), b.results),
+					},
+				},
+			},
+		}))
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+			Results: fakeFieldSelectors(ast.NewIdent("fakeReturns"), b.results),
+		}))
+	}
+	return b.methodBuilder.Build()
+}
+
+// buildCopySliceCode creates the code that copies a slice argument,
+// so that later changes to the slice do not affect the recorded call.
+func (b *FakeMethodBuilder) buildCopySliceCode(paramName string, sliceType *ast.ArrayType) []ast.Stmt {
+	copyName := paramName + "Copy"
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{
+							ast.NewIdent(copyName),
+						},
+						Type: sliceType,
+					},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent(paramName),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent(copyName),
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("make"),
+								Args: []ast.Expr{
+									sliceType,
+									&ast.CallExpr{
+										Fun: ast.NewIdent("len"),
+										Args: []ast.Expr{
+											ast.NewIdent(paramName),
+										},
+									},
+								},
+							},
+						},
+					},
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun: ast.NewIdent("copy"),
+							Args: []ast.Expr{
+								ast.NewIdent(copyName),
+								ast.NewIdent(paramName),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// fakeStructType creates an anonymous struct type with the specified
+// fields, as used by counterfeiter fakes to hold arguments and results.
+func fakeStructType(fields []*ast.Field) *ast.StructType {
+	return &ast.StructType{
+		Fields: &ast.FieldList{
+			List: fields,
+		},
+	}
+}
+
+// fakeFieldSelectors creates selectors for the fields of the specified
+// anonymous struct value that correspond to the specified fields.
+func fakeFieldSelectors(value ast.Expr, fields []*ast.Field) []ast.Expr {
+	selectors := []ast.Expr{}
+	for _, field := range fields {
+		selectors = append(selectors, &ast.SelectorExpr{
+			X:   value,
+			Sel: ast.NewIdent(field.Names[0].String()),
+		})
+	}
+	return selectors
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFakeRecordInvocationMethodBuilder(methodBuilder *MethodBuilder) *FakeRecordInvocationMethodBuilder {
+	return &FakeRecordInvocationMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// FakeRecordInvocationMethodBuilder is responsible for creating a method
+// on the fake structure that records the arguments of a call under the
+// name of the called method, as counterfeiter fakes do.
+//
+// Example:
+//     func (fake *FakeStruct) recordInvocation(key string, args []interface{}) {
+//         // ...
+//     }
+type FakeRecordInvocationMethodBuilder struct {
+	methodBuilder            *MethodBuilder
+	mutexFieldSelector       *ast.SelectorExpr
+	invocationsFieldSelector *ast.SelectorExpr
+}
+
+func (b *FakeRecordInvocationMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *FakeRecordInvocationMethodBuilder) SetInvocationsFieldSelector(selector *ast.SelectorExpr) {
+	b.invocationsFieldSelector = selector
+}
+
+func (b *FakeRecordInvocationMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	keySelector := &ast.IndexExpr{
+		X:     b.invocationsFieldSelector,
+		Index: ast.NewIdent("key"),
+	}
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("key", ast.NewIdent("string")),
+				util.CreateField("args", &ast.ArrayType{
+					Elt: util.CreateEmptyInterface(),
+				}),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.invocationsFieldSelector,
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						b.invocationsFieldSelector,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CompositeLit{
+							Type: fakeInvocationsType(),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			keySelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					keySelector,
+					ast.NewIdent("args"),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFakeReturnsMethodBuilder(methodBuilder *MethodBuilder) *FakeReturnsMethodBuilder {
+	return &FakeReturnsMethodBuilder{
+		methodBuilder: methodBuilder,
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// FakeReturnsMethodBuilder is responsible for creating a method on the
+// fake structure that configures the results of the faked method, as
+// counterfeiter fakes do. If configured as on-call, the method accepts
+// the index of the call to which the results apply.
+//
+// Example:
+//     func (fake *FakeStruct) SumReturns(result1 int) {
+//         // ...
+//     }
+//
+//     func (fake *FakeStruct) SumReturnsOnCall(i int, result1 int) {
+//         // ...
+//     }
+type FakeReturnsMethodBuilder struct {
+	methodBuilder        *MethodBuilder
+	mutexFieldSelector   *ast.SelectorExpr
+	stubFieldSelector    *ast.SelectorExpr
+	returnsFieldSelector *ast.SelectorExpr
+	results              []*ast.Field
+	onCall               bool
+}
+
+func (b *FakeReturnsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+// SetStubFieldSelector configures the field that holds the function
+// which implements the faked method. It is cleared when results
+// are configured.
+func (b *FakeReturnsMethodBuilder) SetStubFieldSelector(selector *ast.SelectorExpr) {
+	b.stubFieldSelector = selector
+}
+
+// SetReturnsFieldSelector configures the field that holds the results,
+// or the results by call index if configured as on-call.
+func (b *FakeReturnsMethodBuilder) SetReturnsFieldSelector(selector *ast.SelectorExpr) {
+	b.returnsFieldSelector = selector
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *FakeReturnsMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+// SetOnCall specifies whether the configured results should apply
+// to a specific call only.
+func (b *FakeReturnsMethodBuilder) SetOnCall(onCall bool) {
+	b.onCall = onCall
+}
+
+func (b *FakeReturnsMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	params := []*ast.Field{}
+	if b.onCall {
+		params = append(params, util.CreateField("i", ast.NewIdent("int")))
+	}
+	params = append(params, b.results...)
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.stubFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("nil"),
+		},
+	}))
+
+	resultValues := []ast.Expr{}
+	for _, result := range b.results {
+		resultValues = append(resultValues, ast.NewIdent(result.Names[0].String()))
+	}
+	resultsType := fakeStructType(b.results)
+	var target ast.Expr = b.returnsFieldSelector
+	if b.onCall {
+		resultsMapType := &ast.MapType{
+			Key:   ast.NewIdent("int"),
+			Value: resultsType,
+		}
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  b.returnsFieldSelector,
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							b.returnsFieldSelector,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("make"),
+								Args: []ast.Expr{
+									resultsMapType,
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+		target = &ast.IndexExpr{
+			X:     b.returnsFieldSelector,
+			Index: ast.NewIdent("i"),
+		}
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			target,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CompositeLit{
+				Type: resultsType,
+				Elts: resultValues,
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -49,6 +49,29 @@ func (m *FileBuilder) AddImport(pkgName, location string) string {
 	return alias
 }
 
+// ImportLocation returns the location of the package that has been
+// imported with the specified alias, if any.
+func (m *FileBuilder) ImportLocation(alias string) (string, bool) {
+	location, found := m.aliasToImport[alias]
+	return location, found
+}
+
+// AddImportsFrom registers all imports of the specified file builder
+// with the same aliases, so that selector expressions that have been
+// resolved against that file can be used in this one as well. Aliases
+// that are already registered are left unchanged.
+func (m *FileBuilder) AddImportsFrom(other *FileBuilder) {
+	for alias, location := range other.aliasToImport {
+		if _, aliasAlreadyRegistered := m.aliasToImport[alias]; aliasAlreadyRegistered {
+			continue
+		}
+		m.aliasToImport[alias] = location
+		if _, locationAlreadyRegistered := m.importToAlias[location]; !locationAlreadyRegistered {
+			m.importToAlias[location] = alias
+		}
+	}
+}
+
 func (m *FileBuilder) allocateUniqueAlias() string {
 	m.aliasCounter++
 	return fmt.Sprintf("alias%d", m.aliasCounter)
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFlagFieldBuilder() *FlagFieldBuilder {
+	return &FlagFieldBuilder{}
+}
+
+// The FlagFieldBuilder is responsible for creating a boolean field
+// which is internally used to track whether some behavior of the
+// stub has been enabled or configured.
+//
+// Example:
+//     type StubStruct struct {
+//         // ...
+//         strict bool
+//         // ...
+//     }
+type FlagFieldBuilder struct {
+	fieldName string
+}
+
+func (b *FlagFieldBuilder) SetFieldName(name string) {
+	b.fieldName = name
+}
+
+func (b *FlagFieldBuilder) Build() *ast.Field {
+	return util.CreateField(b.fieldName, ast.NewIdent("bool"))
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewFormatArgsMethodBuilder(methodBuilder *MethodBuilder) *FormatArgsMethodBuilder {
+	return &FormatArgsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// FormatArgsMethodBuilder is responsible for creating a method that
+// formats a list of call arguments for use in failure messages.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) formatArgs(args []interface{}) string {
+//         // ...
+//     }
+type FormatArgsMethodBuilder struct {
+	methodBuilder   *MethodBuilder
+	sprintfSelector *ast.SelectorExpr
+	joinSelector    *ast.SelectorExpr
+}
+
+// SetSprintfSelector configures the function that is used to format
+// each argument. The selector should have already been resolved.
+func (b *FormatArgsMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+// SetJoinSelector configures the function that is used to join the
+// formatted arguments. The selector should have already been resolved.
+func (b *FormatArgsMethodBuilder) SetJoinSelector(selector *ast.SelectorExpr) {
+	b.joinSelector = selector
+}
+
+func (b *FormatArgsMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("args", &ast.ArrayType{
+					Elt: util.CreateEmptyInterface(),
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("string"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("formattedArgs"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("make"),
+				Args: []ast.Expr{
+					&ast.ArrayType{
+						Elt: ast.NewIdent("string"),
+					},
+					&ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							ast.NewIdent("args"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("i"),
+		Value: ast.NewIdent("arg"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("args"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.IndexExpr{
+							X:     ast.NewIdent("formattedArgs"),
+							Index: ast.NewIdent("i"),
+						},
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: b.sprintfSelector,
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: "\"%#v\"",
+								},
+								ast.NewIdent("arg"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.joinSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("formattedArgs"),
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: "\", \"",
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
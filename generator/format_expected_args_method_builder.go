@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewFormatExpectedArgsMethodBuilder(methodBuilder *MethodBuilder) *FormatExpectedArgsMethodBuilder {
+	return &FormatExpectedArgsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// FormatExpectedArgsMethodBuilder is responsible for creating a method
+// that formats the arguments expected by a call matcher for use in
+// failure messages. Arguments that are not checked are formatted as
+// <any>. If no argument is checked, the result is empty.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) formatExpectedArgs() string {
+//         // ...
+//     }
+type FormatExpectedArgsMethodBuilder struct {
+	methodBuilder           *MethodBuilder
+	argsFieldSelector       *ast.SelectorExpr
+	checksArgsFieldSelector *ast.SelectorExpr
+	sprintfSelector         *ast.SelectorExpr
+	joinSelector            *ast.SelectorExpr
+}
+
+func (b *FormatExpectedArgsMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+func (b *FormatExpectedArgsMethodBuilder) SetChecksArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.checksArgsFieldSelector = selector
+}
+
+// SetSprintfSelector configures the function that is used to format
+// each argument. The selector should have already been resolved.
+func (b *FormatExpectedArgsMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+// SetJoinSelector configures the function that is used to join the
+// formatted arguments. The selector should have already been resolved.
+func (b *FormatExpectedArgsMethodBuilder) SetJoinSelector(selector *ast.SelectorExpr) {
+	b.joinSelector = selector
+}
+
+func (b *FormatExpectedArgsMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("string"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("checked"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			ast.NewIdent("false"),
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("formattedArgs"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("make"),
+				Args: []ast.Expr{
+					&ast.ArrayType{
+						Elt: ast.NewIdent("string"),
+					},
+					&ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							b.argsFieldSelector,
+						},
+					},
+				},
+			},
+		},
+	}))
+	formattedArg := &ast.IndexExpr{
+		X:     ast.NewIdent("formattedArgs"),
+		Index: ast.NewIdent("i"),
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("i"),
+		Value: ast.NewIdent("arg"),
+		Tok:   token.DEFINE,
+		X:     b.argsFieldSelector,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						formattedArg,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: "\"<any>\"",
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.IndexExpr{
+						X:     b.checksArgsFieldSelector,
+						Index: ast.NewIdent("i"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									formattedArg,
+									ast.NewIdent("checked"),
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: b.sprintfSelector,
+										Args: []ast.Expr{
+											&ast.BasicLit{
+												Kind:  token.STRING,
+												Value: "\"%#v\"",
+											},
+											ast.NewIdent("arg"),
+										},
+									},
+									ast.NewIdent("true"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.UnaryExpr{
+			Op: token.NOT,
+			X:  ast.NewIdent("checked"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: "\"\"",
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.joinSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("formattedArgs"),
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: "\", \"",
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
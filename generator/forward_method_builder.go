@@ -0,0 +1,352 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewForwardMethodBuilder(methodBuilder *MethodBuilder) *ForwardMethodBuilder {
+	return &ForwardMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// ForwardMethodBuilder is responsible for creating a method on a composed
+// stub that records calls to a method of an embedded stub in the
+// invocations of the composed stub, and passes them to the recorder of
+// the composed stub, before forwarding them to the embedded stub. This
+// keeps the order of the calls to all methods of the composed stub and
+// reports them under its name. If configured to track panics, calls that
+// do not return normally are marked as panicked.
+//
+// Example:
+//     func (stub *StubStruct) Read(arg1 []byte) (int, error) {
+//         stub.mutex.Lock()
+//         stub.invocations = append(stub.invocations, StubStructInvocation{Sequence: len(stub.invocations) + 1, Method: "Read", Args: []interface{}{arg1}})
+//         stub.mutex.Unlock()
+//         return stub.ReaderStub.Read(arg1)
+//     }
+type ForwardMethodBuilder struct {
+	methodBuilder       *MethodBuilder
+	mutexFieldSelector  *ast.SelectorExpr
+	invocationsSelector *ast.SelectorExpr
+	invocationTypeName  string
+	recorderSelector    *ast.SelectorExpr
+	embeddedSelector    *ast.SelectorExpr
+	tracksPanics        bool
+	stubName            string
+	methodName          string
+	params              []*ast.Field
+	results             []*ast.Field
+}
+
+// SetMutexFieldSelector configures the stub-level mutex, which guards
+// the invocations and recorder fields.
+func (b *ForwardMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *ForwardMethodBuilder) SetInvocationsFieldSelector(selector *ast.SelectorExpr) {
+	b.invocationsSelector = selector
+}
+
+// SetInvocationTypeName configures the name of the type that
+// describes a single entry in the invocations field.
+func (b *ForwardMethodBuilder) SetInvocationTypeName(name string) {
+	b.invocationTypeName = name
+}
+
+// SetRecorderFieldSelector configures the stub-level field that holds
+// the attached recorder. If not set, calls are not passed to a recorder.
+func (b *ForwardMethodBuilder) SetRecorderFieldSelector(selector *ast.SelectorExpr) {
+	b.recorderSelector = selector
+}
+
+// SetEmbeddedStubSelector configures the embedded stub to which
+// calls are forwarded.
+func (b *ForwardMethodBuilder) SetEmbeddedStubSelector(selector *ast.SelectorExpr) {
+	b.embeddedSelector = selector
+}
+
+// SetTracksPanics specifies whether the invocations note which
+// calls panicked.
+func (b *ForwardMethodBuilder) SetTracksPanics(tracksPanics bool) {
+	b.tracksPanics = tracksPanics
+}
+
+// SetStubName specifies the name of the composed stub, as it should
+// appear in records.
+func (b *ForwardMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *ForwardMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetParams specifies the parameters of the method. Their types
+// should have already been resolved.
+func (b *ForwardMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetResults specifies the results of the method. Their types
+// should have already been resolved.
+func (b *ForwardMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *ForwardMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(b.results),
+		},
+	})
+
+	args := []ast.Expr{}
+	for _, param := range b.params {
+		args = append(args, ast.NewIdent(param.Names[0].String()))
+	}
+	hasEllipsis := false
+	if parCount := len(b.params); parCount > 0 {
+		_, hasEllipsis = b.params[parCount-1].Type.(*ast.Ellipsis)
+	}
+
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	if b.invocationsSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildAppendInvocationCode(args)))
+	}
+	if b.tracksPanics {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("index"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.BinaryExpr{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							b.invocationsSelector,
+						},
+					},
+					Op: token.SUB,
+					Y: &ast.BasicLit{
+						Kind:  token.INT,
+						Value: "1",
+					},
+				},
+			},
+		}))
+	}
+	if b.recorderSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("recorder"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.recorderSelector,
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	if b.recorderSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(buildNotifyRecorderCode(b.stubName, b.methodName, args)))
+	}
+
+	ellipsisPos := token.NoPos
+	if hasEllipsis {
+		ellipsisPos = 1
+	}
+	callExpr := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   b.embeddedSelector,
+			Sel: ast.NewIdent(b.methodName),
+		},
+		Args:     args,
+		Ellipsis: ellipsisPos,
+	}
+	if !b.tracksPanics {
+		if len(b.results) > 0 {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+				Results: []ast.Expr{
+					callExpr,
+				},
+			}))
+		} else {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+				X: callExpr,
+			}))
+		}
+		return b.methodBuilder.Build()
+	}
+
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("returned"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			ast.NewIdent("false"),
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildMarkPanickedCode()))
+	results := []ast.Expr{}
+	for i := range b.results {
+		results = append(results, ast.NewIdent(fmt.Sprintf("result%d", i+1)))
+	}
+	if len(results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: results,
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				callExpr,
+			},
+		}))
+	} else {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+			X: callExpr,
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("returned"),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("true"),
+		},
+	}))
+	if len(results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+			Results: results,
+		}))
+	}
+	return b.methodBuilder.Build()
+}
+
+// buildAppendInvocationCode creates the code that appends the call
+// to the invocations of the composed stub.
+func (b *ForwardMethodBuilder) buildAppendInvocationCode(args []ast.Expr) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.invocationsSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.invocationsSelector,
+					&ast.CompositeLit{
+						Type: ast.NewIdent(b.invocationTypeName),
+						Elts: []ast.Expr{
+							&ast.KeyValueExpr{
+								Key: ast.NewIdent("Sequence"),
+								Value: &ast.BinaryExpr{
+									X: &ast.CallExpr{
+										Fun: ast.NewIdent("len"),
+										Args: []ast.Expr{
+											b.invocationsSelector,
+										},
+									},
+									Op: token.ADD,
+									Y: &ast.BasicLit{
+										Kind:  token.INT,
+										Value: "1",
+									},
+								},
+							},
+							&ast.KeyValueExpr{
+								Key: ast.NewIdent("Method"),
+								Value: &ast.BasicLit{
+									Kind:  token.STRING,
+									Value: fmt.Sprintf("\"%s\"", b.methodName),
+								},
+							},
+							&ast.KeyValueExpr{
+								Key: ast.NewIdent("Args"),
+								Value: &ast.CompositeLit{
+									Type: &ast.ArrayType{
+										Elt: util.CreateEmptyInterface(),
+									},
+									Elts: args,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildMarkPanickedCode creates the deferred code that marks the
+// invocation as panicked, unless the embedded stub returned.
+func (b *ForwardMethodBuilder) buildMarkPanickedCode() ast.Stmt {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+
+	return &ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.IfStmt{
+							Cond: &ast.UnaryExpr{
+								Op: token.NOT,
+								X:  ast.NewIdent("returned"),
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									mutexLockBuilder.Build(),
+									&ast.AssignStmt{
+										Lhs: []ast.Expr{
+											&ast.SelectorExpr{
+												X: &ast.IndexExpr{
+													X:     b.invocationsSelector,
+													Index: ast.NewIdent("index"),
+												},
+												Sel: ast.NewIdent("Panicked"),
+											},
+										},
+										Tok: token.ASSIGN,
+										Rhs: []ast.Expr{
+											ast.NewIdent("true"),
+										},
+									},
+									mutexUnlockBuilder.Build(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package generator
+
+import "go/ast"
+
+func NewGateReleaseMethodBuilder(methodBuilder *MethodBuilder) *GateReleaseMethodBuilder {
+	return &GateReleaseMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// GateReleaseMethodBuilder is responsible for creating the method
+// that unblocks all calls that are held by a gate. The method can
+// safely be called multiple times.
+//
+// Example:
+//     func (gate *StubStructGate) Release() {
+//         gate.once.Do(func() {
+//             close(gate.release)
+//         })
+//     }
+type GateReleaseMethodBuilder struct {
+	methodBuilder        *MethodBuilder
+	onceFieldSelector    *ast.SelectorExpr
+	releaseFieldSelector *ast.SelectorExpr
+}
+
+func (b *GateReleaseMethodBuilder) SetOnceFieldSelector(selector *ast.SelectorExpr) {
+	b.onceFieldSelector = selector
+}
+
+func (b *GateReleaseMethodBuilder) SetReleaseFieldSelector(selector *ast.SelectorExpr) {
+	b.releaseFieldSelector = selector
+}
+
+func (b *GateReleaseMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   b.onceFieldSelector,
+				Sel: ast.NewIdent("Do"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: ast.NewIdent("close"),
+									Args: []ast.Expr{
+										b.releaseFieldSelector,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
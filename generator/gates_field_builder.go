@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMethodGatesFieldBuilder() *MethodGatesFieldBuilder {
+	return &MethodGatesFieldBuilder{}
+}
+
+// The MethodGatesFieldBuilder is responsible for creating the field
+// which is internally used to track the gates that the next calls
+// to a given method should block on.
+//
+// Example:
+//     type StubStruct struct {
+//         // ...
+//         sumGates []*StubStructGate
+//         // ...
+//     }
+type MethodGatesFieldBuilder struct {
+	fieldName    string
+	gateTypeName string
+}
+
+func (b *MethodGatesFieldBuilder) SetFieldName(name string) {
+	b.fieldName = name
+}
+
+// SetGateTypeName configures the name of the type that is used
+// to represent a gate.
+func (b *MethodGatesFieldBuilder) SetGateTypeName(name string) {
+	b.gateTypeName = name
+}
+
+func (b *MethodGatesFieldBuilder) Build() *ast.Field {
+	return util.CreateField(b.fieldName, &ast.ArrayType{
+		Elt: &ast.StarExpr{
+			X: ast.NewIdent(b.gateTypeName),
+		},
+	})
+}
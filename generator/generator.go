@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/types"
+	"path/filepath"
 
 	"github.com/mokiat/gostub/resolution"
 	"github.com/mokiat/gostub/util"
@@ -32,9 +34,214 @@ type Config struct {
 	// TargetStructName specifies the name of the stub structure
 	// that will implement the interface
 	TargetStructName string
+
+	// TargetMatchersFilePath specifies the file in which Gomega matchers
+	// for the stub will be saved. If empty, no matchers are generated.
+	TargetMatchersFilePath string
+
+	// TargetGoldenFilePath specifies the file in which the golden file
+	// recorder and replay for the stub will be saved. If empty, no golden
+	// file support is generated.
+	TargetGoldenFilePath string
+
+	// TargetRemoteFilePath specifies the file in which the HTTP control
+	// handler and client for the stub will be saved. If empty, no remote
+	// control support is generated.
+	TargetRemoteFilePath string
+
+	// Deep specifies whether stubs should also be generated, transitively,
+	// for the interfaces that are returned by the methods of the stub.
+	// Such stubs are saved next to the stub and are named after their
+	// interface.
+	Deep bool
+
+	// Random specifies whether methods that configure random results
+	// should be generated for the stub.
+	Random bool
+
+	// Methods specifies the names of the methods that should be stubbed.
+	// The remaining methods of the interface panic when called. If empty,
+	// all methods are stubbed. Deep stubs always stub all methods.
+	Methods []string
+
+	// Compose specifies whether the stubs of the interfaces that are
+	// embedded by the interface should be embedded into the stub, instead
+	// of stubbing their methods again. Such stubs need to exist already
+	// in the package of the stub and are named after their interface.
+	Compose bool
+
+	// Counterfeiter specifies whether the stub should be generated as a
+	// fake whose API and field names match the ones of fakes generated by
+	// counterfeiter. Matchers, golden files, remote control, random
+	// results and deep stubs are not supported for such fakes.
+	Counterfeiter bool
+
+	// Mock specifies whether the stub should be generated as an
+	// expectation-based mock, in the style of gomock, which relies on
+	// the mock runtime package of gostub. Composition is not supported
+	// for such mocks, in addition to the features that are not
+	// supported for counterfeiter fakes.
+	Mock bool
+
+	// Features specifies the optional features that should be generated
+	// for the stub, in addition to the call tracking and configuration
+	// that every stub has.
+	Features Features
+}
+
+// Features specifies which of the optional features of a stub should be
+// generated. None of them are generated by default, so that stubs stay
+// small and only depend on the packages that they need.
+type Features struct {
+
+	// Rules specifies whether methods with both params and results should
+	// get When and CalledWith methods that configure results which are
+	// only returned for matching arguments.
+	Rules bool
+
+	// Strict specifies whether the stub should get a SetStrict method,
+	// after which calls to methods that were not configured are reported.
+	Strict bool
+
+	// Wait specifies whether methods that wait for asynchronous calls
+	// should be generated.
+	Wait bool
+
+	// Hold specifies whether methods that hold calls until they are
+	// released through a gate should be generated.
+	Hold bool
+
+	// Options specifies whether a constructor that accepts functional
+	// options, along with the options for each method, should be
+	// generated.
+	Options bool
+
+	// Expectations specifies whether a constructor that binds the stub
+	// to a test, along with methods that declare the expected number of
+	// calls for each method, should be generated. The constructor accepts
+	// the options of the stub, so this implies Options.
+	Expectations bool
+
+	// Invocations specifies whether the stub should record an ordered
+	// log of the calls to all of its methods.
+	Invocations bool
+
+	// Recorder specifies whether the stub should get an AttachRecorder
+	// method, which passes all calls to a shared recorder.
+	Recorder bool
+
+	// SetsArgs specifies whether methods that configure values to be
+	// assigned into pointer and empty interface params should be
+	// generated.
+	SetsArgs bool
+
+	// InvokesCallbacks specifies whether methods that configure the
+	// invocation of callback params should be generated.
+	InvokesCallbacks bool
+
+	// Scenario specifies whether the stub should get a LoadScenario
+	// method, which configures results from a JSON scenario. It is
+	// implied by the remote control, which relies on it.
+	Scenario bool
+
+	// Latency specifies whether methods that delay calls, or block them
+	// until their context is done, should be generated.
+	Latency bool
+
+	// Panics specifies whether methods that make calls panic should be
+	// generated.
+	Panics bool
+}
+
+// embeddedStubMethods returns the names of the methods that the stubs
+// which are embedded into a composed stub with these features need to
+// have, as the composed stub relies on them.
+func (f Features) embeddedStubMethods() []string {
+	methods := []string{}
+	if f.Strict {
+		methods = append(methods, setStrictMethodName)
+	}
+	if f.Expectations {
+		methods = append(methods, verifyExpectationsMethodName)
+	}
+	if f.Scenario {
+		methods = append(methods, loadScenarioMethodName)
+	}
+	return methods
+}
+
+// promotesMethods checks whether any of the features covers the methods
+// that a composed stub promotes from the stubs that it embeds.
+func (f Features) promotesMethods() bool {
+	return f.Invocations || f.Recorder || f.Expectations || f.Options || f.Scenario
+}
+
+// needStubMutex checks whether any of the features keeps state on the
+// stub itself, which is guarded by a stub-level mutex.
+func (f Features) needStubMutex() bool {
+	return f.Strict || f.Expectations || f.Invocations || f.Recorder
+}
+
+// stubName returns the name of the stub that is generated, or expected
+// to have been generated, for the interface with the specified name.
+func (c Config) stubName(interfaceName string) string {
+	if c.Counterfeiter {
+		return "Fake" + interfaceName
+	}
+	if c.Mock {
+		return "Mock" + interfaceName
+	}
+	return interfaceName + "Stub"
+}
+
+// childConfig returns the configuration that is used to generate
+// a deep stub for the specified interface.
+func (c Config) childConfig(discovery resolution.TypeDiscovery) Config {
+	interfaceName := discovery.Spec.Name.String()
+	targetDir := filepath.Dir(c.TargetFilePath)
+	config := c
+	config.SourcePackageLocation = discovery.Location
+	config.SourceInterfaceName = interfaceName
+	config.TargetFilePath = filepath.Join(targetDir, util.SnakeCase(interfaceName)+"_stub.go")
+	config.TargetStructName = c.stubName(interfaceName)
+	config.Methods = nil
+	config.Compose = false
+	if c.TargetMatchersFilePath != "" {
+		config.TargetMatchersFilePath = filepath.Join(targetDir, util.SnakeCase(interfaceName)+"_matchers.go")
+	}
+	if c.TargetGoldenFilePath != "" {
+		config.TargetGoldenFilePath = filepath.Join(targetDir, util.SnakeCase(interfaceName)+"_golden.go")
+	}
+	if c.TargetRemoteFilePath != "" {
+		config.TargetRemoteFilePath = filepath.Join(targetDir, util.SnakeCase(interfaceName)+"_remote.go")
+	}
+	return config
 }
 
 func Generate(config Config) error {
+	if len(config.Methods) > 0 && config.TargetGoldenFilePath != "" {
+		return errors.New("Golden files cannot be generated for partial stubs!")
+	}
+	if config.Compose && config.TargetGoldenFilePath != "" {
+		return errors.New("Golden files cannot be generated for composed stubs!")
+	}
+	if config.Counterfeiter && config.Mock {
+		return errors.New("Counterfeiter fakes and mocks cannot be generated at the same time!")
+	}
+	if config.Counterfeiter {
+		if err := validateFlavorConfig(config, "counterfeiter fakes"); err != nil {
+			return err
+		}
+	}
+	if config.Mock {
+		if config.Compose {
+			return errors.New("Mocks cannot be composed!")
+		}
+		if err := validateFlavorConfig(config, "mocks"); err != nil {
+			return err
+		}
+	}
+
 	locator := resolution.NewLocator()
 
 	// Do an initial search only with what we have as input
@@ -43,12 +250,128 @@ func Generate(config Config) error {
 	if err != nil {
 		return err
 	}
+	if config.Counterfeiter {
+		return generateFake(locator, discovery, config)
+	}
+	if config.Mock {
+		return generateMock(locator, discovery, config)
+	}
+	return generateStub(locator, discovery, config, make(map[string]string))
+}
+
+// validateFlavorConfig checks that only features that are supported by
+// the specified flavor of generated stubs are requested.
+func validateFlavorConfig(config Config, flavor string) error {
+	switch {
+	case config.TargetMatchersFilePath != "":
+		return fmt.Errorf("Matchers cannot be generated for %s!", flavor)
+	case config.TargetGoldenFilePath != "":
+		return fmt.Errorf("Golden files cannot be generated for %s!", flavor)
+	case config.TargetRemoteFilePath != "":
+		return fmt.Errorf("Remote control cannot be generated for %s!", flavor)
+	case config.Random:
+		return fmt.Errorf("Random results cannot be generated for %s!", flavor)
+	case config.Deep:
+		return fmt.Errorf("Deep stubs cannot be generated for %s!", flavor)
+	case config.Features != Features{}:
+		return fmt.Errorf("Optional stub features cannot be generated for %s!", flavor)
+	}
+	return nil
+}
+
+// generateFake generates a fake for the discovered interface, which
+// is compatible with the fakes that are generated by counterfeiter.
+func generateFake(locator *resolution.Locator, discovery resolution.TypeDiscovery, config Config) error {
+	model := NewCounterfeiterModel(config.TargetPackageName, config.TargetStructName)
+	model.AddStubAssignment(config.SourcePackageLocation, config.SourceInterfaceName)
+
+	stubGen := newGenerator(model, nil, locator)
+	err := stubGen.Process(discovery, config)
+	if err != nil {
+		return err
+	}
 
-	model := NewGeneratorModel(config.TargetPackageName, config.TargetStructName)
+	err = model.Save(config.TargetFilePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Fake '%s' successfully created in '%s'.\n", config.TargetStructName, config.TargetPackageName)
+	return nil
+}
+
+// generateMock generates an expectation-based mock for the discovered
+// interface, which is used in the style of gomock mocks.
+func generateMock(locator *resolution.Locator, discovery resolution.TypeDiscovery, config Config) error {
+	model := NewMockModel(config.TargetPackageName, config.TargetStructName)
 	model.AddStubAssignment(config.SourcePackageLocation, config.SourceInterfaceName)
 
-	stubGen := newGenerator(model, locator)
-	err = stubGen.ProcessInterface(discovery)
+	stubGen := newGenerator(model, nil, locator)
+	err := stubGen.Process(discovery, config)
+	if err != nil {
+		return err
+	}
+
+	err = model.Save(config.TargetFilePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Mock '%s' successfully created in '%s'.\n", config.TargetStructName, config.TargetPackageName)
+	return nil
+}
+
+// generateStub generates a stub for the discovered interface and, in deep
+// mode, for all interfaces that are returned by its methods. The generated
+// map tracks the location of the interface of each generated stub, by stub
+// name, so that each stub is generated only once.
+func generateStub(locator *resolution.Locator, discovery resolution.TypeDiscovery, config Config, generated map[string]string) error {
+	generated[config.TargetStructName] = discovery.Location
+
+	features := config.Features
+	if features.Expectations {
+		// Stubs bound to a test are created through the constructor
+		// that accepts options.
+		features.Options = true
+	}
+	if features.Panics {
+		// Panicked calls are reported through the invocation log.
+		features.Invocations = true
+	}
+	if config.TargetRemoteFilePath != "" {
+		// The remote control configures the stub through scenarios.
+		features.Scenario = true
+	}
+	// Composed stubs check the embedded stubs against the implied
+	// features as well.
+	config.Features = features
+	model := NewGeneratorModel(config.TargetPackageName, config.TargetStructName, features)
+	model.AddStubAssignment(config.SourcePackageLocation, config.SourceInterfaceName)
+
+	var matchersModel *MatchersModel
+	if config.TargetMatchersFilePath != "" {
+		matchersModel = NewMatchersModel(model)
+	}
+
+	var goldenModel *GoldenModel
+	if config.TargetGoldenFilePath != "" {
+		goldenModel = NewGoldenModel(model, config.SourcePackageLocation, config.SourceInterfaceName)
+	}
+
+	var remoteModel *RemoteModel
+	if config.TargetRemoteFilePath != "" {
+		remoteModel = NewRemoteModel(model)
+	}
+
+	stubGen := newGenerator(model, matchersModel, locator)
+	stubGen.goldenModel = goldenModel
+	stubGen.remoteModel = remoteModel
+	if config.Random {
+		model.AddRandomizeResultsMethod()
+		stubGen.randomizer = NewRandomizer(model, stubGen.resolver, locator)
+	}
+	stubGen.deep = config.Deep
+	err := stubGen.Process(discovery, config)
 	if err != nil {
 		return err
 	}
@@ -58,22 +381,150 @@ func Generate(config Config) error {
 		return err
 	}
 
+	if matchersModel != nil {
+		err = matchersModel.Save(config.TargetMatchersFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if goldenModel != nil {
+		err = goldenModel.Save(config.TargetGoldenFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if remoteModel != nil {
+		err = remoteModel.Save(config.TargetRemoteFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("Stub '%s' successfully created in '%s'.\n", config.TargetStructName, config.TargetPackageName)
+
+	for _, child := range stubGen.children {
+		childConfig := config.childConfig(child)
+		if location, found := generated[childConfig.TargetStructName]; found {
+			if location != child.Location {
+				return fmt.Errorf("Cannot generate deep stub '%s' for both '%s' and '%s'!", childConfig.TargetStructName, location, child.Location)
+			}
+			continue
+		}
+		err = generateStub(locator, child, childConfig, generated)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func newGenerator(model *GeneratorModel, locator *resolution.Locator) *stubGenerator {
+// stubModel is the model of the file that holds the stub, to which
+// the processed methods of the interface are added.
+type stubModel interface {
+	Importer
+	IsResolvedType(resolvedType ast.Expr, location, name string) bool
+	AddMethod(config *MethodConfig) error
+	AddUnexpectedMethod(config *MethodConfig)
+	AddEmbeddedStub(stubName string)
+	AddPromotedMethod(stubName string, config *MethodConfig)
+}
+
+func newGenerator(model stubModel, matchersModel *MatchersModel, locator *resolution.Locator) *stubGenerator {
 	return &stubGenerator{
-		model:    model,
-		locator:  locator,
-		resolver: NewResolver(model, locator),
+		model:         model,
+		matchersModel: matchersModel,
+		locator:       locator,
+		resolver:      NewResolver(model, locator),
 	}
 }
 
 type stubGenerator struct {
-	model    *GeneratorModel
-	locator  *resolution.Locator
-	resolver *Resolver
+	model           stubModel
+	matchersModel   *MatchersModel
+	goldenModel     *GoldenModel
+	remoteModel     *RemoteModel
+	randomizer      *Randomizer
+	locator         *resolution.Locator
+	resolver        *Resolver
+	interfaces      []resolution.TypeDiscovery
+	deep            bool
+	children        []resolution.TypeDiscovery
+	methods         map[string]bool
+	composer        *stubComposer
+	promotesMethods bool
+}
+
+// Process adds the methods of the specified interface, as selected by
+// the configuration, to the models of the stub.
+func (g *stubGenerator) Process(discovery resolution.TypeDiscovery, config Config) error {
+	if config.Compose {
+		g.composer = newStubComposer(g.locator, config.TargetFilePath, config.stubName, config.Features.embeddedStubMethods())
+		g.promotesMethods = config.Features.promotesMethods()
+	}
+	if len(config.Methods) > 0 {
+		g.methods = make(map[string]bool)
+		for _, name := range config.Methods {
+			g.methods[name] = false
+		}
+	}
+	err := g.CollectInterfaces(discovery)
+	if err != nil {
+		return err
+	}
+	err = g.ProcessInterface(discovery)
+	if err != nil {
+		return err
+	}
+	for _, name := range config.Methods {
+		if !g.methods[name] {
+			return fmt.Errorf("Method '%s' not found in interface '%s'!", name, config.SourceInterfaceName)
+		}
+	}
+	return nil
+}
+
+// CollectInterfaces records the specified interface and all the
+// interfaces that it embeds, directly or indirectly. These are
+// the interfaces that the stub implements.
+func (g *stubGenerator) CollectInterfaces(discovery resolution.TypeDiscovery) error {
+	g.interfaces = append(g.interfaces, discovery)
+	iFaceType, isIFace := discovery.Spec.Type.(*ast.InterfaceType)
+	if !isIFace {
+		return nil
+	}
+	context := resolution.NewASTFileLocatorContext(discovery.File, discovery.Location)
+	for field := range util.EachFieldInFieldList(iFaceType.Methods) {
+		var subDiscovery resolution.TypeDiscovery
+		var err error
+		switch t := field.Type.(type) {
+		case *ast.Ident:
+			subDiscovery, err = g.locator.FindIdentType(context, t)
+		case *ast.SelectorExpr:
+			subDiscovery, err = g.locator.FindSelectorType(context, t)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = g.CollectInterfaces(subDiscovery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isImplementedInterface checks whether the specified resolved type
+// refers to one of the interfaces that the stub implements.
+func (g *stubGenerator) isImplementedInterface(resolvedType ast.Expr) bool {
+	for _, discovery := range g.interfaces {
+		if g.model.IsResolvedType(resolvedType, discovery.Location, discovery.Spec.Name.String()) {
+			return true
+		}
+	}
+	return false
 }
 
 func (g *stubGenerator) ProcessInterface(discovery resolution.TypeDiscovery) error {
@@ -85,11 +536,29 @@ func (g *stubGenerator) ProcessInterface(discovery resolution.TypeDiscovery) err
 	for field := range util.EachFieldInFieldList(iFaceType.Methods) {
 		switch t := field.Type.(type) {
 		case *ast.FuncType:
-			g.processMethod(context, field.Names[0].String(), t)
+			if err := g.processMethod(context, field.Names[0].String(), t); err != nil {
+				return err
+			}
 		case *ast.Ident:
-			g.processSubInterfaceIdent(context, t)
+			if g.composer != nil {
+				if err := g.composeSubInterfaceIdent(context, t); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := g.processSubInterfaceIdent(context, t); err != nil {
+				return err
+			}
 		case *ast.SelectorExpr:
-			g.processSubInterfaceSelector(context, t)
+			if g.composer != nil {
+				if err := g.composeSubInterfaceSelector(context, t); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := g.processSubInterfaceSelector(context, t); err != nil {
+				return err
+			}
 		default:
 			return errors.New("Unknown statement in interface declaration.")
 		}
@@ -98,6 +567,18 @@ func (g *stubGenerator) ProcessInterface(discovery resolution.TypeDiscovery) err
 }
 
 func (g *stubGenerator) processMethod(context *resolution.LocatorContext, name string, funcType *ast.FuncType) error {
+	if g.methods != nil {
+		if _, selected := g.methods[name]; !selected {
+			return g.processUnexpectedMethod(context, name, funcType)
+		}
+		g.methods[name] = true
+	}
+	// Random results need to be determined before the results are
+	// normalized, since normalization resolves the result types in place.
+	randomResults, err := g.getRandomResults(context, funcType)
+	if err != nil {
+		return err
+	}
 	normalizedParams, err := g.getNormalizedParams(context, funcType)
 	if err != nil {
 		return err
@@ -107,17 +588,93 @@ func (g *stubGenerator) processMethod(context *resolution.LocatorContext, name s
 		return err
 	}
 	source := &MethodConfig{
-		MethodName:    name,
-		MethodParams:  normalizedParams,
-		MethodResults: normalizedResults,
+		MethodName:       name,
+		MethodParams:     normalizedParams,
+		MethodParamNames: g.getParamNames(funcType),
+		MethodResults:    normalizedResults,
+		RandomResults:    randomResults,
+		ReturnsSelf:      len(normalizedResults) == 1 && g.isImplementedInterface(normalizedResults[0].Type),
+	}
+	if g.deep && !source.ReturnsSelf {
+		child, found, err := g.findReturnedInterface(context, funcType)
+		if err != nil {
+			return err
+		}
+		if found {
+			source.ChildStubName = child.Spec.Name.String() + "Stub"
+			g.children = append(g.children, child)
+		}
 	}
 	err = g.model.AddMethod(source)
 	if err != nil {
 		return err
 	}
+	if g.matchersModel != nil {
+		err = g.matchersModel.AddMethod(source)
+		if err != nil {
+			return err
+		}
+	}
+	if g.goldenModel != nil {
+		err = g.goldenModel.AddMethod(source)
+		if err != nil {
+			return err
+		}
+	}
+	if g.remoteModel != nil {
+		err = g.remoteModel.AddMethod(source)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processUnexpectedMethod adds a method that only satisfies the
+// interface, for methods that were not selected in partial mode.
+func (g *stubGenerator) processUnexpectedMethod(context *resolution.LocatorContext, name string, funcType *ast.FuncType) error {
+	normalizedParams, err := g.getNormalizedParams(context, funcType)
+	if err != nil {
+		return err
+	}
+	normalizedResults, err := g.getNormalizedResults(context, funcType)
+	if err != nil {
+		return err
+	}
+	g.model.AddUnexpectedMethod(&MethodConfig{
+		MethodName:    name,
+		MethodParams:  normalizedParams,
+		MethodResults: normalizedResults,
+	})
 	return nil
 }
 
+// findReturnedInterface finds the named interface that is returned
+// by the method, if the method has a single result of such a type.
+func (g *stubGenerator) findReturnedInterface(context *resolution.LocatorContext, funcType *ast.FuncType) (resolution.TypeDiscovery, bool, error) {
+	if funcType.Results == nil || len(funcType.Results.List) != 1 || util.FieldTypeReuseCount(funcType.Results.List[0]) != 1 {
+		return resolution.TypeDiscovery{}, false, nil
+	}
+	var discovery resolution.TypeDiscovery
+	var err error
+	switch t := funcType.Results.List[0].Type.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(t.String()) != nil {
+			return resolution.TypeDiscovery{}, false, nil
+		}
+		discovery, err = g.locator.FindIdentType(context, t)
+	case *ast.SelectorExpr:
+		discovery, err = g.locator.FindSelectorType(context, t)
+	default:
+		return resolution.TypeDiscovery{}, false, nil
+	}
+	if err != nil {
+		return resolution.TypeDiscovery{}, false, err
+	}
+	_, isIFace := discovery.Spec.Type.(*ast.InterfaceType)
+	return discovery, isIFace, nil
+}
+
 func (g *stubGenerator) processSubInterfaceIdent(context *resolution.LocatorContext, ident *ast.Ident) error {
 	discovery, err := g.locator.FindIdentType(context, ident)
 	if err != nil {
@@ -142,6 +699,92 @@ func (g *stubGenerator) processSubInterfaceSelector(context *resolution.LocatorC
 	return nil
 }
 
+// composeSubInterfaceIdent embeds the existing stub of the embedded
+// interface into the stub.
+func (g *stubGenerator) composeSubInterfaceIdent(context *resolution.LocatorContext, ident *ast.Ident) error {
+	discovery, err := g.locator.FindIdentType(context, ident)
+	if err != nil {
+		return err
+	}
+	return g.composeSubInterface(discovery)
+}
+
+// composeSubInterfaceSelector embeds the existing stub of the embedded
+// interface into the stub.
+func (g *stubGenerator) composeSubInterfaceSelector(context *resolution.LocatorContext, selector *ast.SelectorExpr) error {
+	discovery, err := g.locator.FindSelectorType(context, selector)
+	if err != nil {
+		return err
+	}
+	return g.composeSubInterface(discovery)
+}
+
+func (g *stubGenerator) composeSubInterface(discovery resolution.TypeDiscovery) error {
+	stubName, err := g.composer.Compose(discovery)
+	if err != nil {
+		return err
+	}
+	g.model.AddEmbeddedStub(stubName)
+	if !g.promotesMethods {
+		// Resolving the types of the promoted methods would add
+		// imports that the stub does not use.
+		return nil
+	}
+	return g.promoteSubInterface(discovery, stubName)
+}
+
+// promoteSubInterface registers the methods of the specified embedded
+// interface, including the ones of the interfaces that it embeds, as
+// promoted from the specified embedded stub.
+func (g *stubGenerator) promoteSubInterface(discovery resolution.TypeDiscovery, stubName string) error {
+	context := resolution.NewASTFileLocatorContext(discovery.File, discovery.Location)
+	iFaceType, isIFace := discovery.Spec.Type.(*ast.InterfaceType)
+	if !isIFace {
+		return fmt.Errorf("Type '%s' in '%s' is not interface!", discovery.Spec.Name.String(), discovery.Location)
+	}
+	for field := range util.EachFieldInFieldList(iFaceType.Methods) {
+		var subDiscovery resolution.TypeDiscovery
+		var err error
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			if err := g.promoteMethod(context, stubName, field.Names[0].String(), t); err != nil {
+				return err
+			}
+			continue
+		case *ast.Ident:
+			subDiscovery, err = g.locator.FindIdentType(context, t)
+		case *ast.SelectorExpr:
+			subDiscovery, err = g.locator.FindSelectorType(context, t)
+		default:
+			return errors.New("Unknown statement in interface declaration.")
+		}
+		if err != nil {
+			return err
+		}
+		if err = g.promoteSubInterface(subDiscovery, stubName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *stubGenerator) promoteMethod(context *resolution.LocatorContext, stubName, name string, funcType *ast.FuncType) error {
+	normalizedParams, err := g.getNormalizedParams(context, funcType)
+	if err != nil {
+		return err
+	}
+	normalizedResults, err := g.getNormalizedResults(context, funcType)
+	if err != nil {
+		return err
+	}
+	g.model.AddPromotedMethod(stubName, &MethodConfig{
+		MethodName:    name,
+		MethodParams:  normalizedParams,
+		MethodResults: normalizedResults,
+	})
+	return nil
+}
+
 func (g *stubGenerator) getNormalizedParams(context *resolution.LocatorContext, funcType *ast.FuncType) ([]*ast.Field, error) {
 	normalizedParams := []*ast.Field{}
 	paramIndex := 1
@@ -161,6 +804,23 @@ func (g *stubGenerator) getNormalizedParams(context *resolution.LocatorContext,
 	return normalizedParams, nil
 }
 
+// getParamNames returns the original names of all parameters of the
+// method, in the order of the normalized parameters. Anonymous
+// parameters have an empty name.
+func (g *stubGenerator) getParamNames(funcType *ast.FuncType) []string {
+	names := []string{}
+	for param := range util.EachFieldInFieldList(funcType.Params) {
+		if len(param.Names) == 0 {
+			names = append(names, "")
+			continue
+		}
+		for _, name := range param.Names {
+			names = append(names, name.String())
+		}
+	}
+	return names
+}
+
 func (g *stubGenerator) getNormalizedResults(context *resolution.LocatorContext, funcType *ast.FuncType) ([]*ast.Field, error) {
 	normalizedResults := []*ast.Field{}
 	resultIndex := 1
@@ -179,3 +839,24 @@ func (g *stubGenerator) getNormalizedResults(context *resolution.LocatorContext,
 	}
 	return normalizedResults, nil
 }
+
+// getRandomResults returns expressions that create a random value for
+// each of the results of the method. If random results are not enabled,
+// nil is returned.
+func (g *stubGenerator) getRandomResults(context *resolution.LocatorContext, funcType *ast.FuncType) ([]ast.Expr, error) {
+	if g.randomizer == nil {
+		return nil, nil
+	}
+	randomResults := []ast.Expr{}
+	for result := range util.EachFieldInFieldList(funcType.Results) {
+		count := util.FieldTypeReuseCount(result)
+		for i := 0; i < count; i++ {
+			value, err := g.randomizer.RandomValue(context, result.Type, ast.NewIdent(randomSourceParamName))
+			if err != nil {
+				return nil, err
+			}
+			randomResults = append(randomResults, value)
+		}
+	}
+	return randomResults, nil
+}
@@ -0,0 +1,257 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+const goldenRecorderReceiverName string = "recorder"
+const goldenReplayReceiverName string = "replay"
+const goldenTargetFieldName string = "target"
+const goldenMutexFieldName string = "mutex"
+const goldenCallsFieldName string = "calls"
+const goldenErrFieldName string = "err"
+const goldenReporterFieldName string = "reporter"
+const goldenRecordMethodName string = "record"
+const goldenSaveMethodName string = "SaveGolden"
+const goldenNextMethodName string = "next"
+const goldenFailMethodName string = "fail"
+
+// NewGoldenModel creates a model for the companion file that holds
+// the golden file recorder and replay of the specified stub.
+func NewGoldenModel(stubModel *GeneratorModel, interfaceLocation, interfaceName string) *GoldenModel {
+	fileBuilder := NewFileBuilder()
+	fileBuilder.SetPackage(stubModel.fileBuilder.filePackageName)
+
+	model := &GoldenModel{
+		fileBuilder:   fileBuilder,
+		stubModel:     stubModel,
+		stubName:      stubModel.structName,
+		interfaceType: stubModel.resolveInterfaceType(interfaceLocation, interfaceName),
+	}
+	// Calls are saved to golden files as JSON.
+	stubModel.serializesCalls = true
+	model.createCallStruct()
+	model.createRecorderStruct()
+	model.createRecorderAssignment()
+	model.createRecorderConstructor()
+	model.createRecordMethod()
+	model.createSaveMethod()
+	model.createReplayStruct()
+	model.createReplayNextMethod()
+	model.createReplayFailMethod()
+	model.createReplayConstructor()
+	return model
+}
+
+type GoldenModel struct {
+	fileBuilder       *FileBuilder
+	stubModel         *GeneratorModel
+	stubName          string
+	interfaceType     *ast.SelectorExpr
+	replayConstructor *GoldenReplayConstructorBuilder
+}
+
+func (t *GoldenModel) AddMethod(config *MethodConfig) error {
+	t.createRecorderMethod(config)
+	t.replayConstructor.AddReplayedMethod(config, t.stubModel.argsTypeName(config), t.stubModel.resultsTypeName(config))
+	return nil
+}
+
+func (t *GoldenModel) createCallStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.callTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Method", ast.NewIdent("string"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Args", t.resolveJSONSelector("RawMessage"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Results", t.resolveJSONSelector("RawMessage"))))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createRecorderStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.recorderTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenTargetFieldName, t.interfaceType)))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenMutexFieldName, t.resolveSyncSelector("Mutex"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenCallsFieldName, &ast.ArrayType{
+		Elt: ast.NewIdent(t.callTypeName()),
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenErrFieldName, ast.NewIdent("error"))))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createRecorderAssignment() {
+	builder := NewStubToInterfaceStatementBuilder()
+	builder.SetStubName(t.recorderTypeName())
+	builder.SetInterfaceSelector(t.interfaceType)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createRecorderConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.recorderTypeName())
+	builder := NewWrapperConstructorBuilder(methodBuilder)
+	builder.SetWrapperName(t.recorderTypeName())
+	builder.SetTargetFieldName(goldenTargetFieldName)
+	builder.SetTargetType(t.interfaceType)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createRecordMethod() {
+	builder := NewGoldenRecordMethodBuilder(t.createRecorderMethodBuilder(goldenRecordMethodName))
+	builder.SetMutexFieldSelector(t.recorderFieldSelector(goldenMutexFieldName))
+	builder.SetCallsFieldSelector(t.recorderFieldSelector(goldenCallsFieldName))
+	builder.SetErrFieldSelector(t.recorderFieldSelector(goldenErrFieldName))
+	builder.SetMarshalSelector(t.resolveJSONSelector("Marshal"))
+	builder.SetErrorfSelector(t.resolveFmtSelector("Errorf"))
+	builder.SetCallTypeName(t.callTypeName())
+	builder.SetStubName(t.stubName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createSaveMethod() {
+	builder := NewGoldenSaveMethodBuilder(t.createRecorderMethodBuilder(goldenSaveMethodName))
+	builder.SetMutexFieldSelector(t.recorderFieldSelector(goldenMutexFieldName))
+	builder.SetCallsFieldSelector(t.recorderFieldSelector(goldenCallsFieldName))
+	builder.SetErrFieldSelector(t.recorderFieldSelector(goldenErrFieldName))
+	builder.SetMarshalIndentSelector(t.resolveJSONSelector("MarshalIndent"))
+	builder.SetWriteFileSelector(t.resolveIOUtilSelector("WriteFile"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createReplayStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.replayTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenMutexFieldName, t.resolveSyncSelector("Mutex"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenCallsFieldName, &ast.MapType{
+		Key: ast.NewIdent("string"),
+		Value: &ast.ArrayType{
+			Elt: ast.NewIdent(t.callTypeName()),
+		},
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(goldenReporterFieldName, t.stubModel.resolveReporterType())))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createReplayNextMethod() {
+	builder := NewGoldenReplayNextMethodBuilder(t.createReplayMethodBuilder(goldenNextMethodName))
+	builder.SetMutexFieldSelector(t.replayFieldSelector(goldenMutexFieldName))
+	builder.SetCallsFieldSelector(t.replayFieldSelector(goldenCallsFieldName))
+	builder.SetFailMethodSelector(t.replayFieldSelector(goldenFailMethodName))
+	builder.SetMarshalSelector(t.resolveJSONSelector("Marshal"))
+	builder.SetUnmarshalSelector(t.resolveJSONSelector("Unmarshal"))
+	builder.SetCompactSelector(t.resolveJSONSelector("Compact"))
+	builder.SetBufferType(t.resolveBytesSelector("Buffer"))
+	builder.SetEqualSelector(t.resolveBytesSelector("Equal"))
+	builder.SetStubName(t.stubName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createReplayFailMethod() {
+	builder := NewGoldenReplayFailMethodBuilder(t.createReplayMethodBuilder(goldenFailMethodName))
+	builder.SetReporterFieldSelector(t.replayFieldSelector(goldenReporterFieldName))
+	builder.SetSprintfSelector(t.resolveFmtSelector("Sprintf"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) createReplayConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.stubName + "FromGolden")
+	builder := NewGoldenReplayConstructorBuilder(methodBuilder)
+	builder.SetStubName(t.stubName)
+	builder.SetReplayName(t.replayTypeName())
+	builder.SetCallTypeName(t.callTypeName())
+	builder.SetReporterType(t.stubModel.resolveReporterType())
+	builder.SetReadFileSelector(t.resolveIOUtilSelector("ReadFile"))
+	builder.SetUnmarshalSelector(t.resolveJSONSelector("Unmarshal"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.replayConstructor = builder
+}
+
+func (t *GoldenModel) createRecorderMethod(config *MethodConfig) {
+	builder := NewGoldenRecorderMethodBuilder(t.createRecorderMethodBuilder(config.MethodName))
+	builder.SetTargetFieldSelector(t.recorderFieldSelector(goldenTargetFieldName))
+	builder.SetRecordMethodSelector(t.recorderFieldSelector(goldenRecordMethodName))
+	builder.SetArgsTypeName(t.stubModel.argsTypeName(config))
+	builder.SetResultsTypeName(t.stubModel.resultsTypeName(config))
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GoldenModel) callTypeName() string {
+	return t.stubName + "GoldenCall"
+}
+
+func (t *GoldenModel) recorderTypeName() string {
+	return t.stubName + "GoldenRecorder"
+}
+
+func (t *GoldenModel) replayTypeName() string {
+	return t.stubName + "GoldenReplay"
+}
+
+func (t *GoldenModel) createRecorderMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(goldenRecorderReceiverName, t.recorderTypeName())
+	return builder
+}
+
+func (t *GoldenModel) createReplayMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(goldenReplayReceiverName, t.replayTypeName())
+	return builder
+}
+
+func (t *GoldenModel) recorderFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(goldenRecorderReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *GoldenModel) replayFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(goldenReplayReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *GoldenModel) resolveJSONSelector(name string) *ast.SelectorExpr {
+	return t.resolveSelector("json", "encoding/json", name)
+}
+
+func (t *GoldenModel) resolveSyncSelector(name string) *ast.SelectorExpr {
+	return t.resolveSelector("sync", "sync", name)
+}
+
+func (t *GoldenModel) resolveFmtSelector(name string) *ast.SelectorExpr {
+	return t.resolveSelector("fmt", "fmt", name)
+}
+
+func (t *GoldenModel) resolveBytesSelector(name string) *ast.SelectorExpr {
+	return t.resolveSelector("bytes", "bytes", name)
+}
+
+func (t *GoldenModel) resolveIOUtilSelector(name string) *ast.SelectorExpr {
+	return t.resolveSelector("ioutil", "io/ioutil", name)
+}
+
+func (t *GoldenModel) resolveSelector(pkgName, location, name string) *ast.SelectorExpr {
+	alias := t.fileBuilder.AddImport(pkgName, location)
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+// Save saves the golden companion file. All imports of the stub file
+// are registered first, since the types of the method params and results
+// have been resolved against the stub's namespace.
+func (t *GoldenModel) Save(filePath string) error {
+	t.fileBuilder.AddImportsFrom(t.stubModel.fileBuilder)
+	return saveFile(t.fileBuilder, filePath)
+}
@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewGoldenRecordMethodBuilder(methodBuilder *MethodBuilder) *GoldenRecordMethodBuilder {
+	return &GoldenRecordMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// GoldenRecordMethodBuilder is responsible for creating a method on the
+// golden recorder that serializes the arguments and results of a call
+// and appends them to the recorded calls. Serialization happens right
+// away, so that later changes to the values are not recorded. The first
+// serialization error is kept and is returned when the calls are saved.
+//
+// Example:
+//     func (recorder *StubStructGoldenRecorder) record(method string, args interface{}, results interface{}) {
+//         // ...
+//     }
+type GoldenRecordMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	callsFieldSelector *ast.SelectorExpr
+	errFieldSelector   *ast.SelectorExpr
+	marshalSelector    *ast.SelectorExpr
+	errorfSelector     *ast.SelectorExpr
+	callTypeName       string
+	stubName           string
+}
+
+func (b *GoldenRecordMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *GoldenRecordMethodBuilder) SetCallsFieldSelector(selector *ast.SelectorExpr) {
+	b.callsFieldSelector = selector
+}
+
+func (b *GoldenRecordMethodBuilder) SetErrFieldSelector(selector *ast.SelectorExpr) {
+	b.errFieldSelector = selector
+}
+
+// SetMarshalSelector configures the json.Marshal function.
+// The selector should have already been resolved.
+func (b *GoldenRecordMethodBuilder) SetMarshalSelector(selector *ast.SelectorExpr) {
+	b.marshalSelector = selector
+}
+
+// SetErrorfSelector configures the function that is used to create
+// serialization errors. The selector should have already been resolved.
+func (b *GoldenRecordMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+// SetCallTypeName specifies the name of the structure that holds
+// a single recorded call.
+func (b *GoldenRecordMethodBuilder) SetCallTypeName(name string) {
+	b.callTypeName = name
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in error messages.
+func (b *GoldenRecordMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *GoldenRecordMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("method", ast.NewIdent("string")),
+				util.CreateField("args", util.CreateEmptyInterface()),
+				util.CreateField("results", util.CreateEmptyInterface()),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("argsData"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("args"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("resultsData"),
+			ast.NewIdent("resultsErr"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("results"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						ast.NewIdent("resultsErr"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  b.errFieldSelector,
+						Op: token.EQL,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									b.errFieldSelector,
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: b.errorfSelector,
+										Args: []ast.Expr{
+											&ast.BasicLit{
+												Kind:  token.STRING,
+												Value: fmt.Sprintf("\"cannot record call to %s.%%s: %%v\"", b.stubName),
+											},
+											ast.NewIdent("method"),
+											ast.NewIdent("err"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.callsFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.callsFieldSelector,
+					&ast.CompositeLit{
+						Type: ast.NewIdent(b.callTypeName),
+						Elts: []ast.Expr{
+							&ast.KeyValueExpr{
+								Key:   ast.NewIdent("Method"),
+								Value: ast.NewIdent("method"),
+							},
+							&ast.KeyValueExpr{
+								Key:   ast.NewIdent("Args"),
+								Value: ast.NewIdent("argsData"),
+							},
+							&ast.KeyValueExpr{
+								Key:   ast.NewIdent("Results"),
+								Value: ast.NewIdent("resultsData"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewGoldenRecorderMethodBuilder(methodBuilder *MethodBuilder) *GoldenRecorderMethodBuilder {
+	return &GoldenRecorderMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// GoldenRecorderMethodBuilder is responsible for creating a method on
+// the golden recorder that implements the original method from the
+// interface by calling the wrapped implementation and recording the
+// arguments and results of the call.
+//
+// Example:
+//     func (recorder *StubStructGoldenRecorder) Sum(arg1 int, arg2 int) int {
+//         // ...
+//     }
+type GoldenRecorderMethodBuilder struct {
+	methodBuilder        *MethodBuilder
+	targetFieldSelector  *ast.SelectorExpr
+	recordMethodSelector *ast.SelectorExpr
+	argsTypeName         string
+	resultsTypeName      string
+	methodName           string
+	params               []*ast.Field
+	results              []*ast.Field
+}
+
+func (b *GoldenRecorderMethodBuilder) SetTargetFieldSelector(selector *ast.SelectorExpr) {
+	b.targetFieldSelector = selector
+}
+
+// SetRecordMethodSelector configures the method that records the
+// arguments and results of a call.
+func (b *GoldenRecorderMethodBuilder) SetRecordMethodSelector(selector *ast.SelectorExpr) {
+	b.recordMethodSelector = selector
+}
+
+// SetArgsTypeName configures the name of the type that holds the
+// arguments of a single call.
+func (b *GoldenRecorderMethodBuilder) SetArgsTypeName(name string) {
+	b.argsTypeName = name
+}
+
+// SetResultsTypeName configures the name of the type that holds the
+// results of a single call. It is only used if the method has results.
+func (b *GoldenRecorderMethodBuilder) SetResultsTypeName(name string) {
+	b.resultsTypeName = name
+}
+
+// SetMethodName specifies the name of the original method.
+func (b *GoldenRecorderMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *GoldenRecorderMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *GoldenRecorderMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *GoldenRecorderMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(b.results),
+		},
+	})
+
+	args := []ast.Expr{}
+	for _, param := range b.params {
+		args = append(args, ast.NewIdent(param.Names[0].String()))
+	}
+	resultNames := []ast.Expr{}
+	for _, result := range b.results {
+		resultNames = append(resultNames, ast.NewIdent(result.Names[0].String()))
+	}
+
+	targetCall := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   b.targetFieldSelector,
+			Sel: ast.NewIdent(b.methodName),
+		},
+		Args: args,
+	}
+	if count := len(b.params); count > 0 {
+		if _, ok := b.params[count-1].Type.(*ast.Ellipsis); ok {
+			targetCall.Ellipsis = 1
+		}
+	}
+
+	var recordedResults ast.Expr = ast.NewIdent("nil")
+	if len(b.results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: resultNames,
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				targetCall,
+			},
+		}))
+		recordedResults = &ast.UnaryExpr{
+			Op: token.AND,
+			X: &ast.CompositeLit{
+				Type: ast.NewIdent(b.resultsTypeName),
+				Elts: resultNames,
+			},
+		}
+	} else {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+			X: targetCall,
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: b.recordMethodSelector,
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\"%s\"", b.methodName),
+				},
+				&ast.UnaryExpr{
+					Op: token.AND,
+					X: &ast.CompositeLit{
+						Type: ast.NewIdent(b.argsTypeName),
+						Elts: args,
+					},
+				},
+				recordedResults,
+			},
+		},
+	}))
+	if len(b.results) > 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+			Results: resultNames,
+		}))
+	}
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,362 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewGoldenReplayConstructorBuilder(methodBuilder *MethodBuilder) *GoldenReplayConstructorBuilder {
+	return &GoldenReplayConstructorBuilder{
+		methodBuilder:   methodBuilder,
+		replayedMethods: make([]replayedMethod, 0),
+	}
+}
+
+// GoldenReplayConstructorBuilder is responsible for creating a function
+// that loads a golden file and creates a stub which serves the recorded
+// results. Each stub method is configured to take its next recorded call
+// from the replay.
+//
+// Example:
+//     func NewStubStructFromGolden(path string, reporter interface{...}) (*StubStruct, error) {
+//         // ...
+//     }
+type GoldenReplayConstructorBuilder struct {
+	methodBuilder     *MethodBuilder
+	stubName          string
+	replayName        string
+	callTypeName      string
+	reporterType      ast.Expr
+	readFileSelector  *ast.SelectorExpr
+	unmarshalSelector *ast.SelectorExpr
+	replayedMethods   []replayedMethod
+}
+
+func (b *GoldenReplayConstructorBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// SetReplayName specifies the name of the golden replay structure.
+func (b *GoldenReplayConstructorBuilder) SetReplayName(name string) {
+	b.replayName = name
+}
+
+// SetCallTypeName specifies the name of the structure that holds
+// a single recorded call.
+func (b *GoldenReplayConstructorBuilder) SetCallTypeName(name string) {
+	b.callTypeName = name
+}
+
+// SetReporterType configures the type of the reporter to which
+// replay failures are reported.
+func (b *GoldenReplayConstructorBuilder) SetReporterType(reporterType ast.Expr) {
+	b.reporterType = reporterType
+}
+
+// SetReadFileSelector configures the ioutil.ReadFile function.
+// The selector should have already been resolved.
+func (b *GoldenReplayConstructorBuilder) SetReadFileSelector(selector *ast.SelectorExpr) {
+	b.readFileSelector = selector
+}
+
+// SetUnmarshalSelector configures the json.Unmarshal function.
+// The selector should have already been resolved.
+func (b *GoldenReplayConstructorBuilder) SetUnmarshalSelector(selector *ast.SelectorExpr) {
+	b.unmarshalSelector = selector
+}
+
+// AddReplayedMethod configures the stub method with the specified
+// config to be served from the replay. The arguments and results of
+// calls are held in the specified types. The results type is ignored
+// if the method has no results.
+func (b *GoldenReplayConstructorBuilder) AddReplayedMethod(config *MethodConfig, argsTypeName, resultsTypeName string) {
+	b.replayedMethods = append(b.replayedMethods, replayedMethod{
+		config:          config,
+		argsTypeName:    argsTypeName,
+		resultsTypeName: resultsTypeName,
+	})
+}
+
+func (b *GoldenReplayConstructorBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("path", ast.NewIdent("string")),
+				util.CreateField("reporter", b.reporterType),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.stubName),
+					},
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("data"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.readFileSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("path"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildErrorCheck()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("calls"),
+					},
+					Type: &ast.ArrayType{
+						Elt: ast.NewIdent(b.callTypeName),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.unmarshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("data"),
+					&ast.UnaryExpr{
+						Op: token.AND,
+						X:  ast.NewIdent("calls"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildErrorCheck()))
+
+	replayCalls := &ast.SelectorExpr{
+		X:   ast.NewIdent("replay"),
+		Sel: ast.NewIdent("calls"),
+	}
+	methodCalls := &ast.IndexExpr{
+		X: replayCalls,
+		Index: &ast.SelectorExpr{
+			X:   ast.NewIdent("call"),
+			Sel: ast.NewIdent("Method"),
+		},
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("replay"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.replayName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent("calls"),
+							Value: &ast.CallExpr{
+								Fun: ast.NewIdent("make"),
+								Args: []ast.Expr{
+									&ast.MapType{
+										Key: ast.NewIdent("string"),
+										Value: &ast.ArrayType{
+											Elt: ast.NewIdent(b.callTypeName),
+										},
+									},
+								},
+							},
+						},
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent("reporter"),
+							Value: ast.NewIdent("reporter"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("call"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("calls"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						methodCalls,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("append"),
+							Args: []ast.Expr{
+								methodCalls,
+								ast.NewIdent("call"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent(receiverName),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("new"),
+				Args: []ast.Expr{
+					ast.NewIdent(b.stubName),
+				},
+			},
+		},
+	}))
+	for _, method := range b.replayedMethods {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildReplayedMethodCode(method)))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(receiverName),
+			ast.NewIdent("nil"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *GoldenReplayConstructorBuilder) buildErrorCheck() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *GoldenReplayConstructorBuilder) buildReplayedMethodCode(method replayedMethod) ast.Stmt {
+	config := method.config
+	args := []ast.Expr{}
+	for _, param := range config.MethodParams {
+		args = append(args, ast.NewIdent(param.Names[0].String()))
+	}
+
+	statements := []ast.Stmt{}
+	var replayedResults ast.Expr = ast.NewIdent("nil")
+	if config.HasResults() {
+		statements = append(statements, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("results"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("new"),
+					Args: []ast.Expr{
+						ast.NewIdent(method.resultsTypeName),
+					},
+				},
+			},
+		})
+		replayedResults = ast.NewIdent("results")
+	}
+	statements = append(statements, &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("replay"),
+				Sel: ast.NewIdent(goldenNextMethodName),
+			},
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\"%s\"", config.MethodName),
+				},
+				&ast.UnaryExpr{
+					Op: token.AND,
+					X: &ast.CompositeLit{
+						Type: ast.NewIdent(method.argsTypeName),
+						Elts: args,
+					},
+				},
+				replayedResults,
+			},
+		},
+	})
+	if config.HasResults() {
+		results := []ast.Expr{}
+		for _, result := range util.FieldsAsExported(config.MethodResults) {
+			results = append(results, &ast.SelectorExpr{
+				X:   ast.NewIdent("results"),
+				Sel: ast.NewIdent(result.Names[0].String()),
+			})
+		}
+		statements = append(statements, &ast.ReturnStmt{
+			Results: results,
+		})
+	}
+
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			&ast.SelectorExpr{
+				X:   ast.NewIdent(receiverName),
+				Sel: ast.NewIdent(config.StubFieldName()),
+			},
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: config.MethodParams,
+					},
+					Results: &ast.FieldList{
+						List: util.FieldsAsAnonymous(config.MethodResults),
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: statements,
+				},
+			},
+		},
+	}
+}
+
+type replayedMethod struct {
+	config          *MethodConfig
+	argsTypeName    string
+	resultsTypeName string
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewGoldenReplayFailMethodBuilder(methodBuilder *MethodBuilder) *GoldenReplayFailMethodBuilder {
+	return &GoldenReplayFailMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// GoldenReplayFailMethodBuilder is responsible for creating a method on
+// the golden replay that reports a replay failure to the reporter of the
+// replay. If no reporter is available, the method panics.
+//
+// Example:
+//     func (replay *StubStructGoldenReplay) fail(format string, args ...interface{}) {
+//         // ...
+//     }
+type GoldenReplayFailMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	reporterFieldSelector *ast.SelectorExpr
+	sprintfSelector       *ast.SelectorExpr
+}
+
+func (b *GoldenReplayFailMethodBuilder) SetReporterFieldSelector(selector *ast.SelectorExpr) {
+	b.reporterFieldSelector = selector
+}
+
+// SetSprintfSelector configures the function that is used to format
+// the panic message. The selector should have already been resolved.
+func (b *GoldenReplayFailMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+func (b *GoldenReplayFailMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("format", ast.NewIdent("string")),
+				util.CreateField("args", &ast.Ellipsis{
+					Elt: util.CreateEmptyInterface(),
+				}),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.reporterFieldSelector,
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("panic"),
+						Args: []ast.Expr{
+							&ast.CallExpr{
+								Fun: b.sprintfSelector,
+								Args: []ast.Expr{
+									ast.NewIdent("format"),
+									ast.NewIdent("args"),
+								},
+								Ellipsis: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   b.reporterFieldSelector,
+				Sel: ast.NewIdent("Helper"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   b.reporterFieldSelector,
+				Sel: ast.NewIdent("Errorf"),
+			},
+			Args: []ast.Expr{
+				ast.NewIdent("format"),
+				ast.NewIdent("args"),
+			},
+			Ellipsis: 1,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,308 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewGoldenReplayNextMethodBuilder(methodBuilder *MethodBuilder) *GoldenReplayNextMethodBuilder {
+	return &GoldenReplayNextMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// GoldenReplayNextMethodBuilder is responsible for creating a method on
+// the golden replay that takes the next recorded call of a method, checks
+// that it has been recorded with the same arguments and deserializes its
+// results. Missing calls and mismatching arguments are reported as failures.
+//
+// Example:
+//     func (replay *StubStructGoldenReplay) next(method string, args interface{}, results interface{}) {
+//         // ...
+//     }
+type GoldenReplayNextMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	callsFieldSelector *ast.SelectorExpr
+	failMethodSelector *ast.SelectorExpr
+	marshalSelector    *ast.SelectorExpr
+	unmarshalSelector  *ast.SelectorExpr
+	compactSelector    *ast.SelectorExpr
+	bufferType         *ast.SelectorExpr
+	equalSelector      *ast.SelectorExpr
+	stubName           string
+}
+
+func (b *GoldenReplayNextMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *GoldenReplayNextMethodBuilder) SetCallsFieldSelector(selector *ast.SelectorExpr) {
+	b.callsFieldSelector = selector
+}
+
+// SetFailMethodSelector configures the method that is used to report
+// replay failures.
+func (b *GoldenReplayNextMethodBuilder) SetFailMethodSelector(selector *ast.SelectorExpr) {
+	b.failMethodSelector = selector
+}
+
+// SetMarshalSelector configures the json.Marshal function.
+// The selector should have already been resolved.
+func (b *GoldenReplayNextMethodBuilder) SetMarshalSelector(selector *ast.SelectorExpr) {
+	b.marshalSelector = selector
+}
+
+// SetUnmarshalSelector configures the json.Unmarshal function.
+// The selector should have already been resolved.
+func (b *GoldenReplayNextMethodBuilder) SetUnmarshalSelector(selector *ast.SelectorExpr) {
+	b.unmarshalSelector = selector
+}
+
+// SetCompactSelector configures the json.Compact function, which is
+// used to remove the indentation of the recorded arguments.
+// The selector should have already been resolved.
+func (b *GoldenReplayNextMethodBuilder) SetCompactSelector(selector *ast.SelectorExpr) {
+	b.compactSelector = selector
+}
+
+// SetBufferType configures the bytes.Buffer type.
+// The type should have already been resolved.
+func (b *GoldenReplayNextMethodBuilder) SetBufferType(bufferType *ast.SelectorExpr) {
+	b.bufferType = bufferType
+}
+
+// SetEqualSelector configures the bytes.Equal function.
+// The selector should have already been resolved.
+func (b *GoldenReplayNextMethodBuilder) SetEqualSelector(selector *ast.SelectorExpr) {
+	b.equalSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in failure messages.
+func (b *GoldenReplayNextMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *GoldenReplayNextMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	methodCalls := &ast.IndexExpr{
+		X:     b.callsFieldSelector,
+		Index: ast.NewIdent("method"),
+	}
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("method", ast.NewIdent("string")),
+				util.CreateField("args", util.CreateEmptyInterface()),
+				util.CreateField("results", util.CreateEmptyInterface()),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X: &ast.CallExpr{
+				Fun: ast.NewIdent("len"),
+				Args: []ast.Expr{
+					methodCalls,
+				},
+			},
+			Op: token.EQL,
+			Y: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: "0",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				b.buildFailStatement("no more recorded calls to %s.%%s", ast.NewIdent("method")),
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("call"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.IndexExpr{
+				X: methodCalls,
+				Index: &ast.BasicLit{
+					Kind:  token.INT,
+					Value: "0",
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			methodCalls,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.SliceExpr{
+				X: methodCalls,
+				Low: &ast.BasicLit{
+					Kind:  token.INT,
+					Value: "1",
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("argsData"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("args"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildErrorCheck()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("recordedArgs"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("new"),
+				Args: []ast.Expr{
+					b.bufferType,
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.compactSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("recordedArgs"),
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("call"),
+						Sel: ast.NewIdent("Args"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildErrorCheck()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.UnaryExpr{
+			Op: token.NOT,
+			X: &ast.CallExpr{
+				Fun: b.equalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("argsData"),
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("recordedArgs"),
+							Sel: ast.NewIdent("Bytes"),
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				b.buildFailStatement("unexpected arguments in call to %s.%%s: got %%s, recorded %%s",
+					ast.NewIdent("method"),
+					ast.NewIdent("argsData"),
+					ast.NewIdent("recordedArgs"),
+				),
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("results"),
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.unmarshalSelector,
+				Args: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("call"),
+						Sel: ast.NewIdent("Results"),
+					},
+					ast.NewIdent("results"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildErrorCheck()))
+	return b.methodBuilder.Build()
+}
+
+func (b *GoldenReplayNextMethodBuilder) buildErrorCheck() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				b.buildFailStatement("cannot replay call to %s.%%s: %%v",
+					ast.NewIdent("method"),
+					ast.NewIdent("err"),
+				),
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
+
+func (b *GoldenReplayNextMethodBuilder) buildFailStatement(format string, args ...ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: b.failMethodSelector,
+			Args: append([]ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\""+format+"\"", b.stubName),
+				},
+			}, args...),
+		},
+	}
+}
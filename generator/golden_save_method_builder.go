@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewGoldenSaveMethodBuilder(methodBuilder *MethodBuilder) *GoldenSaveMethodBuilder {
+	return &GoldenSaveMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// GoldenSaveMethodBuilder is responsible for creating a method on the
+// golden recorder that writes all recorded calls to a golden file. If
+// any of the calls could not be recorded, the method returns that error
+// instead and the file is not written.
+//
+// Example:
+//     func (recorder *StubStructGoldenRecorder) SaveGolden(path string) error {
+//         // ...
+//     }
+type GoldenSaveMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	mutexFieldSelector    *ast.SelectorExpr
+	callsFieldSelector    *ast.SelectorExpr
+	errFieldSelector      *ast.SelectorExpr
+	marshalIndentSelector *ast.SelectorExpr
+	writeFileSelector     *ast.SelectorExpr
+}
+
+func (b *GoldenSaveMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *GoldenSaveMethodBuilder) SetCallsFieldSelector(selector *ast.SelectorExpr) {
+	b.callsFieldSelector = selector
+}
+
+func (b *GoldenSaveMethodBuilder) SetErrFieldSelector(selector *ast.SelectorExpr) {
+	b.errFieldSelector = selector
+}
+
+// SetMarshalIndentSelector configures the json.MarshalIndent function.
+// The selector should have already been resolved.
+func (b *GoldenSaveMethodBuilder) SetMarshalIndentSelector(selector *ast.SelectorExpr) {
+	b.marshalIndentSelector = selector
+}
+
+// SetWriteFileSelector configures the ioutil.WriteFile function.
+// The selector should have already been resolved.
+func (b *GoldenSaveMethodBuilder) SetWriteFileSelector(selector *ast.SelectorExpr) {
+	b.writeFileSelector = selector
+}
+
+func (b *GoldenSaveMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("path", ast.NewIdent("string")),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.errFieldSelector,
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						b.errFieldSelector,
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("data"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalIndentSelector,
+				Args: []ast.Expr{
+					b.callsFieldSelector,
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: "\"\"",
+					},
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: "\"\\t\"",
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.writeFileSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("path"),
+					ast.NewIdent("data"),
+					&ast.BasicLit{
+						Kind:  token.INT,
+						Value: "0644",
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewHaveReceivedBuilder(methodBuilder *MethodBuilder) *HaveReceivedBuilder {
+	return &HaveReceivedBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// HaveReceivedBuilder is responsible for creating a function that
+// returns a Gomega matcher, which succeeds if the stub has received
+// at least one call to a given method. The returned matcher is specific
+// to the method, so that the expected arguments can be typed.
+//
+// Example:
+//     func StubStructHaveReceivedSum() *StubStructSumCallMatcher {
+//         return &StubStructSumCallMatcher{StubStructCallMatcher{method: "Sum", callHistory: (*StubStruct).sumCallHistory, ...}}
+//     }
+type HaveReceivedBuilder struct {
+	methodBuilder         *MethodBuilder
+	matcherTypeName       string
+	callMatcherTypeName   string
+	argMatcherType        *ast.SelectorExpr
+	argCount              int
+	stubName              string
+	methodName            string
+	callHistoryMethodName string
+}
+
+// SetMatcherTypeName specifies the method-specific matcher type
+// that is returned by the function.
+func (b *HaveReceivedBuilder) SetMatcherTypeName(name string) {
+	b.matcherTypeName = name
+}
+
+// SetCallMatcherTypeName specifies the matcher type that is shared
+// by all methods and embedded in the method-specific one.
+func (b *HaveReceivedBuilder) SetCallMatcherTypeName(name string) {
+	b.callMatcherTypeName = name
+}
+
+// SetArgMatcherType configures the type of the matchers that can be
+// used for individual arguments. The type should have already been
+// resolved.
+func (b *HaveReceivedBuilder) SetArgMatcherType(matcherType *ast.SelectorExpr) {
+	b.argMatcherType = matcherType
+}
+
+// SetArgCount specifies the number of arguments of the method.
+func (b *HaveReceivedBuilder) SetArgCount(count int) {
+	b.argCount = count
+}
+
+func (b *HaveReceivedBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in failure messages.
+func (b *HaveReceivedBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetCallHistoryMethodName specifies the method of the stub that
+// returns the arguments of all calls to the original method.
+func (b *HaveReceivedBuilder) SetCallHistoryMethodName(name string) {
+	b.callHistoryMethodName = name
+}
+
+func (b *HaveReceivedBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.matcherTypeName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.matcherTypeName),
+					Elts: []ast.Expr{
+						&ast.CompositeLit{
+							Type: ast.NewIdent(b.callMatcherTypeName),
+							Elts: []ast.Expr{
+								&ast.KeyValueExpr{
+									Key: ast.NewIdent(matcherMethodFieldName),
+									Value: &ast.BasicLit{
+										Kind:  token.STRING,
+										Value: fmt.Sprintf("\"%s\"", b.methodName),
+									},
+								},
+								&ast.KeyValueExpr{
+									Key: ast.NewIdent(matcherCallHistoryFieldName),
+									Value: &ast.SelectorExpr{
+										X: &ast.ParenExpr{
+											X: &ast.StarExpr{
+												X: ast.NewIdent(b.stubName),
+											},
+										},
+										Sel: ast.NewIdent(b.callHistoryMethodName),
+									},
+								},
+								&ast.KeyValueExpr{
+									Key:   ast.NewIdent(matcherArgsFieldName),
+									Value: b.makeArgsSlice(util.CreateEmptyInterface()),
+								},
+								&ast.KeyValueExpr{
+									Key:   ast.NewIdent(matcherArgMatchersFieldName),
+									Value: b.makeArgsSlice(b.argMatcherType),
+								},
+								&ast.KeyValueExpr{
+									Key:   ast.NewIdent(matcherChecksArgsFieldName),
+									Value: b.makeArgsSlice(ast.NewIdent("bool")),
+								},
+								&ast.KeyValueExpr{
+									Key: ast.NewIdent(matcherTimesFieldName),
+									Value: &ast.UnaryExpr{
+										Op: token.SUB,
+										X: &ast.BasicLit{
+											Kind:  token.INT,
+											Value: "1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *HaveReceivedBuilder) makeArgsSlice(elementType ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: ast.NewIdent("make"),
+		Args: []ast.Expr{
+			&ast.ArrayType{
+				Elt: elementType,
+			},
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf("%d", b.argCount),
+			},
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewHaveReceivedTimesBuilder(methodBuilder *MethodBuilder) *HaveReceivedTimesBuilder {
+	return &HaveReceivedTimesBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// HaveReceivedTimesBuilder is responsible for creating a function that
+// returns a Gomega matcher, which succeeds if the stub has received
+// exactly the specified number of calls to a given method.
+//
+// Example:
+//     func StubStructHaveReceivedSumTimes(count int) *StubStructSumCallMatcher {
+//         return StubStructHaveReceivedSum().Times(count)
+//     }
+type HaveReceivedTimesBuilder struct {
+	methodBuilder    *MethodBuilder
+	matcherTypeName  string
+	haveReceivedName string
+}
+
+func (b *HaveReceivedTimesBuilder) SetMatcherTypeName(name string) {
+	b.matcherTypeName = name
+}
+
+// SetHaveReceivedName specifies the function that creates a matcher
+// for any number of calls to the method.
+func (b *HaveReceivedTimesBuilder) SetHaveReceivedName(name string) {
+	b.haveReceivedName = name
+}
+
+func (b *HaveReceivedTimesBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("count", ast.NewIdent("int")),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.matcherTypeName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent(b.haveReceivedName),
+					},
+					Sel: ast.NewIdent(matcherTimesMethodName),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("count"),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
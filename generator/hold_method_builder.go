@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewHoldMethodBuilder(methodBuilder *MethodBuilder) *HoldMethodBuilder {
+	return &HoldMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// HoldMethodBuilder is responsible for creating a method on the stub
+// structure that makes the next calls to the stubbed method block
+// until the returned gate is released.
+//
+// Example:
+//     func (stub *StubStruct) HoldSum(count int) *StubStructGate {
+//         // ...
+//     }
+type HoldMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	gatesFieldSelector *ast.SelectorExpr
+	gateTypeName       string
+}
+
+func (b *HoldMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *HoldMethodBuilder) SetGatesFieldSelector(selector *ast.SelectorExpr) {
+	b.gatesFieldSelector = selector
+}
+
+func (b *HoldMethodBuilder) SetGateTypeName(name string) {
+	b.gateTypeName = name
+}
+
+func (b *HoldMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("count", ast.NewIdent("int")),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.gateTypeName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("gate"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.gateTypeName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent(gateReleaseFieldName),
+							Value: &ast.CallExpr{
+								Fun: ast.NewIdent("make"),
+								Args: []ast.Expr{
+									&ast.ChanType{
+										Dir:   ast.SEND | ast.RECV,
+										Value: util.CreateEmptyStruct(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ForStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("i"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: "0",
+				},
+			},
+		},
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("i"),
+			Op: token.LSS,
+			Y:  ast.NewIdent("count"),
+		},
+		Post: &ast.IncDecStmt{
+			X:   ast.NewIdent("i"),
+			Tok: token.INC,
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						b.gatesFieldSelector,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("append"),
+							Args: []ast.Expr{
+								b.gatesFieldSelector,
+								ast.NewIdent("gate"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("gate"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewInvokesMethodBuilder(methodBuilder *MethodBuilder) *InvokesMethodBuilder {
+	return &InvokesMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// InvokesMethodBuilder is responsible for creating a method on the stub
+// structure that configures a callback argument to be invoked with the
+// specified arguments during each call to the stubbed method.
+//
+// Example:
+//     func (stub *StubStruct) SubscribeInvokesHandler(arg1 Message) {
+//         // ...
+//     }
+type InvokesMethodBuilder struct {
+	methodBuilder        *MethodBuilder
+	mutexFieldSelector   *ast.SelectorExpr
+	invokesFieldSelector *ast.SelectorExpr
+	callbackType         *ast.FuncType
+}
+
+func (b *InvokesMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+// SetInvokesFieldSelector configures the field that holds the functions
+// which invoke the callback argument.
+func (b *InvokesMethodBuilder) SetInvokesFieldSelector(selector *ast.SelectorExpr) {
+	b.invokesFieldSelector = selector
+}
+
+// SetCallbackType specifies the type of the callback argument.
+// The parameters of the type need to have been normalized and
+// resolved in advance.
+func (b *InvokesMethodBuilder) SetCallbackType(callbackType *ast.FuncType) {
+	b.callbackType = callbackType
+}
+
+func (b *InvokesMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	params := b.callbackType.Params.List
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+
+	args := []ast.Expr{}
+	for _, param := range params {
+		args = append(args, ast.NewIdent(param.Names[0].String()))
+	}
+	callbackCall := &ast.CallExpr{
+		Fun:  ast.NewIdent("callback"),
+		Args: args,
+	}
+	if count := len(params); count > 0 {
+		if _, ok := params[count-1].Type.(*ast.Ellipsis); ok {
+			callbackCall.Ellipsis = 1
+		}
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.invokesFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.invokesFieldSelector,
+					&ast.FuncLit{
+						Type: &ast.FuncType{
+							Params: &ast.FieldList{
+								List: []*ast.Field{
+									util.CreateField("callback", b.callbackType),
+								},
+							},
+						},
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								&ast.ExprStmt{
+									X: callbackCall,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
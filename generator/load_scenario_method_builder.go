@@ -0,0 +1,503 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewLoadScenarioMethodBuilder(methodBuilder *MethodBuilder) *LoadScenarioMethodBuilder {
+	return &LoadScenarioMethodBuilder{
+		methodBuilder: methodBuilder,
+		methods:       make([]*MethodConfig, 0),
+	}
+}
+
+// LoadScenarioMethodBuilder is responsible for creating a method on the
+// stub structure that reads a JSON scenario, which describes the results
+// of the stub methods, and configures the stub accordingly. All entries
+// of the scenario are decoded, in sorted order, before any of them is
+// applied, so that an invalid scenario leaves the stub unchanged.
+//
+// Example:
+//     func (stub *StubStruct) LoadScenario(r io.Reader) error {
+//         // ...
+//     }
+type LoadScenarioMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	readerType         *ast.SelectorExpr
+	rawMessageType     *ast.SelectorExpr
+	newDecoderSelector *ast.SelectorExpr
+	errorfSelector     *ast.SelectorExpr
+	sortStringsSel     *ast.SelectorExpr
+	sortIntsSel        *ast.SelectorExpr
+	stubName           string
+	methods            []*MethodConfig
+}
+
+// SetReaderType configures the io.Reader type.
+// The type should have already been resolved.
+func (b *LoadScenarioMethodBuilder) SetReaderType(readerType *ast.SelectorExpr) {
+	b.readerType = readerType
+}
+
+// SetRawMessageType configures the json.RawMessage type.
+// The type should have already been resolved.
+func (b *LoadScenarioMethodBuilder) SetRawMessageType(rawMessageType *ast.SelectorExpr) {
+	b.rawMessageType = rawMessageType
+}
+
+// SetNewDecoderSelector configures the json.NewDecoder function.
+// The selector should have already been resolved.
+func (b *LoadScenarioMethodBuilder) SetNewDecoderSelector(selector *ast.SelectorExpr) {
+	b.newDecoderSelector = selector
+}
+
+// SetErrorfSelector configures the function that is used to create
+// errors. The selector should have already been resolved.
+func (b *LoadScenarioMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+// SetSortSelectors configures the sort.Strings and sort.Ints functions,
+// which are used to process the entries of the scenario in order.
+// The selectors should have already been resolved.
+func (b *LoadScenarioMethodBuilder) SetSortSelectors(sortStrings, sortInts *ast.SelectorExpr) {
+	b.sortStringsSel = sortStrings
+	b.sortIntsSel = sortInts
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in error messages.
+func (b *LoadScenarioMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// AddMethod registers a stub method with the specified config, so that
+// its results can be configured through the scenario. Methods without
+// results cannot be configured and are reported if they appear in
+// the scenario.
+func (b *LoadScenarioMethodBuilder) AddMethod(config *MethodConfig) {
+	b.methods = append(b.methods, config)
+}
+
+func (b *LoadScenarioMethodBuilder) Build() ast.Decl {
+	valuesType := &ast.ArrayType{
+		Elt: b.rawMessageType,
+	}
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("r", b.readerType),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("scenario"),
+					},
+					Type: &ast.MapType{
+						Key: ast.NewIdent("string"),
+						Value: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: []*ast.Field{
+									util.CreateField("Returns", valuesType),
+									util.CreateField("OnCall", &ast.MapType{
+										Key:   ast.NewIdent("int"),
+										Value: valuesType,
+									}),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.CallExpr{
+						Fun: b.newDecoderSelector,
+						Args: []ast.Expr{
+							ast.NewIdent("r"),
+						},
+					},
+					Sel: ast.NewIdent("Decode"),
+				},
+				Args: []ast.Expr{
+					&ast.UnaryExpr{
+						Op: token.AND,
+						X:  ast.NewIdent("scenario"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: b.errorfSelector,
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: fmt.Sprintf("\"cannot decode scenario for %s: %%v\"", b.stubName),
+								},
+								ast.NewIdent("err"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+
+	clauses := []ast.Stmt{}
+	usesMethodScenario := false
+	for _, config := range b.methods {
+		if config.HasResults() {
+			usesMethodScenario = true
+		}
+		clauses = append(clauses, b.buildMethodClause(config))
+	}
+	clauses = append(clauses, &ast.CaseClause{
+		Body: []ast.Stmt{
+			b.buildErrorReturn("unknown method", ast.NewIdent("method")),
+		},
+	})
+	for _, stmt := range b.buildSortedKeysCode("methods", ast.NewIdent("string"), ast.NewIdent("scenario"), b.sortStringsSel) {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(stmt))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("configure"),
+					},
+					Type: &ast.ArrayType{
+						Elt: &ast.FuncType{
+							Params: &ast.FieldList{},
+						},
+					},
+				},
+			},
+		},
+	}))
+	methodBody := []ast.Stmt{}
+	if usesMethodScenario {
+		methodBody = append(methodBody, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("methodScenario"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.IndexExpr{
+					X:     ast.NewIdent("scenario"),
+					Index: ast.NewIdent("method"),
+				},
+			},
+		})
+	}
+	methodBody = append(methodBody, &ast.SwitchStmt{
+		Tag: ast.NewIdent("method"),
+		Body: &ast.BlockStmt{
+			List: clauses,
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("method"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("methods"),
+		Body: &ast.BlockStmt{
+			List: methodBody,
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("apply"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("configure"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("apply"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("nil"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *LoadScenarioMethodBuilder) buildMethodClause(config *MethodConfig) ast.Stmt {
+	methodName := &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: fmt.Sprintf("\"%s\"", config.MethodName),
+	}
+	if !config.HasResults() {
+		return &ast.CaseClause{
+			List: []ast.Expr{
+				methodName,
+			},
+			Body: []ast.Stmt{
+				b.buildErrorReturn("method has no results", methodName),
+			},
+		}
+	}
+	onCall := &ast.SelectorExpr{
+		X:   ast.NewIdent("methodScenario"),
+		Sel: ast.NewIdent("OnCall"),
+	}
+	onCallBody := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("index"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				ast.NewIdent("index"),
+			},
+		},
+	}
+	onCallBody = append(onCallBody, b.buildConfigureCode(config, &ast.IndexExpr{
+		X:     onCall,
+		Index: ast.NewIdent("index"),
+	}, config.ReturnsOnCallMethodName(), ast.NewIdent("index"))...)
+	body := []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("methodScenario"),
+					Sel: ast.NewIdent("Returns"),
+				},
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: b.buildConfigureCode(config, &ast.SelectorExpr{
+					X:   ast.NewIdent("methodScenario"),
+					Sel: ast.NewIdent("Returns"),
+				}, config.ReturnsMethodName(), nil),
+			},
+		},
+	}
+	body = append(body, b.buildSortedKeysCode("indices", ast.NewIdent("int"), onCall, b.sortIntsSel)...)
+	body = append(body, &ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("index"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("indices"),
+		Body: &ast.BlockStmt{
+			List: onCallBody,
+		},
+	})
+	return &ast.CaseClause{
+		List: []ast.Expr{
+			methodName,
+		},
+		Body: body,
+	}
+}
+
+// buildSortedKeysCode creates the code that collects the keys of the
+// specified map into a slice with the specified name and sorts them.
+func (b *LoadScenarioMethodBuilder) buildSortedKeysCode(name string, keyType ast.Expr, mapExpr ast.Expr, sortSelector *ast.SelectorExpr) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(name),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("make"),
+					Args: []ast.Expr{
+						&ast.ArrayType{
+							Elt: keyType,
+						},
+						&ast.BasicLit{
+							Kind:  token.INT,
+							Value: "0",
+						},
+						&ast.CallExpr{
+							Fun: ast.NewIdent("len"),
+							Args: []ast.Expr{
+								mapExpr,
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.RangeStmt{
+			Key: ast.NewIdent("key"),
+			Tok: token.DEFINE,
+			X:   mapExpr,
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent(name),
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("append"),
+								Args: []ast.Expr{
+									ast.NewIdent(name),
+									ast.NewIdent("key"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: sortSelector,
+				Args: []ast.Expr{
+					ast.NewIdent(name),
+				},
+			},
+		},
+	}
+}
+
+// buildConfigureCode creates the code that decodes the specified values
+// into results and registers a function that passes them to the
+// specified returns method once all entries have been decoded. If an
+// index is specified, it is passed to the returns method first.
+func (b *LoadScenarioMethodBuilder) buildConfigureCode(config *MethodConfig, values ast.Expr, returnsMethodName string, index ast.Expr) []ast.Stmt {
+	args := []ast.Expr{}
+	if index != nil {
+		args = append(args, index)
+	}
+	for _, result := range util.FieldsAsExported(config.MethodResults) {
+		args = append(args, &ast.SelectorExpr{
+			X:   ast.NewIdent("results"),
+			Sel: ast.NewIdent(result.Names[0].String()),
+		})
+	}
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("results"),
+				ast.NewIdent("err"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent(receiverName),
+						Sel: ast.NewIdent(config.DecodeScenarioMethodName()),
+					},
+					Args: []ast.Expr{
+						values,
+					},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("err"),
+						},
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("configure"),
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("append"),
+					Args: []ast.Expr{
+						ast.NewIdent("configure"),
+						&ast.FuncLit{
+							Type: &ast.FuncType{
+								Params: &ast.FieldList{},
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.ExprStmt{
+										X: &ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X:   ast.NewIdent(receiverName),
+												Sel: ast.NewIdent(returnsMethodName),
+											},
+											Args: args,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *LoadScenarioMethodBuilder) buildErrorReturn(reason string, methodName ast.Expr) ast.Stmt {
+	return &ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.errorfSelector,
+				Args: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf("\"cannot configure %s.%%s from scenario: %s\"", b.stubName, reason),
+					},
+					methodName,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMarshalJSONMethodBuilder(methodBuilder *MethodBuilder) *MarshalJSONMethodBuilder {
+	return &MarshalJSONMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// MarshalJSONMethodBuilder is responsible for creating a method on
+// an arguments or results structure that serializes the structure to JSON,
+// replacing error fields with their messages, since errors cannot be
+// serialized directly.
+//
+// Example:
+//     func (value *StubStructMethodResults) MarshalJSON() ([]byte, error) {
+//         // ...
+//     }
+type MarshalJSONMethodBuilder struct {
+	methodBuilder   *MethodBuilder
+	receiverName    string
+	marshalSelector *ast.SelectorExpr
+	fields          []*ast.Field
+}
+
+func (b *MarshalJSONMethodBuilder) SetReceiverName(name string) {
+	b.receiverName = name
+}
+
+// SetMarshalSelector configures the json.Marshal function.
+// The selector should have already been resolved.
+func (b *MarshalJSONMethodBuilder) SetMarshalSelector(selector *ast.SelectorExpr) {
+	b.marshalSelector = selector
+}
+
+// SetFields specifies the fields of the structure. These fields
+// need to have been exported and resolved in advance.
+func (b *MarshalJSONMethodBuilder) SetFields(fields []*ast.Field) {
+	b.fields = fields
+}
+
+func (b *MarshalJSONMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.ArrayType{
+						Elt: ast.NewIdent("byte"),
+					},
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("aux"),
+					},
+					Type: jsonAuxType(b.fields),
+				},
+			},
+		},
+	}))
+	for _, field := range b.fields {
+		fieldName := field.Names[0].String()
+		valueSelector := &ast.SelectorExpr{
+			X:   ast.NewIdent(b.receiverName),
+			Sel: ast.NewIdent(fieldName),
+		}
+		auxSelector := &ast.SelectorExpr{
+			X:   ast.NewIdent("aux"),
+			Sel: ast.NewIdent(fieldName),
+		}
+		if !isErrorType(field.Type) {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					auxSelector,
+				},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{
+					valueSelector,
+				},
+			}))
+			continue
+		}
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  valueSelector,
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent("message"),
+						},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   valueSelector,
+									Sel: ast.NewIdent("Error"),
+								},
+							},
+						},
+					},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							auxSelector,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.UnaryExpr{
+								Op: token.AND,
+								X:  ast.NewIdent("message"),
+							},
+						},
+					},
+				},
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("aux"),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+// jsonAuxType returns an anonymous structure with the specified fields,
+// where error fields are replaced by optional messages.
+func jsonAuxType(fields []*ast.Field) *ast.StructType {
+	auxFields := []*ast.Field{}
+	for _, field := range fields {
+		fieldType := field.Type
+		if isErrorType(fieldType) {
+			fieldType = &ast.StarExpr{
+				X: ast.NewIdent("string"),
+			}
+		}
+		auxFields = append(auxFields, util.CreateField(field.Names[0].String(), fieldType))
+	}
+	return &ast.StructType{
+		Fields: &ast.FieldList{
+			List: auxFields,
+		},
+	}
+}
+
+// isErrorType checks whether the specified type is the builtin
+// error interface.
+func isErrorType(fieldType ast.Expr) bool {
+	ident, ok := fieldType.(*ast.Ident)
+	return ok && ident.String() == "error"
+}
+
+// hasErrorField checks whether any of the specified fields is of
+// the builtin error type.
+func hasErrorField(fields []*ast.Field) bool {
+	for _, field := range fields {
+		if isErrorType(field.Type) {
+			return true
+		}
+	}
+	return false
+}
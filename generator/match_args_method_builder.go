@@ -0,0 +1,274 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMatchArgsMethodBuilder(methodBuilder *MethodBuilder) *MatchArgsMethodBuilder {
+	return &MatchArgsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// MatchArgsMethodBuilder is responsible for creating the method that
+// checks whether the arguments of a single call match the arguments
+// expected by a call matcher. Arguments that have been configured with
+// a Gomega matcher are matched by it, while all other checked arguments
+// are compared for deep equality. A call with a different number of
+// arguments than expected results in an error.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) matchArgs(args []interface{}) (bool, error) {
+//         // ...
+//     }
+type MatchArgsMethodBuilder struct {
+	methodBuilder            *MethodBuilder
+	methodFieldSelector      *ast.SelectorExpr
+	argsFieldSelector        *ast.SelectorExpr
+	argMatchersFieldSelector *ast.SelectorExpr
+	checksArgsFieldSelector  *ast.SelectorExpr
+	deepEqualSelector        *ast.SelectorExpr
+	errorfSelector           *ast.SelectorExpr
+}
+
+func (b *MatchArgsMethodBuilder) SetMethodFieldSelector(selector *ast.SelectorExpr) {
+	b.methodFieldSelector = selector
+}
+
+func (b *MatchArgsMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+func (b *MatchArgsMethodBuilder) SetArgMatchersFieldSelector(selector *ast.SelectorExpr) {
+	b.argMatchersFieldSelector = selector
+}
+
+func (b *MatchArgsMethodBuilder) SetChecksArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.checksArgsFieldSelector = selector
+}
+
+// SetDeepEqualSelector configures the function that is used to
+// compare expected values with actual arguments. The selector should
+// have already been resolved.
+func (b *MatchArgsMethodBuilder) SetDeepEqualSelector(selector *ast.SelectorExpr) {
+	b.deepEqualSelector = selector
+}
+
+// SetErrorfSelector configures the function that is used to create
+// an error when the number of arguments does not match. The selector
+// should have already been resolved.
+func (b *MatchArgsMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+func (b *MatchArgsMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("args", &ast.ArrayType{
+					Elt: util.CreateEmptyInterface(),
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("bool"),
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X: &ast.CallExpr{
+				Fun: ast.NewIdent("len"),
+				Args: []ast.Expr{
+					ast.NewIdent("args"),
+				},
+			},
+			Op: token.NEQ,
+			Y: &ast.CallExpr{
+				Fun: ast.NewIdent("len"),
+				Args: []ast.Expr{
+					b.argsFieldSelector,
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("false"),
+						&ast.CallExpr{
+							Fun: b.errorfSelector,
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: "\"expected %d arguments in calls to %s, got %d\"",
+								},
+								&ast.CallExpr{
+									Fun: ast.NewIdent("len"),
+									Args: []ast.Expr{
+										b.argsFieldSelector,
+									},
+								},
+								b.methodFieldSelector,
+								&ast.CallExpr{
+									Fun: ast.NewIdent("len"),
+									Args: []ast.Expr{
+										ast.NewIdent("args"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	actualArg := &ast.IndexExpr{
+		X:     ast.NewIdent("args"),
+		Index: ast.NewIdent("i"),
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("i"),
+		Value: ast.NewIdent("expected"),
+		Tok:   token.DEFINE,
+		X:     b.argsFieldSelector,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: &ast.UnaryExpr{
+						Op: token.NOT,
+						X: &ast.IndexExpr{
+							X:     b.checksArgsFieldSelector,
+							Index: ast.NewIdent("i"),
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.BranchStmt{
+								Tok: token.CONTINUE,
+							},
+						},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("matched"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: b.deepEqualSelector,
+							Args: []ast.Expr{
+								actualArg,
+								ast.NewIdent("expected"),
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent("argMatcher"),
+						},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							&ast.IndexExpr{
+								X:     b.argMatchersFieldSelector,
+								Index: ast.NewIdent("i"),
+							},
+						},
+					},
+					Cond: &ast.BinaryExpr{
+						X:  ast.NewIdent("argMatcher"),
+						Op: token.NEQ,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.DeclStmt{
+								Decl: &ast.GenDecl{
+									Tok: token.VAR,
+									Specs: []ast.Spec{
+										&ast.ValueSpec{
+											Names: []*ast.Ident{
+												ast.NewIdent("err"),
+											},
+											Type: ast.NewIdent("error"),
+										},
+									},
+								},
+							},
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									ast.NewIdent("matched"),
+									ast.NewIdent("err"),
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   ast.NewIdent("argMatcher"),
+											Sel: ast.NewIdent("Match"),
+										},
+										Args: []ast.Expr{
+											actualArg,
+										},
+									},
+								},
+							},
+							&ast.IfStmt{
+								Cond: &ast.BinaryExpr{
+									X:  ast.NewIdent("err"),
+									Op: token.NEQ,
+									Y:  ast.NewIdent("nil"),
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.ReturnStmt{
+											Results: []ast.Expr{
+												ast.NewIdent("false"),
+												ast.NewIdent("err"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.UnaryExpr{
+						Op: token.NOT,
+						X:  ast.NewIdent("matched"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("false"),
+									ast.NewIdent("nil"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("true"),
+			ast.NewIdent("nil"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
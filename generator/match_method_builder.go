@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMatchMethodBuilder(methodBuilder *MethodBuilder) *MatchMethodBuilder {
+	return &MatchMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// MatchMethodBuilder is responsible for creating the method that
+// checks whether a stub has received the calls described by a
+// call matcher.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) Match(actual interface{}) (bool, error) {
+//         // ...
+//     }
+type MatchMethodBuilder struct {
+	methodBuilder            *MethodBuilder
+	callHistoryFieldSelector *ast.SelectorExpr
+	timesFieldSelector       *ast.SelectorExpr
+	matchArgsMethodSelector  *ast.SelectorExpr
+	errorfSelector           *ast.SelectorExpr
+	stubName                 string
+}
+
+func (b *MatchMethodBuilder) SetCallHistoryFieldSelector(selector *ast.SelectorExpr) {
+	b.callHistoryFieldSelector = selector
+}
+
+func (b *MatchMethodBuilder) SetTimesFieldSelector(selector *ast.SelectorExpr) {
+	b.timesFieldSelector = selector
+}
+
+func (b *MatchMethodBuilder) SetMatchArgsMethodSelector(selector *ast.SelectorExpr) {
+	b.matchArgsMethodSelector = selector
+}
+
+// SetErrorfSelector configures the function that is used to create
+// an error when the matcher is used with a value that is not a stub.
+// The selector should have already been resolved.
+func (b *MatchMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure that the
+// matcher can be used with.
+func (b *MatchMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *MatchMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("actual", util.CreateEmptyInterface()),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("bool"),
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent(receiverName),
+			ast.NewIdent("ok"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.TypeAssertExpr{
+				X: ast.NewIdent("actual"),
+				Type: &ast.StarExpr{
+					X: ast.NewIdent(b.stubName),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.UnaryExpr{
+			Op: token.NOT,
+			X:  ast.NewIdent("ok"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("false"),
+						&ast.CallExpr{
+							Fun: b.errorfSelector,
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: fmt.Sprintf("\"expected a *%s, got %%T\"", b.stubName),
+								},
+								ast.NewIdent("actual"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("count"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: "0",
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("args"),
+		Tok:   token.DEFINE,
+		X: &ast.CallExpr{
+			Fun: b.callHistoryFieldSelector,
+			Args: []ast.Expr{
+				ast.NewIdent(receiverName),
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("matched"),
+						ast.NewIdent("err"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: b.matchArgsMethodSelector,
+							Args: []ast.Expr{
+								ast.NewIdent("args"),
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  ast.NewIdent("err"),
+						Op: token.NEQ,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("false"),
+									ast.NewIdent("err"),
+								},
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: ast.NewIdent("matched"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.IncDecStmt{
+								X:   ast.NewIdent("count"),
+								Tok: token.INC,
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.timesFieldSelector,
+			Op: token.LSS,
+			Y: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: "0",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.BinaryExpr{
+							X:  ast.NewIdent("count"),
+							Op: token.GTR,
+							Y: &ast.BasicLit{
+								Kind:  token.INT,
+								Value: "0",
+							},
+						},
+						ast.NewIdent("nil"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.BinaryExpr{
+				X:  ast.NewIdent("count"),
+				Op: token.EQL,
+				Y:  b.timesFieldSelector,
+			},
+			ast.NewIdent("nil"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
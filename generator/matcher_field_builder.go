@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMatcherFieldBuilder() *MatcherFieldBuilder {
+	return &MatcherFieldBuilder{
+		params: make([]*ast.Field, 0),
+	}
+}
+
+// The MatcherFieldBuilder is responsible for creating the field
+// of a conditional return which holds the function that decides
+// whether a given call matches.
+//
+// Example:
+//     type StubStructSumRule struct {
+//         // ...
+//         matcher func(a int, b int) bool
+//         // ...
+//     }
+type MatcherFieldBuilder struct {
+	fieldName string
+	params    []*ast.Field
+}
+
+func (b *MatcherFieldBuilder) SetFieldName(name string) {
+	b.fieldName = name
+}
+
+// SetParams configures the parameters that the original method has.
+// The parameters should have been normalized and resolved beforehand.
+func (b *MatcherFieldBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+func (b *MatcherFieldBuilder) Build() *ast.Field {
+	return util.CreateField(b.fieldName, &ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("bool"),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,272 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMatcherMessageMethodBuilder(methodBuilder *MethodBuilder) *MatcherMessageMethodBuilder {
+	return &MatcherMessageMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// MatcherMessageMethodBuilder is responsible for creating the method
+// that produces the failure messages of a call matcher. The messages
+// include all calls that have been recorded by the stub.
+//
+// Example:
+//     func (matcher *StubStructCallMatcher) message(actual interface{}, expectation string) string {
+//         // ...
+//     }
+type MatcherMessageMethodBuilder struct {
+	methodBuilder                    *MethodBuilder
+	methodFieldSelector              *ast.SelectorExpr
+	timesFieldSelector               *ast.SelectorExpr
+	callHistoryFieldSelector         *ast.SelectorExpr
+	formatArgsMethodSelector         *ast.SelectorExpr
+	formatExpectedArgsMethodSelector *ast.SelectorExpr
+	sprintfSelector                  *ast.SelectorExpr
+	stubName                         string
+}
+
+func (b *MatcherMessageMethodBuilder) SetMethodFieldSelector(selector *ast.SelectorExpr) {
+	b.methodFieldSelector = selector
+}
+
+func (b *MatcherMessageMethodBuilder) SetTimesFieldSelector(selector *ast.SelectorExpr) {
+	b.timesFieldSelector = selector
+}
+
+func (b *MatcherMessageMethodBuilder) SetCallHistoryFieldSelector(selector *ast.SelectorExpr) {
+	b.callHistoryFieldSelector = selector
+}
+
+func (b *MatcherMessageMethodBuilder) SetFormatArgsMethodSelector(selector *ast.SelectorExpr) {
+	b.formatArgsMethodSelector = selector
+}
+
+func (b *MatcherMessageMethodBuilder) SetFormatExpectedArgsMethodSelector(selector *ast.SelectorExpr) {
+	b.formatExpectedArgsMethodSelector = selector
+}
+
+// SetSprintfSelector configures the function that is used to format
+// the message. The selector should have already been resolved.
+func (b *MatcherMessageMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in the message.
+func (b *MatcherMessageMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *MatcherMessageMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("actual", util.CreateEmptyInterface()),
+				util.CreateField("expectation", ast.NewIdent("string")),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("string"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("description"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.methodFieldSelector,
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("expectedArgs"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: b.formatExpectedArgsMethodSelector,
+				},
+			},
+		},
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("expectedArgs"),
+			Op: token.NEQ,
+			Y: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "\"\"",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("description"),
+					},
+					Tok: token.ADD_ASSIGN,
+					Rhs: []ast.Expr{
+						b.sprintfCall("(%s)", ast.NewIdent("expectedArgs")),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  b.timesFieldSelector,
+			Op: token.GEQ,
+			Y: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: "0",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("description"),
+					},
+					Tok: token.ADD_ASSIGN,
+					Rhs: []ast.Expr{
+						b.sprintfCall(" exactly %d times", b.timesFieldSelector),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("history"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "\"\"",
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(receiverName),
+				ast.NewIdent("ok"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.TypeAssertExpr{
+					X: ast.NewIdent("actual"),
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.stubName),
+					},
+				},
+			},
+		},
+		Cond: ast.NewIdent("ok"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.RangeStmt{
+					Key:   ast.NewIdent("i"),
+					Value: ast.NewIdent("args"),
+					Tok:   token.DEFINE,
+					X: &ast.CallExpr{
+						Fun: b.callHistoryFieldSelector,
+						Args: []ast.Expr{
+							ast.NewIdent(receiverName),
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									ast.NewIdent("history"),
+								},
+								Tok: token.ADD_ASSIGN,
+								Rhs: []ast.Expr{
+									b.sprintfCall("\\n\\t#%d: %s(%s)",
+										&ast.BinaryExpr{
+											X:  ast.NewIdent("i"),
+											Op: token.ADD,
+											Y: &ast.BasicLit{
+												Kind:  token.INT,
+												Value: "1",
+											},
+										},
+										b.methodFieldSelector,
+										&ast.CallExpr{
+											Fun: b.formatArgsMethodSelector,
+											Args: []ast.Expr{
+												ast.NewIdent("args"),
+											},
+										},
+									),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("history"),
+			Op: token.EQL,
+			Y: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "\"\"",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("history"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: "\"\\n\\t<none>\"",
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			b.sprintfCall(fmt.Sprintf("Expected %s %%s %%s\\nRecorded calls:%%s", b.stubName),
+				ast.NewIdent("expectation"),
+				ast.NewIdent("description"),
+				ast.NewIdent("history"),
+			),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *MatcherMessageMethodBuilder) sprintfCall(format string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun: b.sprintfSelector,
+		Args: append([]ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("\"%s\"", format),
+			},
+		}, args...),
+	}
+}
@@ -0,0 +1,350 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+const matcherReceiverName string = "matcher"
+const matcherMethodFieldName string = "method"
+const matcherCallHistoryFieldName string = "callHistory"
+const matcherArgsFieldName string = "args"
+const matcherArgMatchersFieldName string = "argMatchers"
+const matcherChecksArgsFieldName string = "checksArgs"
+const matcherTimesFieldName string = "times"
+const matcherTimesMethodName string = "Times"
+const matchArgsMethodName string = "matchArgs"
+const expectArgMethodName string = "expectArg"
+const expectArgThatMethodName string = "expectArgThat"
+const ignoreArgMethodName string = "ignoreArg"
+const formatArgsMethodName string = "formatArgs"
+const formatExpectedArgsMethodName string = "formatExpectedArgs"
+const matcherMessageMethodName string = "message"
+
+// NewMatchersModel creates a model for the companion file that holds
+// the Gomega matchers of the specified stub.
+func NewMatchersModel(stubModel *GeneratorModel) *MatchersModel {
+	fileBuilder := NewFileBuilder()
+	fileBuilder.SetPackage(stubModel.fileBuilder.filePackageName)
+
+	model := &MatchersModel{
+		fileBuilder: fileBuilder,
+		stubModel:   stubModel,
+		stubName:    stubModel.structName,
+	}
+	// The matchers check calls through the call history of the stub.
+	stubModel.tracksCallHistory = true
+	model.createCallMatcherStruct()
+	model.createCallMatcherAssignment()
+	model.createExpectArgMethods()
+	model.createMatchMethod()
+	model.createMatchArgsMethod()
+	model.createFailureMessageMethods()
+	model.createMessageMethod()
+	model.createFormatArgsMethod()
+	model.createFormatExpectedArgsMethod()
+	return model
+}
+
+type MatchersModel struct {
+	fileBuilder *FileBuilder
+	stubModel   *GeneratorModel
+	stubName    string
+}
+
+func (t *MatchersModel) AddMethod(config *MethodConfig) error {
+	t.createMethodMatcherStruct(config)
+	if config.HasParams() {
+		t.createWithMethod(config)
+		t.createWithArgMethods(config)
+	}
+	t.createTimesMethod(config)
+	t.createHaveReceivedFunc(config)
+	t.createHaveReceivedTimesFunc(config)
+	return nil
+}
+
+func (t *MatchersModel) createCallMatcherStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.matcherTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(matcherMethodFieldName, ast.NewIdent("string"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(matcherCallHistoryFieldName, &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(t.stubName),
+					},
+				},
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.ArrayType{
+						Elt: &ast.ArrayType{
+							Elt: util.CreateEmptyInterface(),
+						},
+					},
+				},
+			},
+		},
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(matcherArgsFieldName, &ast.ArrayType{
+		Elt: util.CreateEmptyInterface(),
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(matcherArgMatchersFieldName, &ast.ArrayType{
+		Elt: t.resolveGomegaMatcherType(),
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(matcherChecksArgsFieldName, &ast.ArrayType{
+		Elt: ast.NewIdent("bool"),
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(matcherTimesFieldName, ast.NewIdent("int"))))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createCallMatcherAssignment() {
+	builder := NewStubToInterfaceStatementBuilder()
+	builder.SetStubName(t.matcherTypeName())
+	builder.SetInterfaceSelector(t.resolveGomegaMatcherType())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createExpectArgMethods() {
+	expectBuilder := t.newExpectArgMethodBuilder(expectArgMethodName)
+	expectBuilder.SetParams([]*ast.Field{
+		util.CreateField("value", util.CreateEmptyInterface()),
+	})
+	expectBuilder.SetValues(ast.NewIdent("value"), ast.NewIdent("nil"), ast.NewIdent("true"))
+	t.fileBuilder.AddDeclarationBuilder(expectBuilder)
+
+	expectThatBuilder := t.newExpectArgMethodBuilder(expectArgThatMethodName)
+	expectThatBuilder.SetParams([]*ast.Field{
+		util.CreateField("argMatcher", t.resolveGomegaMatcherType()),
+	})
+	expectThatBuilder.SetValues(ast.NewIdent("argMatcher"), ast.NewIdent("argMatcher"), ast.NewIdent("true"))
+	t.fileBuilder.AddDeclarationBuilder(expectThatBuilder)
+
+	ignoreBuilder := t.newExpectArgMethodBuilder(ignoreArgMethodName)
+	ignoreBuilder.SetValues(ast.NewIdent("nil"), ast.NewIdent("nil"), ast.NewIdent("false"))
+	t.fileBuilder.AddDeclarationBuilder(ignoreBuilder)
+}
+
+func (t *MatchersModel) newExpectArgMethodBuilder(name string) *ExpectArgMethodBuilder {
+	builder := NewExpectArgMethodBuilder(t.createMethodBuilder(name))
+	builder.SetArgsFieldSelector(t.matcherFieldSelector(matcherArgsFieldName))
+	builder.SetArgMatchersFieldSelector(t.matcherFieldSelector(matcherArgMatchersFieldName))
+	builder.SetChecksArgsFieldSelector(t.matcherFieldSelector(matcherChecksArgsFieldName))
+	return builder
+}
+
+func (t *MatchersModel) createMatchMethod() {
+	builder := NewMatchMethodBuilder(t.createMethodBuilder("Match"))
+	builder.SetCallHistoryFieldSelector(t.matcherFieldSelector(matcherCallHistoryFieldName))
+	builder.SetTimesFieldSelector(t.matcherFieldSelector(matcherTimesFieldName))
+	builder.SetMatchArgsMethodSelector(t.matcherFieldSelector(matchArgsMethodName))
+	builder.SetErrorfSelector(t.resolveFmtFunc("Errorf"))
+	builder.SetStubName(t.stubName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createMatchArgsMethod() {
+	builder := NewMatchArgsMethodBuilder(t.createMethodBuilder(matchArgsMethodName))
+	builder.SetMethodFieldSelector(t.matcherFieldSelector(matcherMethodFieldName))
+	builder.SetArgsFieldSelector(t.matcherFieldSelector(matcherArgsFieldName))
+	builder.SetArgMatchersFieldSelector(t.matcherFieldSelector(matcherArgMatchersFieldName))
+	builder.SetChecksArgsFieldSelector(t.matcherFieldSelector(matcherChecksArgsFieldName))
+	builder.SetDeepEqualSelector(t.resolveDeepEqualFunc())
+	builder.SetErrorfSelector(t.resolveFmtFunc("Errorf"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createFailureMessageMethods() {
+	failureBuilder := NewFailureMessageMethodBuilder(t.createMethodBuilder("FailureMessage"))
+	failureBuilder.SetMessageMethodSelector(t.matcherFieldSelector(matcherMessageMethodName))
+	failureBuilder.SetExpectation("to have received")
+	t.fileBuilder.AddDeclarationBuilder(failureBuilder)
+
+	negatedBuilder := NewFailureMessageMethodBuilder(t.createMethodBuilder("NegatedFailureMessage"))
+	negatedBuilder.SetMessageMethodSelector(t.matcherFieldSelector(matcherMessageMethodName))
+	negatedBuilder.SetExpectation("not to have received")
+	t.fileBuilder.AddDeclarationBuilder(negatedBuilder)
+}
+
+func (t *MatchersModel) createMessageMethod() {
+	builder := NewMatcherMessageMethodBuilder(t.createMethodBuilder(matcherMessageMethodName))
+	builder.SetMethodFieldSelector(t.matcherFieldSelector(matcherMethodFieldName))
+	builder.SetTimesFieldSelector(t.matcherFieldSelector(matcherTimesFieldName))
+	builder.SetCallHistoryFieldSelector(t.matcherFieldSelector(matcherCallHistoryFieldName))
+	builder.SetFormatArgsMethodSelector(t.matcherFieldSelector(formatArgsMethodName))
+	builder.SetFormatExpectedArgsMethodSelector(t.matcherFieldSelector(formatExpectedArgsMethodName))
+	builder.SetSprintfSelector(t.resolveFmtFunc("Sprintf"))
+	builder.SetStubName(t.stubName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createFormatArgsMethod() {
+	builder := NewFormatArgsMethodBuilder(t.createMethodBuilder(formatArgsMethodName))
+	builder.SetSprintfSelector(t.resolveFmtFunc("Sprintf"))
+	builder.SetJoinSelector(t.resolveJoinFunc())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createFormatExpectedArgsMethod() {
+	builder := NewFormatExpectedArgsMethodBuilder(t.createMethodBuilder(formatExpectedArgsMethodName))
+	builder.SetArgsFieldSelector(t.matcherFieldSelector(matcherArgsFieldName))
+	builder.SetChecksArgsFieldSelector(t.matcherFieldSelector(matcherChecksArgsFieldName))
+	builder.SetSprintfSelector(t.resolveFmtFunc("Sprintf"))
+	builder.SetJoinSelector(t.resolveJoinFunc())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createMethodMatcherStruct(config *MethodConfig) {
+	builder := NewStructBuilder()
+	builder.SetName(t.methodMatcherTypeName(config))
+	builder.AddFieldBuilder(FieldToBuilder(&ast.Field{
+		Type: ast.NewIdent(t.matcherTypeName()),
+	}))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createWithMethod(config *MethodConfig) {
+	builder := t.newWithArgsMethodBuilder(config, "With")
+	builder.SetParams(config.MethodParams)
+	for i, param := range config.MethodParams {
+		builder.AddExpectation(t.matcherFieldSelector(expectArgMethodName), i, param.Names[0])
+	}
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createWithArgMethods(config *MethodConfig) {
+	for i, param := range config.MethodParams {
+		alias := config.ParamAlias(i)
+
+		valueBuilder := t.newWithArgsMethodBuilder(config, "With"+alias)
+		valueBuilder.SetParams([]*ast.Field{param})
+		valueBuilder.AddExpectation(t.matcherFieldSelector(expectArgMethodName), i, param.Names[0])
+		t.fileBuilder.AddDeclarationBuilder(valueBuilder)
+
+		thatBuilder := t.newWithArgsMethodBuilder(config, "With"+alias+"That")
+		thatBuilder.SetParams([]*ast.Field{
+			util.CreateField("argMatcher", t.resolveGomegaMatcherType()),
+		})
+		thatBuilder.AddExpectation(t.matcherFieldSelector(expectArgThatMethodName), i, ast.NewIdent("argMatcher"))
+		t.fileBuilder.AddDeclarationBuilder(thatBuilder)
+
+		anyBuilder := t.newWithArgsMethodBuilder(config, "WithAny"+alias)
+		anyBuilder.AddExpectation(t.matcherFieldSelector(ignoreArgMethodName), i)
+		t.fileBuilder.AddDeclarationBuilder(anyBuilder)
+	}
+}
+
+func (t *MatchersModel) newWithArgsMethodBuilder(config *MethodConfig, name string) *WithArgsMethodBuilder {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(name)
+	builder := NewWithArgsMethodBuilder(methodBuilder)
+	builder.SetReceiver(matcherReceiverName, t.methodMatcherTypeName(config))
+	return builder
+}
+
+func (t *MatchersModel) createTimesMethod(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(matcherTimesMethodName)
+	builder := NewChainMethodBuilder(methodBuilder)
+	builder.SetReceiver(matcherReceiverName, t.methodMatcherTypeName(config))
+	builder.SetParams([]*ast.Field{
+		util.CreateField("count", ast.NewIdent("int")),
+	})
+	builder.AddAssignment(t.matcherFieldSelector(matcherTimesFieldName), ast.NewIdent("count"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createHaveReceivedFunc(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(t.haveReceivedName(config))
+	builder := NewHaveReceivedBuilder(methodBuilder)
+	builder.SetMatcherTypeName(t.methodMatcherTypeName(config))
+	builder.SetCallMatcherTypeName(t.matcherTypeName())
+	builder.SetArgMatcherType(t.resolveGomegaMatcherType())
+	builder.SetArgCount(len(config.MethodParams))
+	builder.SetStubName(t.stubName)
+	builder.SetMethodName(config.MethodName)
+	builder.SetCallHistoryMethodName(config.CallHistoryMethodName())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) createHaveReceivedTimesFunc(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(t.haveReceivedName(config) + "Times")
+	builder := NewHaveReceivedTimesBuilder(methodBuilder)
+	builder.SetMatcherTypeName(t.methodMatcherTypeName(config))
+	builder.SetHaveReceivedName(t.haveReceivedName(config))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MatchersModel) matcherTypeName() string {
+	return t.stubName + "CallMatcher"
+}
+
+func (t *MatchersModel) methodMatcherTypeName(config *MethodConfig) string {
+	return t.stubName + config.MethodName + "CallMatcher"
+}
+
+func (t *MatchersModel) haveReceivedName(config *MethodConfig) string {
+	return t.stubName + "HaveReceived" + config.MethodName
+}
+
+func (t *MatchersModel) createMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(matcherReceiverName, t.matcherTypeName())
+	return builder
+}
+
+func (t *MatchersModel) matcherFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(matcherReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *MatchersModel) resolveGomegaMatcherType() *ast.SelectorExpr {
+	alias := t.fileBuilder.AddImport("types", "github.com/onsi/gomega/types")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("GomegaMatcher"),
+	}
+}
+
+func (t *MatchersModel) resolveFmtFunc(name string) *ast.SelectorExpr {
+	alias := t.fileBuilder.AddImport("fmt", "fmt")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *MatchersModel) resolveDeepEqualFunc() *ast.SelectorExpr {
+	alias := t.fileBuilder.AddImport("reflect", "reflect")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("DeepEqual"),
+	}
+}
+
+func (t *MatchersModel) resolveJoinFunc() *ast.SelectorExpr {
+	alias := t.fileBuilder.AddImport("strings", "strings")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Join"),
+	}
+}
+
+// Save saves the matchers companion file. All imports of the stub file
+// are registered first, since the types of the method params have been
+// resolved against the stub's namespace.
+func (t *MatchersModel) Save(filePath string) error {
+	t.fileBuilder.AddImportsFrom(t.stubModel.fileBuilder)
+	return saveFile(t.fileBuilder, filePath)
+}
@@ -38,8 +38,9 @@ func (m *MethodBuilder) Build() ast.Decl {
 	for i, builder := range m.statementBuilders {
 		statements[i] = builder.Build()
 	}
-	return &ast.FuncDecl{
-		Recv: &ast.FieldList{
+	var receiver *ast.FieldList
+	if m.receiverType != "" {
+		receiver = &ast.FieldList{
 			List: []*ast.Field{
 				{
 					Names: []*ast.Ident{
@@ -50,7 +51,10 @@ func (m *MethodBuilder) Build() ast.Decl {
 					},
 				},
 			},
-		},
+		}
+	}
+	return &ast.FuncDecl{
+		Recv: receiver,
 		Name: ast.NewIdent(m.name),
 		Type: m.funcType,
 		Body: &ast.BlockStmt{
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewMockConstructorBuilder(methodBuilder *MethodBuilder) *MockConstructorBuilder {
+	return &MockConstructorBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// MockConstructorBuilder is responsible for creating a function that
+// creates a new mock which is bound to a controller, together with
+// the recorder of its expectations.
+//
+// Example:
+//     func NewMockStruct(ctrl *mock.Controller) *MockStruct {
+//         m := &MockStruct{ctrl: ctrl}
+//         m.recorder = &MockStructMockRecorder{mock: m}
+//         return m
+//     }
+type MockConstructorBuilder struct {
+	methodBuilder     *MethodBuilder
+	mockName          string
+	recorderName      string
+	controllerType    ast.Expr
+	controllerField   string
+	recorderField     string
+	recorderMockField string
+}
+
+func (b *MockConstructorBuilder) SetMockName(name string) {
+	b.mockName = name
+}
+
+func (b *MockConstructorBuilder) SetRecorderName(name string) {
+	b.recorderName = name
+}
+
+// SetControllerType specifies the type of the controller. The type
+// should have already been resolved.
+func (b *MockConstructorBuilder) SetControllerType(controllerType ast.Expr) {
+	b.controllerType = controllerType
+}
+
+// SetFieldNames specifies the names of the controller and recorder
+// fields of the mock and of the mock field of the recorder.
+func (b *MockConstructorBuilder) SetFieldNames(controllerField, recorderField, recorderMockField string) {
+	b.controllerField = controllerField
+	b.recorderField = recorderField
+	b.recorderMockField = recorderMockField
+}
+
+func (b *MockConstructorBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("ctrl"),
+					},
+					Type: b.controllerType,
+				},
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.mockName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("m"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.mockName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(b.controllerField),
+							Value: ast.NewIdent("ctrl"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			&ast.SelectorExpr{
+				X:   ast.NewIdent("m"),
+				Sel: ast.NewIdent(b.recorderField),
+			},
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.recorderName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(b.recorderMockField),
+							Value: ast.NewIdent("m"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("m"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,52 @@
+package generator
+
+import "go/ast"
+
+func NewMockExpectMethodBuilder(methodBuilder *MethodBuilder) *MockExpectMethodBuilder {
+	return &MockExpectMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// MockExpectMethodBuilder is responsible for creating a method on the
+// mock structure that returns the recorder through which expected
+// calls are declared.
+//
+// Example:
+//     func (m *MockStruct) EXPECT() *MockStructMockRecorder {
+//         return m.recorder
+//     }
+type MockExpectMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	recorderName          string
+	recorderFieldSelector *ast.SelectorExpr
+}
+
+func (b *MockExpectMethodBuilder) SetRecorderName(name string) {
+	b.recorderName = name
+}
+
+func (b *MockExpectMethodBuilder) SetRecorderFieldSelector(selector *ast.SelectorExpr) {
+	b.recorderFieldSelector = selector
+}
+
+func (b *MockExpectMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.recorderName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			b.recorderFieldSelector,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMockMethodBuilder(methodBuilder *MethodBuilder) *MockMethodBuilder {
+	return &MockMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// MockMethodBuilder is responsible for creating a method that
+// implements the original method from the interface by passing the
+// call to the controller of the mock, which matches it against the
+// expected calls. Variadic arguments are passed individually.
+//
+// Example:
+//     func (m *MockStruct) Sum(arg1 int, arg2 int) int {
+//         results := m.ctrl.Call(m, "Sum", arg1, arg2)
+//         result1, _ := results[0].(int)
+//         return result1
+//     }
+type MockMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	controllerSelector *ast.SelectorExpr
+	mockExpr           ast.Expr
+	methodName         string
+	params             []*ast.Field
+	results            []*ast.Field
+}
+
+// SetControllerSelector specifies the selector through which the
+// controller of the mock is accessed.
+func (b *MockMethodBuilder) SetControllerSelector(selector *ast.SelectorExpr) {
+	b.controllerSelector = selector
+}
+
+// SetMockExpr specifies the expression that refers to the mock, which
+// is passed to the controller to identify the called method.
+func (b *MockMethodBuilder) SetMockExpr(expr ast.Expr) {
+	b.mockExpr = expr
+}
+
+func (b *MockMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *MockMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *MockMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *MockMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(b.results),
+		},
+	})
+
+	callExpr := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   b.controllerSelector,
+			Sel: ast.NewIdent("Call"),
+		},
+		Args: []ast.Expr{
+			b.mockExpr,
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("\"%s\"", b.methodName),
+			},
+		},
+	}
+	if b.isVariadic() {
+		b.addVariadicArgsCode()
+		callExpr.Args = append(callExpr.Args, ast.NewIdent("args"))
+		callExpr.Ellipsis = 1
+	} else {
+		for _, param := range b.params {
+			callExpr.Args = append(callExpr.Args, ast.NewIdent(param.Names[0].String()))
+		}
+	}
+
+	if len(b.results) == 0 {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+			X: callExpr,
+		}))
+		return b.methodBuilder.Build()
+	}
+
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("results"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			callExpr,
+		},
+	}))
+	resultNames := []ast.Expr{}
+	for i, result := range b.results {
+		name := result.Names[0].String()
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(name),
+				ast.NewIdent("_"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.TypeAssertExpr{
+					X: &ast.IndexExpr{
+						X: ast.NewIdent("results"),
+						Index: &ast.BasicLit{
+							Kind:  token.INT,
+							Value: fmt.Sprintf("%d", i),
+						},
+					},
+					Type: result.Type,
+				},
+			},
+		}))
+		resultNames = append(resultNames, ast.NewIdent(name))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: resultNames,
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *MockMethodBuilder) isVariadic() bool {
+	if len(b.params) == 0 {
+		return false
+	}
+	_, isEllipsis := b.params[len(b.params)-1].Type.(*ast.Ellipsis)
+	return isEllipsis
+}
+
+// addVariadicArgsCode collects the arguments of the call, with the
+// variadic ones appended individually, in an args slice.
+func (b *MockMethodBuilder) addVariadicArgsCode() {
+	fixedArgs := []ast.Expr{}
+	for _, param := range b.params[:len(b.params)-1] {
+		fixedArgs = append(fixedArgs, ast.NewIdent(param.Names[0].String()))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("args"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CompositeLit{
+				Type: &ast.ArrayType{
+					Elt: util.CreateEmptyInterface(),
+				},
+				Elts: fixedArgs,
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("arg"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent(b.params[len(b.params)-1].Names[0].String()),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("args"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("append"),
+							Args: []ast.Expr{
+								ast.NewIdent("args"),
+								ast.NewIdent("arg"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+}
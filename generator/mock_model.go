@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+// The receiver names follow the ones of gomock mocks and do not
+// shadow the mock runtime package.
+const mockReceiverName string = "m"
+const mockRecorderReceiverName string = "mr"
+const mockControllerFieldName string = "ctrl"
+const mockRecorderFieldName string = "recorder"
+const mockRecorderMockFieldName string = "mock"
+const mockRuntimeLocation string = "github.com/mokiat/gostub/mock"
+
+// NewMockModel creates a model for an expectation-based mock, which
+// is used in the style of gomock mocks and relies on the mock runtime
+// package that is shipped with gostub.
+func NewMockModel(pkgName, mockName string) *MockModel {
+	structBuilder := NewStructBuilder()
+	structBuilder.SetName(mockName)
+
+	recorderBuilder := NewStructBuilder()
+	recorderBuilder.SetName(mockName + "MockRecorder")
+
+	fileBuilder := NewFileBuilder()
+	fileBuilder.SetPackage(pkgName)
+	fileBuilder.AddDeclarationBuilder(structBuilder)
+	fileBuilder.AddDeclarationBuilder(recorderBuilder)
+
+	model := &MockModel{
+		fileBuilder:     fileBuilder,
+		structBuilder:   structBuilder,
+		recorderBuilder: recorderBuilder,
+		structName:      mockName,
+	}
+	model.createFields()
+	model.createConstructor()
+	model.createExpectMethod()
+	return model
+}
+
+type MockModel struct {
+	fileBuilder     *FileBuilder
+	structBuilder   *StructBuilder
+	recorderBuilder *StructBuilder
+	structName      string
+}
+
+func (t *MockModel) AddStubAssignment(interfaceLocation, interfaceName string) {
+	builder := NewStubToInterfaceStatementBuilder()
+	builder.SetStubName(t.structName)
+	builder.SetInterfaceSelector(t.resolveInterfaceType(interfaceLocation, interfaceName))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MockModel) AddImport(pkgName, location string) string {
+	return t.fileBuilder.AddImport(pkgName, location)
+}
+
+// IsResolvedType checks whether the specified type, which should have
+// been resolved against the mock's namespace, refers to the type with
+// the specified name in the specified location.
+func (t *MockModel) IsResolvedType(resolvedType ast.Expr, location, name string) bool {
+	return isResolvedType(t.fileBuilder, resolvedType, location, name)
+}
+
+func (t *MockModel) AddMethod(config *MethodConfig) error {
+	t.createMockMethod(config)
+	t.createRecorderMethod(config)
+	return nil
+}
+
+// AddUnexpectedMethod adds a method that satisfies the interface
+// but panics when called, for methods that were not selected for
+// mocking.
+func (t *MockModel) AddUnexpectedMethod(config *MethodConfig) {
+	builder := NewUnexpectedMethodBuilder(t.createMethodBuilder(config.MethodName))
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+// AddEmbeddedStub is not supported by mocks, since embedded mocks
+// would not be bound to the controller of the mock.
+func (t *MockModel) AddEmbeddedStub(stubName string) {
+	panic("mocks cannot embed other mocks")
+}
+
+// AddPromotedMethod is not supported by mocks, since mocks cannot
+// embed other mocks.
+func (t *MockModel) AddPromotedMethod(stubName string, config *MethodConfig) {
+	panic("mocks cannot embed other mocks")
+}
+
+func (t *MockModel) createFields() {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(mockControllerFieldName, &ast.StarExpr{
+		X: t.resolveRuntimeType("Controller"),
+	})))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(mockRecorderFieldName, &ast.StarExpr{
+		X: ast.NewIdent(t.recorderName()),
+	})))
+	t.recorderBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(mockRecorderMockFieldName, &ast.StarExpr{
+		X: ast.NewIdent(t.structName),
+	})))
+}
+
+func (t *MockModel) createConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.structName)
+	builder := NewMockConstructorBuilder(methodBuilder)
+	builder.SetMockName(t.structName)
+	builder.SetRecorderName(t.recorderName())
+	builder.SetControllerType(&ast.StarExpr{
+		X: t.resolveRuntimeType("Controller"),
+	})
+	builder.SetFieldNames(mockControllerFieldName, mockRecorderFieldName, mockRecorderMockFieldName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MockModel) createExpectMethod() {
+	builder := NewMockExpectMethodBuilder(t.createMethodBuilder("EXPECT"))
+	builder.SetRecorderName(t.recorderName())
+	builder.SetRecorderFieldSelector(t.mockFieldSelector(mockRecorderFieldName))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MockModel) createMockMethod(config *MethodConfig) {
+	builder := NewMockMethodBuilder(t.createMethodBuilder(config.MethodName))
+	builder.SetControllerSelector(t.mockFieldSelector(mockControllerFieldName))
+	builder.SetMockExpr(ast.NewIdent(mockReceiverName))
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MockModel) createRecorderMethod(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(config.MethodName)
+	methodBuilder.SetReceiver(mockRecorderReceiverName, t.recorderName())
+	builder := NewMockRecorderMethodBuilder(methodBuilder)
+	builder.SetControllerSelector(&ast.SelectorExpr{
+		X:   t.recorderMockSelector(),
+		Sel: ast.NewIdent(mockControllerFieldName),
+	})
+	builder.SetMockSelector(t.recorderMockSelector())
+	builder.SetCallType(t.resolveRuntimeType("Call"))
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *MockModel) recorderName() string {
+	return t.structName + "MockRecorder"
+}
+
+func (t *MockModel) createMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(mockReceiverName, t.structName)
+	return builder
+}
+
+func (t *MockModel) mockFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(mockReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *MockModel) recorderMockSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(mockRecorderReceiverName),
+		Sel: ast.NewIdent(mockRecorderMockFieldName),
+	}
+}
+
+func (t *MockModel) resolveInterfaceType(location, name string) *ast.SelectorExpr {
+	alias := t.AddImport("", location)
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *MockModel) resolveRuntimeType(name string) *ast.SelectorExpr {
+	alias := t.AddImport("mock", mockRuntimeLocation)
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *MockModel) Save(filePath string) error {
+	return saveFile(t.fileBuilder, filePath)
+}
@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMockRecorderMethodBuilder(methodBuilder *MethodBuilder) *MockRecorderMethodBuilder {
+	return &MockRecorderMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// MockRecorderMethodBuilder is responsible for creating a method on the
+// recorder structure that declares an expected call to the original
+// method. Each argument can be either a value or a matcher.
+//
+// Example:
+//     func (mr *MockStructMockRecorder) Sum(arg1 interface{}, arg2 interface{}) *mock.Call {
+//         return mr.mock.ctrl.RecordCall(mr.mock, "Sum", arg1, arg2)
+//     }
+type MockRecorderMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	controllerSelector *ast.SelectorExpr
+	mockSelector       *ast.SelectorExpr
+	callType           ast.Expr
+	methodName         string
+	params             []*ast.Field
+}
+
+// SetControllerSelector specifies the selector through which the
+// controller of the mock is accessed.
+func (b *MockRecorderMethodBuilder) SetControllerSelector(selector *ast.SelectorExpr) {
+	b.controllerSelector = selector
+}
+
+// SetMockSelector specifies the selector through which the mock
+// is accessed.
+func (b *MockRecorderMethodBuilder) SetMockSelector(selector *ast.SelectorExpr) {
+	b.mockSelector = selector
+}
+
+// SetCallType specifies the type of the expected call that is
+// returned. The type should have already been resolved.
+func (b *MockRecorderMethodBuilder) SetCallType(callType ast.Expr) {
+	b.callType = callType
+}
+
+func (b *MockRecorderMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *MockRecorderMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+func (b *MockRecorderMethodBuilder) Build() ast.Decl {
+	params := []*ast.Field{}
+	args := []ast.Expr{}
+	var variadicArg ast.Expr
+	for _, param := range b.params {
+		name := param.Names[0].String()
+		if _, isEllipsis := param.Type.(*ast.Ellipsis); isEllipsis {
+			params = append(params, util.CreateField(name, &ast.Ellipsis{
+				Elt: util.CreateEmptyInterface(),
+			}))
+			variadicArg = ast.NewIdent(name)
+			continue
+		}
+		params = append(params, util.CreateField(name, util.CreateEmptyInterface()))
+		args = append(args, ast.NewIdent(name))
+	}
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: b.callType,
+					},
+				},
+			},
+		},
+	})
+
+	callExpr := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   b.controllerSelector,
+			Sel: ast.NewIdent("RecordCall"),
+		},
+		Args: []ast.Expr{
+			b.mockSelector,
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("\"%s\"", b.methodName),
+			},
+		},
+	}
+	switch {
+	case variadicArg == nil:
+		callExpr.Args = append(callExpr.Args, args...)
+	case len(args) == 0:
+		callExpr.Args = append(callExpr.Args, variadicArg)
+		callExpr.Ellipsis = 1
+	default:
+		callExpr.Args = append(callExpr.Args, &ast.CallExpr{
+			Fun: ast.NewIdent("append"),
+			Args: []ast.Expr{
+				&ast.CompositeLit{
+					Type: &ast.ArrayType{
+						Elt: util.CreateEmptyInterface(),
+					},
+					Elts: args,
+				},
+				variadicArg,
+			},
+			Ellipsis: 1,
+		})
+		callExpr.Ellipsis = 1
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			callExpr,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
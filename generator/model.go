@@ -1,15 +1,49 @@
 package generator
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
 
 	"github.com/mokiat/gostub/util"
 )
 
 const receiverName string = "stub"
+const ruleReceiverName string = "rule"
+const ruleMutexFieldName string = "mutex"
+const ruleMatcherFieldName string = "matcher"
+const ruleReturnsFieldName string = "returns"
+const stubMutexFieldName string = "mutex"
+const strictFieldName string = "strict"
+const strictReporterFieldName string = "strictReporter"
+const setStrictMethodName string = "SetStrict"
+const reportMethodName string = "reportUnconfiguredCall"
+const gateReceiverName string = "gate"
+const gateOnceFieldName string = "once"
+const gateReleaseFieldName string = "release"
+const gateReleaseMethodName string = "Release"
+const expectationsFieldName string = "expectations"
+const verifiesExpectationsFieldName string = "verifiesExpectations"
+const verifyExpectationsMethodName string = "verifyExpectations"
+const expectationReceiverName string = "expectation"
+const expectationMethodFieldName string = "method"
+const expectationMinFieldName string = "min"
+const expectationMaxFieldName string = "max"
+const expectationCallCountFieldName string = "callCount"
+const expectationCallHistoryFieldName string = "callHistory"
+const expectationVerifyMethodName string = "verify"
+const invocationsFieldName string = "invocations"
+const invocationsMethodName string = "Invocations"
+const recorderFieldName string = "recorder"
+const attachRecorderMethodName string = "AttachRecorder"
+const setArgMethodName string = "setArg"
+const loadScenarioMethodName string = "LoadScenario"
+const randomizeResultsMethodName string = "RandomizeResults"
+const randomSourceParamName string = "r"
+const valueReceiverName string = "value"
 const generatedByComment string = "// Generated by 'github.com/mokiat/gostub'\n\n"
 
-func NewGeneratorModel(pkgName, stubName string) *GeneratorModel {
+func NewGeneratorModel(pkgName, stubName string, features Features) *GeneratorModel {
 	guidBuilder := NewGUIDFieldBuilder()
 	guidBuilder.SetFieldName("StubGUID")
 
@@ -21,17 +55,66 @@ func NewGeneratorModel(pkgName, stubName string) *GeneratorModel {
 	fileBuilder.SetPackage(pkgName)
 	fileBuilder.AddDeclarationBuilder(structBuilder)
 
-	return &GeneratorModel{
+	model := &GeneratorModel{
 		fileBuilder:   fileBuilder,
 		structBuilder: structBuilder,
 		structName:    stubName,
+		features:      features,
 	}
+	if features.needStubMutex() {
+		model.createStubMutexField()
+	}
+	if features.Invocations {
+		model.createInvocationsField()
+		model.createInvocationStruct()
+		model.createInvocationsMethod()
+	}
+	if features.Strict {
+		model.createStrictFields()
+		model.createSetStrictMethod()
+		model.createReportMethod()
+	}
+	if features.Recorder {
+		model.createRecorderField()
+		model.createAttachRecorderMethod()
+	}
+	if features.Scenario {
+		model.createLoadScenarioMethod()
+	}
+	if features.Hold {
+		model.createGateStruct()
+		model.createGateReleaseMethod()
+	}
+	if features.Options {
+		model.createOptionType()
+		model.createConstructor()
+	}
+	if features.Expectations {
+		model.createExpectationsField()
+		model.createExpectationStruct()
+		model.createExpectationBoundsMethods()
+		model.createExpectationVerifyMethod()
+		model.createVerifyExpectationsMethod()
+		model.createTestConstructor()
+		model.tracksCallHistory = true
+	}
+	return model
 }
 
 type GeneratorModel struct {
-	fileBuilder   *FileBuilder
-	structBuilder *StructBuilder
-	structName    string
+	fileBuilder               *FileBuilder
+	structBuilder             *StructBuilder
+	structName                string
+	features                  Features
+	loadScenarioBuilder       *LoadScenarioMethodBuilder
+	randomizeBuilder          *RandomizeResultsMethodBuilder
+	setStrictBuilder          *SetStrictMethodBuilder
+	verifyExpectationsBuilder *VerifyExpectationsMethodBuilder
+	testConstructorBuilder    *TestConstructorBuilder
+	hasStubMutex              bool
+	hasSetArgMethod           bool
+	tracksCallHistory         bool
+	serializesCalls           bool
 }
 
 func (t *GeneratorModel) AddStubAssignment(interfaceLocation, interfaceName string) {
@@ -41,6 +124,22 @@ func (t *GeneratorModel) AddStubAssignment(interfaceLocation, interfaceName stri
 	t.fileBuilder.AddDeclarationBuilder(assignBuilder)
 }
 
+// AddRandomizeResultsMethod adds a method to the stub that configures
+// random results for all methods that have random results specified.
+func (t *GeneratorModel) AddRandomizeResultsMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(randomizeResultsMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewRandomizeResultsMethodBuilder(methodBuilder)
+	builder.SetReporterType(t.resolveLoggerType())
+	builder.SetNowSelector(t.resolveTimeSelector("Now"))
+	builder.SetNewRandSelector(t.resolveRandSelector("New"))
+	builder.SetNewSourceSelector(t.resolveRandSelector("NewSource"))
+	builder.SetStubName(t.structName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.randomizeBuilder = builder
+}
+
 // AddImport assures that the specified package name in the specified
 // location will be added as an import.
 // This function returns the alias to be used in selector expressions.
@@ -50,116 +149,1413 @@ func (t *GeneratorModel) AddImport(pkgName, location string) string {
 	return t.fileBuilder.AddImport(pkgName, location)
 }
 
+// IsResolvedType checks whether the specified type, which should have
+// been resolved against the stub's namespace, refers to the type with
+// the specified name in the specified location.
+func (t *GeneratorModel) IsResolvedType(resolvedType ast.Expr, location, name string) bool {
+	return isResolvedType(t.fileBuilder, resolvedType, location, name)
+}
+
 func (t *GeneratorModel) AddMethod(config *MethodConfig) error {
+	t.createArgsType(config)
+	if fields := t.argsFields(config); t.serializesCalls && hasErrorField(fields) {
+		t.createJSONMethods(t.argsTypeName(config), fields)
+	}
+	if config.HasResults() {
+		t.createResultsType(config)
+		if fields := t.resultsFields(config); t.serializesCalls && hasErrorField(fields) {
+			t.createJSONMethods(t.resultsTypeName(config), fields)
+		}
+	}
 	t.createMethodStubField(config)
 	t.createMutexField(config)
 	t.createArgsForCallField(config)
+	if t.features.Wait {
+		t.createCallSignalField(config)
+	}
+	if t.features.Hold {
+		t.createGatesField(config)
+	}
+	if t.features.Latency {
+		t.createDelayField(config)
+		if t.findContextParam(config) != nil {
+			t.createBlocksField(config)
+		}
+	}
+	if t.features.Panics {
+		t.createPanicFields(config)
+	}
 	if config.HasResults() {
 		t.createReturnsField(config)
+		t.createReturnsOnCallField(config)
+		if t.tracksReturnsConfigured(config) {
+			t.createReturnsConfiguredField(config)
+		}
+	}
+	if t.features.Rules && config.HasParams() && config.HasResults() {
+		t.createRulesField(config)
+	}
+	if t.features.SetsArgs {
+		for _, index := range config.SettableParamIndices() {
+			t.createArgSetsFields(config, index)
+			if t.needsSetArgMethod(config.MethodParams[index]) && !t.hasSetArgMethod {
+				t.createSetArgMethod()
+			}
+		}
+	}
+	if t.features.InvokesCallbacks {
+		for _, index := range config.CallbackParamIndices() {
+			t.createCallbackInvokesField(config, index)
+		}
+	}
+	if config.ChildStubName != "" {
+		if !t.hasStubMutex {
+			t.createStubMutexField()
+		}
+		t.createChildStubField(config)
+		t.createChildStubMethod(config)
 	}
 	t.createStubMethod(config)
 	t.createCallCountMethod(config)
+	t.createCallsMethod(config)
+	if t.features.Wait {
+		t.createWaitMethod(config)
+	}
+	if t.features.Hold {
+		t.createHoldMethod(config)
+	}
+	if t.features.Latency {
+		t.createDelaysMethod(config)
+		if t.findContextParam(config) != nil {
+			t.createBlocksMethod(config)
+		}
+	}
+	if t.features.Panics {
+		t.createPanicsMethod(config)
+		t.createPanicsOnCallMethod(config)
+	}
+	if t.tracksCallHistory {
+		t.createCallHistoryMethod(config)
+	}
+	if t.features.Expectations {
+		t.createExpectMethod(config)
+	}
 	if config.HasParams() {
 		t.createArgsForCallMethod(config)
 	}
+	if t.features.SetsArgs {
+		for _, index := range config.SettableParamIndices() {
+			t.createSetsArgMethod(config, index)
+			t.createSetsArgOnCallMethod(config, index)
+		}
+	}
+	if t.features.InvokesCallbacks {
+		for _, index := range config.CallbackParamIndices() {
+			t.createInvokesMethod(config, index)
+			t.createArgForCallMethod(config, index)
+		}
+	}
 	if config.HasResults() {
 		t.createReturnsMethod(config)
+		t.createReturnsOnCallMethod(config)
+	}
+	if t.features.Scenario && config.HasResults() {
+		t.createDecodeScenarioMethod(config)
+	}
+	if config.HasResults() && t.randomizeBuilder != nil {
+		t.createReturnsRandomMethod(config)
+		t.randomizeBuilder.AddReturnsRandomMethod(config.ReturnsRandomMethodName())
+	}
+	if t.features.Scenario {
+		t.loadScenarioBuilder.AddMethod(config)
+	}
+	if t.features.Options {
+		t.createStubOption(config)
+		if config.HasResults() {
+			t.createReturnsOption(config)
+		}
+	}
+	if t.features.Rules && config.HasParams() && config.HasResults() {
+		t.createWhenMethod(config)
+		t.createCalledWithMethod(config)
+		t.createRuleStruct(config)
+		t.createRuleReturnsMethod(config)
+	}
+	return nil
+}
+
+// AddEmbeddedStub embeds an existing stub into the stub, so that the
+// methods of the interface of the existing stub are promoted from it.
+// Strict mode and expectations apply to the embedded stub as well.
+func (t *GeneratorModel) AddEmbeddedStub(stubName string) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(&ast.Field{
+		Type: ast.NewIdent(stubName),
+	}))
+	embedded := t.stubFieldSelector(stubName)
+	if t.setStrictBuilder != nil {
+		t.setStrictBuilder.AddEmbeddedStub(embedded)
+	}
+	if t.verifyExpectationsBuilder != nil {
+		t.verifyExpectationsBuilder.AddEmbeddedStub(embedded)
+		t.testConstructorBuilder.AddEmbeddedVerifiesExpectationsFieldSelector(&ast.SelectorExpr{
+			X:   embedded,
+			Sel: ast.NewIdent(verifiesExpectationsFieldName),
+		})
+	}
+}
+
+// AddPromotedMethod registers a method that is promoted from the
+// specified embedded stub, so that calls to it are recorded in the
+// invocations of the stub and passed to its recorder, expectations on
+// it are reported under the name of the stub, and it can be configured
+// through the options and scenarios of the stub.
+func (t *GeneratorModel) AddPromotedMethod(stubName string, config *MethodConfig) {
+	if t.features.Invocations || t.features.Recorder {
+		t.createForwardMethod(stubName, config)
+	}
+	if t.features.Expectations {
+		t.createExpectMethod(config)
+	}
+	if t.features.Scenario {
+		t.loadScenarioBuilder.AddMethod(config)
+	}
+	if t.features.Options {
+		t.createStubOption(config)
+		if config.HasResults() {
+			t.createReturnsOption(config)
+		}
+	}
+}
+
+// AddUnexpectedMethod adds a method that satisfies the interface
+// but panics when called, for methods that were not selected for
+// stubbing.
+func (t *GeneratorModel) AddUnexpectedMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.MethodName)
+	builder := NewUnexpectedMethodBuilder(methodBuilder)
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createStubMutexField() {
+	builder := NewMethodMutexFieldBuilder()
+	builder.SetFieldName(stubMutexFieldName)
+	builder.SetMutexType(t.resolveMutexType())
+	t.structBuilder.AddFieldBuilder(builder)
+	t.hasStubMutex = true
+}
+
+func (t *GeneratorModel) createInvocationsField() {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(invocationsFieldName, &ast.ArrayType{
+		Elt: ast.NewIdent(t.invocationTypeName()),
+	})))
+}
+
+func (t *GeneratorModel) createStrictFields() {
+	strictBuilder := NewFlagFieldBuilder()
+	strictBuilder.SetFieldName(strictFieldName)
+	t.structBuilder.AddFieldBuilder(strictBuilder)
+
+	reporterBuilder := NewReporterFieldBuilder()
+	reporterBuilder.SetFieldName(strictReporterFieldName)
+	reporterBuilder.SetReporterType(t.resolveReporterType())
+	t.structBuilder.AddFieldBuilder(reporterBuilder)
+}
+
+func (t *GeneratorModel) createSetStrictMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(setStrictMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewSetStrictMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetStrictFieldSelector(t.stubFieldSelector(strictFieldName))
+	builder.SetReporterFieldSelector(t.stubFieldSelector(strictReporterFieldName))
+	builder.SetReporterType(t.resolveReporterType())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.setStrictBuilder = builder
+}
+
+func (t *GeneratorModel) createReportMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(reportMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewReportMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetStrictFieldSelector(t.stubFieldSelector(strictFieldName))
+	builder.SetReporterFieldSelector(t.stubFieldSelector(strictReporterFieldName))
+	builder.SetSprintfSelector(t.resolveSprintfFunc())
+	builder.SetJoinSelector(t.resolveJoinFunc())
+	builder.SetStubName(t.structName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createInvocationStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.invocationTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Sequence", ast.NewIdent("int"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Method", ast.NewIdent("string"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Args", &ast.ArrayType{
+		Elt: util.CreateEmptyInterface(),
+	})))
+	if t.features.Panics {
+		builder.AddFieldBuilder(FieldToBuilder(util.CreateField("Panicked", ast.NewIdent("bool"))))
+	}
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createInvocationsMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(invocationsMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewCallsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetCallsFieldSelector(t.stubFieldSelector(invocationsFieldName))
+	builder.SetCallTypeName(t.invocationTypeName())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createRecorderField() {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(recorderFieldName, t.resolveRecorderType())))
+}
+
+func (t *GeneratorModel) createAttachRecorderMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(attachRecorderMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewAttachRecorderMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetRecorderFieldSelector(t.stubFieldSelector(recorderFieldName))
+	builder.SetRecorderType(t.resolveRecorderType())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createSetArgMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(setArgMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewSetArgMethodBuilder(methodBuilder)
+	builder.SetValueOfSelector(t.resolveReflectSelector("ValueOf"))
+	builder.SetZeroSelector(t.resolveReflectSelector("Zero"))
+	builder.SetPtrSelector(t.resolveReflectSelector("Ptr"))
+	builder.SetSprintfSelector(t.resolveSprintfFunc())
+	builder.SetStubName(t.structName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.hasSetArgMethod = true
+}
+
+// needsSetArgMethod checks whether values can only be assigned into
+// the specified param through reflection, as it is of an empty
+// interface type.
+func (t *GeneratorModel) needsSetArgMethod(param *ast.Field) bool {
+	_, isPointer := settableArgType(param.Type).(*ast.StarExpr)
+	return !isPointer
+}
+
+func (t *GeneratorModel) createLoadScenarioMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(loadScenarioMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewLoadScenarioMethodBuilder(methodBuilder)
+	builder.SetReaderType(t.resolveReaderType())
+	builder.SetRawMessageType(t.resolveJSONSelector("RawMessage"))
+	builder.SetNewDecoderSelector(t.resolveJSONSelector("NewDecoder"))
+	builder.SetErrorfSelector(t.resolveErrorfFunc())
+	builder.SetSortSelectors(t.resolveSortSelector("Strings"), t.resolveSortSelector("Ints"))
+	builder.SetStubName(t.structName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.loadScenarioBuilder = builder
+}
+
+func (t *GeneratorModel) createGateStruct() {
+	onceBuilder := FieldToBuilder(util.CreateField(gateOnceFieldName, t.resolveOnceType()))
+	releaseBuilder := NewMethodCallSignalFieldBuilder()
+	releaseBuilder.SetFieldName(gateReleaseFieldName)
+
+	builder := NewStructBuilder()
+	builder.SetName(t.gateTypeName())
+	builder.AddFieldBuilder(onceBuilder)
+	builder.AddFieldBuilder(releaseBuilder)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createGateReleaseMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(gateReleaseMethodName)
+	methodBuilder.SetReceiver(gateReceiverName, t.gateTypeName())
+	builder := NewGateReleaseMethodBuilder(methodBuilder)
+	builder.SetOnceFieldSelector(&ast.SelectorExpr{
+		X:   ast.NewIdent(gateReceiverName),
+		Sel: ast.NewIdent(gateOnceFieldName),
+	})
+	builder.SetReleaseFieldSelector(&ast.SelectorExpr{
+		X:   ast.NewIdent(gateReceiverName),
+		Sel: ast.NewIdent(gateReleaseFieldName),
+	})
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createOptionType() {
+	builder := NewOptionTypeBuilder()
+	builder.SetName(t.optionTypeName())
+	builder.SetStubName(t.structName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.structName)
+	builder := NewConstructorBuilder(methodBuilder)
+	builder.SetStubName(t.structName)
+	builder.SetOptionTypeName(t.optionTypeName())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createExpectationsField() {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(expectationsFieldName, &ast.ArrayType{
+		Elt: &ast.StarExpr{
+			X: ast.NewIdent(t.expectationTypeName()),
+		},
+	})))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(verifiesExpectationsFieldName, ast.NewIdent("bool"))))
+}
+
+func (t *GeneratorModel) createExpectationStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.expectationTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(expectationMethodFieldName, ast.NewIdent("string"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(expectationMinFieldName, ast.NewIdent("int"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(expectationMaxFieldName, ast.NewIdent("int"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(expectationCallCountFieldName, &ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("int"),
+				},
+			},
+		},
+	})))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(expectationCallHistoryFieldName, &ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.ArrayType{
+						Elt: &ast.ArrayType{
+							Elt: util.CreateEmptyInterface(),
+						},
+					},
+				},
+			},
+		},
+	})))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createExpectationBoundsMethods() {
+	countParams := []*ast.Field{
+		util.CreateField("count", ast.NewIdent("int")),
+	}
+	t.createExpectationBoundsMethod("Times", countParams, ast.NewIdent("count"), ast.NewIdent("count"))
+	t.createExpectationBoundsMethod("AtLeast", countParams, ast.NewIdent("count"), &ast.UnaryExpr{
+		Op: token.SUB,
+		X: &ast.BasicLit{
+			Kind:  token.INT,
+			Value: "1",
+		},
+	})
+	zero := &ast.BasicLit{
+		Kind:  token.INT,
+		Value: "0",
+	}
+	t.createExpectationBoundsMethod("Never", []*ast.Field{}, zero, zero)
+}
+
+func (t *GeneratorModel) createExpectationBoundsMethod(name string, params []*ast.Field, min, max ast.Expr) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(name)
+	methodBuilder.SetReceiver(expectationReceiverName, t.expectationTypeName())
+	builder := NewExpectationBoundsMethodBuilder(methodBuilder)
+	builder.SetMinFieldSelector(t.expectationFieldSelector(expectationMinFieldName))
+	builder.SetMaxFieldSelector(t.expectationFieldSelector(expectationMaxFieldName))
+	builder.SetExpectationTypeName(t.expectationTypeName())
+	builder.SetParams(params)
+	builder.SetBounds(min, max)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createExpectationVerifyMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(expectationVerifyMethodName)
+	methodBuilder.SetReceiver(expectationReceiverName, t.expectationTypeName())
+	builder := NewExpectationVerifyMethodBuilder(methodBuilder)
+	builder.SetReporterType(t.resolveReporterType())
+	builder.SetSprintfSelector(t.resolveSprintfFunc())
+	builder.SetJoinSelector(t.resolveJoinFunc())
+	builder.SetStubName(t.structName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createVerifyExpectationsMethod() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(verifyExpectationsMethodName)
+	methodBuilder.SetReceiver(receiverName, t.structName)
+	builder := NewVerifyExpectationsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetExpectationsFieldSelector(t.stubFieldSelector(expectationsFieldName))
+	builder.SetReporterType(t.resolveReporterType())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.verifyExpectationsBuilder = builder
+}
+
+func (t *GeneratorModel) createTestConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.structName + "T")
+	builder := NewTestConstructorBuilder(methodBuilder)
+	builder.SetStubName(t.structName)
+	builder.SetOptionTypeName(t.optionTypeName())
+	builder.SetConstructorName("New" + t.structName)
+	builder.SetVerifyExpectationsSelector(t.stubFieldSelector(verifyExpectationsMethodName))
+	builder.SetVerifiesExpectationsFieldSelector(t.stubFieldSelector(verifiesExpectationsFieldName))
+	builder.SetTestType(t.resolveTestType())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.testConstructorBuilder = builder
+}
+
+func (t *GeneratorModel) createArgsType(config *MethodConfig) {
+	builder := NewStructBuilder()
+	builder.SetName(t.argsTypeName(config))
+	for _, field := range t.argsFields(config) {
+		builder.AddFieldBuilder(FieldToBuilder(field))
+	}
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createResultsType(config *MethodConfig) {
+	builder := NewStructBuilder()
+	builder.SetName(t.resultsTypeName(config))
+	for _, field := range t.resultsFields(config) {
+		builder.AddFieldBuilder(FieldToBuilder(field))
+	}
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+// createJSONMethods creates methods that serialize the structure with
+// the specified name and fields to JSON and back. They are only needed
+// for structures with error fields, which are serialized as messages.
+func (t *GeneratorModel) createJSONMethods(typeName string, fields []*ast.Field) {
+	marshalMethodBuilder := NewMethodBuilder()
+	marshalMethodBuilder.SetName("MarshalJSON")
+	marshalMethodBuilder.SetReceiver(valueReceiverName, typeName)
+	marshalBuilder := NewMarshalJSONMethodBuilder(marshalMethodBuilder)
+	marshalBuilder.SetReceiverName(valueReceiverName)
+	marshalBuilder.SetMarshalSelector(t.resolveJSONSelector("Marshal"))
+	marshalBuilder.SetFields(fields)
+	t.fileBuilder.AddDeclarationBuilder(marshalBuilder)
+
+	unmarshalMethodBuilder := NewMethodBuilder()
+	unmarshalMethodBuilder.SetName("UnmarshalJSON")
+	unmarshalMethodBuilder.SetReceiver(valueReceiverName, typeName)
+	unmarshalBuilder := NewUnmarshalJSONMethodBuilder(unmarshalMethodBuilder)
+	unmarshalBuilder.SetReceiverName(valueReceiverName)
+	unmarshalBuilder.SetUnmarshalSelector(t.resolveJSONSelector("Unmarshal"))
+	unmarshalBuilder.SetNewErrorSelector(t.resolveNewErrorFunc())
+	unmarshalBuilder.SetFields(fields)
+	t.fileBuilder.AddDeclarationBuilder(unmarshalBuilder)
+}
+
+func (t *GeneratorModel) createMethodStubField(config *MethodConfig) {
+	builder := NewMethodStubFieldBuilder()
+	builder.SetFieldName(config.StubFieldName())
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createMutexField(config *MethodConfig) {
+	builder := NewMethodMutexFieldBuilder()
+	builder.SetFieldName(config.MutexFieldName())
+	builder.SetMutexType(t.resolveMutexType())
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createArgsForCallField(config *MethodConfig) {
+	builder := NewMethodArgsFieldBuilder()
+	builder.SetFieldName(config.ArgsFieldName())
+	builder.SetArgsTypeName(t.argsTypeName(config))
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createCallSignalField(config *MethodConfig) {
+	builder := NewMethodCallSignalFieldBuilder()
+	builder.SetFieldName(config.CallSignalFieldName())
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createGatesField(config *MethodConfig) {
+	builder := NewMethodGatesFieldBuilder()
+	builder.SetFieldName(config.GatesFieldName())
+	builder.SetGateTypeName(t.gateTypeName())
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createDelayField(config *MethodConfig) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.DelayFieldName(), t.resolveDurationType())))
+}
+
+func (t *GeneratorModel) createPanicFields(config *MethodConfig) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.PanicFieldName(), util.CreateEmptyInterface())))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.PanicOnCallFieldName(), &ast.MapType{
+		Key:   ast.NewIdent("int"),
+		Value: util.CreateEmptyInterface(),
+	})))
+}
+
+func (t *GeneratorModel) createBlocksField(config *MethodConfig) {
+	builder := NewFlagFieldBuilder()
+	builder.SetFieldName(config.BlocksFieldName())
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createReturnsField(config *MethodConfig) {
+	builder := NewReturnsFieldBuilder()
+	builder.SetFieldName(config.ReturnsFieldName())
+	builder.SetResultsTypeName(t.resultsTypeName(config))
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createReturnsOnCallField(config *MethodConfig) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ReturnsOnCallFieldName(), &ast.MapType{
+		Key:   ast.NewIdent("int"),
+		Value: ast.NewIdent(t.resultsTypeName(config)),
+	})))
+}
+
+func (t *GeneratorModel) createReturnsConfiguredField(config *MethodConfig) {
+	builder := NewFlagFieldBuilder()
+	builder.SetFieldName(config.ReturnsConfiguredFieldName())
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createRulesField(config *MethodConfig) {
+	builder := NewMethodRulesFieldBuilder()
+	builder.SetFieldName(config.RulesFieldName())
+	builder.SetRuleTypeName(t.ruleTypeName(config))
+	t.structBuilder.AddFieldBuilder(builder)
+}
+
+func (t *GeneratorModel) createArgSetsFields(config *MethodConfig, index int) {
+	param := config.MethodParams[index]
+	argType := param.Type
+	if ellipsis, ok := argType.(*ast.Ellipsis); ok {
+		argType = &ast.ArrayType{
+			Elt: ellipsis.Elt,
+		}
+	}
+	setsType := &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: argType,
+				},
+			},
+		},
+	}
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ArgSetsFieldName(index), setsType)))
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ArgSetsOnCallFieldName(index), &ast.MapType{
+		Key:   ast.NewIdent("int"),
+		Value: setsType,
+	})))
+}
+
+func (t *GeneratorModel) createCallbackInvokesField(config *MethodConfig, index int) {
+	callbackType := t.normalizedCallbackType(config.MethodParams[index])
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.InvokesFieldName(index), &ast.ArrayType{
+		Elt: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: callbackType,
+					},
+				},
+			},
+		},
+	})))
+}
+
+func (t *GeneratorModel) createChildStubField(config *MethodConfig) {
+	t.structBuilder.AddFieldBuilder(FieldToBuilder(util.CreateField(config.ChildStubFieldName(), &ast.StarExpr{
+		X: ast.NewIdent(config.ChildStubName),
+	})))
+}
+
+func (t *GeneratorModel) createChildStubMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.ChildStubMethodName())
+	builder := NewChildStubMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetChildFieldSelector(t.stubFieldSelector(config.ChildStubFieldName()))
+	builder.SetChildStubName(config.ChildStubName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createForwardMethod(stubName string, config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.MethodName)
+	builder := NewForwardMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	if t.features.Invocations {
+		builder.SetInvocationsFieldSelector(t.stubFieldSelector(invocationsFieldName))
+		builder.SetInvocationTypeName(t.invocationTypeName())
+		builder.SetTracksPanics(t.features.Panics)
+	}
+	if t.features.Recorder {
+		builder.SetRecorderFieldSelector(t.stubFieldSelector(recorderFieldName))
+	}
+	builder.SetEmbeddedStubSelector(t.stubFieldSelector(stubName))
+	builder.SetStubName(t.structName)
+	builder.SetMethodName(config.MethodName)
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createStubMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.MethodName)
+	builder := NewStubMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
+	builder.SetArgsTypeName(t.argsTypeName(config))
+	if t.hasStubMutex {
+		builder.SetStubMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	}
+	if t.features.Invocations {
+		builder.SetInvocationsFieldSelector(t.stubFieldSelector(invocationsFieldName))
+		builder.SetInvocationTypeName(t.invocationTypeName())
+	}
+	if t.features.Recorder {
+		builder.SetRecorderFieldSelector(t.stubFieldSelector(recorderFieldName))
+	}
+	builder.SetStubName(t.structName)
+	builder.SetReturnsFieldSelector(config.ReturnsFieldSelector())
+	if config.HasResults() {
+		builder.SetReturnsOnCallFieldSelector(config.ReturnsOnCallFieldSelector())
+	}
+	builder.SetStubFieldSelector(config.StubFieldSelector())
+	if t.features.Wait {
+		builder.SetCallSignalFieldSelector(config.CallSignalFieldSelector())
+	}
+	if t.tracksReturnsConfigured(config) {
+		builder.SetConfiguredFieldSelector(config.ReturnsConfiguredFieldSelector())
+	}
+	if t.features.Strict {
+		builder.SetReportMethodSelector(t.stubFieldSelector(reportMethodName))
+	}
+	if t.features.Hold {
+		builder.SetGatesFieldSelector(config.GatesFieldSelector())
+		builder.SetGateTypeName(t.gateTypeName())
+	}
+	if t.features.Panics {
+		builder.SetPanicFieldSelectors(config.PanicFieldSelector(), config.PanicOnCallFieldSelector())
+	}
+	if t.features.Latency {
+		builder.SetDelayFieldSelector(config.DelayFieldSelector())
+		builder.SetTimeSelectors(t.resolveTimeSelector("Sleep"), t.resolveAfterFunc(), t.resolveTimeSelector("Time"))
+	}
+	if param := t.findContextParam(config); param != nil {
+		builder.SetContextParamName(param.Names[0].String())
+		if t.features.Latency {
+			builder.SetBlocksFieldSelector(config.BlocksFieldSelector())
+		}
+	}
+	if config.HasResults() {
+		builder.SetResultsTypeName(t.resultsTypeName(config))
+	}
+	builder.SetMethodName(config.MethodName)
+	if t.features.Rules && config.HasParams() && config.HasResults() {
+		builder.SetRulesFieldSelector(config.RulesFieldSelector())
+		builder.SetRuleTypeName(t.ruleTypeName(config))
+	}
+	if t.features.SetsArgs {
+		for _, index := range config.SettableParamIndices() {
+			builder.AddArgSets(config.MethodParams[index].Names[0].String(), t.stubFieldSelector(config.ArgSetsFieldName(index)), t.stubFieldSelector(config.ArgSetsOnCallFieldName(index)))
+		}
+	}
+	if t.features.InvokesCallbacks {
+		for _, index := range config.CallbackParamIndices() {
+			builder.AddCallbackInvokes(config.MethodParams[index].Names[0].String(), t.stubFieldSelector(config.InvokesFieldName(index)))
+		}
+	}
+	if config.ReturnsSelf {
+		builder.SetDefaultResult(ast.NewIdent(receiverName))
+	} else if config.ChildStubName != "" {
+		builder.SetDefaultResult(&ast.CallExpr{
+			Fun: t.stubFieldSelector(config.ChildStubMethodName()),
+		})
+	}
+	builder.SetParams(config.MethodParams)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createCallCountMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.CallCountMethodName())
+	builder := NewCountMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createCallsMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.CallsMethodName())
+	builder := NewCallsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetCallsFieldSelector(config.ArgsFieldSelector())
+	builder.SetCallTypeName(t.argsTypeName(config))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createWaitMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.WaitMethodName())
+	builder := NewWaitMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
+	builder.SetCallSignalFieldSelector(config.CallSignalFieldSelector())
+	builder.SetCallCountMethodSelector(config.CallCountMethodSelector())
+	builder.SetDurationType(t.resolveDurationType())
+	builder.SetAfterSelector(t.resolveAfterFunc())
+	builder.SetErrorfSelector(t.resolveErrorfFunc())
+	builder.SetMethodName(t.structName + "." + config.MethodName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createHoldMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.HoldMethodName())
+	builder := NewHoldMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetGatesFieldSelector(config.GatesFieldSelector())
+	builder.SetGateTypeName(t.gateTypeName())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createDelaysMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.DelaysMethodName())
+	builder := NewDelaysMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetDelayFieldSelector(config.DelayFieldSelector())
+	builder.SetDurationType(t.resolveDurationType())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createPanicsMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.PanicsMethodName())
+	builder := NewPanicsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetPanicFieldSelector(config.PanicFieldSelector())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createPanicsOnCallMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.PanicsOnCallMethodName())
+	builder := NewPanicsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetPanicFieldSelector(config.PanicOnCallFieldSelector())
+	builder.SetOnCall(true)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createBlocksMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.BlocksMethodName())
+	builder := NewBlocksMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetBlocksFieldSelector(config.BlocksFieldSelector())
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createCallHistoryMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.CallHistoryMethodName())
+	builder := NewCallHistoryMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
+	builder.SetParams(config.MethodParams)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createExpectMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.ExpectMethodName())
+	builder := NewExpectMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(t.stubFieldSelector(stubMutexFieldName))
+	builder.SetExpectationsFieldSelector(t.stubFieldSelector(expectationsFieldName))
+	builder.SetVerifiesExpectationsFieldSelector(t.stubFieldSelector(verifiesExpectationsFieldName))
+	builder.SetStubName(t.structName)
+	builder.SetTestConstructorName("New" + t.structName + "T")
+	builder.SetCallCountMethodSelector(config.CallCountMethodSelector())
+	builder.SetCallHistoryMethodSelector(t.stubFieldSelector(config.CallHistoryMethodName()))
+	builder.SetExpectationTypeName(t.expectationTypeName())
+	builder.SetMethodName(config.MethodName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createArgsForCallMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.ArgsForCallMethodName())
+	builder := NewArgsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
+	builder.SetParams(config.MethodParams)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createSetsArgMethod(config *MethodConfig, index int) {
+	methodBuilder := t.createMethodBuilder(config, config.SetsArgMethodName(index))
+	builder := t.newSetsArgMethodBuilder(methodBuilder, config, index)
+	builder.SetSetsFieldSelector(t.stubFieldSelector(config.ArgSetsFieldName(index)))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createSetsArgOnCallMethod(config *MethodConfig, index int) {
+	methodBuilder := t.createMethodBuilder(config, config.SetsArgOnCallMethodName(index))
+	builder := t.newSetsArgMethodBuilder(methodBuilder, config, index)
+	builder.SetSetsFieldSelector(t.stubFieldSelector(config.ArgSetsOnCallFieldName(index)))
+	builder.SetOnCall(true)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) newSetsArgMethodBuilder(methodBuilder *MethodBuilder, config *MethodConfig, index int) *SetsArgMethodBuilder {
+	builder := NewSetsArgMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetSetArgMethodSelector(t.stubFieldSelector(setArgMethodName))
+	builder.SetParam(config.MethodParams[index])
+	builder.SetPosition(index + 1)
+	builder.SetStubName(t.structName)
+	builder.SetMethodName(config.MethodName)
+	return builder
+}
+
+func (t *GeneratorModel) createInvokesMethod(config *MethodConfig, index int) {
+	methodBuilder := t.createMethodBuilder(config, config.InvokesMethodName(index))
+	builder := NewInvokesMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetInvokesFieldSelector(t.stubFieldSelector(config.InvokesFieldName(index)))
+	builder.SetCallbackType(t.normalizedCallbackType(config.MethodParams[index]))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createArgForCallMethod(config *MethodConfig, index int) {
+	methodBuilder := t.createMethodBuilder(config, config.ArgForCallMethodName(index))
+	builder := NewArgForCallMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
+	builder.SetParam(config.MethodParams[index])
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createReturnsMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.ReturnsMethodName())
+	builder := NewReturnsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetReturnsFieldSelector(config.ReturnsFieldSelector())
+	if t.tracksReturnsConfigured(config) {
+		builder.SetConfiguredFieldSelector(config.ReturnsConfiguredFieldSelector())
+	}
+	builder.SetResultsTypeName(t.resultsTypeName(config))
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createReturnsOnCallMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.ReturnsOnCallMethodName())
+	builder := NewReturnsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetReturnsFieldSelector(config.ReturnsOnCallFieldSelector())
+	builder.SetResultsTypeName(t.resultsTypeName(config))
+	builder.SetResults(config.MethodResults)
+	builder.SetOnCall(true)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createReturnsRandomMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.ReturnsRandomMethodName())
+	builder := NewReturnsRandomMethodBuilder(methodBuilder)
+	builder.SetReturnsMethodSelector(config.ReturnsMethodSelector())
+	builder.SetRandType(t.resolveRandSelector("Rand"))
+	builder.SetRandomResults(config.RandomResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createDecodeScenarioMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.DecodeScenarioMethodName())
+	builder := NewDecodeScenarioMethodBuilder(methodBuilder)
+	builder.SetRawMessageType(t.resolveJSONSelector("RawMessage"))
+	builder.SetUnmarshalSelector(t.resolveJSONSelector("Unmarshal"))
+	builder.SetErrorfSelector(t.resolveErrorfFunc())
+	builder.SetNewErrorSelector(t.resolveNewErrorFunc())
+	builder.SetResultsTypeName(t.resultsTypeName(config))
+	builder.SetStubName(t.structName)
+	builder.SetMethodName(config.MethodName)
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createStubOption(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(t.structName + "With" + config.StubFieldName())
+	builder := NewOptionBuilder(methodBuilder)
+	builder.SetStubName(t.structName)
+	builder.SetOptionTypeName(t.optionTypeName())
+	builder.SetParams([]*ast.Field{
+		util.CreateField("fn", &ast.FuncType{
+			Params: &ast.FieldList{
+				List: config.MethodParams,
+			},
+			Results: &ast.FieldList{
+				List: config.MethodResults,
+			},
+		}),
+	})
+	builder.SetApplyStatement(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			config.StubFieldSelector(),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("fn"),
+		},
+	})
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createReturnsOption(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName(t.structName + "With" + config.ReturnsMethodName())
+	builder := NewOptionBuilder(methodBuilder)
+	builder.SetStubName(t.structName)
+	builder.SetOptionTypeName(t.optionTypeName())
+	builder.SetParams(config.MethodResults)
+	resultSelectors := []ast.Expr{}
+	for _, result := range config.MethodResults {
+		resultSelectors = append(resultSelectors, ast.NewIdent(result.Names[0].String()))
+	}
+	builder.SetApplyStatement(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun:  config.ReturnsMethodSelector(),
+			Args: resultSelectors,
+		},
+	})
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createWhenMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.WhenMethodName())
+	builder := NewWhenMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(config.MutexFieldSelector())
+	builder.SetRulesFieldSelector(config.RulesFieldSelector())
+	builder.SetRuleTypeName(t.ruleTypeName(config))
+	builder.SetParams(config.MethodParams)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createCalledWithMethod(config *MethodConfig) {
+	methodBuilder := t.createMethodBuilder(config, config.CalledWithMethodName())
+	builder := NewCalledWithMethodBuilder(methodBuilder)
+	builder.SetWhenMethodSelector(config.WhenMethodSelector())
+	builder.SetDeepEqualSelector(t.resolveDeepEqualFunc())
+	builder.SetRuleTypeName(t.ruleTypeName(config))
+	builder.SetParams(config.MethodParams)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createRuleStruct(config *MethodConfig) {
+	mutexBuilder := NewMethodMutexFieldBuilder()
+	mutexBuilder.SetFieldName(ruleMutexFieldName)
+	mutexBuilder.SetMutexType(&ast.StarExpr{
+		X: t.resolveMutexType(),
+	})
+
+	matcherBuilder := NewMatcherFieldBuilder()
+	matcherBuilder.SetFieldName(ruleMatcherFieldName)
+	matcherBuilder.SetParams(config.MethodParams)
+
+	returnsBuilder := NewReturnsFieldBuilder()
+	returnsBuilder.SetFieldName(ruleReturnsFieldName)
+	returnsBuilder.SetResultsTypeName(t.resultsTypeName(config))
+
+	builder := NewStructBuilder()
+	builder.SetName(t.ruleTypeName(config))
+	builder.AddFieldBuilder(mutexBuilder)
+	builder.AddFieldBuilder(matcherBuilder)
+	builder.AddFieldBuilder(returnsBuilder)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) createRuleReturnsMethod(config *MethodConfig) {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("Returns")
+	methodBuilder.SetReceiver(ruleReceiverName, t.ruleTypeName(config))
+	builder := NewReturnsMethodBuilder(methodBuilder)
+	builder.SetMutexFieldSelector(&ast.SelectorExpr{
+		X:   ast.NewIdent(ruleReceiverName),
+		Sel: ast.NewIdent(ruleMutexFieldName),
+	})
+	builder.SetReturnsFieldSelector(&ast.SelectorExpr{
+		X:   ast.NewIdent(ruleReceiverName),
+		Sel: ast.NewIdent(ruleReturnsFieldName),
+	})
+	builder.SetResultsTypeName(t.resultsTypeName(config))
+	builder.SetResults(config.MethodResults)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *GeneratorModel) argsFields(config *MethodConfig) []*ast.Field {
+	return util.FieldsAsExported(util.FieldsWithoutEllipsis(config.MethodParams))
+}
+
+func (t *GeneratorModel) resultsFields(config *MethodConfig) []*ast.Field {
+	return util.FieldsAsExported(config.MethodResults)
+}
+
+func (t *GeneratorModel) argsTypeName(config *MethodConfig) string {
+	return t.structName + config.MethodName + "Args"
+}
+
+func (t *GeneratorModel) resultsTypeName(config *MethodConfig) string {
+	return t.structName + config.MethodName + "Results"
+}
+
+func (t *GeneratorModel) ruleTypeName(config *MethodConfig) string {
+	return t.structName + config.MethodName + "Rule"
+}
+
+func (t *GeneratorModel) optionTypeName() string {
+	return t.structName + "Option"
+}
+
+func (t *GeneratorModel) expectationTypeName() string {
+	return t.structName + "Expectation"
+}
+
+func (t *GeneratorModel) expectationFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(expectationReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *GeneratorModel) invocationTypeName() string {
+	return t.structName + "Invocation"
+}
+
+func (t *GeneratorModel) gateTypeName() string {
+	return t.structName + "Gate"
+}
+
+// normalizedCallbackType returns the function type of the specified
+// callback parameter, with its parameters normalized (i.e. no type
+// reuse and no anonymous parameters).
+func (t *GeneratorModel) normalizedCallbackType(param *ast.Field) *ast.FuncType {
+	funcType := param.Type.(*ast.FuncType)
+	params := []*ast.Field{}
+	for field := range util.EachFieldInFieldList(funcType.Params) {
+		for i := 0; i < util.FieldTypeReuseCount(field); i++ {
+			params = append(params, util.CreateField(fmt.Sprintf("arg%d", len(params)+1), field.Type))
+		}
+	}
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+		Results: funcType.Results,
+	}
+}
+
+// tracksReturnsConfigured checks whether the stub needs to track if
+// results were configured for the specified method, which is the
+// case for strict stubs and for methods with a default result.
+func (t *GeneratorModel) tracksReturnsConfigured(config *MethodConfig) bool {
+	return t.features.Strict || config.ReturnsSelf || config.ChildStubName != ""
+}
+
+// findContextParam returns the first parameter of the method that
+// is of type context.Context, if any.
+func (t *GeneratorModel) findContextParam(config *MethodConfig) *ast.Field {
+	for _, param := range config.MethodParams {
+		selector, ok := param.Type.(*ast.SelectorExpr)
+		if !ok || selector.Sel.String() != "Context" {
+			continue
+		}
+		alias, ok := selector.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if location, found := t.fileBuilder.ImportLocation(alias.String()); found && location == "context" {
+			return param
+		}
+	}
+	return nil
+}
+
+func (t *GeneratorModel) createMethodBuilder(config *MethodConfig, name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(receiverName, t.structName)
+	return builder
+}
+
+func (t *GeneratorModel) stubFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *GeneratorModel) resolveInterfaceType(location, name string) *ast.SelectorExpr {
+	alias := t.AddImport("", location)
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *GeneratorModel) resolveMutexType() *ast.SelectorExpr {
+	alias := t.AddImport("sync", "sync")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("RWMutex"),
+	}
+}
+
+func (t *GeneratorModel) resolveOnceType() *ast.SelectorExpr {
+	alias := t.AddImport("sync", "sync")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Once"),
+	}
+}
+
+func (t *GeneratorModel) resolveDeepEqualFunc() *ast.SelectorExpr {
+	alias := t.AddImport("reflect", "reflect")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("DeepEqual"),
 	}
-	return nil
 }
 
-func (t *GeneratorModel) createMethodStubField(config *MethodConfig) {
-	builder := NewMethodStubFieldBuilder()
-	builder.SetFieldName(config.StubFieldName())
-	builder.SetParams(config.MethodParams)
-	builder.SetResults(config.MethodResults)
-	t.structBuilder.AddFieldBuilder(builder)
+func (t *GeneratorModel) resolveSprintfFunc() *ast.SelectorExpr {
+	alias := t.AddImport("fmt", "fmt")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Sprintf"),
+	}
 }
 
-func (t *GeneratorModel) createMutexField(config *MethodConfig) {
-	builder := NewMethodMutexFieldBuilder()
-	builder.SetFieldName(config.MutexFieldName())
-	builder.SetMutexType(t.resolveMutexType())
-	t.structBuilder.AddFieldBuilder(builder)
+func (t *GeneratorModel) resolveErrorfFunc() *ast.SelectorExpr {
+	alias := t.AddImport("fmt", "fmt")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Errorf"),
+	}
 }
 
-func (t *GeneratorModel) createArgsForCallField(config *MethodConfig) {
-	builder := NewMethodArgsFieldBuilder()
-	builder.SetFieldName(config.ArgsFieldName())
-	builder.SetParams(config.MethodParams)
-	t.structBuilder.AddFieldBuilder(builder)
+func (t *GeneratorModel) resolveNewErrorFunc() *ast.SelectorExpr {
+	alias := t.AddImport("errors", "errors")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("New"),
+	}
 }
 
-func (t *GeneratorModel) createReturnsField(config *MethodConfig) {
-	builder := NewReturnsFieldBuilder()
-	builder.SetFieldName(config.ReturnsFieldName())
-	builder.SetResults(config.MethodResults)
-	t.structBuilder.AddFieldBuilder(builder)
+func (t *GeneratorModel) resolveSortSelector(name string) *ast.SelectorExpr {
+	alias := t.AddImport("sort", "sort")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
 }
 
-func (t *GeneratorModel) createStubMethod(config *MethodConfig) {
-	methodBuilder := t.createMethodBuilder(config, config.MethodName)
-	builder := NewStubMethodBuilder(methodBuilder)
-	builder.SetMutexFieldSelector(config.MutexFieldSelector())
-	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
-	builder.SetReturnsFieldSelector(config.ReturnsFieldSelector())
-	builder.SetStubFieldSelector(config.StubFieldSelector())
-	builder.SetParams(config.MethodParams)
-	builder.SetResults(config.MethodResults)
-	t.fileBuilder.AddDeclarationBuilder(builder)
+func (t *GeneratorModel) resolveJSONSelector(name string) *ast.SelectorExpr {
+	alias := t.AddImport("json", "encoding/json")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
 }
 
-func (t *GeneratorModel) createCallCountMethod(config *MethodConfig) {
-	methodBuilder := t.createMethodBuilder(config, config.CallCountMethodName())
-	builder := NewCountMethodBuilder(methodBuilder)
-	builder.SetMutexFieldSelector(config.MutexFieldSelector())
-	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
-	t.fileBuilder.AddDeclarationBuilder(builder)
+func (t *GeneratorModel) resolveReaderType() *ast.SelectorExpr {
+	alias := t.AddImport("io", "io")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Reader"),
+	}
 }
 
-func (t *GeneratorModel) createArgsForCallMethod(config *MethodConfig) {
-	methodBuilder := t.createMethodBuilder(config, config.ArgsForCallMethodName())
-	builder := NewArgsMethodBuilder(methodBuilder)
-	builder.SetMutexFieldSelector(config.MutexFieldSelector())
-	builder.SetArgsFieldSelector(config.ArgsFieldSelector())
-	builder.SetParams(config.MethodParams)
-	t.fileBuilder.AddDeclarationBuilder(builder)
+func (t *GeneratorModel) resolveDurationType() *ast.SelectorExpr {
+	alias := t.AddImport("time", "time")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Duration"),
+	}
 }
 
-func (t *GeneratorModel) createReturnsMethod(config *MethodConfig) {
-	methodBuilder := t.createMethodBuilder(config, config.ReturnsMethodName())
-	builder := NewReturnsMethodBuilder(methodBuilder)
-	builder.SetMutexFieldSelector(config.MutexFieldSelector())
-	builder.SetReturnsFieldSelector(config.ReturnsFieldSelector())
-	builder.SetResults(config.MethodResults)
-	t.fileBuilder.AddDeclarationBuilder(builder)
+func (t *GeneratorModel) resolveAfterFunc() *ast.SelectorExpr {
+	alias := t.AddImport("time", "time")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("After"),
+	}
 }
 
-func (t *GeneratorModel) createMethodBuilder(config *MethodConfig, name string) *MethodBuilder {
-	builder := NewMethodBuilder()
-	builder.SetName(name)
-	builder.SetReceiver(receiverName, t.structName)
-	return builder
+func (t *GeneratorModel) resolveTimeSelector(name string) *ast.SelectorExpr {
+	alias := t.AddImport("time", "time")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
 }
 
-func (t *GeneratorModel) resolveInterfaceType(location, name string) *ast.SelectorExpr {
-	alias := t.AddImport("", location)
+func (t *GeneratorModel) resolveRandSelector(name string) *ast.SelectorExpr {
+	alias := t.AddImport("rand", "math/rand")
 	return &ast.SelectorExpr{
 		X:   ast.NewIdent(alias),
 		Sel: ast.NewIdent(name),
 	}
 }
 
-func (t *GeneratorModel) resolveMutexType() *ast.SelectorExpr {
-	alias := t.AddImport("sync", "sync")
+func (t *GeneratorModel) resolveReflectSelector(name string) *ast.SelectorExpr {
+	alias := t.AddImport("reflect", "reflect")
 	return &ast.SelectorExpr{
 		X:   ast.NewIdent(alias),
-		Sel: ast.NewIdent("RWMutex"),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *GeneratorModel) resolveJoinFunc() *ast.SelectorExpr {
+	alias := t.AddImport("strings", "strings")
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent("Join"),
+	}
+}
+
+// resolveReporterType returns the minimal subset of testing.TB that
+// strict stubs need in order to report unconfigured calls.
+func (t *GeneratorModel) resolveReporterType() *ast.InterfaceType {
+	return &ast.InterfaceType{
+		Methods: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("Helper", &ast.FuncType{
+					Params: &ast.FieldList{},
+				}),
+				util.CreateField("Errorf", &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							util.CreateField("format", ast.NewIdent("string")),
+							util.CreateField("args", &ast.Ellipsis{
+								Elt: util.CreateEmptyInterface(),
+							}),
+						},
+					},
+				}),
+			},
+		},
+	}
+}
+
+// resolveLoggerType returns the minimal subset of testing.TB that
+// stubs need in order to log messages.
+func (t *GeneratorModel) resolveLoggerType() *ast.InterfaceType {
+	return &ast.InterfaceType{
+		Methods: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("Helper", &ast.FuncType{
+					Params: &ast.FieldList{},
+				}),
+				util.CreateField("Logf", &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							util.CreateField("format", ast.NewIdent("string")),
+							util.CreateField("args", &ast.Ellipsis{
+								Elt: util.CreateEmptyInterface(),
+							}),
+						},
+					},
+				}),
+			},
+		},
 	}
 }
 
+// resolveRecorderType returns the interface that a recorder, such as
+// the one in the 'github.com/mokiat/gostub/recorder' package, needs to
+// implement in order to be attached to a stub.
+func (t *GeneratorModel) resolveRecorderType() *ast.InterfaceType {
+	return &ast.InterfaceType{
+		Methods: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("Record", &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							util.CreateField("stub", ast.NewIdent("string")),
+							util.CreateField("method", ast.NewIdent("string")),
+							util.CreateField("args", &ast.ArrayType{
+								Elt: util.CreateEmptyInterface(),
+							}),
+						},
+					},
+				}),
+			},
+		},
+	}
+}
+
+// resolveTestType returns the minimal subset of testing.TB that
+// stubs bound to a test need in order to verify expectations.
+func (t *GeneratorModel) resolveTestType() *ast.InterfaceType {
+	testType := t.resolveReporterType()
+	testType.Methods.List = append(testType.Methods.List, util.CreateField("Cleanup", &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+					},
+				},
+			},
+		},
+	}))
+	return testType
+}
+
 func (t *GeneratorModel) Save(filePath string) error {
-	astFile := t.fileBuilder.Build()
+	return saveFile(t.fileBuilder, filePath)
+}
+
+// isResolvedType checks whether the specified type, which should have
+// been resolved against the namespace of the file, refers to the type
+// with the specified name in the specified location.
+func isResolvedType(fileBuilder *FileBuilder, resolvedType ast.Expr, location, name string) bool {
+	selector, ok := resolvedType.(*ast.SelectorExpr)
+	if !ok || selector.Sel.String() != name {
+		return false
+	}
+	alias, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	aliasLocation, found := fileBuilder.ImportLocation(alias.String())
+	return found && aliasLocation == location
+}
+
+// settableArgType returns the type of a single argument that is
+// passed for a param of the specified type, which is the element
+// type for variadic params.
+func settableArgType(paramType ast.Expr) ast.Expr {
+	if ellipsis, ok := paramType.(*ast.Ellipsis); ok {
+		return ellipsis.Elt
+	}
+	return paramType
+}
+
+func saveFile(fileBuilder *FileBuilder, filePath string) error {
+	astFile := fileBuilder.Build()
 
 	sourceCode, err := util.CreateSourceCode(astFile)
 	if err != nil {
@@ -200,11 +1596,32 @@ type MethodConfig struct {
 	// resolved against the generated stub's new namespace)
 	MethodParams []*ast.Field
 
+	// MethodParamNames specifies the original names of the parameters
+	// of the method, in the same order as MethodParams. Anonymous
+	// parameters have an empty name.
+	MethodParamNames []string
+
 	// MethodResults specifies all the results of the method.
 	// They should have been normalized (i.e. no type reuse and no
 	// anonymous results) and resolved (i.e. all selector expressions
 	// resolved against the generated stub's new namespace)
 	MethodResults []*ast.Field
+
+	// ChildStubName specifies the name of the stub that is generated
+	// for the interface which the method returns. If not empty, the
+	// method returns an instance of that stub, unless configured
+	// otherwise.
+	ChildStubName string
+
+	// ReturnsSelf specifies whether the single result of the method
+	// is an interface that the stub implements. Such methods return
+	// the stub itself, unless configured otherwise.
+	ReturnsSelf bool
+
+	// RandomResults specifies expressions that create a random value
+	// for each of the results of the method, drawing from a *rand.Rand
+	// parameter. If empty, no random results can be configured.
+	RandomResults []ast.Expr
 }
 
 func (s *MethodConfig) HasParams() bool {
@@ -248,6 +1665,72 @@ func (s *MethodConfig) ArgsFieldSelector() *ast.SelectorExpr {
 	}
 }
 
+func (s *MethodConfig) CallSignalFieldName() string {
+	return util.ToPrivate(s.MethodName + "CallSignal")
+}
+
+func (s *MethodConfig) CallSignalFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.CallSignalFieldName()),
+	}
+}
+
+func (s *MethodConfig) GatesFieldName() string {
+	return util.ToPrivate(s.MethodName + "Gates")
+}
+
+func (s *MethodConfig) GatesFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.GatesFieldName()),
+	}
+}
+
+func (s *MethodConfig) DelayFieldName() string {
+	return util.ToPrivate(s.MethodName + "Delay")
+}
+
+func (s *MethodConfig) DelayFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.DelayFieldName()),
+	}
+}
+
+func (s *MethodConfig) PanicFieldName() string {
+	return util.ToPrivate(s.MethodName + "Panic")
+}
+
+func (s *MethodConfig) PanicFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.PanicFieldName()),
+	}
+}
+
+func (s *MethodConfig) PanicOnCallFieldName() string {
+	return util.ToPrivate(s.MethodName + "PanicOnCall")
+}
+
+func (s *MethodConfig) PanicOnCallFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.PanicOnCallFieldName()),
+	}
+}
+
+func (s *MethodConfig) BlocksFieldName() string {
+	return util.ToPrivate(s.MethodName + "BlocksUntilContextDone")
+}
+
+func (s *MethodConfig) BlocksFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.BlocksFieldName()),
+	}
+}
+
 func (s *MethodConfig) ReturnsFieldName() string {
 	return util.ToPrivate(s.MethodName + "Returns")
 }
@@ -259,14 +1742,205 @@ func (s *MethodConfig) ReturnsFieldSelector() *ast.SelectorExpr {
 	}
 }
 
+func (s *MethodConfig) ReturnsOnCallFieldName() string {
+	return util.ToPrivate(s.MethodName + "ReturnsOnCall")
+}
+
+func (s *MethodConfig) ReturnsOnCallFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.ReturnsOnCallFieldName()),
+	}
+}
+
+func (s *MethodConfig) ReturnsConfiguredFieldName() string {
+	return util.ToPrivate(s.MethodName + "ReturnsConfigured")
+}
+
+func (s *MethodConfig) ReturnsConfiguredFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.ReturnsConfiguredFieldName()),
+	}
+}
+
+func (s *MethodConfig) RulesFieldName() string {
+	return util.ToPrivate(s.MethodName + "Rules")
+}
+
+func (s *MethodConfig) RulesFieldSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.RulesFieldName()),
+	}
+}
+
+func (s *MethodConfig) ArgSetsFieldName(index int) string {
+	return util.ToPrivate(s.MethodName + s.ParamAlias(index) + "Sets")
+}
+
+func (s *MethodConfig) ArgSetsOnCallFieldName(index int) string {
+	return util.ToPrivate(s.MethodName + s.ParamAlias(index) + "SetsOnCall")
+}
+
+// CallbackParamIndices returns the indices of all parameters that
+// are of a function type.
+func (s *MethodConfig) CallbackParamIndices() []int {
+	indices := []int{}
+	for i, param := range s.MethodParams {
+		if _, ok := param.Type.(*ast.FuncType); ok {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SettableParamIndices returns the indices of all parameters into
+// which values can be assigned. These are parameters of a pointer or
+// an empty interface type, including variadic ones.
+func (s *MethodConfig) SettableParamIndices() []int {
+	indices := []int{}
+	for i, param := range s.MethodParams {
+		switch argType := settableArgType(param.Type).(type) {
+		case *ast.StarExpr:
+			indices = append(indices, i)
+		case *ast.InterfaceType:
+			if argType.Methods == nil || len(argType.Methods.List) == 0 {
+				indices = append(indices, i)
+			}
+		}
+	}
+	return indices
+}
+
+// ParamAlias returns the name that is used to refer to the parameter
+// at the specified index in the names of generated methods. It is
+// the exported original name of the parameter or ArgN if that is not
+// available or would clash with the ArgsForCall method.
+func (s *MethodConfig) ParamAlias(index int) string {
+	name := ""
+	if index < len(s.MethodParamNames) {
+		name = util.ToPublic(s.MethodParamNames[index])
+	}
+	if name == "" || name == "_" || name == "Args" {
+		name = fmt.Sprintf("Arg%d", index+1)
+	}
+	return name
+}
+
+func (s *MethodConfig) InvokesFieldName(index int) string {
+	return util.ToPrivate(s.MethodName + s.ParamAlias(index) + "Invokes")
+}
+
+func (s *MethodConfig) InvokesMethodName(index int) string {
+	return s.MethodName + "Invokes" + s.ParamAlias(index)
+}
+
+func (s *MethodConfig) ArgForCallMethodName(index int) string {
+	return s.MethodName + s.ParamAlias(index) + "ForCall"
+}
+
+func (s *MethodConfig) ChildStubFieldName() string {
+	return util.ToPrivate(s.MethodName + "ChildStub")
+}
+
+func (s *MethodConfig) ChildStubMethodName() string {
+	return s.MethodName + "StubValue"
+}
+
 func (s *MethodConfig) CallCountMethodName() string {
 	return s.MethodName + "CallCount"
 }
 
+func (s *MethodConfig) CallCountMethodSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.CallCountMethodName()),
+	}
+}
+
+func (s *MethodConfig) CallsMethodName() string {
+	return s.MethodName + "Calls"
+}
+
+func (s *MethodConfig) WaitMethodName() string {
+	return "WaitFor" + s.MethodName + "Calls"
+}
+
+func (s *MethodConfig) HoldMethodName() string {
+	return "Hold" + s.MethodName
+}
+
+func (s *MethodConfig) DelaysMethodName() string {
+	return s.MethodName + "Delays"
+}
+
+func (s *MethodConfig) PanicsMethodName() string {
+	return s.MethodName + "Panics"
+}
+
+func (s *MethodConfig) PanicsOnCallMethodName() string {
+	return s.MethodName + "PanicsOnCall"
+}
+
+func (s *MethodConfig) BlocksMethodName() string {
+	return s.MethodName + "BlocksUntilContextDone"
+}
+
+func (s *MethodConfig) CallHistoryMethodName() string {
+	return util.ToPrivate(s.MethodName + "CallHistory")
+}
+
+func (s *MethodConfig) ExpectMethodName() string {
+	return "Expect" + s.MethodName
+}
+
 func (s *MethodConfig) ArgsForCallMethodName() string {
 	return s.MethodName + "ArgsForCall"
 }
 
+func (s *MethodConfig) SetsArgMethodName(index int) string {
+	return s.MethodName + "Sets" + s.ParamAlias(index)
+}
+
+func (s *MethodConfig) SetsArgOnCallMethodName(index int) string {
+	return s.MethodName + "Sets" + s.ParamAlias(index) + "OnCall"
+}
+
 func (s *MethodConfig) ReturnsMethodName() string {
 	return s.MethodName + "Returns"
 }
+
+func (s *MethodConfig) ReturnsMethodSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.ReturnsMethodName()),
+	}
+}
+
+func (s *MethodConfig) ReturnsRandomMethodName() string {
+	return s.MethodName + "ReturnsRandom"
+}
+
+func (s *MethodConfig) ReturnsOnCallMethodName() string {
+	return s.MethodName + "ReturnsOnCall"
+}
+
+func (s *MethodConfig) DecodeScenarioMethodName() string {
+	return util.ToPrivate("decode" + s.MethodName + "Scenario")
+}
+
+func (s *MethodConfig) WhenMethodName() string {
+	return s.MethodName + "When"
+}
+
+func (s *MethodConfig) WhenMethodSelector() *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(receiverName),
+		Sel: ast.NewIdent(s.WhenMethodName()),
+	}
+}
+
+func (s *MethodConfig) CalledWithMethodName() string {
+	return s.MethodName + "CalledWith"
+}
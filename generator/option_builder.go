@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewOptionBuilder(methodBuilder *MethodBuilder) *OptionBuilder {
+	return &OptionBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// OptionBuilder is responsible for creating a function that returns
+// a functional option which configures a given method of the stub
+// when applied by the stub constructor.
+//
+// Example:
+//     func StubStructWithSumReturns(result1 int) StubStructOption {
+//         return func(stub *StubStruct) {
+//             stub.SumReturns(result1)
+//         }
+//     }
+type OptionBuilder struct {
+	methodBuilder  *MethodBuilder
+	stubName       string
+	optionTypeName string
+	params         []*ast.Field
+	applyStatement ast.Stmt
+}
+
+func (b *OptionBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *OptionBuilder) SetOptionTypeName(name string) {
+	b.optionTypeName = name
+}
+
+// SetParams specifies the parameters of the option function. These
+// parameters need to have been normalized and resolved in advance.
+func (b *OptionBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetApplyStatement specifies the statement that configures the
+// stub, which is referred to by the receiver name.
+func (b *OptionBuilder) SetApplyStatement(statement ast.Stmt) {
+	b.applyStatement = statement
+}
+
+func (b *OptionBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent(b.optionTypeName),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							util.CreateField(receiverName, &ast.StarExpr{
+								X: ast.NewIdent(b.stubName),
+							}),
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						b.applyStatement,
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
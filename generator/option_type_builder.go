@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewOptionTypeBuilder() *OptionTypeBuilder {
+	return &OptionTypeBuilder{}
+}
+
+// OptionTypeBuilder is responsible for creating the type of the
+// functional options that can be passed to the stub constructor.
+//
+// Example:
+//     type StubStructOption func(stub *StubStruct)
+type OptionTypeBuilder struct {
+	name     string
+	stubName string
+}
+
+func (b *OptionTypeBuilder) SetName(name string) {
+	b.name = name
+}
+
+func (b *OptionTypeBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *OptionTypeBuilder) Build() ast.Decl {
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(b.name),
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							util.CreateField(receiverName, &ast.StarExpr{
+								X: ast.NewIdent(b.stubName),
+							}),
+						},
+					},
+				},
+			},
+		},
+	}
+}
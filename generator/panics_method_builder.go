@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewPanicsMethodBuilder(methodBuilder *MethodBuilder) *PanicsMethodBuilder {
+	return &PanicsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// PanicsMethodBuilder is responsible for creating a method on the stub
+// structure that configures a value with which the stubbed method
+// should panic. If configured as on-call, the method accepts the index
+// of the call to which the value applies.
+//
+// Example:
+//     func (stub *StubStruct) SumPanics(value interface{}) {
+//         // ...
+//     }
+//
+//     func (stub *StubStruct) SumPanicsOnCall(index int, value interface{}) {
+//         // ...
+//     }
+type PanicsMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	panicFieldSelector *ast.SelectorExpr
+	onCall             bool
+}
+
+func (b *PanicsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+// SetPanicFieldSelector configures the field that holds the panic
+// value, or the panic values by call index if configured as on-call.
+func (b *PanicsMethodBuilder) SetPanicFieldSelector(selector *ast.SelectorExpr) {
+	b.panicFieldSelector = selector
+}
+
+// SetOnCall specifies whether the configured value should apply
+// to a specific call only.
+func (b *PanicsMethodBuilder) SetOnCall(onCall bool) {
+	b.onCall = onCall
+}
+
+func (b *PanicsMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	params := []*ast.Field{}
+	if b.onCall {
+		params = append(params, util.CreateField("index", ast.NewIdent("int")))
+	}
+	params = append(params, util.CreateField("value", util.CreateEmptyInterface()))
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+
+	var target ast.Expr = b.panicFieldSelector
+	if b.onCall {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  b.panicFieldSelector,
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							b.panicFieldSelector,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("make"),
+								Args: []ast.Expr{
+									&ast.MapType{
+										Key:   ast.NewIdent("int"),
+										Value: util.CreateEmptyInterface(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+		target = &ast.IndexExpr{
+			X:     b.panicFieldSelector,
+			Index: ast.NewIdent("index"),
+		}
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			target,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("value"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRandomizeResultsMethodBuilder(methodBuilder *MethodBuilder) *RandomizeResultsMethodBuilder {
+	return &RandomizeResultsMethodBuilder{
+		methodBuilder: methodBuilder,
+		methodNames:   make([]string, 0),
+	}
+}
+
+// RandomizeResultsMethodBuilder is responsible for creating a method on
+// the stub structure that configures random results for all stubbed
+// methods from a single seed. A seed is picked when none is specified.
+// The seed is logged and returned, so that a run can be reproduced.
+//
+// Example:
+//     func (stub *StubStruct) RandomizeResults(seed int64, reporter Logger) int64 {
+//         // ...
+//     }
+type RandomizeResultsMethodBuilder struct {
+	methodBuilder     *MethodBuilder
+	reporterType      ast.Expr
+	nowSelector       *ast.SelectorExpr
+	newRandSelector   *ast.SelectorExpr
+	newSourceSelector *ast.SelectorExpr
+	stubName          string
+	methodNames       []string
+}
+
+// SetReporterType configures the type through which the seed
+// is logged. The type should have already been resolved.
+func (b *RandomizeResultsMethodBuilder) SetReporterType(reporterType ast.Expr) {
+	b.reporterType = reporterType
+}
+
+// SetNowSelector configures the time.Now function.
+// The selector should have already been resolved.
+func (b *RandomizeResultsMethodBuilder) SetNowSelector(selector *ast.SelectorExpr) {
+	b.nowSelector = selector
+}
+
+// SetNewRandSelector configures the rand.New function.
+// The selector should have already been resolved.
+func (b *RandomizeResultsMethodBuilder) SetNewRandSelector(selector *ast.SelectorExpr) {
+	b.newRandSelector = selector
+}
+
+// SetNewSourceSelector configures the rand.NewSource function.
+// The selector should have already been resolved.
+func (b *RandomizeResultsMethodBuilder) SetNewSourceSelector(selector *ast.SelectorExpr) {
+	b.newSourceSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in the logged message.
+func (b *RandomizeResultsMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// AddReturnsRandomMethod registers a method of the stub that configures
+// random results for a stubbed method. Methods are called in the order
+// in which they are registered.
+func (b *RandomizeResultsMethodBuilder) AddReturnsRandomMethod(name string) {
+	b.methodNames = append(b.methodNames, name)
+}
+
+func (b *RandomizeResultsMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("seed", ast.NewIdent("int64")),
+				util.CreateField("reporter", b.reporterType),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("int64"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("seed"),
+			Op: token.EQL,
+			Y: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: "0",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("seed"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X: &ast.CallExpr{
+									Fun: b.nowSelector,
+								},
+								Sel: ast.NewIdent("UnixNano"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("reporter"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("reporter"),
+							Sel: ast.NewIdent("Helper"),
+						},
+					},
+				},
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("reporter"),
+							Sel: ast.NewIdent("Logf"),
+						},
+						Args: []ast.Expr{
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: fmt.Sprintf("%q", "results of "+b.stubName+" are randomized with seed %d"),
+							},
+							ast.NewIdent("seed"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent(randomSourceParamName),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.newRandSelector,
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun: b.newSourceSelector,
+						Args: []ast.Expr{
+							ast.NewIdent("seed"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	for _, name := range b.methodNames {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent(receiverName),
+					Sel: ast.NewIdent(name),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent(randomSourceParamName),
+				},
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("seed"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,510 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/resolution"
+	"github.com/mokiat/gostub/util"
+)
+
+// maxRandomLength specifies the maximum number of elements in random
+// slices and maps.
+const maxRandomLength string = "4"
+
+func NewRandomizer(model Importer, resolver *Resolver, locator *resolution.Locator) *Randomizer {
+	return &Randomizer{
+		model:    model,
+		resolver: resolver,
+		locator:  locator,
+		visiting: make(map[string]bool),
+	}
+}
+
+// Randomizer creates expressions that construct random values of
+// specific types. The expressions draw values from a *rand.Rand and
+// are created from the declarations of the types, so that no
+// reflection is needed at runtime.
+type Randomizer struct {
+	model    Importer
+	resolver *Resolver
+	locator  *resolution.Locator
+	visiting map[string]bool
+}
+
+// RandomValue returns an expression that constructs a random value
+// of the specified type, which is resolved against the specified
+// context. Values are drawn from the specified source.
+//
+// Functions, channels and interfaces other than error are nil. Named
+// types that contain themselves are only expanded once, after which
+// their zero value is used.
+func (r *Randomizer) RandomValue(context *resolution.LocatorContext, astType ast.Expr, source ast.Expr) (ast.Expr, error) {
+	if ident, ok := astType.(*ast.Ident); ok && isBuiltIn(ident.String()) {
+		return r.randomBuiltIn(ident.String(), source), nil
+	}
+	discovery, found, err := r.findNamedType(context, astType)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return r.randomNamed(context, astType, discovery, source)
+	}
+	switch t := astType.(type) {
+	case *ast.ParenExpr:
+		return r.RandomValue(context, t.X, source)
+	case *ast.StarExpr:
+		return r.randomPointer(context, t, source)
+	case *ast.ArrayType:
+		return r.randomArray(context, t, source)
+	case *ast.MapType:
+		return r.randomMap(context, t, source)
+	case *ast.StructType:
+		resolvedType, err := r.resolveType(context, t)
+		if err != nil {
+			return nil, err
+		}
+		return r.randomStruct(context, t, resolvedType, source)
+	}
+	return ast.NewIdent("nil"), nil
+}
+
+func (r *Randomizer) findNamedType(context *resolution.LocatorContext, astType ast.Expr) (resolution.TypeDiscovery, bool, error) {
+	var discovery resolution.TypeDiscovery
+	var err error
+	switch t := astType.(type) {
+	case *ast.Ident:
+		if isBuiltIn(t.String()) {
+			return resolution.TypeDiscovery{}, false, nil
+		}
+		discovery, err = r.locator.FindIdentType(context, t)
+	case *ast.SelectorExpr:
+		discovery, err = r.locator.FindSelectorType(context, t)
+	default:
+		return resolution.TypeDiscovery{}, false, nil
+	}
+	if err != nil {
+		return resolution.TypeDiscovery{}, false, err
+	}
+	return discovery, true, nil
+}
+
+func (r *Randomizer) randomBuiltIn(name string, source ast.Expr) ast.Expr {
+	switch name {
+	case "bool":
+		return &ast.BinaryExpr{
+			X:  r.buildSourceCall(source, "Intn", r.buildIntLit("2")),
+			Op: token.EQL,
+			Y:  r.buildIntLit("1"),
+		}
+	case "float32", "float64":
+		return r.buildConversion(ast.NewIdent(name), r.buildSourceCall(source, "NormFloat64"))
+	case "complex64", "complex128":
+		return r.buildConversion(ast.NewIdent(name), &ast.CallExpr{
+			Fun: ast.NewIdent("complex"),
+			Args: []ast.Expr{
+				r.buildSourceCall(source, "NormFloat64"),
+				r.buildSourceCall(source, "NormFloat64"),
+			},
+		})
+	case "string":
+		return r.buildRandomString(source)
+	case "error":
+		return r.buildRandomError(source)
+	default:
+		return r.buildConversion(ast.NewIdent(name), r.buildSourceCall(source, "Uint64"))
+	}
+}
+
+// randomNamed creates a random value of a named type, based on the
+// declaration of the type. Structures are populated field by field,
+// while other types are converted from a random value of their
+// underlying type.
+func (r *Randomizer) randomNamed(context *resolution.LocatorContext, astType ast.Expr, discovery resolution.TypeDiscovery, source ast.Expr) (ast.Expr, error) {
+	resolvedType, err := r.resolveType(context, astType)
+	if err != nil {
+		return nil, err
+	}
+	key := r.typeKey(discovery)
+	if r.visiting[key] {
+		return &ast.StarExpr{
+			X: &ast.CallExpr{
+				Fun: ast.NewIdent("new"),
+				Args: []ast.Expr{
+					resolvedType,
+				},
+			},
+		}, nil
+	}
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+
+	typeContext := resolution.NewASTFileLocatorContext(discovery.File, discovery.Location)
+	switch t := discovery.Spec.Type.(type) {
+	case *ast.StructType:
+		return r.randomStruct(typeContext, t, resolvedType, source)
+	case *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return ast.NewIdent("nil"), nil
+	}
+	value, err := r.RandomValue(typeContext, discovery.Spec.Type, source)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildConversion(resolvedType, value), nil
+}
+
+// randomStruct populates all exported fields of a structure. Unexported
+// fields cannot be set from the stub's package and keep their zero value.
+func (r *Randomizer) randomStruct(context *resolution.LocatorContext, structType *ast.StructType, resolvedType ast.Expr, source ast.Expr) (ast.Expr, error) {
+	elements := []ast.Expr{}
+	for field := range util.EachFieldInFieldList(structType.Fields) {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{r.embeddedFieldName(field.Type)}
+		}
+		for _, name := range names {
+			if !ast.IsExported(name.String()) {
+				continue
+			}
+			value, err := r.RandomValue(context, field.Type, source)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, &ast.KeyValueExpr{
+				Key:   ast.NewIdent(name.String()),
+				Value: value,
+			})
+		}
+	}
+	return &ast.CompositeLit{
+		Type: resolvedType,
+		Elts: elements,
+	}, nil
+}
+
+// randomPointer creates a pointer to a random value. Pointers to named
+// types that are already being expanded are nil.
+func (r *Randomizer) randomPointer(context *resolution.LocatorContext, starType *ast.StarExpr, source ast.Expr) (ast.Expr, error) {
+	discovery, found, err := r.findNamedType(context, starType.X)
+	if err != nil {
+		return nil, err
+	}
+	if found && r.visiting[r.typeKey(discovery)] {
+		return ast.NewIdent("nil"), nil
+	}
+	resolvedType, err := r.resolveType(context, starType)
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.RandomValue(context, starType.X, source)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildFuncLitCall(resolvedType,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("value"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("new"),
+					Args: []ast.Expr{
+						resolvedType.(*ast.StarExpr).X,
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.StarExpr{
+					X: ast.NewIdent("value"),
+				},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				value,
+			},
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent("value"),
+			},
+		},
+	), nil
+}
+
+// randomArray creates a slice of random length or an array whose
+// elements are all random.
+func (r *Randomizer) randomArray(context *resolution.LocatorContext, arrayType *ast.ArrayType, source ast.Expr) (ast.Expr, error) {
+	resolvedType, err := r.resolveType(context, arrayType)
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.RandomValue(context, arrayType.Elt, source)
+	if err != nil {
+		return nil, err
+	}
+	var declaration ast.Stmt = &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("values"),
+					},
+					Type: resolvedType,
+				},
+			},
+		},
+	}
+	if arrayType.Len == nil {
+		declaration = &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("values"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("make"),
+					Args: []ast.Expr{
+						resolvedType,
+						r.buildSourceCall(source, "Intn", r.buildIntLit(maxRandomLength)),
+					},
+				},
+			},
+		}
+	}
+	return r.buildFuncLitCall(resolvedType,
+		declaration,
+		&ast.RangeStmt{
+			Key: ast.NewIdent("i"),
+			Tok: token.DEFINE,
+			X:   ast.NewIdent("values"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							&ast.IndexExpr{
+								X:     ast.NewIdent("values"),
+								Index: ast.NewIdent("i"),
+							},
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							value,
+						},
+					},
+				},
+			},
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent("values"),
+			},
+		},
+	), nil
+}
+
+// randomMap creates a map with a random number of random entries.
+func (r *Randomizer) randomMap(context *resolution.LocatorContext, mapType *ast.MapType, source ast.Expr) (ast.Expr, error) {
+	resolvedType, err := r.resolveType(context, mapType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := r.RandomValue(context, mapType.Key, source)
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.RandomValue(context, mapType.Value, source)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildFuncLitCall(resolvedType,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("values"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("make"),
+					Args: []ast.Expr{
+						resolvedType,
+					},
+				},
+			},
+		},
+		&ast.ForStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("i"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					r.buildSourceCall(source, "Intn", r.buildIntLit(maxRandomLength)),
+				},
+			},
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("i"),
+				Op: token.GTR,
+				Y:  r.buildIntLit("0"),
+			},
+			Post: &ast.IncDecStmt{
+				X:   ast.NewIdent("i"),
+				Tok: token.DEC,
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							&ast.IndexExpr{
+								X:     ast.NewIdent("values"),
+								Index: key,
+							},
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							value,
+						},
+					},
+				},
+			},
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent("values"),
+			},
+		},
+	), nil
+}
+
+// buildRandomString creates a random alphanumeric string.
+func (r *Randomizer) buildRandomString(source ast.Expr) ast.Expr {
+	alias := r.model.AddImport("strconv", "strconv")
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent(alias),
+			Sel: ast.NewIdent("FormatUint"),
+		},
+		Args: []ast.Expr{
+			r.buildSourceCall(source, "Uint64"),
+			r.buildIntLit("36"),
+		},
+	}
+}
+
+// buildRandomError creates either a nil error or a synthetic one with
+// a random message.
+func (r *Randomizer) buildRandomError(source ast.Expr) ast.Expr {
+	alias := r.model.AddImport("errors", "errors")
+	return r.buildFuncLitCall(ast.NewIdent("error"),
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  r.buildSourceCall(source, "Intn", r.buildIntLit("2")),
+				Op: token.EQL,
+				Y:  r.buildIntLit("0"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("nil"),
+						},
+					},
+				},
+			},
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent(alias),
+						Sel: ast.NewIdent("New"),
+					},
+					Args: []ast.Expr{
+						&ast.BinaryExpr{
+							X: &ast.BasicLit{
+								Kind:  token.STRING,
+								Value: "\"random error \"",
+							},
+							Op: token.ADD,
+							Y:  r.buildRandomString(source),
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
+func (r *Randomizer) buildSourceCall(source ast.Expr, method string, args ...ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   source,
+			Sel: ast.NewIdent(method),
+		},
+		Args: args,
+	}
+}
+
+func (r *Randomizer) buildConversion(resolvedType ast.Expr, value ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: resolvedType,
+		Args: []ast.Expr{
+			value,
+		},
+	}
+}
+
+func (r *Randomizer) buildIntLit(value string) ast.Expr {
+	return &ast.BasicLit{
+		Kind:  token.INT,
+		Value: value,
+	}
+}
+
+// buildFuncLitCall creates a call to a function literal that returns
+// a value of the specified type. This allows values that require
+// statements to be constructed within a single expression.
+func (r *Randomizer) buildFuncLitCall(resultType ast.Expr, statements ...ast.Stmt) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: resultType,
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: statements,
+			},
+		},
+	}
+}
+
+// resolveType resolves a copy of the specified type, since the
+// declarations of types are shared.
+func (r *Randomizer) resolveType(context *resolution.LocatorContext, astType ast.Expr) (ast.Expr, error) {
+	return r.resolver.ResolveType(context, util.CopyType(astType))
+}
+
+func (r *Randomizer) embeddedFieldName(fieldType ast.Expr) *ast.Ident {
+	switch t := fieldType.(type) {
+	case *ast.StarExpr:
+		return r.embeddedFieldName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	case *ast.Ident:
+		return t
+	}
+	return ast.NewIdent("_")
+}
+
+func (r *Randomizer) typeKey(discovery resolution.TypeDiscovery) string {
+	return discovery.Location + "." + discovery.Spec.Name.String()
+}
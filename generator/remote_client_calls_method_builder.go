@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+func NewRemoteClientCallsMethodBuilder(methodBuilder *MethodBuilder) *RemoteClientCallsMethodBuilder {
+	return &RemoteClientCallsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientCallsMethodBuilder is responsible for creating a method
+// on the remote client that retrieves the arguments of all calls to a
+// method of the remote stub.
+//
+// Example:
+//     func (client *StubStructRemoteClient) SumCalls() ([]StubStructSumArgs, error) {
+//         // ...
+//     }
+type RemoteClientCallsMethodBuilder struct {
+	methodBuilder       *MethodBuilder
+	fetchMethodSelector *ast.SelectorExpr
+	callTypeName        string
+	path                string
+}
+
+// SetFetchMethodSelector configures the method that retrieves values
+// from the remote stub.
+func (b *RemoteClientCallsMethodBuilder) SetFetchMethodSelector(selector *ast.SelectorExpr) {
+	b.fetchMethodSelector = selector
+}
+
+// SetCallTypeName specifies the name of the structure that holds
+// the arguments of a single call.
+func (b *RemoteClientCallsMethodBuilder) SetCallTypeName(name string) {
+	b.callTypeName = name
+}
+
+// SetPath specifies the path of the control API that responds with
+// the calls to the method.
+func (b *RemoteClientCallsMethodBuilder) SetPath(path string) {
+	b.path = path
+}
+
+func (b *RemoteClientCallsMethodBuilder) Build() ast.Decl {
+	callsType := &ast.ArrayType{
+		Elt: ast.NewIdent(b.callTypeName),
+	}
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: callsType,
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("calls"),
+					},
+					Type: callsType,
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.fetchMethodSelector,
+				Args: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf("%q", b.path),
+					},
+					&ast.UnaryExpr{
+						Op: token.AND,
+						X:  ast.NewIdent("calls"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("calls"),
+			ast.NewIdent("err"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
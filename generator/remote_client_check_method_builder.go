@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteClientCheckMethodBuilder(methodBuilder *MethodBuilder) *RemoteClientCheckMethodBuilder {
+	return &RemoteClientCheckMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientCheckMethodBuilder is responsible for creating a method
+// on the remote client that converts unsuccessful responses of the
+// remote stub into errors that include the message of the response.
+//
+// Example:
+//     func (client *StubStructRemoteClient) check(response *http.Response) error {
+//         // ...
+//     }
+type RemoteClientCheckMethodBuilder struct {
+	methodBuilder     *MethodBuilder
+	responseType      *ast.SelectorExpr
+	okStatus          *ast.SelectorExpr
+	readAllSelector   *ast.SelectorExpr
+	errorfSelector    *ast.SelectorExpr
+	trimSpaceSelector *ast.SelectorExpr
+	stubName          string
+}
+
+// SetResponseType configures the http.Response type.
+// The type should have already been resolved.
+func (b *RemoteClientCheckMethodBuilder) SetResponseType(responseType *ast.SelectorExpr) {
+	b.responseType = responseType
+}
+
+// SetOKStatus configures the http.StatusOK constant.
+// The selector should have already been resolved.
+func (b *RemoteClientCheckMethodBuilder) SetOKStatus(selector *ast.SelectorExpr) {
+	b.okStatus = selector
+}
+
+// SetReadAllSelector configures the ioutil.ReadAll function.
+// The selector should have already been resolved.
+func (b *RemoteClientCheckMethodBuilder) SetReadAllSelector(selector *ast.SelectorExpr) {
+	b.readAllSelector = selector
+}
+
+// SetErrorfSelector configures the fmt.Errorf function.
+// The selector should have already been resolved.
+func (b *RemoteClientCheckMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+// SetTrimSpaceSelector configures the strings.TrimSpace function.
+// The selector should have already been resolved.
+func (b *RemoteClientCheckMethodBuilder) SetTrimSpaceSelector(selector *ast.SelectorExpr) {
+	b.trimSpaceSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in error messages.
+func (b *RemoteClientCheckMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *RemoteClientCheckMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("response", &ast.StarExpr{
+					X: b.responseType,
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("response"),
+				Sel: ast.NewIdent("StatusCode"),
+			},
+			Op: token.EQL,
+			Y:  b.okStatus,
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("message"),
+			ast.NewIdent("_"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.readAllSelector,
+				Args: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("response"),
+						Sel: ast.NewIdent("Body"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.errorfSelector,
+				Args: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf("%q", "remote "+b.stubName+" responded with %s: %s"),
+					},
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("response"),
+						Sel: ast.NewIdent("Status"),
+					},
+					&ast.CallExpr{
+						Fun: b.trimSpaceSelector,
+						Args: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("string"),
+								Args: []ast.Expr{
+									ast.NewIdent("message"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteClientConfigureMethodBuilder(methodBuilder *MethodBuilder) *RemoteClientConfigureMethodBuilder {
+	return &RemoteClientConfigureMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientConfigureMethodBuilder is responsible for creating a method
+// on the remote client that posts a scenario to the remote stub.
+//
+// Example:
+//     func (client *StubStructRemoteClient) configure(scenario interface{}) error {
+//         // ...
+//     }
+type RemoteClientConfigureMethodBuilder struct {
+	methodBuilder           *MethodBuilder
+	urlFieldSelector        *ast.SelectorExpr
+	httpClientFieldSelector *ast.SelectorExpr
+	checkMethodSelector     *ast.SelectorExpr
+	marshalSelector         *ast.SelectorExpr
+	newReaderSelector       *ast.SelectorExpr
+	scenarioPath            string
+}
+
+func (b *RemoteClientConfigureMethodBuilder) SetURLFieldSelector(selector *ast.SelectorExpr) {
+	b.urlFieldSelector = selector
+}
+
+func (b *RemoteClientConfigureMethodBuilder) SetHTTPClientFieldSelector(selector *ast.SelectorExpr) {
+	b.httpClientFieldSelector = selector
+}
+
+// SetCheckMethodSelector configures the method that verifies the
+// responses of the remote stub.
+func (b *RemoteClientConfigureMethodBuilder) SetCheckMethodSelector(selector *ast.SelectorExpr) {
+	b.checkMethodSelector = selector
+}
+
+// SetMarshalSelector configures the json.Marshal function.
+// The selector should have already been resolved.
+func (b *RemoteClientConfigureMethodBuilder) SetMarshalSelector(selector *ast.SelectorExpr) {
+	b.marshalSelector = selector
+}
+
+// SetNewReaderSelector configures the bytes.NewReader function.
+// The selector should have already been resolved.
+func (b *RemoteClientConfigureMethodBuilder) SetNewReaderSelector(selector *ast.SelectorExpr) {
+	b.newReaderSelector = selector
+}
+
+// SetScenarioPath specifies the path to which scenarios are posted.
+func (b *RemoteClientConfigureMethodBuilder) SetScenarioPath(path string) {
+	b.scenarioPath = path
+}
+
+func (b *RemoteClientConfigureMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("scenario", util.CreateEmptyInterface()),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("data"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("scenario"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildReturnOnError()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("response"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   b.httpClientFieldSelector,
+					Sel: ast.NewIdent("Post"),
+				},
+				Args: []ast.Expr{
+					&ast.BinaryExpr{
+						X:  b.urlFieldSelector,
+						Op: token.ADD,
+						Y: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: fmt.Sprintf("%q", b.scenarioPath),
+						},
+					},
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: "\"application/json\"",
+					},
+					&ast.CallExpr{
+						Fun: b.newReaderSelector,
+						Args: []ast.Expr{
+							ast.NewIdent("data"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildReturnOnError()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("response"),
+					Sel: ast.NewIdent("Body"),
+				},
+				Sel: ast.NewIdent("Close"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.checkMethodSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("response"),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *RemoteClientConfigureMethodBuilder) buildReturnOnError() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteClientConstructorBuilder(methodBuilder *MethodBuilder) *RemoteClientConstructorBuilder {
+	return &RemoteClientConstructorBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientConstructorBuilder is responsible for creating a function
+// that creates a client for the control API of a remote stub. The
+// default HTTP client is used when no client is specified.
+//
+// Example:
+//     func NewStubStructRemoteClient(url string, httpClient *http.Client) *StubStructRemoteClient {
+//         // ...
+//     }
+type RemoteClientConstructorBuilder struct {
+	methodBuilder         *MethodBuilder
+	clientName            string
+	urlFieldName          string
+	httpClientFieldName   string
+	httpClientType        *ast.SelectorExpr
+	defaultClientSelector *ast.SelectorExpr
+}
+
+// SetClientName specifies the name of the client structure.
+func (b *RemoteClientConstructorBuilder) SetClientName(name string) {
+	b.clientName = name
+}
+
+// SetFieldNames specifies the fields of the client structure that
+// hold the URL of the control API and the HTTP client.
+func (b *RemoteClientConstructorBuilder) SetFieldNames(urlFieldName, httpClientFieldName string) {
+	b.urlFieldName = urlFieldName
+	b.httpClientFieldName = httpClientFieldName
+}
+
+// SetHTTPClientType configures the http.Client type.
+// The type should have already been resolved.
+func (b *RemoteClientConstructorBuilder) SetHTTPClientType(httpClientType *ast.SelectorExpr) {
+	b.httpClientType = httpClientType
+}
+
+// SetDefaultClientSelector configures the http.DefaultClient variable.
+// The selector should have already been resolved.
+func (b *RemoteClientConstructorBuilder) SetDefaultClientSelector(selector *ast.SelectorExpr) {
+	b.defaultClientSelector = selector
+}
+
+func (b *RemoteClientConstructorBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("url", ast.NewIdent("string")),
+				util.CreateField("httpClient", &ast.StarExpr{
+					X: b.httpClientType,
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.clientName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("httpClient"),
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("httpClient"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						b.defaultClientSelector,
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.clientName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(b.urlFieldName),
+							Value: ast.NewIdent("url"),
+						},
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(b.httpClientFieldName),
+							Value: ast.NewIdent("httpClient"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewRemoteClientCountMethodBuilder(methodBuilder *MethodBuilder) *RemoteClientCountMethodBuilder {
+	return &RemoteClientCountMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientCountMethodBuilder is responsible for creating a method
+// on the remote client that retrieves how many times a method of the
+// remote stub was called.
+//
+// Example:
+//     func (client *StubStructRemoteClient) SumCallCount() (int, error) {
+//         // ...
+//     }
+type RemoteClientCountMethodBuilder struct {
+	methodBuilder       *MethodBuilder
+	callsMethodSelector *ast.SelectorExpr
+}
+
+// SetCallsMethodSelector configures the method of the remote client
+// that retrieves the calls to the method.
+func (b *RemoteClientCountMethodBuilder) SetCallsMethodSelector(selector *ast.SelectorExpr) {
+	b.callsMethodSelector = selector
+}
+
+func (b *RemoteClientCountMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("int"),
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("calls"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.callsMethodSelector,
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("len"),
+				Args: []ast.Expr{
+					ast.NewIdent("calls"),
+				},
+			},
+			ast.NewIdent("err"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
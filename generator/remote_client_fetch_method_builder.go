@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteClientFetchMethodBuilder(methodBuilder *MethodBuilder) *RemoteClientFetchMethodBuilder {
+	return &RemoteClientFetchMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientFetchMethodBuilder is responsible for creating a method
+// on the remote client that retrieves a value from the specified path
+// of the control API of the remote stub.
+//
+// Example:
+//     func (client *StubStructRemoteClient) fetch(path string, value interface{}) error {
+//         // ...
+//     }
+type RemoteClientFetchMethodBuilder struct {
+	methodBuilder           *MethodBuilder
+	urlFieldSelector        *ast.SelectorExpr
+	httpClientFieldSelector *ast.SelectorExpr
+	checkMethodSelector     *ast.SelectorExpr
+	newDecoderSelector      *ast.SelectorExpr
+}
+
+func (b *RemoteClientFetchMethodBuilder) SetURLFieldSelector(selector *ast.SelectorExpr) {
+	b.urlFieldSelector = selector
+}
+
+func (b *RemoteClientFetchMethodBuilder) SetHTTPClientFieldSelector(selector *ast.SelectorExpr) {
+	b.httpClientFieldSelector = selector
+}
+
+// SetCheckMethodSelector configures the method that verifies the
+// responses of the remote stub.
+func (b *RemoteClientFetchMethodBuilder) SetCheckMethodSelector(selector *ast.SelectorExpr) {
+	b.checkMethodSelector = selector
+}
+
+// SetNewDecoderSelector configures the json.NewDecoder function.
+// The selector should have already been resolved.
+func (b *RemoteClientFetchMethodBuilder) SetNewDecoderSelector(selector *ast.SelectorExpr) {
+	b.newDecoderSelector = selector
+}
+
+func (b *RemoteClientFetchMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("path", ast.NewIdent("string")),
+				util.CreateField("value", util.CreateEmptyInterface()),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("response"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   b.httpClientFieldSelector,
+					Sel: ast.NewIdent("Get"),
+				},
+				Args: []ast.Expr{
+					&ast.BinaryExpr{
+						X:  b.urlFieldSelector,
+						Op: token.ADD,
+						Y:  ast.NewIdent("path"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildReturnOnError()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("response"),
+					Sel: ast.NewIdent("Body"),
+				},
+				Sel: ast.NewIdent("Close"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.checkMethodSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("response"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildReturnOnError()))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.CallExpr{
+						Fun: b.newDecoderSelector,
+						Args: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("response"),
+								Sel: ast.NewIdent("Body"),
+							},
+						},
+					},
+					Sel: ast.NewIdent("Decode"),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("value"),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *RemoteClientFetchMethodBuilder) buildReturnOnError() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}
+}
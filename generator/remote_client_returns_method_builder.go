@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteClientReturnsMethodBuilder(methodBuilder *MethodBuilder) *RemoteClientReturnsMethodBuilder {
+	return &RemoteClientReturnsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteClientReturnsMethodBuilder is responsible for creating a method
+// on the remote client that configures the results of a method of the
+// remote stub. The results are sent as a scenario, so errors are sent
+// as their messages.
+//
+// Example:
+//     func (client *StubStructRemoteClient) SumReturns(result1 int) error {
+//         // ...
+//     }
+type RemoteClientReturnsMethodBuilder struct {
+	methodBuilder           *MethodBuilder
+	configureMethodSelector *ast.SelectorExpr
+	methodName              string
+	results                 []*ast.Field
+	onCall                  bool
+}
+
+// SetConfigureMethodSelector configures the method that sends
+// scenarios to the remote stub.
+func (b *RemoteClientReturnsMethodBuilder) SetConfigureMethodSelector(selector *ast.SelectorExpr) {
+	b.configureMethodSelector = selector
+}
+
+// SetMethodName specifies the name of the stubbed method.
+func (b *RemoteClientReturnsMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetResults specifies the results that the original method
+// uses. These results need to have been normalized and resolved
+// in advance.
+func (b *RemoteClientReturnsMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+// SetOnCall specifies whether the configured results should apply
+// to a specific call only.
+func (b *RemoteClientReturnsMethodBuilder) SetOnCall(onCall bool) {
+	b.onCall = onCall
+}
+
+func (b *RemoteClientReturnsMethodBuilder) Build() ast.Decl {
+	params := []*ast.Field{}
+	if b.onCall {
+		params = append(params, util.CreateField("index", ast.NewIdent("int")))
+	}
+	params = append(params, b.results...)
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+
+	values := []ast.Expr{}
+	for _, result := range b.results {
+		values = append(values, ast.NewIdent(result.Names[0].String()))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("values"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CompositeLit{
+				Type: &ast.ArrayType{
+					Elt: util.CreateEmptyInterface(),
+				},
+				Elts: values,
+			},
+		},
+	}))
+	for i, result := range b.results {
+		if !isErrorType(result.Type) {
+			continue
+		}
+		resultName := ast.NewIdent(result.Names[0].String())
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  resultName,
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							&ast.IndexExpr{
+								X: ast.NewIdent("values"),
+								Index: &ast.BasicLit{
+									Kind:  token.INT,
+									Value: fmt.Sprintf("%d", i),
+								},
+							},
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   resultName,
+									Sel: ast.NewIdent("Error"),
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+	}
+
+	var entry ast.Expr = b.buildEntry("returns", ast.NewIdent("values"))
+	if b.onCall {
+		entry = b.buildEntry("onCall", &ast.CompositeLit{
+			Type: &ast.MapType{
+				Key:   ast.NewIdent("int"),
+				Value: util.CreateEmptyInterface(),
+			},
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{
+					Key:   ast.NewIdent("index"),
+					Value: ast.NewIdent("values"),
+				},
+			},
+		})
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.configureMethodSelector,
+				Args: []ast.Expr{
+					b.buildScenario(b.buildEntry(b.methodName, b.buildScenario(entry))),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+// buildEntry creates a single entry of a scenario.
+func (b *RemoteClientReturnsMethodBuilder) buildEntry(key string, value ast.Expr) ast.Expr {
+	return &ast.KeyValueExpr{
+		Key: &ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf("%q", key),
+		},
+		Value: value,
+	}
+}
+
+func (b *RemoteClientReturnsMethodBuilder) buildScenario(entry ast.Expr) ast.Expr {
+	return &ast.CompositeLit{
+		Type: &ast.MapType{
+			Key:   ast.NewIdent("string"),
+			Value: util.CreateEmptyInterface(),
+		},
+		Elts: []ast.Expr{
+			entry,
+		},
+	}
+}
@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+const remoteHandlerReceiverName string = "handler"
+const remoteClientReceiverName string = "client"
+const remoteStubFieldName string = "stub"
+const remoteURLFieldName string = "url"
+const remoteHTTPClientFieldName string = "httpClient"
+const remoteRespondMethodName string = "respond"
+const remoteConfigureMethodName string = "configure"
+const remoteFetchMethodName string = "fetch"
+const remoteCheckMethodName string = "check"
+const remoteScenarioPath string = "/scenario"
+const remoteCallsPath string = "/calls/"
+
+// NewRemoteModel creates a model for the companion file that holds
+// the HTTP control handler and client of the specified stub.
+func NewRemoteModel(stubModel *GeneratorModel) *RemoteModel {
+	fileBuilder := NewFileBuilder()
+	fileBuilder.SetPackage(stubModel.fileBuilder.filePackageName)
+
+	model := &RemoteModel{
+		fileBuilder: fileBuilder,
+		stubModel:   stubModel,
+		stubName:    stubModel.structName,
+	}
+	// Calls and results are exchanged with the client as JSON.
+	stubModel.serializesCalls = true
+	model.createHandlerStruct()
+	model.createHandlerAssignment()
+	model.createHandlerConstructor()
+	model.createServeMethod()
+	model.createRespondMethod()
+	model.createClientStruct()
+	model.createClientConstructor()
+	model.createConfigureMethod()
+	model.createFetchMethod()
+	model.createCheckMethod()
+	return model
+}
+
+type RemoteModel struct {
+	fileBuilder  *FileBuilder
+	stubModel    *GeneratorModel
+	stubName     string
+	serveBuilder *RemoteServeMethodBuilder
+}
+
+func (t *RemoteModel) AddMethod(config *MethodConfig) error {
+	t.serveBuilder.AddCallsRoute(remoteCallsPath+config.MethodName, config.CallsMethodName())
+	if config.HasResults() {
+		t.createClientReturnsMethod(config, config.ReturnsMethodName(), false)
+		t.createClientReturnsMethod(config, config.ReturnsOnCallMethodName(), true)
+	}
+	t.createClientCallsMethod(config)
+	t.createClientCountMethod(config)
+	return nil
+}
+
+func (t *RemoteModel) createHandlerStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.handlerTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(remoteStubFieldName, &ast.StarExpr{
+		X: ast.NewIdent(t.stubName),
+	})))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createHandlerAssignment() {
+	builder := NewStubToInterfaceStatementBuilder()
+	builder.SetStubName(t.handlerTypeName())
+	builder.SetInterfaceSelector(t.resolveHTTPSelector("Handler"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createHandlerConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.handlerTypeName())
+	builder := NewWrapperConstructorBuilder(methodBuilder)
+	builder.SetWrapperName(t.handlerTypeName())
+	builder.SetTargetFieldName(remoteStubFieldName)
+	builder.SetTargetType(&ast.StarExpr{
+		X: ast.NewIdent(t.stubName),
+	})
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createServeMethod() {
+	builder := NewRemoteServeMethodBuilder(t.createHandlerMethodBuilder("ServeHTTP"))
+	builder.SetStubFieldSelector(t.handlerFieldSelector(remoteStubFieldName))
+	builder.SetRespondMethodSelector(t.handlerFieldSelector(remoteRespondMethodName))
+	builder.SetResponseWriterType(t.resolveHTTPSelector("ResponseWriter"))
+	builder.SetRequestType(t.resolveHTTPSelector("Request"))
+	builder.SetErrorSelector(t.resolveHTTPSelector("Error"))
+	builder.SetNotFoundSelector(t.resolveHTTPSelector("NotFound"))
+	builder.SetStatusSelectors(t.resolveHTTPSelector("StatusMethodNotAllowed"), t.resolveHTTPSelector("StatusBadRequest"))
+	builder.SetScenarioPath(remoteScenarioPath)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+	t.serveBuilder = builder
+}
+
+func (t *RemoteModel) createRespondMethod() {
+	builder := NewRemoteRespondMethodBuilder(t.createHandlerMethodBuilder(remoteRespondMethodName))
+	builder.SetResponseWriterType(t.resolveHTTPSelector("ResponseWriter"))
+	builder.SetMarshalSelector(t.resolveSelector("json", "encoding/json", "Marshal"))
+	builder.SetErrorSelector(t.resolveHTTPSelector("Error"))
+	builder.SetInternalErrorStatus(t.resolveHTTPSelector("StatusInternalServerError"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createClientStruct() {
+	builder := NewStructBuilder()
+	builder.SetName(t.clientTypeName())
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(remoteURLFieldName, ast.NewIdent("string"))))
+	builder.AddFieldBuilder(FieldToBuilder(util.CreateField(remoteHTTPClientFieldName, &ast.StarExpr{
+		X: t.resolveHTTPSelector("Client"),
+	})))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createClientConstructor() {
+	methodBuilder := NewMethodBuilder()
+	methodBuilder.SetName("New" + t.clientTypeName())
+	builder := NewRemoteClientConstructorBuilder(methodBuilder)
+	builder.SetClientName(t.clientTypeName())
+	builder.SetFieldNames(remoteURLFieldName, remoteHTTPClientFieldName)
+	builder.SetHTTPClientType(t.resolveHTTPSelector("Client"))
+	builder.SetDefaultClientSelector(t.resolveHTTPSelector("DefaultClient"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createConfigureMethod() {
+	builder := NewRemoteClientConfigureMethodBuilder(t.createClientMethodBuilder(remoteConfigureMethodName))
+	builder.SetURLFieldSelector(t.clientFieldSelector(remoteURLFieldName))
+	builder.SetHTTPClientFieldSelector(t.clientFieldSelector(remoteHTTPClientFieldName))
+	builder.SetCheckMethodSelector(t.clientFieldSelector(remoteCheckMethodName))
+	builder.SetMarshalSelector(t.resolveSelector("json", "encoding/json", "Marshal"))
+	builder.SetNewReaderSelector(t.resolveSelector("bytes", "bytes", "NewReader"))
+	builder.SetScenarioPath(remoteScenarioPath)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createFetchMethod() {
+	builder := NewRemoteClientFetchMethodBuilder(t.createClientMethodBuilder(remoteFetchMethodName))
+	builder.SetURLFieldSelector(t.clientFieldSelector(remoteURLFieldName))
+	builder.SetHTTPClientFieldSelector(t.clientFieldSelector(remoteHTTPClientFieldName))
+	builder.SetCheckMethodSelector(t.clientFieldSelector(remoteCheckMethodName))
+	builder.SetNewDecoderSelector(t.resolveSelector("json", "encoding/json", "NewDecoder"))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createCheckMethod() {
+	builder := NewRemoteClientCheckMethodBuilder(t.createClientMethodBuilder(remoteCheckMethodName))
+	builder.SetResponseType(t.resolveHTTPSelector("Response"))
+	builder.SetOKStatus(t.resolveHTTPSelector("StatusOK"))
+	builder.SetReadAllSelector(t.resolveSelector("ioutil", "io/ioutil", "ReadAll"))
+	builder.SetErrorfSelector(t.resolveSelector("fmt", "fmt", "Errorf"))
+	builder.SetTrimSpaceSelector(t.resolveSelector("strings", "strings", "TrimSpace"))
+	builder.SetStubName(t.stubName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createClientReturnsMethod(config *MethodConfig, name string, onCall bool) {
+	builder := NewRemoteClientReturnsMethodBuilder(t.createClientMethodBuilder(name))
+	builder.SetConfigureMethodSelector(t.clientFieldSelector(remoteConfigureMethodName))
+	builder.SetMethodName(config.MethodName)
+	builder.SetResults(config.MethodResults)
+	builder.SetOnCall(onCall)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createClientCallsMethod(config *MethodConfig) {
+	builder := NewRemoteClientCallsMethodBuilder(t.createClientMethodBuilder(config.CallsMethodName()))
+	builder.SetFetchMethodSelector(t.clientFieldSelector(remoteFetchMethodName))
+	builder.SetCallTypeName(t.stubModel.argsTypeName(config))
+	builder.SetPath(remoteCallsPath + config.MethodName)
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) createClientCountMethod(config *MethodConfig) {
+	builder := NewRemoteClientCountMethodBuilder(t.createClientMethodBuilder(config.CallCountMethodName()))
+	builder.SetCallsMethodSelector(t.clientFieldSelector(config.CallsMethodName()))
+	t.fileBuilder.AddDeclarationBuilder(builder)
+}
+
+func (t *RemoteModel) handlerTypeName() string {
+	return t.stubName + "RemoteHandler"
+}
+
+func (t *RemoteModel) clientTypeName() string {
+	return t.stubName + "RemoteClient"
+}
+
+func (t *RemoteModel) createHandlerMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(remoteHandlerReceiverName, t.handlerTypeName())
+	return builder
+}
+
+func (t *RemoteModel) createClientMethodBuilder(name string) *MethodBuilder {
+	builder := NewMethodBuilder()
+	builder.SetName(name)
+	builder.SetReceiver(remoteClientReceiverName, t.clientTypeName())
+	return builder
+}
+
+func (t *RemoteModel) handlerFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(remoteHandlerReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *RemoteModel) clientFieldSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(remoteClientReceiverName),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+func (t *RemoteModel) resolveHTTPSelector(name string) *ast.SelectorExpr {
+	return t.resolveSelector("http", "net/http", name)
+}
+
+func (t *RemoteModel) resolveSelector(pkgName, location, name string) *ast.SelectorExpr {
+	alias := t.fileBuilder.AddImport(pkgName, location)
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(alias),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+// Save saves the remote companion file. All imports of the stub file
+// are registered first, since the types of the method results have
+// been resolved against the stub's namespace.
+func (t *RemoteModel) Save(filePath string) error {
+	t.fileBuilder.AddImportsFrom(t.stubModel.fileBuilder)
+	return saveFile(t.fileBuilder, filePath)
+}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteRespondMethodBuilder(methodBuilder *MethodBuilder) *RemoteRespondMethodBuilder {
+	return &RemoteRespondMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// RemoteRespondMethodBuilder is responsible for creating a method on the
+// remote handler that writes the specified value as a JSON response.
+// Values that cannot be serialized result in an internal server error.
+//
+// Example:
+//     func (handler *StubStructRemoteHandler) respond(w http.ResponseWriter, value interface{}) {
+//         // ...
+//     }
+type RemoteRespondMethodBuilder struct {
+	methodBuilder       *MethodBuilder
+	responseWriterType  *ast.SelectorExpr
+	marshalSelector     *ast.SelectorExpr
+	errorSelector       *ast.SelectorExpr
+	internalErrorStatus *ast.SelectorExpr
+}
+
+// SetResponseWriterType configures the http.ResponseWriter type.
+// The type should have already been resolved.
+func (b *RemoteRespondMethodBuilder) SetResponseWriterType(responseWriterType *ast.SelectorExpr) {
+	b.responseWriterType = responseWriterType
+}
+
+// SetMarshalSelector configures the json.Marshal function.
+// The selector should have already been resolved.
+func (b *RemoteRespondMethodBuilder) SetMarshalSelector(selector *ast.SelectorExpr) {
+	b.marshalSelector = selector
+}
+
+// SetErrorSelector configures the http.Error function.
+// The selector should have already been resolved.
+func (b *RemoteRespondMethodBuilder) SetErrorSelector(selector *ast.SelectorExpr) {
+	b.errorSelector = selector
+}
+
+// SetInternalErrorStatus configures the http.StatusInternalServerError
+// constant. The selector should have already been resolved.
+func (b *RemoteRespondMethodBuilder) SetInternalErrorStatus(selector *ast.SelectorExpr) {
+	b.internalErrorStatus = selector
+}
+
+func (b *RemoteRespondMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("w", b.responseWriterType),
+				util.CreateField("value", util.CreateEmptyInterface()),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("data"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.marshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("value"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: b.errorSelector,
+						Args: []ast.Expr{
+							ast.NewIdent("w"),
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("err"),
+									Sel: ast.NewIdent("Error"),
+								},
+							},
+							b.internalErrorStatus,
+						},
+					},
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("w"),
+						Sel: ast.NewIdent("Header"),
+					},
+				},
+				Sel: ast.NewIdent("Set"),
+			},
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: "\"Content-Type\"",
+				},
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: "\"application/json\"",
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("w"),
+				Sel: ast.NewIdent("Write"),
+			},
+			Args: []ast.Expr{
+				ast.NewIdent("data"),
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,253 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewRemoteServeMethodBuilder(methodBuilder *MethodBuilder) *RemoteServeMethodBuilder {
+	return &RemoteServeMethodBuilder{
+		methodBuilder: methodBuilder,
+		callsRoutes:   make([]remoteCallsRoute, 0),
+	}
+}
+
+// RemoteServeMethodBuilder is responsible for creating a method on the
+// remote handler that serves the control API of a stub over HTTP.
+// Scenarios that are posted to the handler are loaded into the stub and
+// the calls to each method of the stub can be retrieved as JSON.
+//
+// Example:
+//     func (handler *StubStructRemoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//         // ...
+//     }
+type RemoteServeMethodBuilder struct {
+	methodBuilder          *MethodBuilder
+	stubFieldSelector      *ast.SelectorExpr
+	respondMethodSelector  *ast.SelectorExpr
+	responseWriterType     *ast.SelectorExpr
+	requestType            *ast.SelectorExpr
+	errorSelector          *ast.SelectorExpr
+	notFoundSelector       *ast.SelectorExpr
+	methodNotAllowedStatus *ast.SelectorExpr
+	badRequestStatus       *ast.SelectorExpr
+	scenarioPath           string
+	callsRoutes            []remoteCallsRoute
+}
+
+func (b *RemoteServeMethodBuilder) SetStubFieldSelector(selector *ast.SelectorExpr) {
+	b.stubFieldSelector = selector
+}
+
+// SetRespondMethodSelector configures the method that writes values
+// as JSON responses.
+func (b *RemoteServeMethodBuilder) SetRespondMethodSelector(selector *ast.SelectorExpr) {
+	b.respondMethodSelector = selector
+}
+
+// SetResponseWriterType configures the http.ResponseWriter type.
+// The type should have already been resolved.
+func (b *RemoteServeMethodBuilder) SetResponseWriterType(responseWriterType *ast.SelectorExpr) {
+	b.responseWriterType = responseWriterType
+}
+
+// SetRequestType configures the http.Request type.
+// The type should have already been resolved.
+func (b *RemoteServeMethodBuilder) SetRequestType(requestType *ast.SelectorExpr) {
+	b.requestType = requestType
+}
+
+// SetErrorSelector configures the http.Error function.
+// The selector should have already been resolved.
+func (b *RemoteServeMethodBuilder) SetErrorSelector(selector *ast.SelectorExpr) {
+	b.errorSelector = selector
+}
+
+// SetNotFoundSelector configures the http.NotFound function.
+// The selector should have already been resolved.
+func (b *RemoteServeMethodBuilder) SetNotFoundSelector(selector *ast.SelectorExpr) {
+	b.notFoundSelector = selector
+}
+
+// SetStatusSelectors configures the http.StatusMethodNotAllowed and
+// http.StatusBadRequest constants.
+// The selectors should have already been resolved.
+func (b *RemoteServeMethodBuilder) SetStatusSelectors(methodNotAllowed, badRequest *ast.SelectorExpr) {
+	b.methodNotAllowedStatus = methodNotAllowed
+	b.badRequestStatus = badRequest
+}
+
+// SetScenarioPath specifies the path to which scenarios are posted.
+func (b *RemoteServeMethodBuilder) SetScenarioPath(path string) {
+	b.scenarioPath = path
+}
+
+// AddCallsRoute configures the specified path to respond with the
+// result of the specified calls method of the stub.
+func (b *RemoteServeMethodBuilder) AddCallsRoute(path, callsMethodName string) {
+	b.callsRoutes = append(b.callsRoutes, remoteCallsRoute{
+		path:            path,
+		callsMethodName: callsMethodName,
+	})
+}
+
+func (b *RemoteServeMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("w", b.responseWriterType),
+				util.CreateField("r", &ast.StarExpr{
+					X: b.requestType,
+				}),
+			},
+		},
+	})
+
+	clauses := []ast.Stmt{
+		&ast.CaseClause{
+			List: []ast.Expr{
+				b.buildStringLit(b.scenarioPath),
+			},
+			Body: b.buildLoadScenarioCode(),
+		},
+	}
+	for _, route := range b.callsRoutes {
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{
+				b.buildStringLit(route.path),
+			},
+			Body: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: b.respondMethodSelector,
+						Args: []ast.Expr{
+							ast.NewIdent("w"),
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   b.stubFieldSelector,
+									Sel: ast.NewIdent(route.callsMethodName),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	clauses = append(clauses, &ast.CaseClause{
+		Body: []ast.Stmt{
+			&ast.ExprStmt{
+				X: &ast.CallExpr{
+					Fun: b.notFoundSelector,
+					Args: []ast.Expr{
+						ast.NewIdent("w"),
+						ast.NewIdent("r"),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.SwitchStmt{
+		Tag: &ast.SelectorExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("r"),
+				Sel: ast.NewIdent("URL"),
+			},
+			Sel: ast.NewIdent("Path"),
+		},
+		Body: &ast.BlockStmt{
+			List: clauses,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+// buildLoadScenarioCode creates the code that loads a posted scenario
+// into the stub. Scenarios that cannot be loaded are rejected.
+func (b *RemoteServeMethodBuilder) buildLoadScenarioCode() []ast.Stmt {
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("r"),
+					Sel: ast.NewIdent("Method"),
+				},
+				Op: token.NEQ,
+				Y:  b.buildStringLit("POST"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					b.buildErrorCode(b.buildStringLit("method not allowed"), b.methodNotAllowedStatus),
+					&ast.ReturnStmt{},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   b.stubFieldSelector,
+							Sel: ast.NewIdent(loadScenarioMethodName),
+						},
+						Args: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("r"),
+								Sel: ast.NewIdent("Body"),
+							},
+						},
+					},
+				},
+			},
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					b.buildErrorCode(&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("err"),
+							Sel: ast.NewIdent("Error"),
+						},
+					}, b.badRequestStatus),
+				},
+			},
+		},
+	}
+}
+
+func (b *RemoteServeMethodBuilder) buildErrorCode(message, status ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: b.errorSelector,
+			Args: []ast.Expr{
+				ast.NewIdent("w"),
+				message,
+				status,
+			},
+		},
+	}
+}
+
+func (b *RemoteServeMethodBuilder) buildStringLit(value string) ast.Expr {
+	return &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: fmt.Sprintf("%q", value),
+	}
+}
+
+// remoteCallsRoute describes a path of the control API that responds
+// with the calls to a method of the stub.
+type remoteCallsRoute struct {
+	path            string
+	callsMethodName string
+}
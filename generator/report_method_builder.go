@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewReportMethodBuilder(methodBuilder *MethodBuilder) *ReportMethodBuilder {
+	return &ReportMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ReportMethodBuilder is responsible for creating a method on the stub
+// structure that is used by stub methods to report that they were
+// called without being configured. The report is only made when the
+// stub is in strict mode. If no reporter is attached, the method panics.
+//
+// Example:
+//     func (stub *StubStruct) reportUnconfiguredCall(method string, args ...interface{}) {
+//         // ...
+//     }
+type ReportMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	mutexFieldSelector    *ast.SelectorExpr
+	strictFieldSelector   *ast.SelectorExpr
+	reporterFieldSelector *ast.SelectorExpr
+	sprintfSelector       *ast.SelectorExpr
+	joinSelector          *ast.SelectorExpr
+	stubName              string
+}
+
+func (b *ReportMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *ReportMethodBuilder) SetStrictFieldSelector(selector *ast.SelectorExpr) {
+	b.strictFieldSelector = selector
+}
+
+func (b *ReportMethodBuilder) SetReporterFieldSelector(selector *ast.SelectorExpr) {
+	b.reporterFieldSelector = selector
+}
+
+// SetSprintfSelector configures the function that is used to format
+// the report message. The selector should have already been resolved.
+func (b *ReportMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+// SetJoinSelector configures the function that is used to join the
+// formatted arguments. The selector should have already been resolved.
+func (b *ReportMethodBuilder) SetJoinSelector(selector *ast.SelectorExpr) {
+	b.joinSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// included in the report message.
+func (b *ReportMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *ReportMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("method", ast.NewIdent("string")),
+				util.CreateField("args", &ast.Ellipsis{
+					Elt: util.CreateEmptyInterface(),
+				}),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("strict"),
+			ast.NewIdent("reporter"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.strictFieldSelector,
+			b.reporterFieldSelector,
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.UnaryExpr{
+			Op: token.NOT,
+			X:  ast.NewIdent("strict"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("formattedArgs"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("make"),
+				Args: []ast.Expr{
+					&ast.ArrayType{
+						Elt: ast.NewIdent("string"),
+					},
+					&ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							ast.NewIdent("args"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("i"),
+		Value: ast.NewIdent("arg"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("args"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.IndexExpr{
+							X:     ast.NewIdent("formattedArgs"),
+							Index: ast.NewIdent("i"),
+						},
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: b.sprintfSelector,
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: "\"%#v\"",
+								},
+								ast.NewIdent("arg"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("message"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.sprintfSelector,
+				Args: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf("\"unconfigured call to %s.%%s(%%s)\"", b.stubName),
+					},
+					ast.NewIdent("method"),
+					&ast.CallExpr{
+						Fun: b.joinSelector,
+						Args: []ast.Expr{
+							ast.NewIdent("formattedArgs"),
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: "\", \"",
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("reporter"),
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("panic"),
+						Args: []ast.Expr{
+							ast.NewIdent("message"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("reporter"),
+				Sel: ast.NewIdent("Helper"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("reporter"),
+				Sel: ast.NewIdent("Errorf"),
+			},
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: "\"%s\"",
+				},
+				ast.NewIdent("message"),
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
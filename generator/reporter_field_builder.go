@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewReporterFieldBuilder() *ReporterFieldBuilder {
+	return &ReporterFieldBuilder{}
+}
+
+// The ReporterFieldBuilder is responsible for creating the field
+// which holds the test reporter (e.g. *testing.T) that strict stubs
+// use to report unconfigured calls.
+//
+// Example:
+//     type StubStruct struct {
+//         // ...
+//         strictReporter interface {
+//             Helper()
+//             Errorf(format string, args ...interface{})
+//         }
+//         // ...
+//     }
+type ReporterFieldBuilder struct {
+	fieldName    string
+	reporterType ast.Expr
+}
+
+func (b *ReporterFieldBuilder) SetFieldName(name string) {
+	b.fieldName = name
+}
+
+// SetReporterType configures the type of the reporter.
+// The type should have already been resolved.
+func (b *ReporterFieldBuilder) SetReporterType(reporterType ast.Expr) {
+	b.reporterType = reporterType
+}
+
+func (b *ReporterFieldBuilder) Build() *ast.Field {
+	return util.CreateField(b.fieldName, b.reporterType)
+}
@@ -50,7 +50,7 @@ func (r *Resolver) ResolveType(context *resolution.LocatorContext, astType ast.E
 }
 
 func (r *Resolver) resolveIdent(context *resolution.LocatorContext, ident *ast.Ident) (ast.Expr, error) {
-	if r.isBuiltIn(ident.String()) {
+	if isBuiltIn(ident.String()) {
 		return ident, nil
 	}
 	discovery, err := r.locator.FindIdentType(context, ident)
@@ -154,7 +154,7 @@ func (r *Resolver) resolveEllipsisType(context *resolution.LocatorContext, astTy
 
 // isBuiltIn should return whether a type, specified by its name,
 // is native to the language or not.
-func (r *Resolver) isBuiltIn(name string) bool {
+func isBuiltIn(name string) bool {
 	switch name {
 	case "bool":
 		return true
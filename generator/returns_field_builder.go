@@ -24,8 +24,8 @@ func NewReturnsFieldBuilder() *ReturnsFieldBuilder {
 //         // ...
 //     }
 type ReturnsFieldBuilder struct {
-	fieldName string
-	results   []*ast.Field
+	fieldName       string
+	resultsTypeName string
 }
 
 func (b *ReturnsFieldBuilder) SetFieldName(name string) {
@@ -34,14 +34,12 @@ func (b *ReturnsFieldBuilder) SetFieldName(name string) {
 
 // SetResults configures the results that the original method has.
 // The results should have been normalized and resolved beforehand.
-func (b *ReturnsFieldBuilder) SetResults(results []*ast.Field) {
-	b.results = results
+// SetResultsTypeName configures the name of the type that holds
+// the results of a single call.
+func (b *ReturnsFieldBuilder) SetResultsTypeName(name string) {
+	b.resultsTypeName = name
 }
 
 func (b *ReturnsFieldBuilder) Build() *ast.Field {
-	return util.CreateField(b.fieldName, &ast.StructType{
-		Fields: &ast.FieldList{
-			List: b.results,
-		},
-	})
+	return util.CreateField(b.fieldName, ast.NewIdent(b.resultsTypeName))
 }
@@ -3,6 +3,8 @@ package generator
 import (
 	"go/ast"
 	"go/token"
+
+	"github.com/mokiat/gostub/util"
 )
 
 func NewReturnsMethodBuilder(methodBuilder *MethodBuilder) *ReturnsMethodBuilder {
@@ -14,27 +16,49 @@ func NewReturnsMethodBuilder(methodBuilder *MethodBuilder) *ReturnsMethodBuilder
 
 // ReturnsMethodBuilder is responsible for creating a method on the stub
 // structure that allows you to specify the results to be returned by
-// default when the stub method is called.
+// default when the stub method is called. If configured as on-call, the
+// method accepts the index of the call to which the results apply.
 //
 // Example:
 //     func (stub *StubStruct) AddressReturns(name string, number int) {
 //         // ...
 //     }
+//
+//     func (stub *StubStruct) AddressReturnsOnCall(index int, name string, number int) {
+//         // ...
+//     }
 type ReturnsMethodBuilder struct {
 	methodBuilder        *MethodBuilder
 	mutexFieldSelector   *ast.SelectorExpr
 	returnsFieldSelector *ast.SelectorExpr
+	configuredSelector   *ast.SelectorExpr
+	resultsTypeName      string
 	results              []*ast.Field
+	onCall               bool
 }
 
 func (b *ReturnsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
 	b.mutexFieldSelector = selector
 }
 
+// SetReturnsFieldSelector configures the field that holds the results,
+// or the results by call index if configured as on-call.
 func (b *ReturnsMethodBuilder) SetReturnsFieldSelector(selector *ast.SelectorExpr) {
 	b.returnsFieldSelector = selector
 }
 
+// SetConfiguredFieldSelector configures an optional field that
+// should be marked once results have been specified.
+func (b *ReturnsMethodBuilder) SetConfiguredFieldSelector(selector *ast.SelectorExpr) {
+	b.configuredSelector = selector
+}
+
+// SetResultsTypeName configures the name of the type that holds
+// the results of a single call.
+func (b *ReturnsMethodBuilder) SetResultsTypeName(name string) {
+	b.resultsTypeName = name
+}
+
 // SetResults specifies the results that the original method
 // uses. These results need to have been normalized and resolved
 // in advance.
@@ -42,6 +66,12 @@ func (b *ReturnsMethodBuilder) SetResults(results []*ast.Field) {
 	b.results = results
 }
 
+// SetOnCall specifies whether the configured results should apply
+// to a specific call only.
+func (b *ReturnsMethodBuilder) SetOnCall(onCall bool) {
+	b.onCall = onCall
+}
+
 func (b *ReturnsMethodBuilder) Build() ast.Decl {
 	mutexLockBuilder := NewMutexActionBuilder()
 	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
@@ -52,33 +82,81 @@ func (b *ReturnsMethodBuilder) Build() ast.Decl {
 	mutexUnlockBuilder.SetAction("Unlock")
 	mutexUnlockBuilder.SetDeferred(true)
 
+	params := []*ast.Field{}
+	if b.onCall {
+		params = append(params, util.CreateField("index", ast.NewIdent("int")))
+	}
+	params = append(params, b.results...)
 	b.methodBuilder.SetType(&ast.FuncType{
 		Params: &ast.FieldList{
-			List: b.results,
+			List: params,
 		},
 	})
 	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
 	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
 
+	var returns ast.Expr = b.returnsFieldSelector
+	if b.onCall {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  b.returnsFieldSelector,
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							b.returnsFieldSelector,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("make"),
+								Args: []ast.Expr{
+									&ast.MapType{
+										Key:   ast.NewIdent("int"),
+										Value: ast.NewIdent(b.resultsTypeName),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+		returns = &ast.IndexExpr{
+			X:     b.returnsFieldSelector,
+			Index: ast.NewIdent("index"),
+		}
+	}
+
 	resultSelectors := []ast.Expr{}
 	for _, result := range b.results {
 		resultSelectors = append(resultSelectors, ast.NewIdent(result.Names[0].String()))
 	}
 	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
 		Lhs: []ast.Expr{
-			b.returnsFieldSelector,
+			returns,
 		},
 		Tok: token.ASSIGN,
 		Rhs: []ast.Expr{
 			&ast.CompositeLit{
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: b.results,
-					},
-				},
+				Type: ast.NewIdent(b.resultsTypeName),
 				Elts: resultSelectors,
 			},
 		},
 	}))
+	if b.configuredSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				b.configuredSelector,
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				ast.NewIdent("true"),
+			},
+		}))
+	}
 	return b.methodBuilder.Build()
 }
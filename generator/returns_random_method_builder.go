@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewReturnsRandomMethodBuilder(methodBuilder *MethodBuilder) *ReturnsRandomMethodBuilder {
+	return &ReturnsRandomMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// ReturnsRandomMethodBuilder is responsible for creating a method on the
+// stub structure that configures random results for the stubbed method.
+// The values are drawn from the specified source, so the same source
+// state always produces the same results.
+//
+// Example:
+//     func (stub *StubStruct) SumReturnsRandom(r *rand.Rand) {
+//         // ...
+//     }
+type ReturnsRandomMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	returnsMethodSelector *ast.SelectorExpr
+	randType              *ast.SelectorExpr
+	randomResults         []ast.Expr
+}
+
+// SetReturnsMethodSelector configures the method that is used to
+// configure the results.
+func (b *ReturnsRandomMethodBuilder) SetReturnsMethodSelector(selector *ast.SelectorExpr) {
+	b.returnsMethodSelector = selector
+}
+
+// SetRandType configures the rand.Rand type.
+// The type should have already been resolved.
+func (b *ReturnsRandomMethodBuilder) SetRandType(randType *ast.SelectorExpr) {
+	b.randType = randType
+}
+
+// SetRandomResults specifies the expressions that create a random
+// value for each of the results of the stubbed method.
+func (b *ReturnsRandomMethodBuilder) SetRandomResults(results []ast.Expr) {
+	b.randomResults = results
+}
+
+func (b *ReturnsRandomMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField(randomSourceParamName, &ast.StarExpr{
+					X: b.randType,
+				}),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun:  b.returnsMethodSelector,
+			Args: b.randomResults,
+		},
+	}))
+	return b.methodBuilder.Build()
+}
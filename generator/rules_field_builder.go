@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"go/ast"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewMethodRulesFieldBuilder() *MethodRulesFieldBuilder {
+	return &MethodRulesFieldBuilder{}
+}
+
+// The MethodRulesFieldBuilder is responsible for creating the field
+// which is internally used to track the conditional returns that the
+// end-user has registered for a given method.
+//
+// Example:
+//     type StubStruct struct {
+//         // ...
+//         sumRules []*StubStructSumRule
+//         // ...
+//     }
+type MethodRulesFieldBuilder struct {
+	fieldName    string
+	ruleTypeName string
+}
+
+func (b *MethodRulesFieldBuilder) SetFieldName(name string) {
+	b.fieldName = name
+}
+
+// SetRuleTypeName configures the name of the type that is used
+// to represent a single conditional return.
+func (b *MethodRulesFieldBuilder) SetRuleTypeName(name string) {
+	b.ruleTypeName = name
+}
+
+func (b *MethodRulesFieldBuilder) Build() *ast.Field {
+	return util.CreateField(b.fieldName, &ast.ArrayType{
+		Elt: &ast.StarExpr{
+			X: ast.NewIdent(b.ruleTypeName),
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewSetArgMethodBuilder(methodBuilder *MethodBuilder) *SetArgMethodBuilder {
+	return &SetArgMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// SetArgMethodBuilder is responsible for creating a method on the stub
+// structure that is used to assign configured values into empty
+// interface arguments, whose actual type is only known at call time.
+// The method panics if the argument is not a non-nil pointer.
+//
+// Example:
+//     func (stub *StubStruct) setArg(method string, position int, arg interface{}, value interface{}) {
+//         // ...
+//     }
+type SetArgMethodBuilder struct {
+	methodBuilder   *MethodBuilder
+	valueOfSelector *ast.SelectorExpr
+	zeroSelector    *ast.SelectorExpr
+	ptrSelector     *ast.SelectorExpr
+	sprintfSelector *ast.SelectorExpr
+	stubName        string
+}
+
+// SetValueOfSelector configures the reflect.ValueOf function.
+// The selector should have already been resolved.
+func (b *SetArgMethodBuilder) SetValueOfSelector(selector *ast.SelectorExpr) {
+	b.valueOfSelector = selector
+}
+
+// SetZeroSelector configures the reflect.Zero function.
+// The selector should have already been resolved.
+func (b *SetArgMethodBuilder) SetZeroSelector(selector *ast.SelectorExpr) {
+	b.zeroSelector = selector
+}
+
+// SetPtrSelector configures the reflect.Ptr kind.
+// The selector should have already been resolved.
+func (b *SetArgMethodBuilder) SetPtrSelector(selector *ast.SelectorExpr) {
+	b.ptrSelector = selector
+}
+
+// SetSprintfSelector configures the function that is used to format
+// the panic message. The selector should have already been resolved.
+func (b *SetArgMethodBuilder) SetSprintfSelector(selector *ast.SelectorExpr) {
+	b.sprintfSelector = selector
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// used in panic messages.
+func (b *SetArgMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *SetArgMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("method", ast.NewIdent("string")),
+				util.CreateField("position", ast.NewIdent("int")),
+				util.CreateField("arg", util.CreateEmptyInterface()),
+				util.CreateField("value", util.CreateEmptyInterface()),
+			},
+		},
+	})
+	for _, stmt := range b.buildSetArgCode() {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(stmt))
+	}
+	return b.methodBuilder.Build()
+}
+
+// buildSetArgCode creates the code that assigns the value into
+// the pointer that is held by the argument.
+func (b *SetArgMethodBuilder) buildSetArgCode() []ast.Stmt {
+	target := ast.NewIdent("target")
+	targetElem := b.callOn(target, "Elem")
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				target,
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: b.valueOfSelector,
+					Args: []ast.Expr{
+						ast.NewIdent("arg"),
+					},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X: &ast.BinaryExpr{
+					X:  b.callOn(target, "Kind"),
+					Op: token.NEQ,
+					Y:  b.ptrSelector,
+				},
+				Op: token.LOR,
+				Y:  b.callOn(target, "IsNil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					b.buildPanicCode("not a non-nil pointer"),
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("value"),
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: b.callOn(targetElem, "Set", &ast.CallExpr{
+							Fun: b.zeroSelector,
+							Args: []ast.Expr{
+								b.callOn(targetElem, "Type"),
+							},
+						}),
+					},
+				},
+			},
+			Else: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: b.callOn(targetElem, "Set", &ast.CallExpr{
+							Fun: b.valueOfSelector,
+							Args: []ast.Expr{
+								ast.NewIdent("value"),
+							},
+						}),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *SetArgMethodBuilder) buildPanicCode(reason string) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent("panic"),
+			Args: []ast.Expr{
+				&ast.CallExpr{
+					Fun: b.sprintfSelector,
+					Args: []ast.Expr{
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: fmt.Sprintf("\"cannot set argument %%d of %s.%%s: %s\"", b.stubName, reason),
+						},
+						ast.NewIdent("position"),
+						ast.NewIdent("method"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *SetArgMethodBuilder) callOn(receiver ast.Expr, method string, args ...ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   receiver,
+			Sel: ast.NewIdent(method),
+		},
+		Args: args,
+	}
+}
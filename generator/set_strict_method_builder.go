@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewSetStrictMethodBuilder(methodBuilder *MethodBuilder) *SetStrictMethodBuilder {
+	return &SetStrictMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// SetStrictMethodBuilder is responsible for creating a method on the
+// stub structure that switches the stub to strict mode, where calls
+// to unconfigured methods are reported. Composed stubs switch the
+// stubs that they embed to strict mode as well.
+//
+// Example:
+//     func (stub *StubStruct) SetStrict(reporter interface {
+//         Helper()
+//         Errorf(format string, args ...interface{})
+//     }) {
+//         // ...
+//     }
+type SetStrictMethodBuilder struct {
+	methodBuilder         *MethodBuilder
+	mutexFieldSelector    *ast.SelectorExpr
+	strictFieldSelector   *ast.SelectorExpr
+	reporterFieldSelector *ast.SelectorExpr
+	reporterType          ast.Expr
+	embeddedSelectors     []*ast.SelectorExpr
+}
+
+func (b *SetStrictMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *SetStrictMethodBuilder) SetStrictFieldSelector(selector *ast.SelectorExpr) {
+	b.strictFieldSelector = selector
+}
+
+func (b *SetStrictMethodBuilder) SetReporterFieldSelector(selector *ast.SelectorExpr) {
+	b.reporterFieldSelector = selector
+}
+
+// SetReporterType configures the type of the reporter.
+// The type should have already been resolved.
+func (b *SetStrictMethodBuilder) SetReporterType(reporterType ast.Expr) {
+	b.reporterType = reporterType
+}
+
+// AddEmbeddedStub registers a stub that is embedded into the stub,
+// so that it is switched to strict mode as well.
+func (b *SetStrictMethodBuilder) AddEmbeddedStub(selector *ast.SelectorExpr) {
+	b.embeddedSelectors = append(b.embeddedSelectors, selector)
+}
+
+func (b *SetStrictMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("reporter", b.reporterType),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.strictFieldSelector,
+			b.reporterFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			ast.NewIdent("true"),
+			ast.NewIdent("reporter"),
+		},
+	}))
+	for _, embedded := range b.embeddedSelectors {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   embedded,
+					Sel: ast.NewIdent(setStrictMethodName),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("reporter"),
+				},
+			},
+		}))
+	}
+	return b.methodBuilder.Build()
+}
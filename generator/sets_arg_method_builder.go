@@ -0,0 +1,317 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewSetsArgMethodBuilder(methodBuilder *MethodBuilder) *SetsArgMethodBuilder {
+	return &SetsArgMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// SetsArgMethodBuilder is responsible for creating a method on the stub
+// structure that configures a value to be assigned into a pointer
+// argument of the stubbed method. The value is of the element type of
+// the pointer, so that mismatches are caught at compile time. Empty
+// interface arguments accept any value, which is assigned through the
+// setArg method. Variadic arguments accept a value for each element.
+// If configured as on-call, the method accepts the index of the call
+// to which the value applies.
+//
+// Example:
+//     func (stub *StubStruct) LoadSetsInto(value Customer) {
+//         // ...
+//     }
+//
+//     func (stub *StubStruct) LoadSetsIntoOnCall(index int, value Customer) {
+//         // ...
+//     }
+type SetsArgMethodBuilder struct {
+	methodBuilder        *MethodBuilder
+	mutexFieldSelector   *ast.SelectorExpr
+	setsFieldSelector    *ast.SelectorExpr
+	setArgMethodSelector *ast.SelectorExpr
+	onCall               bool
+	param                *ast.Field
+	position             int
+	stubName             string
+	methodName           string
+}
+
+func (b *SetsArgMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+// SetSetsFieldSelector configures the field that holds the function
+// which assigns the value, or the functions by call index if
+// configured as on-call.
+func (b *SetsArgMethodBuilder) SetSetsFieldSelector(selector *ast.SelectorExpr) {
+	b.setsFieldSelector = selector
+}
+
+// SetSetArgMethodSelector configures the method that assigns values
+// into empty interface arguments.
+func (b *SetsArgMethodBuilder) SetSetArgMethodSelector(selector *ast.SelectorExpr) {
+	b.setArgMethodSelector = selector
+}
+
+// SetOnCall specifies whether the configured value should apply
+// to a specific call only.
+func (b *SetsArgMethodBuilder) SetOnCall(onCall bool) {
+	b.onCall = onCall
+}
+
+// SetParam specifies the argument into which the value is assigned.
+// The type of the argument should have already been resolved.
+func (b *SetsArgMethodBuilder) SetParam(param *ast.Field) {
+	b.param = param
+}
+
+// SetPosition specifies the one-based position of the argument,
+// as it should appear in panic messages.
+func (b *SetsArgMethodBuilder) SetPosition(position int) {
+	b.position = position
+}
+
+// SetStubName specifies the name of the stub structure, which is
+// used in panic messages.
+func (b *SetsArgMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// SetMethodName specifies the name of the stubbed method, which is
+// used in panic messages.
+func (b *SetsArgMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+func (b *SetsArgMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	paramName := b.param.Names[0].String()
+	argType := b.param.Type
+	ellipsis, variadic := argType.(*ast.Ellipsis)
+	if variadic {
+		argType = &ast.ArrayType{
+			Elt: ellipsis.Elt,
+		}
+	}
+
+	params := []*ast.Field{}
+	if b.onCall {
+		params = append(params, util.CreateField("index", ast.NewIdent("int")))
+	}
+	if variadic {
+		params = append(params, util.CreateField("values", &ast.Ellipsis{
+			Elt: b.valueType(ellipsis.Elt),
+		}))
+	} else {
+		params = append(params, util.CreateField("value", b.valueType(argType)))
+	}
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+
+	var assignCode []ast.Stmt
+	if variadic {
+		assignCode = b.buildAssignValuesCode(ast.NewIdent(paramName), ellipsis.Elt)
+	} else {
+		assignCode = b.buildAssignValueCode(ast.NewIdent(paramName), ast.NewIdent("value"), argType)
+	}
+	setsType := &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField(paramName, argType),
+			},
+		},
+	}
+
+	var target ast.Expr = b.setsFieldSelector
+	if b.onCall {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildEnsureMapCode(target, &ast.MapType{
+			Key:   ast.NewIdent("int"),
+			Value: setsType,
+		})))
+		target = &ast.IndexExpr{
+			X:     target,
+			Index: ast.NewIdent("index"),
+		}
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			target,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.FuncLit{
+				Type: setsType,
+				Body: &ast.BlockStmt{
+					List: assignCode,
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+// valueType returns the type of the value that can be assigned into
+// an argument of the specified type.
+func (b *SetsArgMethodBuilder) valueType(argType ast.Expr) ast.Expr {
+	if pointer, ok := argType.(*ast.StarExpr); ok {
+		return pointer.X
+	}
+	return util.CreateEmptyInterface()
+}
+
+// buildAssignValuesCode creates the code that assigns each of the
+// configured values into the corresponding variadic argument.
+func (b *SetsArgMethodBuilder) buildAssignValuesCode(args ast.Expr, argType ast.Expr) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X: &ast.CallExpr{
+					Fun: ast.NewIdent("len"),
+					Args: []ast.Expr{
+						ast.NewIdent("values"),
+					},
+				},
+				Op: token.GTR,
+				Y: &ast.CallExpr{
+					Fun: ast.NewIdent("len"),
+					Args: []ast.Expr{
+						args,
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					b.buildPanicCode("out of range"),
+				},
+			},
+		},
+		&ast.RangeStmt{
+			Key:   ast.NewIdent("i"),
+			Value: ast.NewIdent("value"),
+			Tok:   token.DEFINE,
+			X:     ast.NewIdent("values"),
+			Body: &ast.BlockStmt{
+				List: b.buildAssignValueCode(&ast.IndexExpr{
+					X:     args,
+					Index: ast.NewIdent("i"),
+				}, ast.NewIdent("value"), argType),
+			},
+		},
+	}
+}
+
+// buildAssignValueCode creates the code that assigns the value into
+// a single argument. Pointer arguments are assigned directly, while
+// empty interface arguments are passed to the setArg method.
+func (b *SetsArgMethodBuilder) buildAssignValueCode(arg ast.Expr, value ast.Expr, argType ast.Expr) []ast.Stmt {
+	if _, ok := argType.(*ast.StarExpr); !ok {
+		return []ast.Stmt{
+			&ast.ExprStmt{
+				X: &ast.CallExpr{
+					Fun: b.setArgMethodSelector,
+					Args: []ast.Expr{
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: fmt.Sprintf("\"%s\"", b.methodName),
+						},
+						&ast.BasicLit{
+							Kind:  token.INT,
+							Value: fmt.Sprintf("%d", b.position),
+						},
+						arg,
+						value,
+					},
+				},
+			},
+		}
+	}
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  arg,
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					b.buildPanicCode("not a non-nil pointer"),
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.StarExpr{
+					X: arg,
+				},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				value,
+			},
+		},
+	}
+}
+
+func (b *SetsArgMethodBuilder) buildPanicCode(reason string) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent("panic"),
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\"cannot set argument %d of %s.%s: %s\"", b.position, b.stubName, b.methodName, reason),
+				},
+			},
+		},
+	}
+}
+
+// buildEnsureMapCode creates the code that initializes the specified
+// map, unless it has already been initialized.
+func (b *SetsArgMethodBuilder) buildEnsureMapCode(target ast.Expr, mapType ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  target,
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						target,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("make"),
+							Args: []ast.Expr{
+								mapType,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
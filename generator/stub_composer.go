@@ -0,0 +1,343 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mokiat/gostub/resolution"
+	"github.com/mokiat/gostub/util"
+)
+
+func newStubComposer(locator *resolution.Locator, targetFilePath string, stubName func(string) string, requiredMethods []string) *stubComposer {
+	return &stubComposer{
+		locator:         locator,
+		stubName:        stubName,
+		targetDir:       filepath.Dir(targetFilePath),
+		targetFilePath:  targetFilePath,
+		requiredMethods: requiredMethods,
+	}
+}
+
+// stubComposer finds the existing stubs that are embedded into a composed
+// stub, in place of the interfaces that the stubbed interface embeds. The
+// existing stubs need to be located in the package of the composed stub
+// and need to have the required methods, which the composed stub calls.
+type stubComposer struct {
+	locator         *resolution.Locator
+	stubName        func(string) string
+	targetDir       string
+	targetFilePath  string
+	requiredMethods []string
+	structs         map[string]*ast.StructType
+	methods         map[string]map[string]string
+}
+
+// Compose returns the name of the existing stub for the specified
+// interface. An error is returned if the stub is missing or if the
+// signature of any of its methods, with all types resolved to their
+// packages, does not match the respective interface method, or if any
+// of the required methods is missing, as the stub was generated with
+// fewer features.
+func (c *stubComposer) Compose(discovery resolution.TypeDiscovery) (string, error) {
+	interfaceName := discovery.Spec.Name.String()
+	stubName := c.stubName(interfaceName)
+	if err := c.parseTargetDir(); err != nil {
+		return "", err
+	}
+	if _, found := c.structs[stubName]; !found {
+		return "", fmt.Errorf("Stub '%s' for embedded interface '%s' not found in '%s'! Generate it first.", stubName, interfaceName, c.targetDir)
+	}
+	expected := make(map[string]string)
+	if err := c.collectInterfaceMethods(discovery, expected); err != nil {
+		return "", err
+	}
+	actual := make(map[string]string)
+	c.collectStubMethods(stubName, actual)
+
+	names := []string{}
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		signature, found := actual[name]
+		if !found || signature != expected[name] {
+			return "", fmt.Errorf("Stub '%s' does not match method '%s' of interface '%s'! Regenerate it first.", stubName, name, interfaceName)
+		}
+	}
+	for _, name := range c.requiredMethods {
+		if _, found := actual[name]; !found {
+			return "", fmt.Errorf("Stub '%s' lacks method '%s', which the composed stub relies on! Regenerate it with the same features.", stubName, name)
+		}
+	}
+	return stubName, nil
+}
+
+// parseTargetDir collects the struct types and their methods that are
+// declared in the package of the composed stub, except for the file of
+// the composed stub itself, which is about to be regenerated.
+func (c *stubComposer) parseTargetDir() error {
+	if c.structs != nil {
+		return nil
+	}
+	c.structs = make(map[string]*ast.StructType)
+	c.methods = make(map[string]map[string]string)
+
+	targetFileName := filepath.Base(c.targetFilePath)
+	pkgs, err := parser.ParseDir(token.NewFileSet(), c.targetDir, func(info os.FileInfo) bool {
+		return info.Name() != targetFileName && !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			c.collectDeclarations(file)
+		}
+	}
+	return nil
+}
+
+// collectDeclarations collects the struct types and the signatures of
+// the methods that are declared in the specified file. Types in the
+// signatures are resolved against the imports of the file.
+func (c *stubComposer) collectDeclarations(file *ast.File) {
+	imports := make(map[string]string)
+	for _, importSpec := range file.Imports {
+		location := strings.Trim(importSpec.Path.Value, "\"")
+		alias := path.Base(location)
+		if importSpec.Name != nil {
+			alias = importSpec.Name.String()
+		}
+		imports[alias] = location
+	}
+	qualify := func(namedType ast.Expr) (string, error) {
+		if selector, ok := namedType.(*ast.SelectorExpr); ok {
+			if pkg, ok := selector.X.(*ast.Ident); ok {
+				if location, found := imports[pkg.String()]; found {
+					return location + "." + selector.Sel.String(), nil
+				}
+			}
+		}
+		return types.ExprString(namedType), nil
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+					c.structs[typeSpec.Name.String()] = structType
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) != 1 {
+				continue
+			}
+			recvType := d.Recv.List[0].Type
+			if star, ok := recvType.(*ast.StarExpr); ok {
+				recvType = star.X
+			}
+			recvIdent, ok := recvType.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if c.methods[recvIdent.String()] == nil {
+				c.methods[recvIdent.String()] = make(map[string]string)
+			}
+			signature, _ := typeSignature(d.Type, qualify)
+			c.methods[recvIdent.String()][d.Name.String()] = signature
+		}
+	}
+}
+
+// collectStubMethods collects the methods of the specified stub,
+// including the ones that are promoted from embedded stubs, in case
+// the stub is itself composed.
+func (c *stubComposer) collectStubMethods(stubName string, methods map[string]string) {
+	for name, signature := range c.methods[stubName] {
+		methods[name] = signature
+	}
+	structType, found := c.structs[stubName]
+	if !found {
+		return
+	}
+	for _, field := range structType.Fields.List {
+		if ident, ok := field.Type.(*ast.Ident); ok && len(field.Names) == 0 {
+			embedded := make(map[string]string)
+			c.collectStubMethods(ident.String(), embedded)
+			for name, signature := range embedded {
+				if _, found := methods[name]; !found {
+					methods[name] = signature
+				}
+			}
+		}
+	}
+}
+
+// collectInterfaceMethods collects the signatures of the methods of the
+// specified interface, including the ones of all interfaces that it
+// embeds. Types in the signatures are resolved through the locator.
+func (c *stubComposer) collectInterfaceMethods(discovery resolution.TypeDiscovery, methods map[string]string) error {
+	iFaceType, isIFace := discovery.Spec.Type.(*ast.InterfaceType)
+	if !isIFace {
+		return fmt.Errorf("Type '%s' in '%s' is not interface!", discovery.Spec.Name.String(), discovery.Location)
+	}
+	context := resolution.NewASTFileLocatorContext(discovery.File, discovery.Location)
+	qualify := func(namedType ast.Expr) (string, error) {
+		var typeDiscovery resolution.TypeDiscovery
+		var err error
+		switch t := namedType.(type) {
+		case *ast.Ident:
+			typeDiscovery, err = c.locator.FindIdentType(context, t)
+		case *ast.SelectorExpr:
+			typeDiscovery, err = c.locator.FindSelectorType(context, t)
+		}
+		if err != nil {
+			return "", err
+		}
+		return typeDiscovery.Location + "." + typeDiscovery.Spec.Name.String(), nil
+	}
+
+	for field := range util.EachFieldInFieldList(iFaceType.Methods) {
+		var subDiscovery resolution.TypeDiscovery
+		var err error
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			signature, err := typeSignature(t, qualify)
+			if err != nil {
+				return err
+			}
+			methods[field.Names[0].String()] = signature
+			continue
+		case *ast.Ident:
+			subDiscovery, err = c.locator.FindIdentType(context, t)
+		case *ast.SelectorExpr:
+			subDiscovery, err = c.locator.FindSelectorType(context, t)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = c.collectInterfaceMethods(subDiscovery, methods); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeSignature returns a textual representation of the specified type,
+// in which parameter names are omitted and named types are replaced by
+// the result of the qualify function, so that types that are written
+// differently in different files can be compared.
+func typeSignature(astType ast.Expr, qualify func(ast.Expr) (string, error)) (string, error) {
+	switch t := astType.(type) {
+	case *ast.Ident:
+		if isBuiltIn(t.String()) {
+			return t.String(), nil
+		}
+		return qualify(t)
+	case *ast.SelectorExpr:
+		return qualify(t)
+	case *ast.ParenExpr:
+		return typeSignature(t.X, qualify)
+	case *ast.StarExpr:
+		elem, err := typeSignature(t.X, qualify)
+		return "*" + elem, err
+	case *ast.Ellipsis:
+		elem, err := typeSignature(t.Elt, qualify)
+		return "..." + elem, err
+	case *ast.ArrayType:
+		length := ""
+		if t.Len != nil {
+			length = types.ExprString(t.Len)
+		}
+		elem, err := typeSignature(t.Elt, qualify)
+		return "[" + length + "]" + elem, err
+	case *ast.MapType:
+		key, err := typeSignature(t.Key, qualify)
+		if err != nil {
+			return "", err
+		}
+		value, err := typeSignature(t.Value, qualify)
+		return "map[" + key + "]" + value, err
+	case *ast.ChanType:
+		prefix := "chan "
+		switch t.Dir {
+		case ast.SEND:
+			prefix = "chan<- "
+		case ast.RECV:
+			prefix = "<-chan "
+		}
+		elem, err := typeSignature(t.Value, qualify)
+		return prefix + elem, err
+	case *ast.FuncType:
+		params, err := fieldTypeSignatures(t.Params, qualify)
+		if err != nil {
+			return "", err
+		}
+		results, err := fieldTypeSignatures(t.Results, qualify)
+		return "func(" + strings.Join(params, ", ") + ") (" + strings.Join(results, ", ") + ")", err
+	case *ast.StructType:
+		fields, err := namedFieldSignatures(t.Fields, qualify)
+		return "struct{" + strings.Join(fields, "; ") + "}", err
+	case *ast.InterfaceType:
+		methods, err := namedFieldSignatures(t.Methods, qualify)
+		return "interface{" + strings.Join(methods, "; ") + "}", err
+	}
+	return types.ExprString(astType), nil
+}
+
+// fieldTypeSignatures returns the signatures of the types of all
+// fields in the list, with a separate entry for each field name.
+func fieldTypeSignatures(fieldList *ast.FieldList, qualify func(ast.Expr) (string, error)) ([]string, error) {
+	signatures := []string{}
+	for field := range util.EachFieldInFieldList(fieldList) {
+		signature, err := typeSignature(field.Type, qualify)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < util.FieldTypeReuseCount(field); i++ {
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures, nil
+}
+
+// namedFieldSignatures returns the signatures of all fields in the list,
+// including their names, as is needed for struct fields and interface
+// methods.
+func namedFieldSignatures(fieldList *ast.FieldList, qualify func(ast.Expr) (string, error)) ([]string, error) {
+	signatures := []string{}
+	for field := range util.EachFieldInFieldList(fieldList) {
+		signature, err := typeSignature(field.Type, qualify)
+		if err != nil {
+			return nil, err
+		}
+		if len(field.Names) > 0 {
+			names := make([]string, len(field.Names))
+			for i, name := range field.Names {
+				names[i] = name.String()
+			}
+			signature = strings.Join(names, ", ") + " " + signature
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
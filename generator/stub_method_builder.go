@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 
@@ -28,7 +29,34 @@ type StubMethodBuilder struct {
 	mutexFieldSelector   *ast.SelectorExpr
 	argsFieldSelector    *ast.SelectorExpr
 	returnsFieldSelector *ast.SelectorExpr
+	returnsOnCallSel     *ast.SelectorExpr
 	stubFieldSelector    *ast.SelectorExpr
+	rulesFieldSelector   *ast.SelectorExpr
+	ruleTypeName         string
+	callSignalSelector   *ast.SelectorExpr
+	configuredSelector   *ast.SelectorExpr
+	reportMethodSelector *ast.SelectorExpr
+	gatesFieldSelector   *ast.SelectorExpr
+	gateTypeName         string
+	delayFieldSelector   *ast.SelectorExpr
+	blocksFieldSelector  *ast.SelectorExpr
+	sleepSelector        *ast.SelectorExpr
+	afterSelector        *ast.SelectorExpr
+	timeType             ast.Expr
+	argsTypeName         string
+	resultsTypeName      string
+	invocationsSelector  *ast.SelectorExpr
+	stubMutexSelector    *ast.SelectorExpr
+	invocationTypeName   string
+	recorderSelector     *ast.SelectorExpr
+	argSets              []argSets
+	panicSelector        *ast.SelectorExpr
+	panicOnCallSelector  *ast.SelectorExpr
+	callbackInvokes      []callbackInvokes
+	defaultResult        ast.Expr
+	stubName             string
+	contextParamName     string
+	methodName           string
 	params               []*ast.Field
 	results              []*ast.Field
 }
@@ -45,10 +73,182 @@ func (b *StubMethodBuilder) SetReturnsFieldSelector(selector *ast.SelectorExpr)
 	b.returnsFieldSelector = selector
 }
 
+// SetReturnsOnCallFieldSelector configures the field that holds the
+// results for specific calls. If not set, the stub method will not
+// evaluate any results for specific calls.
+func (b *StubMethodBuilder) SetReturnsOnCallFieldSelector(selector *ast.SelectorExpr) {
+	b.returnsOnCallSel = selector
+}
+
 func (b *StubMethodBuilder) SetStubFieldSelector(selector *ast.SelectorExpr) {
 	b.stubFieldSelector = selector
 }
 
+// SetRulesFieldSelector configures the field that holds the conditional
+// returns of the method. If not set, the stub method will not evaluate
+// any conditional returns.
+func (b *StubMethodBuilder) SetRulesFieldSelector(selector *ast.SelectorExpr) {
+	b.rulesFieldSelector = selector
+}
+
+// SetRuleTypeName specifies the name of the structure that represents
+// a conditional return, so that rules can be copied before they are
+// evaluated.
+func (b *StubMethodBuilder) SetRuleTypeName(name string) {
+	b.ruleTypeName = name
+}
+
+// SetCallSignalFieldSelector configures the field that is used to
+// notify goroutines that wait for the method to be called. If not set,
+// no goroutines are notified.
+func (b *StubMethodBuilder) SetCallSignalFieldSelector(selector *ast.SelectorExpr) {
+	b.callSignalSelector = selector
+}
+
+// SetConfiguredFieldSelector configures the field that tracks whether
+// default results have been specified for the method. If not set, the
+// default results are always returned.
+func (b *StubMethodBuilder) SetConfiguredFieldSelector(selector *ast.SelectorExpr) {
+	b.configuredSelector = selector
+}
+
+// SetReportMethodSelector configures the method that is called when
+// the stub method is called without having been configured. If not
+// set, such calls are not reported.
+func (b *StubMethodBuilder) SetReportMethodSelector(selector *ast.SelectorExpr) {
+	b.reportMethodSelector = selector
+}
+
+// SetArgsTypeName configures the name of the type that holds the
+// arguments of a single call.
+func (b *StubMethodBuilder) SetArgsTypeName(name string) {
+	b.argsTypeName = name
+}
+
+// SetInvocationsFieldSelector configures the stub-level field that
+// records the calls to all methods of the stub, in order. If not set,
+// calls are only recorded per method.
+func (b *StubMethodBuilder) SetInvocationsFieldSelector(selector *ast.SelectorExpr) {
+	b.invocationsSelector = selector
+}
+
+// SetStubMutexFieldSelector configures the stub-level mutex that
+// guards the invocations and recorder fields.
+func (b *StubMethodBuilder) SetStubMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.stubMutexSelector = selector
+}
+
+// SetInvocationTypeName configures the name of the type that
+// describes a single entry in the invocations field.
+func (b *StubMethodBuilder) SetInvocationTypeName(name string) {
+	b.invocationTypeName = name
+}
+
+// SetRecorderFieldSelector configures the stub-level field that holds
+// the attached recorder, if any. The recorder is notified of the call
+// after all mutexes have been released. If not set, no recorder is
+// notified.
+func (b *StubMethodBuilder) SetRecorderFieldSelector(selector *ast.SelectorExpr) {
+	b.recorderSelector = selector
+}
+
+// SetStubName specifies the name of the stub, as it should appear
+// in recorded calls.
+func (b *StubMethodBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+// AddArgSets configures the fields that hold the functions which
+// assign values into the specified pointer parameter, for all calls
+// and for specific calls respectively.
+func (b *StubMethodBuilder) AddArgSets(paramName string, selector, onCallSelector *ast.SelectorExpr) {
+	b.argSets = append(b.argSets, argSets{
+		paramName:      paramName,
+		selector:       selector,
+		onCallSelector: onCallSelector,
+	})
+}
+
+// SetPanicFieldSelectors configures the fields that hold the values
+// with which the method should panic, for all calls and for specific
+// calls respectively. If not set, the method never panics.
+func (b *StubMethodBuilder) SetPanicFieldSelectors(value, valueOnCall *ast.SelectorExpr) {
+	b.panicSelector = value
+	b.panicOnCallSelector = valueOnCall
+}
+
+// AddCallbackInvokes configures the field that holds the functions
+// which invoke the specified callback parameter during each call.
+func (b *StubMethodBuilder) AddCallbackInvokes(paramName string, selector *ast.SelectorExpr) {
+	b.callbackInvokes = append(b.callbackInvokes, callbackInvokes{
+		paramName: paramName,
+		selector:  selector,
+	})
+}
+
+// SetDefaultResult specifies the single result that is returned when
+// the method has no results configured. If not set, such calls are
+// reported and zero values are returned.
+func (b *StubMethodBuilder) SetDefaultResult(result ast.Expr) {
+	b.defaultResult = result
+}
+
+// SetGatesFieldSelector configures the field that holds the gates
+// which the next calls to the method should block on. If not set,
+// calls are never held.
+func (b *StubMethodBuilder) SetGatesFieldSelector(selector *ast.SelectorExpr) {
+	b.gatesFieldSelector = selector
+}
+
+// SetGateTypeName configures the name of the type that is used
+// to represent a gate.
+func (b *StubMethodBuilder) SetGateTypeName(name string) {
+	b.gateTypeName = name
+}
+
+// SetDelayFieldSelector configures the field that holds the duration
+// that each call should take before it returns. If not set, calls
+// are never delayed.
+func (b *StubMethodBuilder) SetDelayFieldSelector(selector *ast.SelectorExpr) {
+	b.delayFieldSelector = selector
+}
+
+// SetBlocksFieldSelector configures the field that tracks whether
+// calls should block until their context is done. It should only be
+// set when a context parameter has been specified.
+func (b *StubMethodBuilder) SetBlocksFieldSelector(selector *ast.SelectorExpr) {
+	b.blocksFieldSelector = selector
+}
+
+// SetTimeSelectors configures the functions that are used to delay
+// calls and the type of the values that their timers deliver.
+// The selectors should have already been resolved.
+func (b *StubMethodBuilder) SetTimeSelectors(sleep, after *ast.SelectorExpr, timeType ast.Expr) {
+	b.sleepSelector = sleep
+	b.afterSelector = after
+	b.timeType = timeType
+}
+
+// SetResultsTypeName configures the name of the type that holds the
+// results of a single call. It is used to return zero values when the
+// context of a delayed call is done.
+func (b *StubMethodBuilder) SetResultsTypeName(name string) {
+	b.resultsTypeName = name
+}
+
+// SetContextParamName specifies the parameter of type context.Context
+// whose cancellation should unblock a held call. If not set, held
+// calls block until their gate is released.
+func (b *StubMethodBuilder) SetContextParamName(name string) {
+	b.contextParamName = name
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in reports.
+func (b *StubMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
 // SetParams specifies the parameters that the original method
 // uses. These parameters need to have been normalized and resolved
 // in advance.
@@ -71,7 +271,6 @@ func (b *StubMethodBuilder) Build() ast.Decl {
 	mutexUnlockBuilder := NewMutexActionBuilder()
 	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
 	mutexUnlockBuilder.SetAction("Unlock")
-	mutexUnlockBuilder.SetDeferred(true)
 
 	b.methodBuilder.SetType(&ast.FuncType{
 		Params: &ast.FieldList{
@@ -82,13 +281,19 @@ func (b *StubMethodBuilder) Build() ast.Decl {
 		},
 	})
 	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
-	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
 
 	paramSelectors := []ast.Expr{}
 	for _, param := range b.params {
 		paramSelectors = append(paramSelectors, ast.NewIdent(param.Names[0].String()))
 	}
 
+	hasEllipsis := false
+	if parCount := len(b.params); parCount > 0 {
+		if _, ok := b.params[parCount-1].Type.(*ast.Ellipsis); ok {
+			hasEllipsis = true
+		}
+	}
+
 	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
 		Lhs: []ast.Expr{
 			b.argsFieldSelector,
@@ -100,11 +305,7 @@ func (b *StubMethodBuilder) Build() ast.Decl {
 				Args: []ast.Expr{
 					b.argsFieldSelector,
 					&ast.CompositeLit{
-						Type: &ast.StructType{
-							Fields: &ast.FieldList{
-								List: util.FieldsWithoutEllipsis(b.params),
-							},
-						},
+						Type: ast.NewIdent(b.argsTypeName),
 						Elts: paramSelectors,
 					},
 				},
@@ -112,26 +313,949 @@ func (b *StubMethodBuilder) Build() ast.Decl {
 		},
 	}))
 
-	hasEllipsis := false
-	if parCount := len(b.params); parCount > 0 {
-		if _, ok := b.params[parCount-1].Type.(*ast.Ellipsis); ok {
-			hasEllipsis = true
+	if b.needsCallIndex() {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("callIndex"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.BinaryExpr{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("len"),
+						Args: []ast.Expr{
+							b.argsFieldSelector,
+						},
+					},
+					Op: token.SUB,
+					Y: &ast.BasicLit{
+						Kind:  token.INT,
+						Value: "1",
+					},
+				},
+			},
+		}))
+	}
+	if b.panicSelector != nil {
+		b.addSelectPanicCode()
+	}
+
+	for _, invokes := range b.callbackInvokes {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(invokes.localName()),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				invokes.selector,
+			},
+		}))
+	}
+
+	if b.invocationsSelector != nil || b.recorderSelector != nil {
+		b.addRecordInvocationCode(paramSelectors)
+	}
+
+	if b.callSignalSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("callSignal"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.callSignalSelector,
+			},
+		}))
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				b.callSignalSelector,
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				ast.NewIdent("nil"),
+			},
+		}))
+	}
+	if b.gatesFieldSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildDeclareGateCode()))
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildTakeGateCode()))
+	}
+	if b.delayFieldSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("delay"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.delayFieldSelector,
+			},
+		}))
+	}
+	if b.blocksFieldSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("blocks"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.blocksFieldSelector,
+			},
+		}))
+	}
+	if b.hasUnlockedCode() {
+		b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+		if b.recorderSelector != nil {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(buildNotifyRecorderCode(b.stubName, b.methodName, paramSelectors)))
+		}
+		if b.callSignalSelector != nil {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildNotifyWaitersCode()))
+		}
+		if b.contextParamName != "" && (b.gatesFieldSelector != nil || b.blocksFieldSelector != nil) {
+			for _, stmt := range b.buildContextDoneCode() {
+				b.methodBuilder.AddStatementBuilder(StatementToBuilder(stmt))
+			}
+		}
+		if b.gatesFieldSelector != nil {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildWaitGateCode()))
+		}
+		if b.panicSelector != nil {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildPanicCode()))
+		}
+		if b.delayFieldSelector != nil {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildDelayCode()))
 		}
+		for _, invokes := range b.callbackInvokes {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildInvokeCallbackCode(invokes)))
+		}
+		b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	}
+	for _, stmt := range b.buildCopyConfigCode() {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(stmt))
+	}
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	for _, sets := range b.argSets {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(b.buildSetArgCode(sets)))
 	}
 
-	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+	callStubStmt := &ast.IfStmt{
 		Cond: &ast.BinaryExpr{
-			X:  b.stubFieldSelector,
+			X:  ast.NewIdent("stubFunc"),
 			Op: token.NEQ,
 			Y:  ast.NewIdent("nil"),
 		},
 		Body: b.buildCallStubMethodCode(paramSelectors, hasEllipsis),
-		Else: b.buildReturnReturnsCode(),
-	}))
+	}
+	if len(b.results) > 0 || b.reportMethodSelector != nil {
+		callStubStmt.Else = b.buildReturnReturnsCode(paramSelectors, hasEllipsis)
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(callStubStmt))
 
 	return b.methodBuilder.Build()
 }
 
+// needsCallIndex checks whether any of the configuration of the method
+// applies to specific calls, in which case the index of the current
+// call needs to be known.
+func (b *StubMethodBuilder) needsCallIndex() bool {
+	return (len(b.results) > 0 && b.returnsOnCallSel != nil) || b.panicSelector != nil || len(b.argSets) > 0
+}
+
+// hasUnlockedCode checks whether any code needs to be executed after
+// the call has been recorded and before the configuration of the
+// method is evaluated, without the mutex being held.
+func (b *StubMethodBuilder) hasUnlockedCode() bool {
+	return b.recorderSelector != nil || b.callSignalSelector != nil || b.gatesFieldSelector != nil ||
+		b.panicSelector != nil || b.delayFieldSelector != nil || len(b.callbackInvokes) > 0
+}
+
+// addRecordInvocationCode adds the code that appends the call to
+// the stub-level invocations, which is guarded by the stub mutex.
+func (b *StubMethodBuilder) addRecordInvocationCode(args []ast.Expr) {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.stubMutexSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.stubMutexSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	if b.invocationsSelector != nil {
+		b.addAppendInvocationCode(args)
+	}
+	if b.recorderSelector != nil {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("recorder"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.recorderSelector,
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+}
+
+// addAppendInvocationCode adds the code that appends the call to the
+// stub-level invocations. Whether the call panics is only noted if
+// the method can be configured to panic.
+func (b *StubMethodBuilder) addAppendInvocationCode(args []ast.Expr) {
+	elts := []ast.Expr{
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent("Sequence"),
+			Value: &ast.BinaryExpr{
+				X: &ast.CallExpr{
+					Fun: ast.NewIdent("len"),
+					Args: []ast.Expr{
+						b.invocationsSelector,
+					},
+				},
+				Op: token.ADD,
+				Y: &ast.BasicLit{
+					Kind:  token.INT,
+					Value: "1",
+				},
+			},
+		},
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent("Method"),
+			Value: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("\"%s\"", b.methodName),
+			},
+		},
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent("Args"),
+			Value: &ast.CompositeLit{
+				Type: &ast.ArrayType{
+					Elt: util.CreateEmptyInterface(),
+				},
+				Elts: args,
+			},
+		},
+	}
+	if b.panicSelector != nil {
+		elts = append(elts, &ast.KeyValueExpr{
+			Key: ast.NewIdent("Panicked"),
+			Value: &ast.BinaryExpr{
+				X:  ast.NewIdent("panicValue"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+		})
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.invocationsSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.invocationsSelector,
+					&ast.CompositeLit{
+						Type: ast.NewIdent(b.invocationTypeName),
+						Elts: elts,
+					},
+				},
+			},
+		},
+	}))
+}
+
+// addSelectPanicCode adds the code that determines the value with
+// which the current call should panic, preferring the value that
+// was configured for the specific call.
+func (b *StubMethodBuilder) addSelectPanicCode() {
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("panicValue"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.panicSelector,
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("value"),
+				ast.NewIdent("ok"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.IndexExpr{
+					X:     b.panicOnCallSelector,
+					Index: ast.NewIdent("callIndex"),
+				},
+			},
+		},
+		Cond: ast.NewIdent("ok"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("panicValue"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						ast.NewIdent("value"),
+					},
+				},
+			},
+		},
+	}))
+}
+
+// buildPanicCode creates the code that panics with the value that was
+// selected for the current call, if any. It is executed after the
+// mutex has been released, so that the stub remains usable.
+func (b *StubMethodBuilder) buildPanicCode() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("panicValue"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("panic"),
+						Args: []ast.Expr{
+							ast.NewIdent("panicValue"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildInvokeCallbackCode creates the code that invokes a callback
+// parameter with all configured arguments, unless the callback is nil.
+// It is executed after the mutex has been released, so that callbacks
+// can use the stub.
+func (b *StubMethodBuilder) buildInvokeCallbackCode(invokes callbackInvokes) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent(invokes.paramName),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.RangeStmt{
+					Key:   ast.NewIdent("_"),
+					Value: ast.NewIdent("invoke"),
+					Tok:   token.DEFINE,
+					X:     ast.NewIdent(invokes.localName()),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: ast.NewIdent("invoke"),
+									Args: []ast.Expr{
+										ast.NewIdent(invokes.paramName),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildCopyConfigCode creates the code that copies the configuration
+// of the method into local variables, while the mutex is held. This
+// way, the configured stub function, matchers and assignments are
+// evaluated after the mutex has been released and can use the stub.
+func (b *StubMethodBuilder) buildCopyConfigCode() []ast.Stmt {
+	statements := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("stubFunc"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.stubFieldSelector,
+			},
+		},
+	}
+	if len(b.results) > 0 {
+		statements = append(statements, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("returns"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.returnsFieldSelector,
+			},
+		})
+	}
+	if len(b.results) > 0 && b.configuredSelector != nil {
+		statements = append(statements, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("configured"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				b.configuredSelector,
+			},
+		})
+	}
+	if len(b.results) > 0 && b.returnsOnCallSel != nil {
+		statements = append(statements, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("returnsOnCall"),
+				ast.NewIdent("hasReturnsOnCall"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.IndexExpr{
+					X:     b.returnsOnCallSel,
+					Index: ast.NewIdent("callIndex"),
+				},
+			},
+		})
+	}
+	if len(b.results) > 0 && b.rulesFieldSelector != nil {
+		statements = append(statements, b.buildCopyRulesCode()...)
+	}
+	for _, sets := range b.argSets {
+		statements = append(statements, b.buildSelectArgSetsCode(sets)...)
+	}
+	return statements
+}
+
+// buildCopyRulesCode creates the code that copies the conditional
+// returns, so that later changes to them do not affect the call.
+func (b *StubMethodBuilder) buildCopyRulesCode() []ast.Stmt {
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("rules"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("make"),
+					Args: []ast.Expr{
+						&ast.ArrayType{
+							Elt: ast.NewIdent(b.ruleTypeName),
+						},
+						&ast.CallExpr{
+							Fun: ast.NewIdent("len"),
+							Args: []ast.Expr{
+								b.rulesFieldSelector,
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.RangeStmt{
+			Key:   ast.NewIdent("i"),
+			Value: ast.NewIdent("rule"),
+			Tok:   token.DEFINE,
+			X:     b.rulesFieldSelector,
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							&ast.IndexExpr{
+								X:     ast.NewIdent("rules"),
+								Index: ast.NewIdent("i"),
+							},
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.StarExpr{
+								X: ast.NewIdent("rule"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildSelectArgSetsCode creates the code that selects the function
+// which assigns into a pointer parameter, preferring the one that was
+// configured for the current call over the one for all calls.
+func (b *StubMethodBuilder) buildSelectArgSetsCode(sets argSets) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(sets.localName()),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				sets.selector,
+			},
+		},
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("sets"),
+					ast.NewIdent("ok"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.IndexExpr{
+						X:     sets.onCallSelector,
+						Index: ast.NewIdent("callIndex"),
+					},
+				},
+			},
+			Cond: ast.NewIdent("ok"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent(sets.localName()),
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							ast.NewIdent("sets"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildSetArgCode creates the code that assigns the configured value
+// into a pointer parameter, if any.
+func (b *StubMethodBuilder) buildSetArgCode(sets argSets) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent(sets.localName()),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent(sets.localName()),
+						Args: []ast.Expr{
+							ast.NewIdent(sets.paramName),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildNotifyRecorderCode creates the code that passes the call to
+// the recorder that was attached at the time of the call.
+func buildNotifyRecorderCode(stubName, methodName string, args []ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("recorder"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("recorder"),
+							Sel: ast.NewIdent("Record"),
+						},
+						Args: []ast.Expr{
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: fmt.Sprintf("\"%s\"", stubName),
+							},
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: fmt.Sprintf("\"%s\"", methodName),
+							},
+							&ast.CompositeLit{
+								Type: &ast.ArrayType{
+									Elt: util.CreateEmptyInterface(),
+								},
+								Elts: args,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildNotifyWaitersCode creates the code that wakes up all goroutines
+// that are waiting for the method to be called. It is executed after
+// the mutex has been released.
+func (b *StubMethodBuilder) buildNotifyWaitersCode() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("callSignal"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: ast.NewIdent("close"),
+						Args: []ast.Expr{
+							ast.NewIdent("callSignal"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *StubMethodBuilder) buildDeclareGateCode() ast.Stmt {
+	return &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("gate"),
+					},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.gateTypeName),
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildTakeGateCode creates the code that removes the first pending
+// gate, if any, so that the current call is held by it.
+func (b *StubMethodBuilder) buildTakeGateCode() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X: &ast.CallExpr{
+				Fun: ast.NewIdent("len"),
+				Args: []ast.Expr{
+					b.gatesFieldSelector,
+				},
+			},
+			Op: token.GTR,
+			Y: &ast.BasicLit{
+				Kind:  token.INT,
+				Value: "0",
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("gate"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.IndexExpr{
+							X: b.gatesFieldSelector,
+							Index: &ast.BasicLit{
+								Kind:  token.INT,
+								Value: "0",
+							},
+						},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						b.gatesFieldSelector,
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.SliceExpr{
+							X: b.gatesFieldSelector,
+							Low: &ast.BasicLit{
+								Kind:  token.INT,
+								Value: "1",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildContextDoneCode creates the code that obtains the channel that
+// is closed once the context of the call is done. The channel stays
+// nil for calls with a nil context, so they are never cancelled.
+func (b *StubMethodBuilder) buildContextDoneCode() []ast.Stmt {
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{
+							ast.NewIdent("done"),
+						},
+						Type: &ast.ChanType{
+							Dir: ast.RECV,
+							Value: &ast.StructType{
+								Fields: &ast.FieldList{},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent(b.contextParamName),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent("done"),
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent(b.contextParamName),
+									Sel: ast.NewIdent("Done"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildWaitGateCode creates the code that blocks the current call
+// until its gate is released. It is executed after the mutex has
+// been released, so that other calls to the stub are not affected.
+// If the method has a context parameter, the call stops waiting once
+// the context is done and the error of the context is returned as the
+// error result.
+func (b *StubMethodBuilder) buildWaitGateCode() ast.Stmt {
+	releaseExpr := &ast.UnaryExpr{
+		Op: token.ARROW,
+		X: &ast.SelectorExpr{
+			X:   ast.NewIdent("gate"),
+			Sel: ast.NewIdent(gateReleaseFieldName),
+		},
+	}
+	var waitStmt ast.Stmt
+	if b.contextParamName == "" {
+		waitStmt = &ast.ExprStmt{
+			X: releaseExpr,
+		}
+	} else {
+		waitStmt = &ast.SelectStmt{
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.CommClause{
+						Comm: &ast.ExprStmt{
+							X: releaseExpr,
+						},
+					},
+					&ast.CommClause{
+						Comm: &ast.ExprStmt{
+							X: &ast.UnaryExpr{
+								Op: token.ARROW,
+								X:  ast.NewIdent("done"),
+							},
+						},
+						Body: b.buildReturnContextErrCode(),
+					},
+				},
+			},
+		}
+	}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("gate"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				waitStmt,
+			},
+		},
+	}
+}
+
+// buildDelayCode creates the code that delays the current call by the
+// configured duration. It is executed after the mutex has been released,
+// so that other calls to the stub are not affected. If the method has a
+// context parameter, the call stops waiting once the context is done
+// and the error of the context is returned as the error result.
+func (b *StubMethodBuilder) buildDelayCode() ast.Stmt {
+	delayCond := &ast.BinaryExpr{
+		X:  ast.NewIdent("delay"),
+		Op: token.GTR,
+		Y: &ast.BasicLit{
+			Kind:  token.INT,
+			Value: "0",
+		},
+	}
+	if b.blocksFieldSelector == nil {
+		return &ast.IfStmt{
+			Cond: delayCond,
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun: b.sleepSelector,
+							Args: []ast.Expr{
+								ast.NewIdent("delay"),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  delayCond,
+			Op: token.LOR,
+			Y:  ast.NewIdent("blocks"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{
+									ast.NewIdent("delayed"),
+								},
+								Type: &ast.ChanType{
+									Dir:   ast.RECV,
+									Value: b.timeType,
+								},
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.UnaryExpr{
+						Op: token.NOT,
+						X:  ast.NewIdent("blocks"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									ast.NewIdent("delayed"),
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: b.afterSelector,
+										Args: []ast.Expr{
+											ast.NewIdent("delay"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.SelectStmt{
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.CommClause{
+								Comm: &ast.ExprStmt{
+									X: &ast.UnaryExpr{
+										Op: token.ARROW,
+										X:  ast.NewIdent("delayed"),
+									},
+								},
+							},
+							&ast.CommClause{
+								Comm: &ast.ExprStmt{
+									X: &ast.UnaryExpr{
+										Op: token.ARROW,
+										X:  ast.NewIdent("done"),
+									},
+								},
+								Body: b.buildReturnContextErrCode(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildReturnContextErrCode creates the code that returns zero values
+// with the error of the context as the last error result. Methods
+// without an error result continue with their configured results.
+func (b *StubMethodBuilder) buildReturnContextErrCode() []ast.Stmt {
+	errIndex := -1
+	for i, result := range b.results {
+		if isErrorType(result.Type) {
+			errIndex = i
+		}
+	}
+	if errIndex < 0 {
+		return nil
+	}
+	errSelector := &ast.SelectorExpr{
+		X:   ast.NewIdent("results"),
+		Sel: ast.NewIdent(util.ToPublic(b.results[errIndex].Names[0].String())),
+	}
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{
+							ast.NewIdent("results"),
+						},
+						Type: ast.NewIdent(b.resultsTypeName),
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				errSelector,
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent(b.contextParamName),
+						Sel: ast.NewIdent("Err"),
+					},
+				},
+			},
+		},
+		&ast.ReturnStmt{
+			Results: b.buildResultSelectors(ast.NewIdent("results")),
+		},
+	}
+}
+
 func (b *StubMethodBuilder) buildCallStubMethodCode(args []ast.Expr, hasEllipsis bool) *ast.BlockStmt {
 	ellipsisPos := token.NoPos
 	if hasEllipsis {
@@ -139,7 +1263,7 @@ func (b *StubMethodBuilder) buildCallStubMethodCode(args []ast.Expr, hasEllipsis
 	}
 	callExpr := &ast.CallExpr{
 		Ellipsis: ellipsisPos,
-		Fun:      b.stubFieldSelector,
+		Fun:      ast.NewIdent("stubFunc"),
 		Args:     args,
 	}
 	var stmt ast.Stmt
@@ -161,22 +1285,149 @@ func (b *StubMethodBuilder) buildCallStubMethodCode(args []ast.Expr, hasEllipsis
 	}
 }
 
-func (b *StubMethodBuilder) buildReturnReturnsCode() ast.Stmt {
+func (b *StubMethodBuilder) buildReturnReturnsCode(args []ast.Expr, hasEllipsis bool) ast.Stmt {
 	if len(b.results) == 0 {
-		return nil
+		return &ast.BlockStmt{
+			List: []ast.Stmt{
+				b.buildReportCode(args),
+			},
+		}
 	}
-	resultSelectors := []ast.Expr{}
-	for _, result := range b.results {
-		resultSelectors = append(resultSelectors, &ast.SelectorExpr{
-			X:   b.returnsFieldSelector,
-			Sel: ast.NewIdent(result.Names[0].String()),
+	statements := []ast.Stmt{}
+	if b.returnsOnCallSel != nil {
+		statements = append(statements, b.buildReturnOnCallCode())
+	}
+	if b.rulesFieldSelector != nil {
+		statements = append(statements, b.buildEvaluateRulesCode(args, hasEllipsis))
+	}
+	if b.configuredSelector != nil {
+		var unconfiguredCode ast.Stmt = b.buildReportCode(args)
+		if b.defaultResult != nil {
+			unconfiguredCode = &ast.ReturnStmt{
+				Results: []ast.Expr{
+					b.defaultResult,
+				},
+			}
+		}
+		statements = append(statements, &ast.IfStmt{
+			Cond: &ast.UnaryExpr{
+				Op: token.NOT,
+				X:  ast.NewIdent("configured"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					unconfiguredCode,
+				},
+			},
 		})
 	}
+	statements = append(statements, &ast.ReturnStmt{
+		Results: b.buildResultSelectors(ast.NewIdent("returns")),
+	})
 	return &ast.BlockStmt{
-		List: []ast.Stmt{
-			&ast.ReturnStmt{
-				Results: resultSelectors,
+		List: statements,
+	}
+}
+
+// buildReturnOnCallCode creates the code that returns the results
+// that were configured for the current call, if any.
+func (b *StubMethodBuilder) buildReturnOnCallCode() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: ast.NewIdent("hasReturnsOnCall"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: b.buildResultSelectors(ast.NewIdent("returnsOnCall")),
+				},
+			},
+		},
+	}
+}
+
+func (b *StubMethodBuilder) buildReportCode(args []ast.Expr) ast.Stmt {
+	reportArgs := []ast.Expr{
+		&ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf("\"%s\"", b.methodName),
+		},
+	}
+	reportArgs = append(reportArgs, args...)
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun:  b.reportMethodSelector,
+			Args: reportArgs,
+		},
+	}
+}
+
+func (b *StubMethodBuilder) buildEvaluateRulesCode(args []ast.Expr, hasEllipsis bool) ast.Stmt {
+	ellipsisPos := token.NoPos
+	if hasEllipsis {
+		ellipsisPos = 1
+	}
+	ruleReturnsSelector := &ast.SelectorExpr{
+		X:   ast.NewIdent("rule"),
+		Sel: ast.NewIdent(ruleReturnsFieldName),
+	}
+	return &ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent("rule"),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("rules"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: &ast.CallExpr{
+						Ellipsis: ellipsisPos,
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("rule"),
+							Sel: ast.NewIdent(ruleMatcherFieldName),
+						},
+						Args: args,
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: b.buildResultSelectors(ruleReturnsSelector),
+							},
+						},
+					},
+				},
 			},
 		},
 	}
 }
+
+func (b *StubMethodBuilder) buildResultSelectors(returnsSelector ast.Expr) []ast.Expr {
+	resultSelectors := []ast.Expr{}
+	for _, result := range b.results {
+		resultSelectors = append(resultSelectors, &ast.SelectorExpr{
+			X:   returnsSelector,
+			Sel: ast.NewIdent(util.ToPublic(result.Names[0].String())),
+		})
+	}
+	return resultSelectors
+}
+
+// callbackInvokes describes a callback parameter of the stubbed method
+// and the field that holds the functions which invoke it.
+type callbackInvokes struct {
+	paramName string
+	selector  *ast.SelectorExpr
+}
+
+func (c callbackInvokes) localName() string {
+	return c.paramName + "Invokes"
+}
+
+// argSets describes a pointer parameter of the stubbed method and the
+// fields that hold the functions which assign values into it.
+type argSets struct {
+	paramName      string
+	selector       *ast.SelectorExpr
+	onCallSelector *ast.SelectorExpr
+}
+
+func (s argSets) localName() string {
+	return s.paramName + "Sets"
+}
@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewTestConstructorBuilder(methodBuilder *MethodBuilder) *TestConstructorBuilder {
+	return &TestConstructorBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// TestConstructorBuilder is responsible for creating a function that
+// creates a new stub which is bound to a test. All expectations that
+// are declared on the stub are verified when the test finishes. Only
+// such stubs accept expectations. Composed stubs bind the stubs that
+// they embed to the test as well.
+//
+// Example:
+//     func NewStubStructT(t interface {
+//         Helper()
+//         Errorf(format string, args ...interface{})
+//         Cleanup(func())
+//     }, opts ...StubStructOption) *StubStruct {
+//         stub := NewStubStruct(opts...)
+//         stub.verifiesExpectations = true
+//         t.Cleanup(func() {
+//             stub.verifyExpectations(t)
+//         })
+//         return stub
+//     }
+type TestConstructorBuilder struct {
+	methodBuilder              *MethodBuilder
+	constructorName            string
+	verifyExpectationsSelector *ast.SelectorExpr
+	verifiesSelector           *ast.SelectorExpr
+	testType                   ast.Expr
+	stubName                   string
+	optionTypeName             string
+	embeddedVerifiesSelectors  []*ast.SelectorExpr
+}
+
+func (b *TestConstructorBuilder) SetStubName(name string) {
+	b.stubName = name
+}
+
+func (b *TestConstructorBuilder) SetOptionTypeName(name string) {
+	b.optionTypeName = name
+}
+
+// SetConstructorName specifies the name of the constructor that
+// creates the stub and applies the options to it.
+func (b *TestConstructorBuilder) SetConstructorName(name string) {
+	b.constructorName = name
+}
+
+func (b *TestConstructorBuilder) SetVerifyExpectationsSelector(selector *ast.SelectorExpr) {
+	b.verifyExpectationsSelector = selector
+}
+
+// SetVerifiesExpectationsFieldSelector configures the field that marks
+// the stub as one whose expectations are verified.
+func (b *TestConstructorBuilder) SetVerifiesExpectationsFieldSelector(selector *ast.SelectorExpr) {
+	b.verifiesSelector = selector
+}
+
+// SetTestType configures the type of the test that the stub is bound
+// to. The type should have already been resolved.
+func (b *TestConstructorBuilder) SetTestType(testType ast.Expr) {
+	b.testType = testType
+}
+
+// AddEmbeddedVerifiesExpectationsFieldSelector configures the field
+// that marks a stub which is embedded into the stub as one whose
+// expectations are verified.
+func (b *TestConstructorBuilder) AddEmbeddedVerifiesExpectationsFieldSelector(selector *ast.SelectorExpr) {
+	b.embeddedVerifiesSelectors = append(b.embeddedVerifiesSelectors, selector)
+}
+
+func (b *TestConstructorBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("t", b.testType),
+				util.CreateField("opts", &ast.Ellipsis{
+					Elt: ast.NewIdent(b.optionTypeName),
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.stubName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent(receiverName),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent(b.constructorName),
+				Args: []ast.Expr{
+					ast.NewIdent("opts"),
+				},
+				Ellipsis: 1,
+			},
+		},
+	}))
+	for _, selector := range append([]*ast.SelectorExpr{b.verifiesSelector}, b.embeddedVerifiesSelectors...) {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				selector,
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				ast.NewIdent("true"),
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("t"),
+				Sel: ast.NewIdent("Cleanup"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: b.verifyExpectationsSelector,
+									Args: []ast.Expr{
+										ast.NewIdent("t"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(receiverName),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewUnexpectedMethodBuilder(methodBuilder *MethodBuilder) *UnexpectedMethodBuilder {
+	return &UnexpectedMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		results:       make([]*ast.Field, 0),
+	}
+}
+
+// UnexpectedMethodBuilder is responsible for creating a method that
+// implements a method from the interface which was not selected for
+// stubbing. The method panics when called.
+//
+// Example:
+//     func (stub *StubStruct) Sum(int, int) int {
+//         panic("unexpected call to Sum")
+//     }
+type UnexpectedMethodBuilder struct {
+	methodBuilder *MethodBuilder
+	methodName    string
+	params        []*ast.Field
+	results       []*ast.Field
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in the panic message.
+func (b *UnexpectedMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *UnexpectedMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// SetResults specifies the results that the original method
+// returns. These results need to have been normalized and resolved
+// in advance.
+func (b *UnexpectedMethodBuilder) SetResults(results []*ast.Field) {
+	b.results = results
+}
+
+func (b *UnexpectedMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: util.FieldsAsAnonymous(b.params),
+		},
+		Results: &ast.FieldList{
+			List: util.FieldsAsAnonymous(b.results),
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent("panic"),
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("\"unexpected call to %s\"", b.methodName),
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewUnmarshalJSONMethodBuilder(methodBuilder *MethodBuilder) *UnmarshalJSONMethodBuilder {
+	return &UnmarshalJSONMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// UnmarshalJSONMethodBuilder is responsible for creating a method on
+// an arguments or results structure that deserializes the structure from
+// JSON, recreating error fields from their messages.
+//
+// Example:
+//     func (value *StubStructMethodResults) UnmarshalJSON(data []byte) error {
+//         // ...
+//     }
+type UnmarshalJSONMethodBuilder struct {
+	methodBuilder     *MethodBuilder
+	receiverName      string
+	unmarshalSelector *ast.SelectorExpr
+	newErrorSelector  *ast.SelectorExpr
+	fields            []*ast.Field
+}
+
+func (b *UnmarshalJSONMethodBuilder) SetReceiverName(name string) {
+	b.receiverName = name
+}
+
+// SetUnmarshalSelector configures the json.Unmarshal function.
+// The selector should have already been resolved.
+func (b *UnmarshalJSONMethodBuilder) SetUnmarshalSelector(selector *ast.SelectorExpr) {
+	b.unmarshalSelector = selector
+}
+
+// SetNewErrorSelector configures the errors.New function.
+// The selector should have already been resolved.
+func (b *UnmarshalJSONMethodBuilder) SetNewErrorSelector(selector *ast.SelectorExpr) {
+	b.newErrorSelector = selector
+}
+
+// SetFields specifies the fields of the structure. These fields
+// need to have been exported and resolved in advance.
+func (b *UnmarshalJSONMethodBuilder) SetFields(fields []*ast.Field) {
+	b.fields = fields
+}
+
+func (b *UnmarshalJSONMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("data", &ast.ArrayType{
+					Elt: ast.NewIdent("byte"),
+				}),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						ast.NewIdent("aux"),
+					},
+					Type: jsonAuxType(b.fields),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.unmarshalSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("data"),
+					&ast.UnaryExpr{
+						Op: token.AND,
+						X:  ast.NewIdent("aux"),
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}))
+	for _, field := range b.fields {
+		fieldName := field.Names[0].String()
+		valueSelector := &ast.SelectorExpr{
+			X:   ast.NewIdent(b.receiverName),
+			Sel: ast.NewIdent(fieldName),
+		}
+		auxSelector := &ast.SelectorExpr{
+			X:   ast.NewIdent("aux"),
+			Sel: ast.NewIdent(fieldName),
+		}
+		if !isErrorType(field.Type) {
+			b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					valueSelector,
+				},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{
+					auxSelector,
+				},
+			}))
+			continue
+		}
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  auxSelector,
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							valueSelector,
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: b.newErrorSelector,
+								Args: []ast.Expr{
+									&ast.StarExpr{
+										X: auxSelector,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("nil"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
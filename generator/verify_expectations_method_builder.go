@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewVerifyExpectationsMethodBuilder(methodBuilder *MethodBuilder) *VerifyExpectationsMethodBuilder {
+	return &VerifyExpectationsMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// VerifyExpectationsMethodBuilder is responsible for creating a method
+// on the stub structure that verifies all expectations that have been
+// declared on the stub. Composed stubs verify the expectations of the
+// stubs that they embed as well.
+//
+// Example:
+//     func (stub *StubStruct) verifyExpectations(reporter interface {
+//         Helper()
+//         Errorf(format string, args ...interface{})
+//     }) {
+//         // ...
+//     }
+type VerifyExpectationsMethodBuilder struct {
+	methodBuilder             *MethodBuilder
+	mutexFieldSelector        *ast.SelectorExpr
+	expectationsFieldSelector *ast.SelectorExpr
+	reporterType              ast.Expr
+	embeddedSelectors         []*ast.SelectorExpr
+}
+
+func (b *VerifyExpectationsMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *VerifyExpectationsMethodBuilder) SetExpectationsFieldSelector(selector *ast.SelectorExpr) {
+	b.expectationsFieldSelector = selector
+}
+
+// SetReporterType configures the type of the reporter that failures
+// are reported to. The type should have already been resolved.
+func (b *VerifyExpectationsMethodBuilder) SetReporterType(reporterType ast.Expr) {
+	b.reporterType = reporterType
+}
+
+// AddEmbeddedStub registers a stub that is embedded into the stub,
+// so that its expectations are verified as well.
+func (b *VerifyExpectationsMethodBuilder) AddEmbeddedStub(selector *ast.SelectorExpr) {
+	b.embeddedSelectors = append(b.embeddedSelectors, selector)
+}
+
+func (b *VerifyExpectationsMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("RLock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("RUnlock")
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("reporter", b.reporterType),
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("reporter"),
+				Sel: ast.NewIdent("Helper"),
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("expectations"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			b.expectationsFieldSelector,
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.RangeStmt{
+		Key:   ast.NewIdent("_"),
+		Value: ast.NewIdent(expectationReceiverName),
+		Tok:   token.DEFINE,
+		X:     ast.NewIdent("expectations"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent(expectationReceiverName),
+							Sel: ast.NewIdent(expectationVerifyMethodName),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("reporter"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	for _, embedded := range b.embeddedSelectors {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   embedded,
+					Sel: ast.NewIdent(verifyExpectationsMethodName),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("reporter"),
+				},
+			},
+		}))
+	}
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,230 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewWaitMethodBuilder(methodBuilder *MethodBuilder) *WaitMethodBuilder {
+	return &WaitMethodBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// WaitMethodBuilder is responsible for creating a method on the stub
+// structure that blocks until the stubbed method has been called
+// a given number of times or a timeout expires.
+//
+// Example:
+//     func (stub *StubStruct) WaitForSumCalls(count int, timeout time.Duration) error {
+//         // ...
+//     }
+type WaitMethodBuilder struct {
+	methodBuilder           *MethodBuilder
+	mutexFieldSelector      *ast.SelectorExpr
+	argsFieldSelector       *ast.SelectorExpr
+	callSignalFieldSelector *ast.SelectorExpr
+	callCountMethodSelector *ast.SelectorExpr
+	durationType            ast.Expr
+	afterSelector           *ast.SelectorExpr
+	errorfSelector          *ast.SelectorExpr
+	methodName              string
+}
+
+func (b *WaitMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *WaitMethodBuilder) SetArgsFieldSelector(selector *ast.SelectorExpr) {
+	b.argsFieldSelector = selector
+}
+
+func (b *WaitMethodBuilder) SetCallSignalFieldSelector(selector *ast.SelectorExpr) {
+	b.callSignalFieldSelector = selector
+}
+
+func (b *WaitMethodBuilder) SetCallCountMethodSelector(selector *ast.SelectorExpr) {
+	b.callCountMethodSelector = selector
+}
+
+// SetDurationType configures the type of the timeout parameter.
+// The type should have already been resolved.
+func (b *WaitMethodBuilder) SetDurationType(durationType ast.Expr) {
+	b.durationType = durationType
+}
+
+// SetAfterSelector configures the function that is used to create
+// the timeout channel. The selector should have already been resolved.
+func (b *WaitMethodBuilder) SetAfterSelector(selector *ast.SelectorExpr) {
+	b.afterSelector = selector
+}
+
+// SetErrorfSelector configures the function that is used to create
+// the timeout error. The selector should have already been resolved.
+func (b *WaitMethodBuilder) SetErrorfSelector(selector *ast.SelectorExpr) {
+	b.errorfSelector = selector
+}
+
+// SetMethodName specifies the name of the original method, as
+// it should appear in the timeout error.
+func (b *WaitMethodBuilder) SetMethodName(name string) {
+	b.methodName = name
+}
+
+func (b *WaitMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("count", ast.NewIdent("int")),
+				util.CreateField("timeout", b.durationType),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	})
+
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("deadline"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: b.afterSelector,
+				Args: []ast.Expr{
+					ast.NewIdent("timeout"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ForStmt{
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				mutexLockBuilder.Build(),
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X: &ast.CallExpr{
+							Fun: ast.NewIdent("len"),
+							Args: []ast.Expr{
+								b.argsFieldSelector,
+							},
+						},
+						Op: token.GEQ,
+						Y:  ast.NewIdent("count"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							mutexUnlockBuilder.Build(),
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("nil"),
+								},
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  b.callSignalFieldSelector,
+						Op: token.EQL,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									b.callSignalFieldSelector,
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: ast.NewIdent("make"),
+										Args: []ast.Expr{
+											&ast.ChanType{
+												Dir:   ast.SEND | ast.RECV,
+												Value: util.CreateEmptyStruct(),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("callSignal"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						b.callSignalFieldSelector,
+					},
+				},
+				mutexUnlockBuilder.Build(),
+				&ast.SelectStmt{
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.CommClause{
+								Comm: &ast.ExprStmt{
+									X: &ast.UnaryExpr{
+										Op: token.ARROW,
+										X:  ast.NewIdent("callSignal"),
+									},
+								},
+							},
+							&ast.CommClause{
+								Comm: &ast.ExprStmt{
+									X: &ast.UnaryExpr{
+										Op: token.ARROW,
+										X:  ast.NewIdent("deadline"),
+									},
+								},
+								Body: []ast.Stmt{
+									&ast.ReturnStmt{
+										Results: []ast.Expr{
+											b.buildTimeoutError(),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
+
+func (b *WaitMethodBuilder) buildTimeoutError() ast.Expr {
+	return &ast.CallExpr{
+		Fun: b.errorfSelector,
+		Args: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("\"timed out after %%v waiting for %%d calls to %s, got %%d\"", b.methodName),
+			},
+			ast.NewIdent("timeout"),
+			ast.NewIdent("count"),
+			&ast.CallExpr{
+				Fun: b.callCountMethodSelector,
+			},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func NewWhenMethodBuilder(methodBuilder *MethodBuilder) *WhenMethodBuilder {
+	return &WhenMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+	}
+}
+
+// WhenMethodBuilder is responsible for creating a method on the stub
+// structure that allows you to register results which are returned
+// only when the arguments of a call satisfy a given matcher.
+//
+// Example:
+//     func (stub *StubStruct) SumWhen(matcher func(a int, b int) bool) *StubStructSumRule {
+//         // ...
+//     }
+type WhenMethodBuilder struct {
+	methodBuilder      *MethodBuilder
+	mutexFieldSelector *ast.SelectorExpr
+	rulesFieldSelector *ast.SelectorExpr
+	ruleTypeName       string
+	params             []*ast.Field
+}
+
+func (b *WhenMethodBuilder) SetMutexFieldSelector(selector *ast.SelectorExpr) {
+	b.mutexFieldSelector = selector
+}
+
+func (b *WhenMethodBuilder) SetRulesFieldSelector(selector *ast.SelectorExpr) {
+	b.rulesFieldSelector = selector
+}
+
+func (b *WhenMethodBuilder) SetRuleTypeName(name string) {
+	b.ruleTypeName = name
+}
+
+// SetParams specifies the parameters that the original method
+// uses. These parameters need to have been normalized and resolved
+// in advance.
+func (b *WhenMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+func (b *WhenMethodBuilder) Build() ast.Decl {
+	mutexLockBuilder := NewMutexActionBuilder()
+	mutexLockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexLockBuilder.SetAction("Lock")
+
+	mutexUnlockBuilder := NewMutexActionBuilder()
+	mutexUnlockBuilder.SetMutexFieldSelector(b.mutexFieldSelector)
+	mutexUnlockBuilder.SetAction("Unlock")
+	mutexUnlockBuilder.SetDeferred(true)
+
+	matcherBuilder := NewMatcherFieldBuilder()
+	matcherBuilder.SetFieldName("matcher")
+	matcherBuilder.SetParams(b.params)
+
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				matcherBuilder.Build(),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.ruleTypeName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(mutexLockBuilder)
+	b.methodBuilder.AddStatementBuilder(mutexUnlockBuilder)
+
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("rule"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.ruleTypeName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent(ruleMutexFieldName),
+							Value: &ast.UnaryExpr{
+								Op: token.AND,
+								X:  b.mutexFieldSelector,
+							},
+						},
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(ruleMatcherFieldName),
+							Value: ast.NewIdent("matcher"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.AssignStmt{
+		Lhs: []ast.Expr{
+			b.rulesFieldSelector,
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: ast.NewIdent("append"),
+				Args: []ast.Expr{
+					b.rulesFieldSelector,
+					ast.NewIdent("rule"),
+				},
+			},
+		},
+	}))
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("rule"),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+func NewWithArgsMethodBuilder(methodBuilder *MethodBuilder) *WithArgsMethodBuilder {
+	return &WithArgsMethodBuilder{
+		methodBuilder: methodBuilder,
+		params:        make([]*ast.Field, 0),
+		calls:         make([]ast.Stmt, 0),
+	}
+}
+
+// WithArgsMethodBuilder is responsible for creating a method of the
+// call matcher of a given stub method that configures the expected
+// arguments, in a type-safe manner. The method returns the matcher,
+// so that calls to such methods can be chained.
+//
+// Example:
+//     func (matcher *StubStructSumCallMatcher) With(a int, b int) *StubStructSumCallMatcher {
+//         matcher.expectArg(0, a)
+//         matcher.expectArg(1, b)
+//         return matcher
+//     }
+type WithArgsMethodBuilder struct {
+	methodBuilder *MethodBuilder
+	receiverName  string
+	receiverType  string
+	params        []*ast.Field
+	calls         []ast.Stmt
+}
+
+// SetReceiver specifies the name and type of the receiver that
+// is returned by the method.
+func (b *WithArgsMethodBuilder) SetReceiver(name, recType string) {
+	b.receiverName = name
+	b.receiverType = recType
+}
+
+// SetParams configures the parameters of the method. Their types
+// should have already been resolved.
+func (b *WithArgsMethodBuilder) SetParams(params []*ast.Field) {
+	b.params = params
+}
+
+// AddExpectation specifies that the method should call the specified
+// method with the index of an argument, followed by the specified
+// values, if any.
+func (b *WithArgsMethodBuilder) AddExpectation(method *ast.SelectorExpr, index int, values ...ast.Expr) {
+	b.calls = append(b.calls, &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: method,
+			Args: append([]ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf("%d", index),
+				},
+			}, values...),
+		},
+	})
+}
+
+func (b *WithArgsMethodBuilder) Build() ast.Decl {
+	b.methodBuilder.SetReceiver(b.receiverName, b.receiverType)
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: b.params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.receiverType),
+					},
+				},
+			},
+		},
+	})
+	for _, call := range b.calls {
+		b.methodBuilder.AddStatementBuilder(StatementToBuilder(call))
+	}
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(b.receiverName),
+		},
+	}))
+	return b.methodBuilder.Build()
+}
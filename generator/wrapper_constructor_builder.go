@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/mokiat/gostub/util"
+)
+
+func NewWrapperConstructorBuilder(methodBuilder *MethodBuilder) *WrapperConstructorBuilder {
+	return &WrapperConstructorBuilder{
+		methodBuilder: methodBuilder,
+	}
+}
+
+// WrapperConstructorBuilder is responsible for creating a function
+// that creates a structure which wraps the specified target, such as
+// a golden recorder that wraps an implementation of the stubbed interface.
+//
+// Example:
+//     func NewStubStructGoldenRecorder(target Interface) *StubStructGoldenRecorder {
+//         // ...
+//     }
+type WrapperConstructorBuilder struct {
+	methodBuilder   *MethodBuilder
+	wrapperName     string
+	targetFieldName string
+	targetType      ast.Expr
+}
+
+// SetWrapperName specifies the name of the wrapping structure.
+func (b *WrapperConstructorBuilder) SetWrapperName(name string) {
+	b.wrapperName = name
+}
+
+// SetTargetFieldName specifies the field of the wrapping structure
+// that holds the target.
+func (b *WrapperConstructorBuilder) SetTargetFieldName(name string) {
+	b.targetFieldName = name
+}
+
+// SetTargetType configures the type of the target.
+// The type should have already been resolved.
+func (b *WrapperConstructorBuilder) SetTargetType(targetType ast.Expr) {
+	b.targetType = targetType
+}
+
+func (b *WrapperConstructorBuilder) Build() ast.Decl {
+	b.methodBuilder.SetType(&ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				util.CreateField("target", b.targetType),
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(b.wrapperName),
+					},
+				},
+			},
+		},
+	})
+	b.methodBuilder.AddStatementBuilder(StatementToBuilder(&ast.ReturnStmt{
+		Results: []ast.Expr{
+			&ast.UnaryExpr{
+				Op: token.AND,
+				X: &ast.CompositeLit{
+					Type: ast.NewIdent(b.wrapperName),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key:   ast.NewIdent(b.targetFieldName),
+							Value: ast.NewIdent("target"),
+						},
+					},
+				},
+			},
+		},
+	}))
+	return b.methodBuilder.Build()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	cli "gopkg.in/urfave/cli.v1"
 
@@ -27,10 +28,20 @@ func RunGoStub(c *cli.Context) {
 }
 
 type goStubInput struct {
-	InterfaceName   string
-	SourceDirectory string
-	StubName        string
-	OutputFilePath  string
+	InterfaceName    string
+	SourceDirectory  string
+	StubName         string
+	OutputFilePath   string
+	MatchersFilePath string
+	GoldenFilePath   string
+	RemoteFilePath   string
+	Deep             bool
+	Random           bool
+	Methods          []string
+	Compose          bool
+	Counterfeiter    bool
+	Mock             bool
+	Features         generator.Features
 }
 
 func parseInput(c *cli.Context) (goStubInput, error) {
@@ -48,16 +59,37 @@ func parseInput(c *cli.Context) (goStubInput, error) {
 		return goStubInput{}, err
 	}
 
+	counterfeiter := c.Bool("counterfeiter")
+	mock := c.Bool("mock")
+
 	stubName := c.String("name")
 	if stubName == "" {
-		stubName = interfaceName + "Stub"
+		switch {
+		case counterfeiter:
+			stubName = "Fake" + interfaceName
+		case mock:
+			stubName = "Mock" + interfaceName
+		default:
+			stubName = interfaceName + "Stub"
+		}
 	}
 
 	outputFileName := c.String("output")
 	if outputFileName == "" {
-		outputFolder := filepath.Join(sourceDir, filepath.Base(sourceDir)+"_stubs")
-		outputFile := util.SnakeCase(interfaceName) + "_stub.go"
-		outputFileName = filepath.Join(outputFolder, outputFile)
+		switch {
+		case counterfeiter:
+			outputFolder := filepath.Join(sourceDir, filepath.Base(sourceDir)+"fakes")
+			outputFile := "fake_" + util.SnakeCase(interfaceName) + ".go"
+			outputFileName = filepath.Join(outputFolder, outputFile)
+		case mock:
+			outputFolder := filepath.Join(sourceDir, filepath.Base(sourceDir)+"_mocks")
+			outputFile := util.SnakeCase(interfaceName) + "_mock.go"
+			outputFileName = filepath.Join(outputFolder, outputFile)
+		default:
+			outputFolder := filepath.Join(sourceDir, filepath.Base(sourceDir)+"_stubs")
+			outputFile := util.SnakeCase(interfaceName) + "_stub.go"
+			outputFileName = filepath.Join(outputFolder, outputFile)
+		}
 	}
 	if filepath.Ext(outputFileName) != ".go" {
 		return goStubInput{}, errors.New("The output file needs to have the `go` extension! Run `gostub --help` for more information.")
@@ -67,11 +99,57 @@ func parseInput(c *cli.Context) (goStubInput, error) {
 		return goStubInput{}, err
 	}
 
+	matchersFileName := ""
+	if c.Bool("matchers") {
+		matchersFileName = strings.TrimSuffix(strings.TrimSuffix(outputFileName, ".go"), "_stub") + "_matchers.go"
+	}
+
+	goldenFileName := ""
+	if c.Bool("golden") {
+		goldenFileName = strings.TrimSuffix(strings.TrimSuffix(outputFileName, ".go"), "_stub") + "_golden.go"
+	}
+
+	remoteFileName := ""
+	if c.Bool("remote") {
+		remoteFileName = strings.TrimSuffix(strings.TrimSuffix(outputFileName, ".go"), "_stub") + "_remote.go"
+	}
+
+	methods := []string{}
+	for _, name := range strings.Split(c.String("methods"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			methods = append(methods, name)
+		}
+	}
+
 	return goStubInput{
-		InterfaceName:   interfaceName,
-		SourceDirectory: sourceDir,
-		StubName:        stubName,
-		OutputFilePath:  outputFileName,
+		InterfaceName:    interfaceName,
+		SourceDirectory:  sourceDir,
+		StubName:         stubName,
+		OutputFilePath:   outputFileName,
+		MatchersFilePath: matchersFileName,
+		GoldenFilePath:   goldenFileName,
+		RemoteFilePath:   remoteFileName,
+		Deep:             c.Bool("deep"),
+		Random:           c.Bool("random"),
+		Methods:          methods,
+		Compose:          c.Bool("compose"),
+		Counterfeiter:    counterfeiter,
+		Mock:             mock,
+		Features: generator.Features{
+			Rules:            c.Bool("rules"),
+			Strict:           c.Bool("strict"),
+			Wait:             c.Bool("wait"),
+			Hold:             c.Bool("hold"),
+			Options:          c.Bool("options"),
+			Expectations:     c.Bool("expect"),
+			Invocations:      c.Bool("invocations"),
+			Recorder:         c.Bool("recorder"),
+			SetsArgs:         c.Bool("sets"),
+			InvokesCallbacks: c.Bool("invokes"),
+			Scenario:         c.Bool("scenario"),
+			Latency:          c.Bool("latency"),
+			Panics:           c.Bool("panics"),
+		},
 	}, nil
 }
 
@@ -86,6 +164,16 @@ func prepareGeneratorConfig(input goStubInput) (generator.Config, error) {
 	config.TargetFilePath = input.OutputFilePath
 	config.TargetPackageName = filepath.Base(filepath.Dir(input.OutputFilePath))
 	config.TargetStructName = input.StubName
+	config.TargetMatchersFilePath = input.MatchersFilePath
+	config.TargetGoldenFilePath = input.GoldenFilePath
+	config.TargetRemoteFilePath = input.RemoteFilePath
+	config.Deep = input.Deep
+	config.Random = input.Random
+	config.Methods = input.Methods
+	config.Compose = input.Compose
+	config.Counterfeiter = input.Counterfeiter
+	config.Mock = input.Mock
+	config.Features = input.Features
 	return config, nil
 }
 
@@ -25,6 +25,94 @@ func main() {
 			Name:  "name, n",
 			Usage: "the name of the generated stub. If not specified, the 'Stub' suffix is appended to the interface name in order to form the stub name.",
 		},
+		cli.BoolFlag{
+			Name:  "matchers, m",
+			Usage: "generate Gomega matchers for the stub in a companion '_matchers.go' file next to the stub.",
+		},
+		cli.BoolFlag{
+			Name:  "deep, d",
+			Usage: "also generate stubs for the interfaces returned by methods, transitively. Methods return such child stubs by default.",
+		},
+		cli.BoolFlag{
+			Name:  "golden, g",
+			Usage: "generate a recorder that saves calls to a golden file and a replaying stub constructor in a companion '_golden.go' file next to the stub.",
+		},
+		cli.BoolFlag{
+			Name:  "remote, r",
+			Usage: "generate an HTTP handler that exposes the configuration and calls of the stub and a client for it in a companion '_remote.go' file next to the stub. Implies --scenario.",
+		},
+		cli.BoolFlag{
+			Name:  "random",
+			Usage: "generate methods that configure random results for the stub, drawn from a seed.",
+		},
+		cli.BoolFlag{
+			Name:  "compose, c",
+			Usage: "embed the existing stubs of the interfaces embedded by the interface, instead of stubbing their methods again. The stubs need to have been generated in the output package already.",
+		},
+		cli.BoolFlag{
+			Name:  "counterfeiter",
+			Usage: "generate a fake whose API, field names and file layout match the ones of counterfeiter, so that tests written against counterfeiter fakes keep working. If not specified otherwise, the fake is named 'Fake' followed by the interface name and is saved in a 'fakes' package.",
+		},
+		cli.BoolFlag{
+			Name:  "mock",
+			Usage: "generate an expectation-based mock in the style of gomock, which is driven by a controller from the 'github.com/mokiat/gostub/mock' package. If not specified otherwise, the mock is named 'Mock' followed by the interface name and is saved in a '_mocks' package.",
+		},
+		cli.BoolFlag{
+			Name:  "rules",
+			Usage: "generate When and CalledWith methods that configure results which are only returned for matching arguments.",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "generate a SetStrict method, after which calls to methods without configured results are reported.",
+		},
+		cli.BoolFlag{
+			Name:  "wait",
+			Usage: "generate methods that wait for asynchronous calls to the stub.",
+		},
+		cli.BoolFlag{
+			Name:  "hold",
+			Usage: "generate methods that hold calls to the stub until they are released through a gate.",
+		},
+		cli.BoolFlag{
+			Name:  "options",
+			Usage: "generate a constructor for the stub that accepts functional options, along with the options for each method.",
+		},
+		cli.BoolFlag{
+			Name:  "expect",
+			Usage: "generate a constructor that binds the stub to a test, along with methods that declare the expected number of calls, which are verified at cleanup. Implies --options.",
+		},
+		cli.BoolFlag{
+			Name:  "invocations",
+			Usage: "generate an Invocations method that returns the calls to all methods of the stub, in order.",
+		},
+		cli.BoolFlag{
+			Name:  "recorder",
+			Usage: "generate an AttachRecorder method that passes all calls to a shared recorder, such as the one in the 'github.com/mokiat/gostub/recorder' package.",
+		},
+		cli.BoolFlag{
+			Name:  "sets",
+			Usage: "generate methods that configure values to be assigned into pointer and empty interface params on call.",
+		},
+		cli.BoolFlag{
+			Name:  "invokes",
+			Usage: "generate methods that configure callback params to be invoked on call.",
+		},
+		cli.BoolFlag{
+			Name:  "scenario",
+			Usage: "generate a LoadScenario method that configures results from a JSON scenario. Only JSON is supported.",
+		},
+		cli.BoolFlag{
+			Name:  "latency",
+			Usage: "generate methods that delay calls, or block them until their context is done.",
+		},
+		cli.BoolFlag{
+			Name:  "panics",
+			Usage: "generate methods that make calls panic. Implies --invocations, which reports the panicked calls.",
+		},
+		cli.StringFlag{
+			Name:  "methods",
+			Usage: "a comma-separated list of the methods to be stubbed (e.g. 'Get,Put'). The remaining methods panic when called. If not specified, all methods are stubbed.",
+		},
 	}
 	app.Action = RunGoStub
 	app.Run(os.Args)
@@ -35,7 +123,7 @@ NAME
    {{.Name}} - {{.Usage}}
 
 SYNOPSIS
-   {{.Name}} [-s source_folder] [-o output_file] [-n stub_name] interface_name
+   {{.Name}} [-s source_folder] [-o output_file] [-n stub_name] [-m] [-d] [-g] [-r] [--random] [-c] [--counterfeiter] [--mock] [--rules] [--strict] [--wait] [--hold] [--options] [--expect] [--invocations] [--recorder] [--sets] [--invokes] [--scenario] [--latency] [--panics] [--methods method_names] interface_name
 
 DESCRIPTION
    The gostub command generates a Stub structure which implements the specified interface.
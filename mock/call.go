@@ -0,0 +1,231 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const unlimited int = -1
+
+func newCall(t TestReporter, receiver interface{}, method string, methodType reflect.Type, args []interface{}) *Call {
+	matchers := make([]Matcher, len(args))
+	for i, arg := range args {
+		switch matcher := arg.(type) {
+		case Matcher:
+			matchers[i] = matcher
+		case nil:
+			matchers[i] = Nil()
+		default:
+			matchers[i] = Eq(arg)
+		}
+	}
+	return &Call{
+		t:          t,
+		receiver:   receiver,
+		method:     method,
+		methodType: methodType,
+		matchers:   matchers,
+		results:    zeroResults(methodType),
+		minTimes:   1,
+		maxTimes:   1,
+	}
+}
+
+// Call represents an expected call to a method of a mock. Its methods
+// configure the expectation and return the Call, so that they can be
+// chained.
+type Call struct {
+	t             TestReporter
+	receiver      interface{}
+	method        string
+	methodType    reflect.Type
+	matchers      []Matcher
+	results       []interface{}
+	action        reflect.Value
+	actionResults bool
+	prerequisites []*Call
+	minTimes      int
+	maxTimes      int
+	count         int
+}
+
+// Return configures the results of the call. The number and types of
+// the results need to match the ones of the method.
+func (c *Call) Return(results ...interface{}) *Call {
+	c.t.Helper()
+	if len(results) != c.methodType.NumOut() {
+		c.t.Fatalf("gostub: wrong number of results to Return for %s: got %d, want %d", c, len(results), c.methodType.NumOut())
+		return c
+	}
+	values := make([]interface{}, len(results))
+	for i, result := range results {
+		resultType := c.methodType.Out(i)
+		if result == nil {
+			if !isNillable(resultType) {
+				c.t.Fatalf("gostub: wrong type of result %d to Return for %s: nil is not assignable to %s", i+1, c, resultType)
+				return c
+			}
+			values[i] = reflect.Zero(resultType).Interface()
+			continue
+		}
+		if !reflect.TypeOf(result).AssignableTo(resultType) {
+			c.t.Fatalf("gostub: wrong type of result %d to Return for %s: %T is not assignable to %s", i+1, c, result, resultType)
+			return c
+		}
+		values[i] = result
+	}
+	c.results = values
+	return c
+}
+
+// Do configures a function that is called with the arguments of the
+// call each time the call is matched. Its results are ignored.
+func (c *Call) Do(f interface{}) *Call {
+	c.action = reflect.ValueOf(f)
+	c.actionResults = false
+	return c
+}
+
+// DoAndReturn configures a function that is called with the arguments
+// of the call each time the call is matched and whose results are
+// returned by the call.
+func (c *Call) DoAndReturn(f interface{}) *Call {
+	c.action = reflect.ValueOf(f)
+	c.actionResults = true
+	return c
+}
+
+// Times configures the call to be expected exactly the specified
+// number of times.
+func (c *Call) Times(n int) *Call {
+	c.minTimes = n
+	c.maxTimes = n
+	return c
+}
+
+// MinTimes configures the call to be expected at least the specified
+// number of times. The call is no longer limited to a single time,
+// unless MaxTimes is also used.
+func (c *Call) MinTimes(n int) *Call {
+	c.minTimes = n
+	if c.maxTimes == 1 {
+		c.maxTimes = unlimited
+	}
+	return c
+}
+
+// MaxTimes configures the call to be expected at most the specified
+// number of times. The call is no longer required, unless MinTimes
+// is also used.
+func (c *Call) MaxTimes(n int) *Call {
+	c.maxTimes = n
+	if c.minTimes == 1 {
+		c.minTimes = 0
+	}
+	return c
+}
+
+// AnyTimes configures the call to be expected any number of times,
+// including none.
+func (c *Call) AnyTimes() *Call {
+	c.minTimes = 0
+	c.maxTimes = unlimited
+	return c
+}
+
+// After configures the call to be expected only after the specified
+// call has been called its minimum number of times.
+func (c *Call) After(prerequisite *Call) *Call {
+	c.prerequisites = append(c.prerequisites, prerequisite)
+	return c
+}
+
+func (c *Call) String() string {
+	args := make([]string, len(c.matchers))
+	for i, matcher := range c.matchers {
+		args[i] = matcher.String()
+	}
+	return fmt.Sprintf("%T.%s(%s)", c.receiver, c.method, strings.Join(args, ", "))
+}
+
+func (c *Call) matches(args []interface{}) error {
+	if len(args) != len(c.matchers) {
+		return fmt.Errorf("expected call %s has %d arguments, got %d", c, len(c.matchers), len(args))
+	}
+	for i, arg := range args {
+		if !c.matchers[i].Matches(arg) {
+			return fmt.Errorf("expected call %s does not match argument %d: got %#v, want %s", c, i+1, arg, c.matchers[i])
+		}
+	}
+	for _, prerequisite := range c.prerequisites {
+		if !prerequisite.satisfied() {
+			return fmt.Errorf("expected call %s is expected after %s, which has not been called yet", c, prerequisite)
+		}
+	}
+	return nil
+}
+
+func (c *Call) exhausted() bool {
+	return c.maxTimes != unlimited && c.count >= c.maxTimes
+}
+
+func (c *Call) satisfied() bool {
+	return c.count >= c.minTimes
+}
+
+func (c *Call) called() {
+	c.count++
+}
+
+func (c *Call) invoke(args []interface{}) []interface{} {
+	if !c.action.IsValid() {
+		return c.results
+	}
+	actionType := c.action.Type()
+	values := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			values[i] = reflect.Zero(paramType(actionType, i))
+		} else {
+			values[i] = reflect.ValueOf(arg)
+		}
+	}
+	actionValues := c.action.Call(values)
+	if !c.actionResults {
+		return c.results
+	}
+	results := make([]interface{}, len(actionValues))
+	for i, value := range actionValues {
+		results[i] = value.Interface()
+	}
+	return results
+}
+
+// paramType returns the type of the parameter of the specified function
+// that receives the argument at the specified index, with variadic
+// arguments passed individually.
+func paramType(funcType reflect.Type, index int) reflect.Type {
+	if funcType.IsVariadic() && index >= funcType.NumIn()-1 {
+		return funcType.In(funcType.NumIn() - 1).Elem()
+	}
+	return funcType.In(index)
+}
+
+// zeroResults returns the zero values of the results of the specified
+// method type.
+func zeroResults(methodType reflect.Type) []interface{} {
+	results := make([]interface{}, methodType.NumOut())
+	for i := range results {
+		results[i] = reflect.Zero(methodType.Out(i)).Interface()
+	}
+	return results
+}
+
+func isNillable(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	}
+	return false
+}
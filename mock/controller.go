@@ -0,0 +1,135 @@
+// Package mock provides the runtime of the expectation-based mocks that
+// are generated by gostub. Tests declare the calls that they expect
+// through the EXPECT method of a generated mock and the Controller
+// verifies that the calls are made as declared.
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TestReporter is used by the Controller to report failures. It is
+// satisfied by *testing.T and by GinkgoT().
+type TestReporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// NewController creates a new Controller that reports failures to
+// the specified reporter.
+func NewController(t TestReporter) *Controller {
+	return &Controller{
+		t:     t,
+		calls: make(map[callKey][]*Call),
+	}
+}
+
+// Controller tracks the expected calls of all mocks that have been
+// created with it and matches the actual calls against them.
+//
+// It is safe for concurrent use.
+type Controller struct {
+	t     TestReporter
+	mutex sync.Mutex
+	calls map[callKey][]*Call
+	order []*Call
+}
+
+type callKey struct {
+	receiver interface{}
+	method   string
+}
+
+// RecordCall registers an expected call to the specified method of
+// the specified mock. Nil arguments are matched with Nil and other
+// arguments that are not Matchers are compared with Eq. The call is
+// expected exactly once, unless configured otherwise. It is called
+// by generated mocks.
+func (c *Controller) RecordCall(receiver interface{}, method string, args ...interface{}) *Call {
+	c.t.Helper()
+	methodValue := reflect.ValueOf(receiver).MethodByName(method)
+	if !methodValue.IsValid() {
+		c.t.Fatalf("gostub: failed to expect %T.%s: method not found", receiver, method)
+		return nil
+	}
+	call := newCall(c.t, receiver, method, methodValue.Type(), args)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := callKey{receiver, method}
+	c.calls[key] = append(c.calls[key], call)
+	c.order = append(c.order, call)
+	return call
+}
+
+// Call matches a call to the specified method of the specified mock
+// against the expected calls and returns the results of the matching
+// one. An unexpected call is reported as fatal and, should the reporter
+// not stop the test, zero results are returned. It is called by
+// generated mocks.
+func (c *Controller) Call(receiver interface{}, method string, args ...interface{}) []interface{} {
+	c.t.Helper()
+	call, err := c.match(receiver, method, args)
+	if err != nil {
+		c.t.Fatalf("gostub: %s", err)
+		return zeroResults(reflect.ValueOf(receiver).MethodByName(method).Type())
+	}
+	return call.invoke(args)
+}
+
+func (c *Controller) match(receiver interface{}, method string, args []interface{}) (*Call, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	reasons := []string{}
+	for _, call := range c.calls[callKey{receiver, method}] {
+		if err := call.matches(args); err != nil {
+			reasons = append(reasons, err.Error())
+			continue
+		}
+		if call.exhausted() {
+			reasons = append(reasons, fmt.Sprintf("expected call %s has already been called the max number of times", call))
+			continue
+		}
+		call.called()
+		return call, nil
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "there are no expected calls of the method")
+	}
+	return nil, fmt.Errorf("unexpected call to %T.%s(%s) because:\n\t%s", receiver, method, formatArgs(args), strings.Join(reasons, "\n\t"))
+}
+
+// Finish reports an error for each expected call that has not been
+// called its minimum number of times. It should be deferred right
+// after the Controller is created.
+func (c *Controller) Finish() {
+	c.t.Helper()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, call := range c.order {
+		if !call.satisfied() {
+			c.t.Errorf("gostub: missing call %s", call)
+		}
+	}
+}
+
+// InOrder declares that the specified calls are expected to be made
+// in the specified order. Calls that are not listed are unaffected.
+func InOrder(calls ...*Call) {
+	for i := 1; i < len(calls); i++ {
+		calls[i].After(calls[i-1])
+	}
+}
+
+func formatArgs(args []interface{}) string {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		result[i] = fmt.Sprintf("%#v", arg)
+	}
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,174 @@
+package mock_test
+
+import (
+	"errors"
+
+	"github.com/mokiat/gostub/mock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Controller", func() {
+	var reporter *recordingReporter
+	var ctrl *mock.Controller
+	var target *greeter
+
+	BeforeEach(func() {
+		reporter = new(recordingReporter)
+		ctrl = mock.NewController(reporter)
+		target = &greeter{ctrl: ctrl}
+	})
+
+	It("returns zero results by default", func() {
+		target.expectGreet("John")
+		result, err := target.Greet("John")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(result).Should(BeEmpty())
+		ctrl.Finish()
+		Ω(reporter.errors).Should(BeEmpty())
+		Ω(reporter.fatals).Should(BeEmpty())
+	})
+
+	It("returns the configured results", func() {
+		target.expectGreet("John").Return("Hello John", errors.New("failed"))
+		result, err := target.Greet("John")
+		Ω(err).Should(MatchError("failed"))
+		Ω(result).Should(Equal("Hello John"))
+	})
+
+	It("accepts nil results of nillable types", func() {
+		target.expectGreet("John").Return("Hello John", nil)
+		_, err := target.Greet("John")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reporter.fatals).Should(BeEmpty())
+	})
+
+	It("reports results that do not match the method", func() {
+		target.expectGreet("John").Return("Hello John")
+		target.expectGreet("Jane").Return(nil, nil)
+		target.expectGreet("Jim").Return(1, nil)
+		Ω(reporter.fatals).Should(HaveLen(3))
+		Ω(reporter.fatals[0]).Should(ContainSubstring("wrong number of results"))
+		Ω(reporter.fatals[1]).Should(ContainSubstring("nil is not assignable to string"))
+		Ω(reporter.fatals[2]).Should(ContainSubstring("int is not assignable to string"))
+	})
+
+	It("matches variadic arguments individually", func() {
+		target.expectGreet("John", "formal", mock.Any()).Return("Good day, John", nil)
+		result, _ := target.Greet("John", "formal", "short")
+		Ω(result).Should(Equal("Good day, John"))
+		Ω(reporter.fatals).Should(BeEmpty())
+	})
+
+	It("matches nil arguments of nillable types", func() {
+		target.expectRemember("John", nil)
+		target.Remember("John", nil)
+		ctrl.Finish()
+		Ω(reporter.errors).Should(BeEmpty())
+		Ω(reporter.fatals).Should(BeEmpty())
+	})
+
+	It("reports unexpected calls as fatal", func() {
+		target.expectGreet("John")
+		result, err := target.Greet("Jane")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(result).Should(BeEmpty())
+		Ω(reporter.fatals).Should(HaveLen(1))
+		Ω(reporter.fatals[0]).Should(ContainSubstring(`unexpected call to *mock_test.greeter.Greet("Jane")`))
+		Ω(reporter.fatals[0]).Should(ContainSubstring(`does not match argument 1: got "Jane", want is equal to "John"`))
+	})
+
+	It("reports calls to methods without expectations", func() {
+		target.Greet("John")
+		Ω(reporter.fatals).Should(HaveLen(1))
+		Ω(reporter.fatals[0]).Should(ContainSubstring("there are no expected calls of the method"))
+	})
+
+	It("expects calls once by default", func() {
+		target.expectGreet("John")
+		target.Greet("John")
+		target.Greet("John")
+		Ω(reporter.fatals).Should(HaveLen(1))
+		Ω(reporter.fatals[0]).Should(ContainSubstring("has already been called the max number of times"))
+	})
+
+	It("uses the next matching expectation when one is exhausted", func() {
+		target.expectGreet("John").Return("first", nil)
+		target.expectGreet("John").Return("second", nil)
+		first, _ := target.Greet("John")
+		second, _ := target.Greet("John")
+		Ω(first).Should(Equal("first"))
+		Ω(second).Should(Equal("second"))
+		Ω(reporter.fatals).Should(BeEmpty())
+	})
+
+	It("reports missing calls when finished", func() {
+		target.expectGreet("John").Times(2)
+		target.expectGreet("Jane").AnyTimes()
+		target.Greet("John")
+		ctrl.Finish()
+		Ω(reporter.errors).Should(HaveLen(1))
+		Ω(reporter.errors[0]).Should(ContainSubstring(`missing call *mock_test.greeter.Greet(is equal to "John")`))
+	})
+
+	It("supports bounded numbers of calls", func() {
+		target.expectGreet("John").MinTimes(2)
+		target.expectGreet("Jane").MaxTimes(2)
+		target.Greet("John")
+		target.Greet("John")
+		target.Greet("John")
+		target.Greet("Jane")
+		target.Greet("Jane")
+		target.Greet("Jane")
+		ctrl.Finish()
+		Ω(reporter.errors).Should(BeEmpty())
+		Ω(reporter.fatals).Should(HaveLen(1))
+	})
+
+	It("calls the configured actions", func() {
+		var names []string
+		target.expectGreet("John").Do(func(name string, tags ...string) {
+			names = append(names, name)
+		}).Return("Hello", nil)
+		target.expectGreet(mock.Any(), mock.Any()).DoAndReturn(func(name string, tags ...string) (string, error) {
+			return name + " " + tags[0], nil
+		})
+
+		first, _ := target.Greet("John")
+		second, _ := target.Greet("Jane", "formal")
+		Ω(first).Should(Equal("Hello"))
+		Ω(second).Should(Equal("Jane formal"))
+		Ω(names).Should(Equal([]string{"John"}))
+	})
+
+	Describe("ordering", func() {
+		It("accepts calls in order", func() {
+			mock.InOrder(
+				target.expectGreet("John"),
+				target.expectGreet("Jane"),
+			)
+			target.Greet("John")
+			target.Greet("Jane")
+			Ω(reporter.fatals).Should(BeEmpty())
+		})
+
+		It("reports calls out of order", func() {
+			mock.InOrder(
+				target.expectGreet("John"),
+				target.expectGreet("Jane"),
+			)
+			target.Greet("Jane")
+			Ω(reporter.fatals).Should(HaveLen(1))
+			Ω(reporter.fatals[0]).Should(ContainSubstring(`is expected after *mock_test.greeter.Greet(is equal to "John")`))
+		})
+
+		It("accepts unordered calls in any order", func() {
+			target.expectGreet("John")
+			target.expectGreet("Jane")
+			target.Greet("Jane")
+			target.Greet("John")
+			Ω(reporter.fatals).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Matcher checks whether an argument of a call matches the expected
+// call.
+type Matcher interface {
+	Matches(x interface{}) bool
+	String() string
+}
+
+// Any returns a Matcher that matches any argument.
+func Any() Matcher {
+	return anyMatcher{}
+}
+
+// Eq returns a Matcher that matches arguments that are deeply equal
+// to the specified value.
+func Eq(x interface{}) Matcher {
+	return eqMatcher{x}
+}
+
+// Nil returns a Matcher that matches nil arguments, including nil
+// values of pointer, slice, map, channel and function types.
+func Nil() Matcher {
+	return nilMatcher{}
+}
+
+// Not returns a Matcher that matches arguments that are not matched
+// by the specified one. Values that are not Matchers are compared
+// with Eq.
+func Not(x interface{}) Matcher {
+	if matcher, ok := x.(Matcher); ok {
+		return notMatcher{matcher}
+	}
+	return notMatcher{Eq(x)}
+}
+
+type anyMatcher struct{}
+
+func (anyMatcher) Matches(interface{}) bool {
+	return true
+}
+
+func (anyMatcher) String() string {
+	return "is anything"
+}
+
+type eqMatcher struct {
+	x interface{}
+}
+
+func (m eqMatcher) Matches(x interface{}) bool {
+	return reflect.DeepEqual(m.x, x)
+}
+
+func (m eqMatcher) String() string {
+	return fmt.Sprintf("is equal to %#v", m.x)
+}
+
+type nilMatcher struct{}
+
+func (nilMatcher) Matches(x interface{}) bool {
+	if x == nil {
+		return true
+	}
+	value := reflect.ValueOf(x)
+	return isNillable(value.Type()) && value.IsNil()
+}
+
+func (nilMatcher) String() string {
+	return "is nil"
+}
+
+type notMatcher struct {
+	m Matcher
+}
+
+func (m notMatcher) Matches(x interface{}) bool {
+	return !m.m.Matches(x)
+}
+
+func (m notMatcher) String() string {
+	return "not(" + m.m.String() + ")"
+}
@@ -0,0 +1,36 @@
+package mock_test
+
+import (
+	"github.com/mokiat/gostub/mock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matchers", func() {
+	It("Any matches anything", func() {
+		Ω(mock.Any().Matches(nil)).Should(BeTrue())
+		Ω(mock.Any().Matches(1)).Should(BeTrue())
+	})
+
+	It("Eq matches deeply equal values", func() {
+		Ω(mock.Eq([]int{1, 2}).Matches([]int{1, 2})).Should(BeTrue())
+		Ω(mock.Eq([]int{1, 2}).Matches([]int{2, 1})).Should(BeFalse())
+		Ω(mock.Eq(1).String()).Should(Equal("is equal to 1"))
+	})
+
+	It("Nil matches nil values", func() {
+		var slice []int
+		var pointer *int
+		Ω(mock.Nil().Matches(nil)).Should(BeTrue())
+		Ω(mock.Nil().Matches(slice)).Should(BeTrue())
+		Ω(mock.Nil().Matches(pointer)).Should(BeTrue())
+		Ω(mock.Nil().Matches(0)).Should(BeFalse())
+	})
+
+	It("Not negates matchers and values", func() {
+		Ω(mock.Not(mock.Nil()).Matches(1)).Should(BeTrue())
+		Ω(mock.Not(1).Matches(1)).Should(BeFalse())
+		Ω(mock.Not(1).String()).Should(Equal("not(is equal to 1)"))
+	})
+})
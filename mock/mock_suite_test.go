@@ -0,0 +1,60 @@
+package mock_test
+
+import (
+	"fmt"
+
+	"github.com/mokiat/gostub/mock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMock(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mock Suite")
+}
+
+type recordingReporter struct {
+	errors []string
+	fatals []string
+}
+
+func (r *recordingReporter) Helper() {}
+
+func (r *recordingReporter) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingReporter) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+// greeter is written the way that generated mocks are.
+type greeter struct {
+	ctrl *mock.Controller
+}
+
+func (g *greeter) Greet(name string, tags ...string) (string, error) {
+	args := []interface{}{name}
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+	results := g.ctrl.Call(g, "Greet", args...)
+	result1, _ := results[0].(string)
+	result2, _ := results[1].(error)
+	return result1, result2
+}
+
+func (g *greeter) expectGreet(name interface{}, tags ...interface{}) *mock.Call {
+	return g.ctrl.RecordCall(g, "Greet", append([]interface{}{name}, tags...)...)
+}
+
+func (g *greeter) Remember(name string, photo []byte) {
+	g.ctrl.Call(g, "Remember", name, photo)
+}
+
+func (g *greeter) expectRemember(name, photo interface{}) *mock.Call {
+	return g.ctrl.RecordCall(g, "Remember", name, photo)
+}
@@ -0,0 +1,89 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// MaxDiagramArgLength specifies the number of characters after which
+// arguments are abbreviated in diagrams.
+const MaxDiagramArgLength = 20
+
+// Dump returns a human-readable description of the specified calls,
+// one per line, with all arguments in full.
+func Dump(calls []Call) string {
+	lines := make([]string, len(calls))
+	for i, call := range calls {
+		lines[i] = call.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MermaidDiagram renders the specified calls as a Mermaid sequence
+// diagram, where each call is shown as a message from the caller
+// to the called stub.
+//
+// Example:
+//     sequenceDiagram
+//         participant Service
+//         participant DBStub
+//         Service->>DBStub: Begin()
+func MermaidDiagram(caller string, calls []Call) string {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintln(buffer, "sequenceDiagram")
+	for _, participant := range participants(caller, calls) {
+		fmt.Fprintf(buffer, "    participant %s\n", participant)
+	}
+	for _, call := range calls {
+		fmt.Fprintf(buffer, "    %s->>%s: %s\n", caller, call.Stub, escapeMermaid(message(call)))
+	}
+	return buffer.String()
+}
+
+// PlantUMLDiagram renders the specified calls as a PlantUML sequence
+// diagram, where each call is shown as a message from the caller
+// to the called stub.
+//
+// Example:
+//     @startuml
+//     participant Service
+//     participant DBStub
+//     Service -> DBStub: Begin()
+//     @enduml
+func PlantUMLDiagram(caller string, calls []Call) string {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintln(buffer, "@startuml")
+	for _, participant := range participants(caller, calls) {
+		fmt.Fprintf(buffer, "participant %s\n", participant)
+	}
+	for _, call := range calls {
+		fmt.Fprintf(buffer, "%s -> %s: %s\n", caller, call.Stub, message(call))
+	}
+	fmt.Fprintln(buffer, "@enduml")
+	return buffer.String()
+}
+
+// participants returns the caller, followed by all called stubs
+// in the order in which they were first called.
+func participants(caller string, calls []Call) []string {
+	result := []string{caller}
+	seen := map[string]bool{caller: true}
+	for _, call := range calls {
+		if !seen[call.Stub] {
+			seen[call.Stub] = true
+			result = append(result, call.Stub)
+		}
+	}
+	return result
+}
+
+func message(call Call) string {
+	return fmt.Sprintf("%s(%s)", call.Method, formatArgs(call.Args, MaxDiagramArgLength))
+}
+
+// escapeMermaid replaces characters that have a special meaning in
+// Mermaid messages with their entity codes.
+func escapeMermaid(text string) string {
+	return strings.NewReplacer("#", "#35;", ";", "#59;").Replace(text)
+}
@@ -0,0 +1,53 @@
+package recorder_test
+
+import (
+	. "github.com/mokiat/gostub/recorder"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diagram", func() {
+	var calls []Call
+
+	BeforeEach(func() {
+		calls = []Call{
+			{Sequence: 1, Stub: "DBStub", Method: "Begin", Args: []interface{}{}},
+			{Sequence: 2, Stub: "CacheStub", Method: "Set", Args: []interface{}{"a rather long cache key", 1}},
+			{Sequence: 3, Stub: "DBStub", Method: "Exec", Args: []interface{}{"a;b"}},
+		}
+	})
+
+	It("dumps all calls in full", func() {
+		Ω(Dump(calls)).Should(Equal(
+			"#1 DBStub.Begin()\n" +
+				"#2 CacheStub.Set(\"a rather long cache key\", 1)\n" +
+				"#3 DBStub.Exec(\"a;b\")",
+		))
+	})
+
+	It("renders a Mermaid diagram", func() {
+		Ω(MermaidDiagram("Service", calls)).Should(Equal(
+			"sequenceDiagram\n" +
+				"    participant Service\n" +
+				"    participant DBStub\n" +
+				"    participant CacheStub\n" +
+				"    Service->>DBStub: Begin()\n" +
+				"    Service->>CacheStub: Set(\"a rather long cache..., 1)\n" +
+				"    Service->>DBStub: Exec(\"a#59;b\")\n",
+		))
+	})
+
+	It("renders a PlantUML diagram", func() {
+		Ω(PlantUMLDiagram("Service", calls)).Should(Equal(
+			"@startuml\n" +
+				"participant Service\n" +
+				"participant DBStub\n" +
+				"participant CacheStub\n" +
+				"Service -> DBStub: Begin()\n" +
+				"Service -> CacheStub: Set(\"a rather long cache..., 1)\n" +
+				"Service -> DBStub: Exec(\"a;b\")\n" +
+				"@enduml\n",
+		))
+	})
+})
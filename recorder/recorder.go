@@ -0,0 +1,142 @@
+// Package recorder provides a call recorder that can be shared by
+// multiple generated stubs, in order to track the calls to all of them
+// in a single global sequence.
+package recorder
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Call describes a single call to a method of a stub, as observed
+// by a Recorder.
+type Call struct {
+
+	// Sequence specifies the position of the call in the global
+	// sequence of the recorder. The first call has sequence 1.
+	Sequence int
+
+	// Stub specifies the name of the stub that was called.
+	Stub string
+
+	// Method specifies the name of the method that was called.
+	Method string
+
+	// Args holds the arguments that were used for the call.
+	Args []interface{}
+}
+
+// Name returns the qualified name of the called method, in the
+// form StubName.MethodName.
+func (c Call) Name() string {
+	return c.Stub + "." + c.Method
+}
+
+func (c Call) String() string {
+	return fmt.Sprintf("#%d %s(%s)", c.Sequence, c.Name(), formatArgs(c.Args, 0))
+}
+
+// formatArgs formats the specified arguments as a comma-separated
+// list. Arguments that are longer than limit are abbreviated, unless
+// limit is zero.
+func formatArgs(args []interface{}, limit int) string {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		result[i] = abbreviate(fmt.Sprintf("%#v", arg), limit)
+	}
+	return strings.Join(result, ", ")
+}
+
+func abbreviate(text string, limit int) string {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "..."
+}
+
+// Listener is a function that gets notified of each call as it
+// is recorded.
+type Listener func(call Call)
+
+// NewRecorder creates a new Recorder without any recorded calls.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Recorder tracks the calls to all stubs that it has been attached
+// to, in the order in which they were made.
+//
+// It is safe for concurrent use.
+type Recorder struct {
+	mutex     sync.Mutex
+	calls     []Call
+	listeners []Listener
+}
+
+// Record adds a call to the global sequence of the recorder and
+// notifies all listeners. It is called by generated stubs.
+func (r *Recorder) Record(stub string, method string, args []interface{}) {
+	r.mutex.Lock()
+	call := Call{
+		Sequence: len(r.calls) + 1,
+		Stub:     stub,
+		Method:   method,
+		Args:     args,
+	}
+	r.calls = append(r.calls, call)
+	listeners := r.listeners
+	r.mutex.Unlock()
+
+	for _, listener := range listeners {
+		listener(call)
+	}
+}
+
+// AddListener registers a function that will be called for each
+// subsequently recorded call. Listeners are not called under lock,
+// so they are allowed to use the recorder and the stubs.
+func (r *Recorder) AddListener(listener Listener) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.listeners = append(r.listeners, listener)
+}
+
+// Calls returns a copy of all calls that were recorded so far.
+func (r *Recorder) Calls() []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Call{}, r.calls...)
+}
+
+// InOrder checks that calls to the specified methods, each in the
+// form StubName.MethodName, were recorded in the specified order.
+// Other calls are allowed to appear in between.
+// An error describing all recorded calls is returned if the check
+// fails.
+func (r *Recorder) InOrder(names ...string) error {
+	calls := r.Calls()
+	position := 0
+	for _, name := range names {
+		found := false
+		for ; position < len(calls); position++ {
+			if calls[position].Name() == name {
+				found = true
+				position++
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("expected call to %s in order %s, recorded calls:%s", name, strings.Join(names, ", "), formatCalls(calls))
+		}
+	}
+	return nil
+}
+
+func formatCalls(calls []Call) string {
+	if len(calls) == 0 {
+		return " none"
+	}
+	return "\n\t" + strings.Replace(Dump(calls), "\n", "\n\t", -1)
+}
@@ -0,0 +1,80 @@
+package recorder_test
+
+import (
+	. "github.com/mokiat/gostub/recorder"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var rec *Recorder
+
+	BeforeEach(func() {
+		rec = NewRecorder()
+	})
+
+	It("has no calls initially", func() {
+		Ω(rec.Calls()).Should(BeEmpty())
+	})
+
+	It("records calls in a single sequence", func() {
+		rec.Record("DBStub", "Begin", []interface{}{})
+		rec.Record("CacheStub", "Set", []interface{}{"key", 1})
+		Ω(rec.Calls()).Should(Equal([]Call{
+			{Sequence: 1, Stub: "DBStub", Method: "Begin", Args: []interface{}{}},
+			{Sequence: 2, Stub: "CacheStub", Method: "Set", Args: []interface{}{"key", 1}},
+		}))
+	})
+
+	It("notifies listeners of each call", func() {
+		var notified []Call
+		rec.AddListener(func(call Call) {
+			notified = append(notified, call)
+		})
+		rec.Record("DBStub", "Begin", []interface{}{})
+		Ω(notified).Should(HaveLen(1))
+		Ω(notified[0].Name()).Should(Equal("DBStub.Begin"))
+	})
+
+	It("allows listeners to use the recorder", func() {
+		rec.AddListener(func(call Call) {
+			rec.Calls()
+		})
+		rec.Record("DBStub", "Begin", []interface{}{})
+		Ω(rec.Calls()).Should(HaveLen(1))
+	})
+
+	It("formats calls", func() {
+		call := Call{Sequence: 3, Stub: "CacheStub", Method: "Set", Args: []interface{}{"key", 1}}
+		Ω(call.String()).Should(Equal(`#3 CacheStub.Set("key", 1)`))
+	})
+
+	Describe("InOrder", func() {
+		BeforeEach(func() {
+			rec.Record("DBStub", "Begin", []interface{}{})
+			rec.Record("CacheStub", "Set", []interface{}{"key", 1})
+			rec.Record("DBStub", "Commit", []interface{}{})
+		})
+
+		It("succeeds for calls in order", func() {
+			Ω(rec.InOrder("DBStub.Begin", "DBStub.Commit")).Should(Succeed())
+			Ω(rec.InOrder("DBStub.Begin", "CacheStub.Set", "DBStub.Commit")).Should(Succeed())
+		})
+
+		It("fails for calls out of order", func() {
+			err := rec.InOrder("DBStub.Commit", "CacheStub.Set")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("expected call to CacheStub.Set"))
+			Ω(err.Error()).Should(ContainSubstring(`#2 CacheStub.Set("key", 1)`))
+		})
+
+		It("fails for calls that were not made", func() {
+			Ω(rec.InOrder("QueueStub.Publish")).ShouldNot(Succeed())
+		})
+
+		It("fails for repeated calls made once", func() {
+			Ω(rec.InOrder("DBStub.Begin", "DBStub.Begin")).ShouldNot(Succeed())
+		})
+	})
+})
@@ -51,16 +51,6 @@ type importEntry struct {
 	Location string
 }
 
-func (c *LocatorContext) CandidateLocations(alias string) []string {
-	if alias == "." {
-		return c.LocalLocations()
-	}
-	if location, found := c.AliasedLocation(alias); found {
-		return []string{location}
-	}
-	return c.NonLocalNonAliasedLocations(alias)
-}
-
 func (c *LocatorContext) LocalLocations() []string {
 	result := []string{}
 	for _, imp := range c.imports {
@@ -71,10 +61,10 @@ func (c *LocatorContext) LocalLocations() []string {
 	return result
 }
 
-func (c *LocatorContext) NonLocalNonAliasedLocations(alias string) []string {
+func (c *LocatorContext) NonAliasedLocations() []string {
 	result := []string{}
 	for _, imp := range c.imports {
-		if imp.Alias == "" && strings.HasSuffix(imp.Location, alias) {
+		if imp.Alias == "" {
 			result = append(result, imp.Location)
 		}
 	}
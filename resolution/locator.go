@@ -11,12 +11,14 @@ import (
 
 func NewLocator() *Locator {
 	return &Locator{
-		cache: make(map[string][]TypeDiscovery),
+		cache:        make(map[string][]TypeDiscovery),
+		packageNames: make(map[string]string),
 	}
 }
 
 type Locator struct {
-	cache map[string][]TypeDiscovery
+	cache        map[string][]TypeDiscovery
+	packageNames map[string]string
 }
 
 type TypeDiscovery struct {
@@ -26,7 +28,7 @@ type TypeDiscovery struct {
 }
 
 func (l *Locator) FindIdentType(context *LocatorContext, ref *ast.Ident) (TypeDiscovery, error) {
-	locations := context.CandidateLocations(".")
+	locations := context.LocalLocations()
 	return l.findTypeDeclarationInLocations(ref.String(), locations)
 }
 
@@ -35,10 +37,48 @@ func (l *Locator) FindSelectorType(context *LocatorContext, ref *ast.SelectorExp
 	if !ok {
 		panic("Selector expression is not a reference!")
 	}
-	locations := context.CandidateLocations(aliasIdent.String())
+	alias := aliasIdent.String()
+	if location, found := context.AliasedLocation(alias); found {
+		return l.findTypeDeclarationInLocations(ref.Sel.String(), []string{location})
+	}
+	locations, err := l.findPackageLocations(alias, context.NonAliasedLocations())
+	if err != nil {
+		return TypeDiscovery{}, err
+	}
 	return l.findTypeDeclarationInLocations(ref.Sel.String(), locations)
 }
 
+// findPackageLocations returns the locations whose package name matches
+// the specified name. The package name need not match the last element
+// of the location.
+func (l *Locator) findPackageLocations(name string, candidateLocations []string) ([]string, error) {
+	result := []string{}
+	for _, location := range candidateLocations {
+		packageName, err := l.findPackageName(location)
+		if err != nil {
+			return nil, err
+		}
+		if packageName == name {
+			result = append(result, location)
+		}
+	}
+	return result, nil
+}
+
+func (l *Locator) findPackageName(location string) (string, error) {
+	packageName, found := l.packageNames[location]
+	if found {
+		return packageName, nil
+	}
+
+	packageName, err := util.ImportToPackageName(location)
+	if err != nil {
+		return "", err
+	}
+	l.packageNames[location] = packageName
+	return packageName, nil
+}
+
 func (l *Locator) findTypeDeclarationInLocations(name string, candidateLocations []string) (TypeDiscovery, error) {
 	for _, location := range candidateLocations {
 		discovery, found, err := l.findTypeDeclarationInLocation(name, location)
@@ -1,6 +1,9 @@
 package util
 
-import "go/ast"
+import (
+	"go/ast"
+	"go/token"
+)
 
 func CreateField(name string, fieldType ast.Expr) *ast.Field {
 	return &ast.Field{
@@ -36,3 +39,117 @@ func FieldsWithoutEllipsis(fields []*ast.Field) []*ast.Field {
 	}
 	return result
 }
+
+// FieldsAsExported returns copies of the specified fields whose
+// names have been converted to exported ones.
+func FieldsAsExported(fields []*ast.Field) []*ast.Field {
+	result := make([]*ast.Field, len(fields))
+	for i, field := range fields {
+		names := make([]*ast.Ident, len(field.Names))
+		for j, name := range field.Names {
+			names[j] = ast.NewIdent(ToPublic(name.String()))
+		}
+		result[i] = &ast.Field{
+			Names: names,
+			Type:  field.Type,
+		}
+	}
+	return result
+}
+
+// CreateEmptyInterface creates an `interface{}` type expression that
+// is printed on a single line.
+func CreateEmptyInterface() *ast.InterfaceType {
+	return &ast.InterfaceType{
+		Methods: &ast.FieldList{
+			Opening: token.Pos(1),
+			Closing: token.Pos(1),
+		},
+	}
+}
+
+// CreateEmptyStruct creates a `struct{}` type expression that
+// is printed on a single line.
+func CreateEmptyStruct() *ast.StructType {
+	return &ast.StructType{
+		Fields: &ast.FieldList{
+			Opening: token.Pos(1),
+			Closing: token.Pos(1),
+		},
+	}
+}
+
+// CopyType returns a deep copy of the specified type expression, so
+// that the copy can be modified (e.g. resolved) without affecting the
+// original type.
+func CopyType(astType ast.Expr) ast.Expr {
+	switch t := astType.(type) {
+	case *ast.Ident:
+		return ast.NewIdent(t.Name)
+	case *ast.SelectorExpr:
+		return &ast.SelectorExpr{
+			X:   CopyType(t.X),
+			Sel: ast.NewIdent(t.Sel.Name),
+		}
+	case *ast.StarExpr:
+		return &ast.StarExpr{
+			X: CopyType(t.X),
+		}
+	case *ast.ParenExpr:
+		return &ast.ParenExpr{
+			X: CopyType(t.X),
+		}
+	case *ast.ArrayType:
+		return &ast.ArrayType{
+			Len: t.Len,
+			Elt: CopyType(t.Elt),
+		}
+	case *ast.MapType:
+		return &ast.MapType{
+			Key:   CopyType(t.Key),
+			Value: CopyType(t.Value),
+		}
+	case *ast.ChanType:
+		return &ast.ChanType{
+			Dir:   t.Dir,
+			Value: CopyType(t.Value),
+		}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{
+			Elt: CopyType(t.Elt),
+		}
+	case *ast.FuncType:
+		return &ast.FuncType{
+			Params:  copyFieldList(t.Params),
+			Results: copyFieldList(t.Results),
+		}
+	case *ast.StructType:
+		return &ast.StructType{
+			Fields: copyFieldList(t.Fields),
+		}
+	case *ast.InterfaceType:
+		return &ast.InterfaceType{
+			Methods: copyFieldList(t.Methods),
+		}
+	}
+	return astType
+}
+
+func copyFieldList(fieldList *ast.FieldList) *ast.FieldList {
+	if fieldList == nil {
+		return nil
+	}
+	result := &ast.FieldList{
+		Opening: fieldList.Opening,
+		Closing: fieldList.Closing,
+		List:    make([]*ast.Field, len(fieldList.List)),
+	}
+	for i, field := range fieldList.List {
+		result.List[i] = &ast.Field{
+			Names: field.Names,
+			Type:  CopyType(field.Type),
+			Tag:   field.Tag,
+		}
+	}
+	return result
+}
@@ -1,7 +1,10 @@
 package util_test
 
 import (
+	"bytes"
 	"go/ast"
+	"go/format"
+	"go/token"
 
 	. "github.com/mokiat/gostub/util"
 
@@ -107,4 +110,123 @@ var _ = Describe("AstConstruct", func() {
 			Ω(processed[1].Type).Should(BeAssignableToTypeOf(&ast.ArrayType{}))
 		})
 	})
+
+	Describe("FieldsAsExported", func() {
+		var fields []*ast.Field
+
+		BeforeEach(func() {
+			firstField := &ast.Field{
+				Names: []*ast.Ident{
+					ast.NewIdent("name1"),
+					ast.NewIdent("Name2"),
+				},
+				Type: ast.NewIdent("string"),
+			}
+			secondField := &ast.Field{
+				Type: ast.NewIdent("int"),
+			}
+			fields = []*ast.Field{
+				firstField,
+				secondField,
+			}
+		})
+
+		It("returns the same number of fields", func() {
+			processed := FieldsAsExported(fields)
+			Ω(processed).Should(HaveLen(2))
+		})
+
+		It("preserves the types of the fields", func() {
+			processed := FieldsAsExported(fields)
+			Ω(processed[0].Type).Should(Equal(fields[0].Type))
+			Ω(processed[1].Type).Should(Equal(fields[1].Type))
+		})
+
+		It("exports the names of the fields", func() {
+			processed := FieldsAsExported(fields)
+			Ω(processed[0].Names).Should(HaveLen(2))
+			Ω(processed[0].Names[0].String()).Should(Equal("Name1"))
+			Ω(processed[0].Names[1].String()).Should(Equal("Name2"))
+			Ω(processed[1].Names).Should(BeEmpty())
+		})
+
+		It("does not modify the original fields", func() {
+			FieldsAsExported(fields)
+			Ω(fields[0].Names[0].String()).Should(Equal("name1"))
+		})
+	})
+
+	Describe("CreateEmptyInterface", func() {
+		It("has no methods", func() {
+			iface := CreateEmptyInterface()
+			Ω(iface.Methods.List).Should(BeEmpty())
+		})
+
+		It("is printed on a single line", func() {
+			code := &bytes.Buffer{}
+			err := format.Node(code, token.NewFileSet(), CreateEmptyInterface())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(code.String()).Should(Equal("interface{}"))
+		})
+	})
+	Describe("CreateEmptyStruct", func() {
+		It("has no fields", func() {
+			structType := CreateEmptyStruct()
+			Ω(structType.Fields.List).Should(BeEmpty())
+		})
+
+		It("is printed on a single line", func() {
+			code := &bytes.Buffer{}
+			err := format.Node(code, token.NewFileSet(), CreateEmptyStruct())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(code.String()).Should(Equal("struct{}"))
+		})
+	})
+
+	Describe("CopyType", func() {
+		var original ast.Expr
+
+		BeforeEach(func() {
+			original = &ast.MapType{
+				Key: ast.NewIdent("string"),
+				Value: &ast.ArrayType{
+					Elt: &ast.StarExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("http"),
+							Sel: ast.NewIdent("Request"),
+						},
+					},
+				},
+			}
+		})
+
+		It("produces an equal type", func() {
+			Ω(CopyType(original)).Should(Equal(original))
+		})
+
+		It("does not share nodes with the original type", func() {
+			copied := CopyType(original).(*ast.MapType)
+			copied.Value.(*ast.ArrayType).Elt = ast.NewIdent("int")
+			copied.Key.(*ast.Ident).Name = "int"
+
+			originalMap := original.(*ast.MapType)
+			Ω(originalMap.Key.(*ast.Ident).Name).Should(Equal("string"))
+			Ω(originalMap.Value.(*ast.ArrayType).Elt).Should(BeAssignableToTypeOf(&ast.StarExpr{}))
+		})
+
+		It("copies the fields of struct types", func() {
+			original = &ast.StructType{
+				Fields: &ast.FieldList{
+					List: []*ast.Field{
+						CreateField("Name", ast.NewIdent("string")),
+					},
+				},
+			}
+			copied := CopyType(original).(*ast.StructType)
+			copied.Fields.List[0].Type = ast.NewIdent("int")
+
+			originalStruct := original.(*ast.StructType)
+			Ω(originalStruct.Fields.List[0].Type).Should(Equal(ast.NewIdent("string")))
+		})
+	})
 })
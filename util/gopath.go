@@ -35,3 +35,19 @@ func ImportToDir(imp string) (string, error) {
 	}
 	return pkg.Dir, nil
 }
+
+// ImportToPackageName returns the name of the package at an import
+// location, which is the name that the package is referenced by when
+// it is imported without an alias.
+//
+// For example,
+//     gopkg.in/yaml.v2
+// will be converted to
+//     yaml
+func ImportToPackageName(imp string) (string, error) {
+	pkg, err := build.Import(imp, "", 0)
+	if err != nil {
+		return "", err
+	}
+	return pkg.Name, nil
+}
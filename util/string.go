@@ -25,3 +25,10 @@ func ToPrivate(name string) string {
 	}
 	return strings.ToLower(name[0:1]) + name[1:]
 }
+
+func ToPublic(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[0:1]) + name[1:]
+}
@@ -56,4 +56,19 @@ var _ = Describe("String", func() {
 			Ω(ToPrivate("U")).Should(Equal("u"))
 		})
 	})
+
+	Describe("ToPublic", func() {
+		It("has no effect on empty strings", func() {
+			Ω(ToPublic("")).Should(Equal(""))
+		})
+		It("has no effect on public names", func() {
+			Ω(ToPublic("PublicName")).Should(Equal("PublicName"))
+		})
+		It("converts lower camel case to upper camel case", func() {
+			Ω(ToPublic("doSomething")).Should(Equal("DoSomething"))
+		})
+		It("works on single letters", func() {
+			Ω(ToPublic("u")).Should(Equal("U"))
+		})
+	})
 })